@@ -10,6 +10,7 @@ require (
 	k8s.io/api v0.33.7
 	k8s.io/apimachinery v0.33.7
 	k8s.io/client-go v0.33.7
+	sigs.k8s.io/yaml v1.6.0
 )
 
 require (
@@ -55,7 +56,6 @@ require (
 	sigs.k8s.io/json v0.0.0-20241014173422-cfa47c3a1cc8 // indirect
 	sigs.k8s.io/randfill v1.0.0 // indirect
 	sigs.k8s.io/structured-merge-diff/v4 v4.6.0 // indirect
-	sigs.k8s.io/yaml v1.6.0 // indirect
 )
 
 // OpenShift 4.20 compatibility requirements
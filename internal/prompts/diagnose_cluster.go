@@ -73,7 +73,11 @@ Follow this exact sequence to ensure fast responses:
    - Node conditions (Ready, MemoryPressure, DiskPressure)
    - Resource capacity and usage
 
-3. **cluster://incidents** (5s cache, if Coordination Engine enabled)
+3. **cluster://operators** (30s cache, OpenShift only)
+   - ClusterOperator Available/Progressing/Degraded conditions
+   - ClusterVersion: current version, in-progress updates, failing conditions
+
+4. **cluster://incidents** (5s cache, if Coordination Engine enabled)
    - Active incidents from self-healing system
    - Severity, status, affected resources
 
@@ -87,6 +91,11 @@ Based on Resource data, identify:
 - ❌ Failed/pending pods
 - ❌ Resource pressure (memory/disk)
 - ❌ Active incidents with high severity
+- ❌ Degraded or unavailable ClusterOperators
+
+**Before calling NotReady nodes an outage**, check whether the Machine Config
+Operator is rolling out an update: nodes reboot one by one during an MCO
+rollout and are NotReady/SchedulingDisabled for a few minutes each.
 
 ## Step 3: INVESTIGATE (Tools Only When Needed)
 
@@ -96,6 +105,14 @@ Based on Resource data, identify:
   - Use when you need container restart counts, detailed status
   - Provide namespace filter to limit results
 
+- **get-machine-config-status**: MachineConfigPool rollout state
+  - Use when nodes are NotReady or SchedulingDisabled
+  - Tells "NotReady because an MCO rollout is in progress" apart from a real outage
+  - Reports paused pools, rendered config mismatches and nodes stuck cordoned
+
+- **get-cluster-operators**: ClusterOperator conditions and ClusterVersion details
+  - Use when cluster://operators shows degraded operators or a stalled update
+
 - **list-incidents**: Get incident details with filters
   - Use when you need specific incident parameters
 
@@ -126,6 +143,11 @@ Current filter: **%s**
 3. Use list-pods tool with namespace filter → Get pod details
 4. Diagnose: Application issue, not infrastructure
 
+✅ **CORRECT (NotReady nodes):**
+1. Read cluster://health → 1 node NotReady
+2. Use get-machine-config-status → worker pool 2/5 updated, node is applying a new rendered config
+3. Diagnose: Expected reboot during an MCO rollout, not an outage
+
 ❌ **INCORRECT (Slow, may timeout):**
 1. Use list-pods with no filters → Returns 1000+ pods, takes 5-10s
 2. Manually analyze all pods
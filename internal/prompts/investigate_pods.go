@@ -114,8 +114,13 @@ Look for common issues:
 
 ### Step 4: Identify Root Cause
 
+For any failing pod, **use the diagnose-pod tool** (pod_name, namespace). In one call it returns
+exit codes, OOM kills, probe failures, previous-container logs, warning events and a classified
+probable cause with evidence. Use get-pod-logs for more log context and get-events for the
+event timeline of related objects (e.g. the owning Deployment).
+
 Based on pod data:
-1. **Application Issues**: Restarts, crash loops → Check logs (outside MCP scope)
+1. **Application Issues**: Restarts, crash loops → diagnose-pod, then get-pod-logs with previous=true
 2. **Resource Issues**: Pending, evicted → Check node capacity
 3. **Configuration Issues**: Failed to pull image → Check manifests
 4. **Infrastructure Issues**: Node problems → Read cluster://nodes resource
@@ -123,7 +128,7 @@ Based on pod data:
 ### Step 5: Recommend Actions
 
 Provide specific, actionable remediation:
-- **If crashloop**: Suggest checking application logs and configuration
+- **If crashloop**: Quote the probable cause and evidence from diagnose-pod
 - **If pending**: Show resource availability and scheduling constraints
 - **If image issues**: Verify image registry and credentials
 - **If node issues**: Point to cluster://nodes for node health
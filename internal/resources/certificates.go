@@ -0,0 +1,96 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/KubeHeal/openshift-cluster-health-mcp/pkg/cache"
+	"github.com/KubeHeal/openshift-cluster-health-mcp/pkg/certs"
+	"github.com/KubeHeal/openshift-cluster-health-mcp/pkg/clients"
+)
+
+// certificatesCacheTTL is long because a cluster-wide scan lists every ConfigMap
+const certificatesCacheTTL = 5 * time.Minute
+
+// CertificatesResource provides the cluster://certificates MCP resource
+type CertificatesResource struct {
+	k8sClient   *clients.K8sClient
+	cache       *cache.MemoryCache
+	scanSecrets bool
+}
+
+// NewCertificatesResource creates a new certificates resource;
+// kubernetes.io/tls Secrets are only read when scanSecrets is set
+func NewCertificatesResource(k8sClient *clients.K8sClient, cache *cache.MemoryCache, scanSecrets bool) *CertificatesResource {
+	return &CertificatesResource{
+		k8sClient:   k8sClient,
+		cache:       cache,
+		scanSecrets: scanSecrets,
+	}
+}
+
+// URI returns the resource URI
+func (r *CertificatesResource) URI() string {
+	return "cluster://certificates"
+}
+
+// Name returns the resource name
+func (r *CertificatesResource) Name() string {
+	return "Certificate Expiry"
+}
+
+// Description returns the resource description
+func (r *CertificatesResource) Description() string {
+	return "Certificates expiring within 30 days (critical within 7) from service CA bundles, Routes and, if enabled, TLS Secrets, grouped by namespace and owner; metadata only"
+}
+
+// MimeType returns the MIME type of the resource
+func (r *CertificatesResource) MimeType() string {
+	return "application/json"
+}
+
+// CertificatesData represents the certificates resource data
+type CertificatesData struct {
+	Timestamp string `json:"timestamp"`
+	certs.Report
+	Thresholds     certs.Thresholds `json:"thresholds"`
+	SecretsScanned bool             `json:"secrets_scanned"`
+	Notes          []string         `json:"notes,omitempty"`
+}
+
+// Read retrieves the certificates resource
+func (r *CertificatesResource) Read(ctx context.Context) (string, error) {
+	// Check cache first (5 minute TTL)
+	cacheKey := "resource:cluster:certificates"
+	if cached, found := r.cache.Get(cacheKey); found {
+		if data, ok := cached.(string); ok {
+			return data, nil
+		}
+	}
+
+	sources, notes, err := r.k8sClient.CollectCertificateSources(ctx, "", r.scanSecrets)
+	if err != nil {
+		return "", fmt.Errorf("failed to collect certificates: %w", err)
+	}
+
+	now := time.Now()
+	data := CertificatesData{
+		Timestamp:      now.UTC().Format(time.RFC3339),
+		Report:         certs.Scan(sources, now, certs.DefaultThresholds, true),
+		Thresholds:     certs.DefaultThresholds,
+		SecretsScanned: r.scanSecrets,
+		Notes:          notes,
+	}
+
+	jsonData, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal certificates data: %w", err)
+	}
+
+	jsonStr := string(jsonData)
+	r.cache.SetWithTTL(cacheKey, jsonStr, certificatesCacheTTL)
+
+	return jsonStr, nil
+}
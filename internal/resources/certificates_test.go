@@ -0,0 +1,14 @@
+package resources
+
+import "testing"
+
+func TestCertificatesResource_Metadata(t *testing.T) {
+	resource := NewCertificatesResource(nil, nil, false)
+
+	if resource.URI() != "cluster://certificates" {
+		t.Errorf("Expected URI 'cluster://certificates', got '%s'", resource.URI())
+	}
+	if resource.MimeType() != "application/json" {
+		t.Errorf("Expected MIME type 'application/json', got '%s'", resource.MimeType())
+	}
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/KubeHeal/openshift-cluster-health-mcp/pkg/cache"
 	"github.com/KubeHeal/openshift-cluster-health-mcp/pkg/clients"
+	"github.com/KubeHeal/openshift-cluster-health-mcp/pkg/health"
 )
 
 // ClusterHealthResource provides the cluster://health MCP resource
@@ -48,11 +49,14 @@ func (r *ClusterHealthResource) MimeType() string {
 
 // ClusterHealthData represents the cluster health resource data
 type ClusterHealthData struct {
-	Status        string    `json:"status"`
-	Timestamp     string    `json:"timestamp"`
-	Source        string    `json:"source"`
-	Nodes         NodeStats `json:"nodes"`
-	Pods          PodStats  `json:"pods"`
+	Status        string                  `json:"status"`
+	Score         float64                 `json:"score"`
+	Timestamp     string                  `json:"timestamp"`
+	Source        string                  `json:"source"`
+	Nodes         NodeStats               `json:"nodes"`
+	Pods          PodStats                `json:"pods"`
+	Dimensions    []health.DimensionScore `json:"dimensions,omitempty"`
+	Findings      []health.Finding        `json:"findings,omitempty"`
 	ResourceUsage struct {
 		CPU    ResourceUsageDetail `json:"cpu"`
 		Memory ResourceUsageDetail `json:"memory"`
@@ -118,8 +122,13 @@ func (r *ClusterHealthResource) fetchFromKubernetesAPI(ctx context.Context) (Clu
 	data := ClusterHealthData{
 		Timestamp: time.Now().UTC().Format(time.RFC3339),
 		Status:    health.Status,
+		Score:     health.Score,
 		Message:   generateHealthMessage(health),
 	}
+	if health.Scoring != nil {
+		data.Dimensions = health.Scoring.Dimensions
+		data.Findings = health.Scoring.Findings
+	}
 
 	// Map health data
 	data.Nodes.Total = health.Nodes.Total
@@ -138,7 +147,14 @@ func (r *ClusterHealthResource) fetchFromKubernetesAPI(ctx context.Context) (Clu
 	// Calculate active issues
 	data.ActiveIssues = health.Nodes.NotReady + health.Pods.Failed + health.Pods.Pending
 
-	// Add warnings for critical issues
+	// Add warnings for critical issues; prefer the scoring engine's
+	// findings, which skip ignored namespaces and discount Job failures
+	if health.Scoring != nil {
+		for _, finding := range health.Scoring.Findings {
+			data.Warnings = append(data.Warnings, finding.Message)
+		}
+		return data, nil
+	}
 	if health.Nodes.NotReady > 0 {
 		data.Warnings = append(data.Warnings, fmt.Sprintf("%d nodes are not ready", health.Nodes.NotReady))
 	}
@@ -172,8 +188,8 @@ func (r *ClusterHealthResource) cacheAndReturn(cacheKey string, data ClusterHeal
 func generateHealthMessage(health *clients.ClusterHealth) string {
 	switch health.Status {
 	case "healthy":
-		return fmt.Sprintf("Cluster is healthy: %d/%d nodes ready, %d/%d pods running",
-			health.Nodes.Ready, health.Nodes.Total, health.Pods.Running, health.Pods.Total)
+		return fmt.Sprintf("Cluster is healthy: %d/%d nodes ready, %d/%d pods running%s",
+			health.Nodes.Ready, health.Nodes.Total, health.Pods.Running, health.Pods.Total, scoreSuffix(health))
 	case "degraded":
 		issues := []string{}
 		if health.Nodes.NotReady > 0 {
@@ -186,10 +202,22 @@ func generateHealthMessage(health *clients.ClusterHealth) string {
 			issues = append(issues, fmt.Sprintf("%d pods pending", health.Pods.Pending))
 		}
 		if len(issues) > 0 {
-			return fmt.Sprintf("Cluster is degraded: %v", issues)
+			return fmt.Sprintf("Cluster is degraded: %v%s", issues, scoreSuffix(health))
 		}
-		return "Cluster is degraded"
+		return "Cluster is degraded" + scoreSuffix(health)
 	default:
-		return "Cluster status: " + health.Status
+		return "Cluster status: " + health.Status + scoreSuffix(health)
+	}
+}
+
+// scoreSuffix describes the health score and its worst finding, if scored
+func scoreSuffix(health *clients.ClusterHealth) string {
+	if health.Scoring == nil {
+		return ""
+	}
+	suffix := fmt.Sprintf(" (score %.1f/100", health.Score)
+	if len(health.Scoring.Findings) > 0 {
+		suffix += "; " + health.Scoring.Findings[0].Message
 	}
+	return suffix + ")"
 }
@@ -0,0 +1,82 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/KubeHeal/openshift-cluster-health-mcp/pkg/cache"
+	"github.com/KubeHeal/openshift-cluster-health-mcp/pkg/clients"
+)
+
+// ClusterOperatorsResource provides the cluster://operators MCP resource
+type ClusterOperatorsResource struct {
+	k8sClient *clients.K8sClient
+	cache     *cache.MemoryCache
+}
+
+// NewClusterOperatorsResource creates a new cluster operators resource
+func NewClusterOperatorsResource(k8sClient *clients.K8sClient, cache *cache.MemoryCache) *ClusterOperatorsResource {
+	return &ClusterOperatorsResource{
+		k8sClient: k8sClient,
+		cache:     cache,
+	}
+}
+
+// URI returns the resource URI
+func (r *ClusterOperatorsResource) URI() string {
+	return "cluster://operators"
+}
+
+// Name returns the resource name
+func (r *ClusterOperatorsResource) Name() string {
+	return "Cluster Operators"
+}
+
+// Description returns the resource description
+func (r *ClusterOperatorsResource) Description() string {
+	return "OpenShift ClusterOperator conditions and ClusterVersion status including current version, update history, failing conditions and available updates"
+}
+
+// MimeType returns the MIME type of the resource
+func (r *ClusterOperatorsResource) MimeType() string {
+	return "application/json"
+}
+
+// ClusterOperatorsData represents the cluster operators resource data
+type ClusterOperatorsData struct {
+	Timestamp string `json:"timestamp"`
+	*clients.OperatorStatusReport
+}
+
+// Read retrieves the cluster operators resource
+func (r *ClusterOperatorsResource) Read(ctx context.Context) (string, error) {
+	// Check cache first (30 second TTL, operators change slowly)
+	cacheKey := "resource:cluster:operators"
+	if cached, found := r.cache.Get(cacheKey); found {
+		if data, ok := cached.(string); ok {
+			return data, nil
+		}
+	}
+
+	report, err := r.k8sClient.GetOperatorStatus(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to get cluster operators: %w", err)
+	}
+
+	data := ClusterOperatorsData{
+		Timestamp:            time.Now().UTC().Format(time.RFC3339),
+		OperatorStatusReport: report,
+	}
+
+	jsonData, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal cluster operators data: %w", err)
+	}
+
+	jsonStr := string(jsonData)
+	r.cache.SetWithTTL(cacheKey, jsonStr, 30*time.Second)
+
+	return jsonStr, nil
+}
@@ -0,0 +1,111 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/KubeHeal/openshift-cluster-health-mcp/pkg/cache"
+	"github.com/KubeHeal/openshift-cluster-health-mcp/pkg/clients"
+)
+
+const (
+	// warningEventsWindow is how far back cluster://events/warnings looks
+	warningEventsWindow = time.Hour
+	// warningEventsTopReasons caps the number of reasons returned
+	warningEventsTopReasons = 10
+)
+
+// WarningEventsResource provides the cluster://events/warnings MCP resource
+type WarningEventsResource struct {
+	k8sClient *clients.K8sClient
+	cache     *cache.MemoryCache
+}
+
+// NewWarningEventsResource creates a new warning events resource
+func NewWarningEventsResource(k8sClient *clients.K8sClient, cache *cache.MemoryCache) *WarningEventsResource {
+	return &WarningEventsResource{
+		k8sClient: k8sClient,
+		cache:     cache,
+	}
+}
+
+// URI returns the resource URI
+func (r *WarningEventsResource) URI() string {
+	return "cluster://events/warnings"
+}
+
+// Name returns the resource name
+func (r *WarningEventsResource) Name() string {
+	return "Warning Events"
+}
+
+// Description returns the resource description
+func (r *WarningEventsResource) Description() string {
+	return "Top warning event reasons cluster-wide over the last hour, with affected object and namespace counts"
+}
+
+// MimeType returns the MIME type of the resource
+func (r *WarningEventsResource) MimeType() string {
+	return "application/json"
+}
+
+// WarningEventsData represents the warning events resource data
+type WarningEventsData struct {
+	Timestamp     string                       `json:"timestamp"`
+	WindowMinutes int                          `json:"window_minutes"`
+	TotalWarnings int32                        `json:"total_warnings"`
+	UniqueEvents  int                          `json:"unique_events"`
+	TopReasons    []clients.EventReasonSummary `json:"top_reasons"`
+}
+
+// Read retrieves the warning events resource
+func (r *WarningEventsResource) Read(ctx context.Context) (string, error) {
+	// Check cache first (30 second TTL)
+	cacheKey := "resource:cluster:events:warnings"
+	if cached, found := r.cache.Get(cacheKey); found {
+		if data, ok := cached.(string); ok {
+			return data, nil
+		}
+	}
+
+	// The type is filtered server-side, so normal events are never listed
+	now := time.Now()
+	events, err := r.k8sClient.ListEventTimeline(ctx, clients.EventFilter{
+		Type:  "Warning",
+		Since: now.Add(-warningEventsWindow),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to list warning events: %w", err)
+	}
+
+	data := summarizeWarningEvents(events, now)
+
+	jsonData, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal warning events data: %w", err)
+	}
+
+	jsonStr := string(jsonData)
+	r.cache.SetWithTTL(cacheKey, jsonStr, 30*time.Second)
+
+	return jsonStr, nil
+}
+
+// summarizeWarningEvents builds the resource payload from warning events
+func summarizeWarningEvents(events []clients.Event, now time.Time) WarningEventsData {
+	data := WarningEventsData{
+		Timestamp:     now.UTC().Format(time.RFC3339),
+		WindowMinutes: int(warningEventsWindow.Minutes()),
+		UniqueEvents:  len(events),
+		TopReasons:    clients.SummarizeEventReasons(events),
+	}
+	for _, e := range events {
+		data.TotalWarnings += e.Count
+	}
+	if len(data.TopReasons) > warningEventsTopReasons {
+		data.TopReasons = data.TopReasons[:warningEventsTopReasons]
+	}
+	return data
+}
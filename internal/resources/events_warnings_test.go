@@ -0,0 +1,45 @@
+package resources
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/KubeHeal/openshift-cluster-health-mcp/pkg/clients"
+)
+
+func TestWarningEventsResource_Metadata(t *testing.T) {
+	resource := NewWarningEventsResource(nil, nil)
+
+	if resource.URI() != "cluster://events/warnings" {
+		t.Errorf("Expected URI 'cluster://events/warnings', got '%s'", resource.URI())
+	}
+	if resource.MimeType() != "application/json" {
+		t.Errorf("Expected MIME type 'application/json', got '%s'", resource.MimeType())
+	}
+}
+
+func TestSummarizeWarningEvents(t *testing.T) {
+	now := time.Now()
+	var events []clients.Event
+	for i := 0; i < 12; i++ {
+		events = append(events, clients.Event{
+			Namespace: "app",
+			Kind:      "Pod",
+			Name:      fmt.Sprintf("pod-%d", i),
+			Type:      "Warning",
+			Reason:    fmt.Sprintf("Reason%02d", i),
+			Count:     int32(i + 1),
+			LastSeen:  now,
+		})
+	}
+
+	data := summarizeWarningEvents(events, now)
+
+	if data.TotalWarnings != 78 || data.UniqueEvents != 12 || data.WindowMinutes != 60 {
+		t.Errorf("Unexpected totals: %+v", data)
+	}
+	if len(data.TopReasons) != warningEventsTopReasons || data.TopReasons[0].Reason != "Reason11" {
+		t.Errorf("Expected top %d reasons led by Reason11, got %+v", warningEventsTopReasons, data.TopReasons)
+	}
+}
@@ -0,0 +1,102 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/KubeHeal/openshift-cluster-health-mcp/pkg/history"
+)
+
+// maxHistoryPoints bounds the number of downsampled points returned by the
+// resource so the payload stays small regardless of the retained window
+const maxHistoryPoints = 48
+
+// HealthHistoryResource provides the cluster://health/history MCP resource
+type HealthHistoryResource struct {
+	sampler *history.Sampler
+}
+
+// NewHealthHistoryResource creates a new health history resource
+func NewHealthHistoryResource(sampler *history.Sampler) *HealthHistoryResource {
+	return &HealthHistoryResource{
+		sampler: sampler,
+	}
+}
+
+// URI returns the resource URI
+func (r *HealthHistoryResource) URI() string {
+	return "cluster://health/history"
+}
+
+// Name returns the resource name
+func (r *HealthHistoryResource) Name() string {
+	return "Cluster Health History"
+}
+
+// Description returns the resource description
+func (r *HealthHistoryResource) Description() string {
+	return "Time series of cluster health samples recorded in-process, with status transitions and the retained window downsampled for quick review"
+}
+
+// MimeType returns the MIME type of the resource
+func (r *HealthHistoryResource) MimeType() string {
+	return "application/json"
+}
+
+// HealthHistoryData represents the health history resource data
+type HealthHistoryData struct {
+	Timestamp       string              `json:"timestamp"`
+	SampleInterval  string              `json:"sample_interval"`
+	RetainedSamples int                 `json:"retained_samples"`
+	MaxSamples      int                 `json:"max_samples"`
+	Oldest          string              `json:"oldest,omitempty"`
+	Newest          string              `json:"newest,omitempty"`
+	Timeline        *history.Timeline   `json:"timeline"`
+	TopRestarters   []history.Restarter `json:"top_restarters,omitempty"`
+}
+
+// Read retrieves the health history resource
+func (r *HealthHistoryResource) Read(ctx context.Context) (string, error) {
+	if r.sampler == nil {
+		return "", fmt.Errorf("health history sampler not enabled")
+	}
+
+	store := r.sampler.Store()
+	samples := store.Snapshot()
+	now := time.Now().UTC()
+
+	data := HealthHistoryData{
+		Timestamp:       now.Format(time.RFC3339),
+		SampleInterval:  r.sampler.Interval().String(),
+		RetainedSamples: len(samples),
+		MaxSamples:      store.Capacity(),
+	}
+
+	from := now
+	if len(samples) > 0 {
+		from = samples[0].Timestamp
+		data.Oldest = samples[0].Timestamp.Format(time.RFC3339)
+		data.Newest = samples[len(samples)-1].Timestamp.Format(time.RFC3339)
+		data.TopRestarters = samples[len(samples)-1].TopRestarters
+	}
+
+	data.Timeline = history.BuildTimeline(samples, from, now, historyStep(now.Sub(from), r.sampler.Interval()))
+
+	jsonData, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal health history: %w", err)
+	}
+	return string(jsonData), nil
+}
+
+// historyStep picks a step that keeps the window within maxHistoryPoints,
+// never finer than the sampling interval
+func historyStep(window, interval time.Duration) time.Duration {
+	step := window / maxHistoryPoints
+	if step < interval {
+		step = interval
+	}
+	return step.Round(time.Second)
+}
@@ -3,8 +3,12 @@ package server
 import (
 	"fmt"
 	"os"
+	"sort"
 	"strconv"
+	"strings"
 	"time"
+
+	"github.com/KubeHeal/openshift-cluster-health-mcp/pkg/health"
 )
 
 // TransportType defines the MCP transport protocol
@@ -37,6 +41,7 @@ type Config struct {
 	// Integration Endpoints
 	CoordinationEngineURL string // Coordination Engine base URL
 	PrometheusURL         string // Prometheus API URL
+	PrometheusCAFile      string // Extra CA bundle for Prometheus TLS (default: service CA if mounted)
 	KServeNamespace       string // KServe models namespace
 	KServePredictorPort   int    // KServe predictor port (8080 for RawDeployment, 80 for Serverless)
 
@@ -49,6 +54,32 @@ type Config struct {
 	CacheTTL           time.Duration // Cache TTL for Kubernetes API responses
 	RequestTimeout     time.Duration // HTTP client timeout
 	MaxConcurrentTools int           // Max concurrent tool executions
+
+	// Health History Settings
+	EnableHealthHistory  bool          // Record periodic health samples for cluster://health/history
+	HealthSampleInterval time.Duration // Interval between health samples
+	HealthHistorySize    int           // Maximum number of samples retained in memory
+	HealthHistoryPath    string        // Optional file (e.g. on a PVC) to persist samples across restarts
+
+	// Health Scoring Settings
+	HealthScoreWeights       map[string]float64 // Per-dimension weight overrides (e.g. nodes=0.3,storage=0)
+	HealthDegradedThreshold  float64            // Overall scores below this are degraded
+	HealthUnhealthyThreshold float64            // Overall scores below this are unhealthy
+	HealthIgnoreNamespaces   []string           // Namespaces excluded from workload/storage scoring ('*' suffix = prefix)
+
+	// Capacity Planning Settings
+	PodProfilesFile      string // Optional YAML/JSON file of named pod profiles
+	PodProfilesConfigMap string // Optional namespace/name of a ConfigMap with a profiles.yaml key
+
+	// Cost Estimation Settings
+	PricingFile      string // Optional YAML/JSON pricing model file
+	PricingConfigMap string // Optional namespace/name of a ConfigMap with a pricing.yaml key
+
+	// Certificate Scanning Settings
+	EnableSecretCertScan bool // Read kubernetes.io/tls Secrets for certificate expiry (opt-in)
+
+	// Security Posture Settings
+	SecuritySuppressions map[string][]string // Namespace pattern ('*' suffix = prefix) -> suppressed check IDs ('*' = all)
 }
 
 // NewConfig creates a Config from environment variables with sensible defaults
@@ -69,6 +100,7 @@ func NewConfig() *Config {
 		// Integration Endpoints
 		CoordinationEngineURL: getEnv("COORDINATION_ENGINE_URL", "http://coordination-engine:8080"),
 		PrometheusURL:         getEnv("PROMETHEUS_URL", "https://prometheus-k8s.openshift-monitoring.svc:9091"),
+		PrometheusCAFile:      getEnv("PROMETHEUS_CA_FILE", ""),
 		KServeNamespace:       getEnv("KSERVE_NAMESPACE", "self-healing-platform"),
 		KServePredictorPort:   getEnvInt("KSERVE_PREDICTOR_PORT", 8080), // Default 8080 for RawDeployment mode
 
@@ -81,6 +113,32 @@ func NewConfig() *Config {
 		CacheTTL:           getEnvDuration("CACHE_TTL", 30*time.Second),
 		RequestTimeout:     getEnvDuration("REQUEST_TIMEOUT", 10*time.Second),
 		MaxConcurrentTools: getEnvInt("MAX_CONCURRENT_TOOLS", 10),
+
+		// Health History Settings (default: 24h of 1 minute samples, memory only)
+		EnableHealthHistory:  getEnvBool("ENABLE_HEALTH_HISTORY", true),
+		HealthSampleInterval: getEnvDuration("HEALTH_SAMPLE_INTERVAL", 60*time.Second),
+		HealthHistorySize:    getEnvInt("HEALTH_HISTORY_SIZE", 1440),
+		HealthHistoryPath:    getEnv("HEALTH_HISTORY_PATH", ""),
+
+		// Health Scoring Settings (unset weights use the engine defaults)
+		HealthScoreWeights:       getEnvWeights("HEALTH_SCORE_WEIGHTS"),
+		HealthDegradedThreshold:  getEnvFloat("HEALTH_DEGRADED_THRESHOLD", 90),
+		HealthUnhealthyThreshold: getEnvFloat("HEALTH_UNHEALTHY_THRESHOLD", 60),
+		HealthIgnoreNamespaces:   getEnvList("HEALTH_IGNORE_NAMESPACES"),
+
+		// Capacity Planning Settings (default: builtin small/medium/large profiles only)
+		PodProfilesFile:      getEnv("POD_PROFILES_FILE", ""),
+		PodProfilesConfigMap: getEnv("POD_PROFILES_CONFIGMAP", ""),
+
+		// Cost Estimation Settings (default: no pricing model, costs omitted)
+		PricingFile:      getEnv("PRICING_FILE", ""),
+		PricingConfigMap: getEnv("PRICING_CONFIGMAP", ""),
+
+		// Certificate Scanning Settings (default: Secrets are never read)
+		EnableSecretCertScan: getEnvBool("ENABLE_SECRET_CERT_SCAN", false),
+
+		// Security Posture Settings (default: nothing suppressed)
+		SecuritySuppressions: getEnvSuppressions("SECURITY_SUPPRESSIONS"),
 	}
 
 	return cfg
@@ -102,6 +160,53 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("cache TTL too low: %v (minimum 1s)", c.CacheTTL)
 	}
 
+	if c.EnableHealthHistory {
+		if c.HealthSampleInterval < 5*time.Second {
+			return fmt.Errorf("health sample interval too low: %v (minimum 5s)", c.HealthSampleInterval)
+		}
+		if c.HealthHistorySize < 1 {
+			return fmt.Errorf("invalid health history size: %d (must be at least 1)", c.HealthHistorySize)
+		}
+	}
+
+	if c.HealthUnhealthyThreshold < 0 || c.HealthDegradedThreshold > 100 ||
+		c.HealthUnhealthyThreshold >= c.HealthDegradedThreshold {
+		return fmt.Errorf("invalid health thresholds: unhealthy=%v degraded=%v (must satisfy 0 <= unhealthy < degraded <= 100)",
+			c.HealthUnhealthyThreshold, c.HealthDegradedThreshold)
+	}
+	totalWeight := 0.0
+	for dim, weight := range health.DefaultWeights {
+		if override, ok := c.HealthScoreWeights[dim]; ok {
+			weight = override
+		}
+		totalWeight += weight
+	}
+	for dim, weight := range c.HealthScoreWeights {
+		if _, ok := health.DefaultWeights[dim]; !ok {
+			return fmt.Errorf("unknown health score dimension: %s (must be one of %s)", dim, strings.Join(healthDimensions(), ", "))
+		}
+		if weight < 0 {
+			return fmt.Errorf("invalid health score weight for %s: %v (must be >= 0)", dim, weight)
+		}
+	}
+	if totalWeight <= 0 {
+		return fmt.Errorf("invalid health score weights: at least one dimension must have a weight above 0")
+	}
+
+	if c.PodProfilesConfigMap != "" {
+		namespace, name, ok := strings.Cut(c.PodProfilesConfigMap, "/")
+		if !ok || namespace == "" || name == "" {
+			return fmt.Errorf("invalid pod profiles configmap: %s (must be namespace/name)", c.PodProfilesConfigMap)
+		}
+	}
+
+	if c.PricingConfigMap != "" {
+		namespace, name, ok := strings.Cut(c.PricingConfigMap, "/")
+		if !ok || namespace == "" || name == "" {
+			return fmt.Errorf("invalid pricing configmap: %s (must be namespace/name)", c.PricingConfigMap)
+		}
+	}
+
 	return nil
 }
 
@@ -110,6 +215,16 @@ func (c *Config) GetHTTPAddr() string {
 	return fmt.Sprintf("%s:%d", c.HTTPHost, c.HTTPPort)
 }
 
+// healthDimensions returns the scoring dimension names, sorted
+func healthDimensions() []string {
+	dims := make([]string, 0, len(health.DefaultWeights))
+	for dim := range health.DefaultWeights {
+		dims = append(dims, dim)
+	}
+	sort.Strings(dims)
+	return dims
+}
+
 // Helper functions to read environment variables
 
 func getEnv(key, defaultValue string) string {
@@ -146,6 +261,59 @@ func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
 	return defaultValue
 }
 
+func getEnvFloat(key string, defaultValue float64) float64 {
+	if value := os.Getenv(key); value != "" {
+		if floatValue, err := strconv.ParseFloat(value, 64); err == nil {
+			return floatValue
+		}
+	}
+	return defaultValue
+}
+
+// getEnvList parses a comma-separated list, dropping empty entries
+func getEnvList(key string) []string {
+	var list []string
+	for _, item := range strings.Split(os.Getenv(key), ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
+// getEnvWeights parses "name=weight" pairs (e.g. "nodes=0.3,storage=0"),
+// skipping malformed entries
+func getEnvWeights(key string) map[string]float64 {
+	weights := make(map[string]float64)
+	for _, item := range getEnvList(key) {
+		name, value, ok := strings.Cut(item, "=")
+		if !ok {
+			continue
+		}
+		weight, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil {
+			continue
+		}
+		weights[strings.TrimSpace(name)] = weight
+	}
+	return weights
+}
+
+// getEnvSuppressions parses "namespace:check" pairs (e.g.
+// "monitoring:host_network,ci-*:*"), skipping malformed entries
+func getEnvSuppressions(key string) map[string][]string {
+	suppressions := make(map[string][]string)
+	for _, item := range getEnvList(key) {
+		namespace, check, ok := strings.Cut(item, ":")
+		namespace, check = strings.TrimSpace(namespace), strings.TrimSpace(check)
+		if !ok || namespace == "" || check == "" {
+			continue
+		}
+		suppressions[namespace] = append(suppressions[namespace], check)
+	}
+	return suppressions
+}
+
 func getEnvTransport(key string, defaultValue TransportType) TransportType {
 	value := os.Getenv(key)
 	if value == "" {
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"log"
 	"net/http"
+	"os"
 	"strings"
 	"time"
 
@@ -15,6 +16,8 @@ import (
 	"github.com/KubeHeal/openshift-cluster-health-mcp/internal/tools"
 	"github.com/KubeHeal/openshift-cluster-health-mcp/pkg/cache"
 	"github.com/KubeHeal/openshift-cluster-health-mcp/pkg/clients"
+	"github.com/KubeHeal/openshift-cluster-health-mcp/pkg/health"
+	"github.com/KubeHeal/openshift-cluster-health-mcp/pkg/history"
 )
 
 // MCPServer wraps the official MCP SDK server
@@ -25,7 +28,11 @@ type MCPServer struct {
 	k8sClient      *clients.K8sClient
 	ceClient       *clients.CoordinationEngineClient
 	kserve         *clients.KServeClient
+	prometheus     *clients.PrometheusClient // Prometheus client (nil if disabled)
+	podProfiles    *tools.PodProfileCatalog  // Builtin, configured and deployment-derived pod profiles
+	pricing        *tools.PricingSource      // Cost pricing model (unconfigured if no file/ConfigMap)
 	cache          *cache.MemoryCache
+	sampler        *history.Sampler         // Background health sampler (nil if disabled)
 	sessionManager *SessionManager          // Session manager for REST API clients
 	tools          map[string]Tool          // Registry of available tools (typed for type safety)
 	resources      map[string]interface{}   // Registry of available resources
@@ -54,10 +61,35 @@ func NewMCPServer(config *Config) (*MCPServer, error) {
 		log.Printf("Connected to Kubernetes cluster (version: %s)", version)
 	}
 
+	// Configure the weighted health scoring model
+	k8sClient.SetHealthEngine(health.NewEngine(health.Config{
+		Weights:            config.HealthScoreWeights,
+		DegradedThreshold:  &config.HealthDegradedThreshold,
+		UnhealthyThreshold: &config.HealthUnhealthyThreshold,
+		IgnoredNamespaces:  config.HealthIgnoreNamespaces,
+	}))
+	if len(config.HealthIgnoreNamespaces) > 0 {
+		log.Printf("Health scoring ignores namespaces: %v", config.HealthIgnoreNamespaces)
+	}
+
 	// Initialize cache with configured TTL
 	memoryCache := cache.NewMemoryCache(config.CacheTTL)
 	log.Printf("Initialized cache with TTL: %s", config.CacheTTL)
 
+	// Initialize background health sampler if enabled
+	var sampler *history.Sampler
+	if config.EnableHealthHistory {
+		sampler = history.NewSampler(history.NewK8sCollector(k8sClient, 5), history.SamplerConfig{
+			Interval:    config.HealthSampleInterval,
+			Capacity:    config.HealthHistorySize,
+			PersistPath: config.HealthHistoryPath,
+			Timeout:     config.RequestTimeout,
+		})
+		log.Printf("Initialized health history sampler (interval: %s, size: %d)", config.HealthSampleInterval, config.HealthHistorySize)
+	} else {
+		log.Printf("Health history sampler disabled (use ENABLE_HEALTH_HISTORY=true to enable)")
+	}
+
 	// Initialize Coordination Engine client if enabled
 	var ceClient *clients.CoordinationEngineClient
 	if config.EnableCoordinationEngine {
@@ -82,6 +114,55 @@ func NewMCPServer(config *Config) (*MCPServer, error) {
 		log.Printf("KServe integration disabled (use ENABLE_KSERVE=true to enable)")
 	}
 
+	// Initialize Prometheus client if enabled
+	var prometheusClient *clients.PrometheusClient
+	if config.EnablePrometheus {
+		caFile := config.PrometheusCAFile
+		if caFile == "" {
+			if _, err := os.Stat(clients.DefaultServiceCAFile); err == nil {
+				caFile = clients.DefaultServiceCAFile
+			}
+		}
+		restConfig := k8sClient.GetConfig()
+		prometheusClient, err = clients.NewPrometheusClient(clients.PrometheusConfig{
+			URL:             config.PrometheusURL,
+			Timeout:         config.RequestTimeout,
+			BearerToken:     restConfig.BearerToken,
+			BearerTokenFile: restConfig.BearerTokenFile,
+			CAFile:          caFile,
+		})
+		if err != nil {
+			log.Printf("Warning: Prometheus client not initialized: %v", err)
+			prometheusClient = nil
+		} else {
+			log.Printf("Initialized Prometheus client: %s", config.PrometheusURL)
+		}
+	} else {
+		log.Printf("Prometheus integration disabled (use ENABLE_PROMETHEUS=true to enable)")
+	}
+
+	// Pod profile catalog (definitions are re-read on each lookup; load once
+	// here only to surface configuration errors at startup)
+	podProfiles := tools.NewPodProfileCatalog(k8sClient, config.PodProfilesFile, config.PodProfilesConfigMap)
+	if config.PodProfilesFile != "" || config.PodProfilesConfigMap != "" {
+		if defs, err := podProfiles.Definitions(context.Background()); err != nil {
+			log.Printf("Warning: pod profiles not loaded: %v", err)
+		} else {
+			log.Printf("Loaded %d pod profiles", len(defs))
+		}
+	}
+
+	// Cost pricing model (re-read on each use; load once here only to
+	// surface configuration errors at startup)
+	pricing := tools.NewPricingSource(k8sClient, config.PricingFile, config.PricingConfigMap)
+	if pricing.Configured() {
+		if model, err := pricing.Model(context.Background()); err != nil {
+			log.Printf("Warning: pricing model not loaded: %v", err)
+		} else {
+			log.Printf("Loaded pricing model (%s, %d instance types)", model.Currency, len(model.InstanceTypes))
+		}
+	}
+
 	// Create MCP server with metadata
 	impl := &mcp.Implementation{
 		Name:    config.Name,
@@ -101,7 +182,11 @@ func NewMCPServer(config *Config) (*MCPServer, error) {
 		k8sClient:      k8sClient,
 		ceClient:       ceClient,
 		kserve:         kserveClient,
+		prometheus:     prometheusClient,
+		podProfiles:    podProfiles,
+		pricing:        pricing,
 		cache:          memoryCache,
+		sampler:        sampler,
 		sessionManager: sessionManager,
 		tools:          make(map[string]Tool),
 		resources:      make(map[string]interface{}),
@@ -140,9 +225,87 @@ func (s *MCPServer) registerTools() error {
 	s.registerTool(listPodsTool)
 
 	// Register calculate-pod-capacity tool (capacity planning)
-	calculatePodCapacityTool := tools.NewCalculatePodCapacityTool(s.k8sClient)
+	calculatePodCapacityTool := tools.NewCalculatePodCapacityTool(s.k8sClient, s.podProfiles)
 	s.registerTool(calculatePodCapacityTool)
 
+	// Register get-cluster-operators tool (OpenShift ClusterOperators/ClusterVersion, with cache)
+	clusterOperatorsTool := tools.NewClusterOperatorsTool(s.k8sClient, s.cache)
+	s.registerTool(clusterOperatorsTool)
+
+	// Register get-machine-config-status tool (MachineConfigPool rollouts, no cache)
+	machineConfigStatusTool := tools.NewMachineConfigStatusTool(s.k8sClient)
+	s.registerTool(machineConfigStatusTool)
+
+	// Register get-pod-logs tool (container logs with error highlighting and redaction, no cache)
+	podLogsTool := tools.NewPodLogsTool(s.k8sClient)
+	s.registerTool(podLogsTool)
+
+	// Register get-events tool (merged core/v1 and events.k8s.io/v1 timeline, no cache)
+	eventsTool := tools.NewEventsTool(s.k8sClient)
+	s.registerTool(eventsTool)
+
+	// Register diagnose-pod tool (CrashLoopBackOff root cause, no cache)
+	diagnosePodTool := tools.NewDiagnosePodTool(s.k8sClient)
+	s.registerTool(diagnosePodTool)
+
+	// Register get-workload-health tool (Deployment/StatefulSet/DaemonSet rollouts, no cache)
+	workloadHealthTool := tools.NewWorkloadHealthTool(s.k8sClient)
+	s.registerTool(workloadHealthTool)
+
+	// Register explain-pending-pods tool (scheduling simulation, no cache)
+	explainPendingPodsTool := tools.NewExplainPendingPodsTool(s.k8sClient)
+	s.registerTool(explainPendingPodsTool)
+
+	// Register analyze-failure-resilience tool (node/zone loss simulation, no cache)
+	failureResilienceTool := tools.NewFailureResilienceTool(s.k8sClient)
+	s.registerTool(failureResilienceTool)
+
+	// Register preview-node-drain tool (read-only drain impact, no cache)
+	nodeDrainTool := tools.NewNodeDrainTool(s.k8sClient)
+	s.registerTool(nodeDrainTool)
+
+	// Register recommend-resource-requests tool (Prometheus usage, metrics API fallback)
+	resourceRecommendationsTool := tools.NewResourceRecommendationsTool(s.k8sClient, s.prometheus, s.pricing)
+	s.registerTool(resourceRecommendationsTool)
+
+	// Register get-namespace-costs tool (pricing model cost allocation, no cache)
+	namespaceCostsTool := tools.NewNamespaceCostsTool(s.k8sClient, s.prometheus, s.pricing)
+	s.registerTool(namespaceCostsTool)
+
+	// Register get-autoscaler-status tool (HPA bounds, metrics and flapping, no cache)
+	autoscalerStatusTool := tools.NewAutoscalerStatusTool(s.k8sClient)
+	s.registerTool(autoscalerStatusTool)
+
+	// Register get-namespace-health tool (per-namespace score and cluster ranking, no cache)
+	namespaceHealthTool := tools.NewNamespaceHealthTool(s.k8sClient)
+	s.registerTool(namespaceHealthTool)
+
+	// Register check-service-health tool (EndpointSlices, selectors and target ports, no cache)
+	serviceHealthTool := tools.NewServiceHealthTool(s.k8sClient)
+	s.registerTool(serviceHealthTool)
+
+	// Register check-route-health tool (OpenShift Routes and IngressControllers, no cache)
+	routeHealthTool := tools.NewRouteHealthTool(s.k8sClient)
+	s.registerTool(routeHealthTool)
+
+	// Register get-storage-health tool (PVCs, PVs, attachments and classes; optional Prometheus usage, no cache)
+	storageHealthTool := tools.NewStorageHealthTool(s.k8sClient, s.prometheus)
+	s.registerTool(storageHealthTool)
+
+	// Register scan-certificate-expiry tool (ConfigMaps, Routes, opt-in Secrets, no cache)
+	certificateExpiryTool := tools.NewCertificateExpiryTool(s.k8sClient, s.config.EnableSecretCertScan)
+	s.registerTool(certificateExpiryTool)
+
+	// Register scan-security-posture tool (pod spec checks with per-namespace suppressions, no cache)
+	securityPostureTool := tools.NewSecurityPostureTool(s.k8sClient, s.config.SecuritySuppressions)
+	s.registerTool(securityPostureTool)
+
+	// Register get-health-timeline tool (if health history sampler enabled)
+	if s.sampler != nil {
+		healthTimelineTool := tools.NewHealthTimelineTool(s.sampler)
+		s.registerTool(healthTimelineTool)
+	}
+
 	// Register Coordination Engine tools if enabled
 	if s.ceClient != nil {
 		listIncidentsTool := tools.NewListIncidentsTool(s.ceClient)
@@ -164,7 +327,7 @@ func (s *MCPServer) registerTools() error {
 		s.registerTool(predictResourceUsageTool)
 
 		// NEW: Analyze scaling impact tool (capacity planning)
-		analyzeScalingImpactTool := tools.NewAnalyzeScalingImpactTool(s.ceClient, s.k8sClient)
+		analyzeScalingImpactTool := tools.NewAnalyzeScalingImpactTool(s.ceClient, s.k8sClient, s.prometheus, s.pricing)
 		s.registerTool(analyzeScalingImpactTool)
 	} else {
 		log.Printf("Skipping Coordination Engine tools (not enabled)")
@@ -264,6 +427,28 @@ func (s *MCPServer) registerResources() error {
 	s.resources[nodesResource.URI()] = nodesResource
 	log.Printf("Registered resource: %s - %s", nodesResource.URI(), nodesResource.Name())
 
+	// Register cluster://operators resource (always available, errors on non-OpenShift clusters)
+	clusterOperatorsResource := resources.NewClusterOperatorsResource(s.k8sClient, s.cache)
+	s.resources[clusterOperatorsResource.URI()] = clusterOperatorsResource
+	log.Printf("Registered resource: %s - %s", clusterOperatorsResource.URI(), clusterOperatorsResource.Name())
+
+	// Register cluster://events/warnings resource (always available)
+	warningEventsResource := resources.NewWarningEventsResource(s.k8sClient, s.cache)
+	s.resources[warningEventsResource.URI()] = warningEventsResource
+	log.Printf("Registered resource: %s - %s", warningEventsResource.URI(), warningEventsResource.Name())
+
+	// Register cluster://certificates resource (always available, Secrets only if enabled)
+	certificatesResource := resources.NewCertificatesResource(s.k8sClient, s.cache, s.config.EnableSecretCertScan)
+	s.resources[certificatesResource.URI()] = certificatesResource
+	log.Printf("Registered resource: %s - %s", certificatesResource.URI(), certificatesResource.Name())
+
+	// Register cluster://health/history resource (if health history sampler enabled)
+	if s.sampler != nil {
+		healthHistoryResource := resources.NewHealthHistoryResource(s.sampler)
+		s.resources[healthHistoryResource.URI()] = healthHistoryResource
+		log.Printf("Registered resource: %s - %s", healthHistoryResource.URI(), healthHistoryResource.Name())
+	}
+
 	// Register cluster://incidents resource (if Coordination Engine enabled)
 	if s.ceClient != nil {
 		incidentsResource := resources.NewIncidentsResource(s.ceClient, s.cache)
@@ -353,6 +538,13 @@ func (s *MCPServer) GetResources() map[string]interface{} {
 // Start begins serving MCP requests using the configured transport
 // As of 2025-12-17, only HTTP/SSE transport is supported (stdio DEPRECATED)
 func (s *MCPServer) Start(ctx context.Context) error {
+	// Start background health sampler (not in NewMCPServer, so constructing
+	// a server does not poll the API)
+	if s.sampler != nil {
+		s.sampler.Start()
+		log.Printf("Started health history sampler")
+	}
+
 	switch s.config.Transport {
 	case TransportHTTP:
 		return s.startHTTPTransport(ctx)
@@ -731,6 +923,34 @@ func (s *MCPServer) handleListResources(w http.ResponseWriter, r *http.Request)
 				Description: r.Description(),
 				MimeType:    r.MimeType(),
 			})
+		case *resources.HealthHistoryResource:
+			resourcesList = append(resourcesList, ResourceInfo{
+				URI:         r.URI(),
+				Name:        r.Name(),
+				Description: r.Description(),
+				MimeType:    r.MimeType(),
+			})
+		case *resources.ClusterOperatorsResource:
+			resourcesList = append(resourcesList, ResourceInfo{
+				URI:         r.URI(),
+				Name:        r.Name(),
+				Description: r.Description(),
+				MimeType:    r.MimeType(),
+			})
+		case *resources.WarningEventsResource:
+			resourcesList = append(resourcesList, ResourceInfo{
+				URI:         r.URI(),
+				Name:        r.Name(),
+				Description: r.Description(),
+				MimeType:    r.MimeType(),
+			})
+		case *resources.CertificatesResource:
+			resourcesList = append(resourcesList, ResourceInfo{
+				URI:         r.URI(),
+				Name:        r.Name(),
+				Description: r.Description(),
+				MimeType:    r.MimeType(),
+			})
 		}
 	}
 
@@ -828,6 +1048,10 @@ func (s *MCPServer) Stop() error {
 	if s.sessionManager != nil {
 		s.sessionManager.Stop()
 	}
+	// Stop background health sampler
+	if s.sampler != nil {
+		s.sampler.Stop()
+	}
 	if s.httpServer != nil {
 		log.Println("Stopping HTTP server...")
 		// Add timeout to graceful shutdown
@@ -1114,6 +1338,14 @@ func (s *MCPServer) handleResourceRead(w http.ResponseWriter, r *http.Request) {
 		result, err = res.Read(ctx)
 	case *resources.RemediationHistoryResource:
 		result, err = res.Read(ctx)
+	case *resources.HealthHistoryResource:
+		result, err = res.Read(ctx)
+	case *resources.ClusterOperatorsResource:
+		result, err = res.Read(ctx)
+	case *resources.WarningEventsResource:
+		result, err = res.Read(ctx)
+	case *resources.CertificatesResource:
+		result, err = res.Read(ctx)
 	default:
 		writeJSONError(w, http.StatusInternalServerError, "resource type not supported")
 		return
@@ -6,12 +6,15 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/KubeHeal/openshift-cluster-health-mcp/internal/resources"
 	"github.com/KubeHeal/openshift-cluster-health-mcp/pkg/cache"
 	"github.com/KubeHeal/openshift-cluster-health-mcp/pkg/clients"
+	"github.com/KubeHeal/openshift-cluster-health-mcp/pkg/health"
 )
 
 func setupTestServer(t *testing.T) *MCPServer {
@@ -86,7 +89,7 @@ func TestMCPServer_RegisterTools(t *testing.T) {
 	}()
 	defer server.cache.Close()
 
-	expectedTools := []string{"get-cluster-health", "list-pods", "calculate-pod-capacity"}
+	expectedTools := []string{"get-cluster-health", "list-pods", "calculate-pod-capacity", "get-cluster-operators", "get-machine-config-status", "get-pod-logs", "get-events", "diagnose-pod", "get-workload-health", "explain-pending-pods", "analyze-failure-resilience", "preview-node-drain", "recommend-resource-requests", "get-namespace-costs", "get-autoscaler-status", "get-namespace-health", "check-service-health", "check-route-health", "get-storage-health", "scan-certificate-expiry", "scan-security-posture"}
 	for _, toolName := range expectedTools {
 		if _, exists := server.tools[toolName]; !exists {
 			t.Errorf("Expected tool %s to be registered", toolName)
@@ -635,6 +638,61 @@ func TestConfigValidation(t *testing.T) {
 	}
 }
 
+func TestConfigValidation_HealthScoring(t *testing.T) {
+	config := NewConfig()
+	config.HealthUnhealthyThreshold = 90
+	config.HealthDegradedThreshold = 80
+	if err := config.Validate(); err == nil {
+		t.Error("Expected error when unhealthy threshold is not below degraded threshold")
+	}
+
+	config = NewConfig()
+	config.HealthScoreWeights = map[string]float64{"nodes": -1}
+	if err := config.Validate(); err == nil {
+		t.Error("Expected error for negative health score weight")
+	}
+
+	config = NewConfig()
+	config.HealthScoreWeights = map[string]float64{"node": 0.3}
+	if err := config.Validate(); err == nil {
+		t.Error("Expected error for unknown health score dimension")
+	}
+
+	config = NewConfig()
+	config.HealthScoreWeights = map[string]float64{}
+	for dim := range health.DefaultWeights {
+		config.HealthScoreWeights[dim] = 0
+	}
+	if err := config.Validate(); err == nil {
+		t.Error("Expected error when every health score weight is 0")
+	}
+
+	config = NewConfig()
+	config.HealthUnhealthyThreshold = 0
+	config.HealthScoreWeights = map[string]float64{"storage": 0}
+	if err := config.Validate(); err != nil {
+		t.Errorf("Expected unhealthy threshold 0 and a zero storage weight to be valid, got %v", err)
+	}
+}
+
+func TestGetEnvWeights(t *testing.T) {
+	t.Setenv("HEALTH_SCORE_WEIGHTS", "nodes=0.5, storage=0,bogus,networking=abc")
+
+	weights := getEnvWeights("HEALTH_SCORE_WEIGHTS")
+	if len(weights) != 2 || weights["nodes"] != 0.5 || weights["storage"] != 0 {
+		t.Errorf("Unexpected weights: %v", weights)
+	}
+}
+
+func TestGetEnvSuppressions(t *testing.T) {
+	t.Setenv("SECURITY_SUPPRESSIONS", "monitoring:host_network, monitoring:host_path,ci-*:*,bogus,:privileged")
+
+	suppressions := getEnvSuppressions("SECURITY_SUPPRESSIONS")
+	if len(suppressions) != 2 || len(suppressions["monitoring"]) != 2 || suppressions["ci-*"][0] != "*" {
+		t.Errorf("Unexpected suppressions: %v", suppressions)
+	}
+}
+
 func TestHTTPServerIntegration(t *testing.T) {
 	server := setupTestServer(t)
 	defer func() {
@@ -669,3 +727,35 @@ func TestHTTPServerIntegration(t *testing.T) {
 		}
 	}
 }
+
+func TestCertificatesResource_ListAndRead(t *testing.T) {
+	memoryCache := cache.NewMemoryCache(30 * time.Second)
+	defer memoryCache.Close()
+	// A cached scan lets the read succeed without a cluster
+	memoryCache.Set("resource:cluster:certificates", `{"summary":{"certificates":0}}`)
+
+	certificates := resources.NewCertificatesResource(nil, memoryCache, false)
+	server := &MCPServer{
+		config:         NewConfig(),
+		cache:          memoryCache,
+		resources:      map[string]interface{}{certificates.URI(): certificates},
+		sessionManager: NewSessionManager(time.Minute, 10),
+	}
+
+	w := httptest.NewRecorder()
+	server.handleListResources(w, httptest.NewRequest(http.MethodGet, "/mcp/resources", nil))
+	if !strings.Contains(w.Body.String(), `"cluster://certificates"`) {
+		t.Errorf("Expected cluster://certificates to be listed, got %s", w.Body.String())
+	}
+
+	session, err := server.sessionManager.CreateSession(nil)
+	if err != nil {
+		t.Fatalf("Failed to create session: %v", err)
+	}
+	req := httptest.NewRequest(http.MethodGet, "/mcp/resources/cluster://certificates/read?sessionid="+session.ID, nil)
+	w = httptest.NewRecorder()
+	server.handleResourceRead(w, req)
+	if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), "certificates") {
+		t.Errorf("Expected cluster://certificates to be readable, got %d: %s", w.Code, w.Body.String())
+	}
+}
@@ -4,23 +4,32 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"strings"
 	"time"
 
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/KubeHeal/openshift-cluster-health-mcp/pkg/capacity"
 	"github.com/KubeHeal/openshift-cluster-health-mcp/pkg/clients"
 )
 
 // AnalyzeScalingImpactTool provides MCP tool for analyzing replica scaling impact
 type AnalyzeScalingImpactTool struct {
-	ceClient  *clients.CoordinationEngineClient
-	k8sClient *clients.K8sClient
+	ceClient   *clients.CoordinationEngineClient
+	k8sClient  *clients.K8sClient
+	prometheus *clients.PrometheusClient
+	pricing    *PricingSource
 }
 
-// NewAnalyzeScalingImpactTool creates a new analyze-scaling-impact tool
-func NewAnalyzeScalingImpactTool(ceClient *clients.CoordinationEngineClient, k8sClient *clients.K8sClient) *AnalyzeScalingImpactTool {
+// NewAnalyzeScalingImpactTool creates a new analyze-scaling-impact tool;
+// prometheus may be nil, in which case etcd and API server metrics are skipped,
+// and pricing may be nil, in which case no cost estimates are made
+func NewAnalyzeScalingImpactTool(ceClient *clients.CoordinationEngineClient, k8sClient *clients.K8sClient, prometheus *clients.PrometheusClient, pricing *PricingSource) *AnalyzeScalingImpactTool {
 	return &AnalyzeScalingImpactTool{
-		ceClient:  ceClient,
-		k8sClient: k8sClient,
+		ceClient:   ceClient,
+		k8sClient:  k8sClient,
+		prometheus: prometheus,
+		pricing:    pricing,
 	}
 }
 
@@ -33,7 +42,9 @@ func (t *AnalyzeScalingImpactTool) Name() string {
 func (t *AnalyzeScalingImpactTool) Description() string {
 	return "Analyze the impact of scaling a deployment to a target replica count. " +
 		"Provides namespace resource impact analysis, performance predictions, " +
-		"infrastructure considerations, and alternative scaling scenarios. " +
+		"HorizontalPodAutoscaler bound conflicts, " +
+		"infrastructure considerations, and alternative scaling scenarios, " +
+		"with monthly cost deltas when a pricing model is configured. " +
 		"Useful for capacity planning and 'what-if' scaling decisions."
 }
 
@@ -67,7 +78,7 @@ func (t *AnalyzeScalingImpactTool) InputSchema() map[string]interface{} {
 			},
 			"include_infrastructure": map[string]interface{}{
 				"type":        "boolean",
-				"description": "Whether to analyze impact on infrastructure pods (etcd, API server, scheduler). Default: true.",
+				"description": "Whether to analyze control plane impact (etcd, API server, scheduler) from object counts and, when Prometheus is enabled, etcd size and API server latency. Default: true.",
 				"default":     true,
 			},
 		},
@@ -116,6 +127,10 @@ type NamespaceImpact struct {
 	MemoryUsedBytes        int64   `json:"memory_used_bytes,omitempty"`
 	CPUProjectedMillicores int64   `json:"cpu_projected_millicores,omitempty"`
 	MemoryProjectedBytes   int64   `json:"memory_projected_bytes,omitempty"`
+	HasQuota               bool    `json:"has_quota"`
+	// Constraints names the ResourceQuota (or cluster free capacity) behind
+	// the CPU and memory limits
+	Constraints map[string]capacity.QuotaConstraint `json:"constraints,omitempty"`
 }
 
 // InfrastructureImpact represents the impact on cluster infrastructure
@@ -124,6 +139,10 @@ type InfrastructureImpact struct {
 	APIServerImpact   string `json:"api_server_impact"`
 	SchedulerImpact   string `json:"scheduler_impact"`
 	EstimatedOverhead string `json:"estimated_overhead"`
+	// Basis holds the numbers behind each rating, keyed by component
+	Basis      map[string]string      `json:"basis,omitempty"`
+	Projection ControlPlaneProjection `json:"projection"`
+	Signals    ControlPlaneSignals    `json:"signals"`
 }
 
 // AlternativeScenario represents an alternative scaling scenario
@@ -131,6 +150,19 @@ type AlternativeScenario struct {
 	Replicas       int     `json:"replicas"`
 	ProjectedUsage float64 `json:"projected_usage"`
 	Safe           bool    `json:"safe"`
+	// MonthlyCostDelta is the cost change from the current replicas
+	MonthlyCostDelta *float64 `json:"monthly_cost_delta,omitempty"`
+}
+
+// ScalingCost prices the deployment's requests before and after scaling
+type ScalingCost struct {
+	Currency string                 `json:"currency"`
+	Rates    capacity.ResourceRates `json:"rates"`
+	// RatesBasis describes which nodes the blended rates come from
+	RatesBasis       string               `json:"rates_basis"`
+	CurrentMonthly   capacity.MonthlyCost `json:"current_monthly"`
+	ProjectedMonthly capacity.MonthlyCost `json:"projected_monthly"`
+	MonthlyDelta     capacity.MonthlyCost `json:"monthly_delta"`
 }
 
 // AnalyzeScalingImpactOutput represents the tool output
@@ -142,6 +174,8 @@ type AnalyzeScalingImpactOutput struct {
 	ProjectedState       ProjectedState        `json:"projected_state"`
 	NamespaceImpact      NamespaceImpact       `json:"namespace_impact"`
 	InfrastructureImpact *InfrastructureImpact `json:"infrastructure_impact,omitempty"`
+	Autoscaler           *AutoscalerContext    `json:"autoscaler,omitempty"`
+	Cost                 *ScalingCost          `json:"cost,omitempty"`
 	Warnings             []string              `json:"warnings"`
 	Recommendation       string                `json:"recommendation"`
 	AlternativeScenarios []AlternativeScenario `json:"alternative_scenarios"`
@@ -190,15 +224,9 @@ func (t *AnalyzeScalingImpactTool) Execute(ctx context.Context, args map[string]
 	}
 
 	// Get namespace quota information
-	quotaInfo, err := t.getNamespaceQuota(ctx, input.Namespace)
+	quotaInfo, err := t.getNamespaceQuota(ctx, input.Namespace, deploymentInfo.quotaTarget())
 	if err != nil {
-		// Use default quota if unavailable
-		quotaInfo = &NamespaceQuotaInfo{
-			CPULimitMillicores:    4000, // 4 cores
-			MemoryLimitBytes:      8 * 1024 * 1024 * 1024, // 8 GB
-			CPUUsedMillicores:     1000,
-			MemoryUsedBytes:       2 * 1024 * 1024 * 1024,
-		}
+		return nil, fmt.Errorf("failed to determine namespace capacity: %w", err)
 	}
 
 	// Calculate current state
@@ -241,7 +269,8 @@ func (t *AnalyzeScalingImpactTool) Execute(ctx context.Context, args map[string]
 		includeInfra = *input.IncludeInfrastructure
 	}
 	if includeInfra {
-		infraImpact = t.analyzeInfrastructureImpact(currentReplicas, input.TargetReplicas, input.Namespace)
+		signals := t.collectControlPlaneSignals(ctx, input.Namespace, input.Deployment)
+		infraImpact = t.analyzeInfrastructureImpact(currentReplicas, input.TargetReplicas, signals)
 	}
 
 	// Generate warnings
@@ -250,6 +279,20 @@ func (t *AnalyzeScalingImpactTool) Execute(ctx context.Context, args map[string]
 	// Generate recommendation
 	recommendation := t.generateRecommendation(namespaceImpact, infraImpact, input.TargetReplicas)
 
+	// Account for a HorizontalPodAutoscaler that would fight a manual scale
+	var autoscaler *AutoscalerContext
+	if hpas, err := t.k8sClient.ListHorizontalPodAutoscalers(ctx, input.Namespace); err != nil {
+		warnings = append(warnings, fmt.Sprintf("HorizontalPodAutoscalers not checked: %v", err))
+	} else if hpa := findDeploymentHPA(hpas.Items, input.Deployment); hpa != nil {
+		var hpaWarnings []string
+		var hpaRecommendation string
+		autoscaler, hpaWarnings, hpaRecommendation = autoscalerConflict(analyzeAutoscaler(hpa, nil), input.TargetReplicas)
+		warnings = append(hpaWarnings, warnings...)
+		if hpaRecommendation != "" {
+			recommendation = hpaRecommendation + " " + recommendation
+		}
+	}
+
 	// Generate alternative scenarios
 	alternatives := t.generateAlternativeScenarios(
 		currentReplicas,
@@ -259,6 +302,15 @@ func (t *AnalyzeScalingImpactTool) Execute(ctx context.Context, args map[string]
 		overheadFactor,
 	)
 
+	// Price the change when a pricing model is configured
+	cost, err := t.estimateCost(ctx, deploymentInfo, currentState, projectedState)
+	if err != nil {
+		warnings = append(warnings, fmt.Sprintf("Cost not estimated: %v", err))
+	} else if cost != nil {
+		priceAlternativeScenarios(alternatives, cost.Rates, currentState, projectedState)
+		recommendation += fmt.Sprintf(" Estimated cost change: %+.2f %s/month.", cost.MonthlyDelta.Total, cost.Currency)
+	}
+
 	output := AnalyzeScalingImpactOutput{
 		Status:               "success",
 		Deployment:           input.Deployment,
@@ -267,6 +319,8 @@ func (t *AnalyzeScalingImpactTool) Execute(ctx context.Context, args map[string]
 		ProjectedState:       projectedState,
 		NamespaceImpact:      namespaceImpact,
 		InfrastructureImpact: infraImpact,
+		Autoscaler:           autoscaler,
+		Cost:                 cost,
 		Warnings:             warnings,
 		Recommendation:       recommendation,
 		AlternativeScenarios: alternatives,
@@ -325,6 +379,16 @@ type DeploymentInfo struct {
 	MemoryRequest    int64 // bytes
 	CPULimit         int64
 	MemoryLimit      int64
+	PriorityClass    string
+	NodeSelector     map[string]string
+}
+
+// quotaTarget describes the deployment's pods for scoped quota matching
+func (d *DeploymentInfo) quotaTarget() capacity.QuotaTarget {
+	return capacity.QuotaTarget{
+		BestEffort:    d.CPURequest == 0 && d.MemoryRequest == 0 && d.CPULimit == 0 && d.MemoryLimit == 0,
+		PriorityClass: d.PriorityClass,
+	}
 }
 
 // getDeploymentInfo retrieves deployment information from Kubernetes
@@ -354,6 +418,8 @@ func (t *AnalyzeScalingImpactTool) getDeploymentInfo(ctx context.Context, namesp
 		MemoryRequest:     deployment.MemoryRequest,
 		CPULimit:          deployment.CPULimit,
 		MemoryLimit:       deployment.MemoryLimit,
+		PriorityClass:     deployment.PriorityClassName,
+		NodeSelector:      deployment.NodeSelector,
 	}
 
 	return info, nil
@@ -376,24 +442,21 @@ func (t *AnalyzeScalingImpactTool) getCurrentMetrics(ctx context.Context, namesp
 	var totalCPU, totalMemory int64
 	var podCount int
 
-	for _, pod := range podList.Items {
+	for i := range podList.Items {
+		pod := &podList.Items[i]
 		// Filter pods belonging to this deployment
-		if !strings.Contains(pod.Name, deployment) {
+		ref := capacity.WorkloadOf(pod)
+		if ref.Kind != "Deployment" || ref.Name != deployment {
 			continue
 		}
-		if pod.Status.Phase != "Running" {
+		if pod.Status.Phase != corev1.PodRunning {
 			continue
 		}
 
-		// Get resource requests from containers
-		for _, container := range pod.Spec.Containers {
-			if cpu := container.Resources.Requests.Cpu(); cpu != nil {
-				totalCPU += cpu.MilliValue()
-			}
-			if mem := container.Resources.Requests.Memory(); mem != nil {
-				totalMemory += mem.Value()
-			}
-		}
+		// Effective pod requests, including sidecars, init containers and overhead
+		requests := capacity.PodResourcesOf(pod).Requests
+		totalCPU += requests.MilliCPU
+		totalMemory += requests.Memory
 		podCount++
 	}
 
@@ -418,20 +481,15 @@ type NamespaceQuotaInfo struct {
 	CPUUsedMillicores     int64
 	MemoryUsedBytes       int64
 	HasQuota              bool
+	Constraints           map[string]capacity.QuotaConstraint
 }
 
-// getNamespaceQuota retrieves namespace resource quota information
-func (t *AnalyzeScalingImpactTool) getNamespaceQuota(ctx context.Context, namespace string) (*NamespaceQuotaInfo, error) {
-	quota, err := t.k8sClient.GetResourceQuota(ctx, namespace)
+// getNamespaceQuota retrieves the effective namespace capacity across all
+// ResourceQuotas, falling back to cluster free capacity without a quota
+func (t *AnalyzeScalingImpactTool) getNamespaceQuota(ctx context.Context, namespace string, target capacity.QuotaTarget) (*NamespaceQuotaInfo, error) {
+	quota, err := loadNamespaceCapacity(ctx, t.k8sClient, namespace, target, capacity.PlacementOptions{})
 	if err != nil {
-		// Return default quota if not found
-		return &NamespaceQuotaInfo{
-			CPULimitMillicores:    4000, // 4 cores
-			MemoryLimitBytes:      8 * 1024 * 1024 * 1024, // 8 GB
-			CPUUsedMillicores:     1000,
-			MemoryUsedBytes:       2 * 1024 * 1024 * 1024,
-			HasQuota:              false,
-		}, nil
+		return nil, err
 	}
 
 	return &NamespaceQuotaInfo{
@@ -439,7 +497,8 @@ func (t *AnalyzeScalingImpactTool) getNamespaceQuota(ctx context.Context, namesp
 		MemoryLimitBytes:      quota.MemoryLimitBytes,
 		CPUUsedMillicores:     quota.CPUUsedMillicores,
 		MemoryUsedBytes:       quota.MemoryUsedBytes,
-		HasQuota:              true,
+		HasQuota:              quota.HasQuota,
+		Constraints:           quota.Constraints,
 	}, nil
 }
 
@@ -492,64 +551,22 @@ func (t *AnalyzeScalingImpactTool) calculateNamespaceImpact(
 		MemoryUsedBytes:        quota.MemoryUsedBytes,
 		CPUProjectedMillicores: int64(projectedCPUUsed),
 		MemoryProjectedBytes:   int64(projectedMemUsed),
+		HasQuota:               quota.HasQuota,
+		Constraints:            quota.Constraints,
 	}
 }
 
-// analyzeInfrastructureImpact analyzes the impact on cluster infrastructure
-func (t *AnalyzeScalingImpactTool) analyzeInfrastructureImpact(currentReplicas, targetReplicas int, namespace string) *InfrastructureImpact {
-	replicaDelta := targetReplicas - currentReplicas
-	
-	// Calculate impact levels based on replica count changes
-	etcdImpact := "low"
-	apiServerImpact := "low"
-	schedulerImpact := "low"
-	
-	absChange := replicaDelta
-	if absChange < 0 {
-		absChange = -absChange
-	}
-
-	// etcd impact (object storage)
-	if absChange > 10 || targetReplicas > 20 {
-		etcdImpact = "high"
-	} else if absChange > 5 || targetReplicas > 10 {
-		etcdImpact = "medium"
-	}
+// analyzeInfrastructureImpact rates etcd, API server and scheduler impact from
+// the projected control plane load and the observed cluster signals
+func (t *AnalyzeScalingImpactTool) analyzeInfrastructureImpact(currentReplicas, targetReplicas int, signals ControlPlaneSignals) *InfrastructureImpact {
+	projection := projectControlPlaneLoad(currentReplicas, targetReplicas, signals)
+	etcdImpact, etcdBasis := rateEtcdImpact(projection, signals)
+	apiServerImpact, apiServerBasis := rateAPIServerImpact(projection, signals)
+	schedulerImpact, schedulerBasis := rateSchedulerImpact(projection, signals)
 
-	// API server impact (request rate)
-	if absChange > 8 || targetReplicas > 15 {
-		apiServerImpact = "high"
-	} else if absChange > 4 || targetReplicas > 8 {
-		apiServerImpact = "medium"
-	}
-
-	// Scheduler impact
-	if absChange > 5 {
-		schedulerImpact = "medium"
-	}
-	if absChange > 10 {
-		schedulerImpact = "high"
-	}
-
-	// Estimate overhead
-	overheadPct := absChange * 2
-	if overheadPct > 20 {
-		overheadPct = 20
-	}
-
-	estimatedOverhead := fmt.Sprintf("%d%% increase in control plane CPU", overheadPct)
-	if replicaDelta < 0 {
-		estimatedOverhead = fmt.Sprintf("%d%% decrease in control plane load", -overheadPct/2)
-	}
-
-	// Infrastructure namespaces have higher impact
-	if strings.HasPrefix(namespace, "openshift-") || strings.HasPrefix(namespace, "kube-") {
-		if etcdImpact == "low" {
-			etcdImpact = "medium"
-		}
-		if apiServerImpact == "low" {
-			apiServerImpact = "medium"
-		}
+	estimatedOverhead := fmt.Sprintf("%+d API objects, %s etcd storage", projection.ObjectDelta, formatSignedBytes(projection.StorageDeltaBytes))
+	if signals.PodWatchers > 0 {
+		estimatedOverhead += fmt.Sprintf(", %+d watch events", projection.WatchEventDelta)
 	}
 
 	return &InfrastructureImpact{
@@ -557,6 +574,13 @@ func (t *AnalyzeScalingImpactTool) analyzeInfrastructureImpact(currentReplicas,
 		APIServerImpact:   apiServerImpact,
 		SchedulerImpact:   schedulerImpact,
 		EstimatedOverhead: estimatedOverhead,
+		Basis: map[string]string{
+			"etcd":       etcdBasis,
+			"api_server": apiServerBasis,
+			"scheduler":  schedulerBasis,
+		},
+		Projection: projection,
+		Signals:    signals,
 	}
 }
 
@@ -691,6 +715,72 @@ func (t *AnalyzeScalingImpactTool) generateAlternativeScenarios(
 	return scenarios
 }
 
+// estimateCost prices the deployment's requests at the current and target
+// replica counts; it returns nil without a pricing model. Rates are blended
+// over the schedulable nodes matching the deployment's node selector.
+func (t *AnalyzeScalingImpactTool) estimateCost(ctx context.Context, info *DeploymentInfo, current CurrentState, projected ProjectedState) (*ScalingCost, error) {
+	model, err := t.pricing.Model(ctx)
+	if err != nil || model == nil {
+		return nil, err
+	}
+	nodes, err := t.k8sClient.ListNodes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	candidates, basis := pricingNodes(nodes.Items, info.NodeSelector)
+	rates := capacity.NewNodePricer(model, candidates).Blended()
+	return scalingCost(rates, model.Currency, basis, current, projected), nil
+}
+
+// pricingNodes selects the schedulable nodes matching a node selector,
+// falling back to all nodes when none match
+func pricingNodes(nodes []corev1.Node, nodeSelector map[string]string) ([]corev1.Node, string) {
+	selector := labels.SelectorFromSet(nodeSelector)
+	var matching []corev1.Node
+	for _, node := range nodes {
+		if node.Spec.Unschedulable || !selector.Matches(labels.Set(node.Labels)) {
+			continue
+		}
+		matching = append(matching, node)
+	}
+	if len(matching) == 0 {
+		return nodes, fmt.Sprintf("all %d nodes", len(nodes))
+	}
+	if len(nodeSelector) > 0 {
+		return matching, fmt.Sprintf("%d schedulable nodes matching the deployment's node selector", len(matching))
+	}
+	return matching, fmt.Sprintf("%d schedulable nodes", len(matching))
+}
+
+// scalingCost prices the current and projected requests (CPU in millicores,
+// memory in MB)
+func scalingCost(rates capacity.ResourceRates, currency, basis string, current CurrentState, projected ProjectedState) *ScalingCost {
+	currentCost := rates.MonthlyCost(current.TotalCPU, current.TotalMemory*1024*1024)
+	projectedCost := rates.MonthlyCost(projected.TotalCPU, projected.TotalMemory*1024*1024)
+	return &ScalingCost{
+		Currency:         currency,
+		Rates:            rates,
+		RatesBasis:       basis,
+		CurrentMonthly:   currentCost,
+		ProjectedMonthly: projectedCost,
+		MonthlyDelta:     projectedCost.Sub(currentCost),
+	}
+}
+
+// priceAlternativeScenarios sets each scenario's monthly cost delta from the
+// current replicas, using the projected per-pod requests for other counts
+func priceAlternativeScenarios(scenarios []AlternativeScenario, rates capacity.ResourceRates, current CurrentState, projected ProjectedState) {
+	currentCost := rates.MonthlyCost(current.TotalCPU, current.TotalMemory*1024*1024)
+	for i := range scenarios {
+		delta := 0.0
+		if replicas := float64(scenarios[i].Replicas); scenarios[i].Replicas != current.Replicas {
+			cost := rates.MonthlyCost(projected.CPUPerPodEst*replicas, projected.MemoryPerPodEst*replicas*1024*1024)
+			delta = cost.Sub(currentCost).Total
+		}
+		scenarios[i].MonthlyCostDelta = &delta
+	}
+}
+
 // maxFloat returns the maximum of two floats
 func maxFloat(a, b float64) float64 {
 	if a > b {
@@ -2,6 +2,11 @@ package tools
 
 import (
 	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/KubeHeal/openshift-cluster-health-mcp/pkg/capacity"
 )
 
 func TestAnalyzeScalingImpactTool_Name(t *testing.T) {
@@ -279,75 +284,89 @@ func TestAnalyzeScalingImpactTool_CalculateNamespaceImpact(t *testing.T) {
 func TestAnalyzeScalingImpactTool_AnalyzeInfrastructureImpact(t *testing.T) {
 	tool := &AnalyzeScalingImpactTool{}
 
+	// A 600-pod cluster with ~20k objects and 16Ki pods
+	cluster := ControlPlaneSignals{
+		ObjectSource:   SignalSourceAPI,
+		ObjectCounts:   map[string]int64{"pods": 600},
+		TotalObjects:   20000,
+		PodCount:       600,
+		PodObjectBytes: 16 * 1024,
+	}
+	// Same cluster with Prometheus: etcd near its quota and a slow API server
+	stressed := cluster
+	stressed.EtcdDBSizeBytes = 6600 * 1024 * 1024
+	stressed.EtcdQuotaBytes = 8 * 1024 * 1024 * 1024
+	stressed.APIServerP99Seconds = 1.5
+	stressed.PodWatchers = 40
+
 	testCases := []struct {
 		name            string
 		currentReplicas int
 		targetReplicas  int
-		namespace       string
-		expectHighAPI   bool
-		expectHighEtcd  bool
+		signals         ControlPlaneSignals
+		expectEtcd      string
+		expectAPI       string
+		expectScheduler string
 	}{
 		{
-			name:            "Small scale up",
+			name:            "Small scale up on a large cluster",
 			currentReplicas: 2,
-			targetReplicas:  4,
-			namespace:       "my-app",
-			expectHighAPI:   false,
-			expectHighEtcd:  false,
+			targetReplicas:  15,
+			signals:         cluster,
+			expectEtcd:      "low",
+			expectAPI:       "low",
+			expectScheduler: "low",
 		},
 		{
-			name:            "Large scale up",
+			name:            "Large scale up relative to the cluster",
 			currentReplicas: 2,
-			targetReplicas:  15,
-			namespace:       "my-app",
-			expectHighAPI:   true,
-			expectHighEtcd:  true,
+			targetReplicas:  402,
+			signals:         cluster,
+			expectEtcd:      "high",
+			expectAPI:       "high",
+			expectScheduler: "high",
 		},
 		{
-			name:            "Infrastructure namespace",
+			name:            "Moderate scale up on a stressed control plane",
 			currentReplicas: 2,
-			targetReplicas:  5,
-			namespace:       "openshift-monitoring",
-			expectHighAPI:   false, // But should be medium due to infra namespace
-			expectHighEtcd:  false,
+			targetReplicas:  40,
+			signals:         stressed,
+			expectEtcd:      "high",
+			expectAPI:       "high",
+			expectScheduler: "low",
 		},
 		{
 			name:            "Scale down",
-			currentReplicas: 10,
+			currentReplicas: 400,
 			targetReplicas:  5,
-			namespace:       "production",
-			expectHighAPI:   false,
-			expectHighEtcd:  false,
+			signals:         stressed,
+			expectEtcd:      "low",
+			expectAPI:       "low",
+			expectScheduler: "low",
 		},
 	}
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			impact := tool.analyzeInfrastructureImpact(tc.currentReplicas, tc.targetReplicas, tc.namespace)
+			impact := tool.analyzeInfrastructureImpact(tc.currentReplicas, tc.targetReplicas, tc.signals)
 
 			if impact == nil {
 				t.Fatal("Expected non-nil infrastructure impact")
 			}
-
-			if tc.expectHighAPI && impact.APIServerImpact != "high" {
-				t.Errorf("Expected high API server impact, got '%s'", impact.APIServerImpact)
-			}
-			if tc.expectHighEtcd && impact.EtcdImpact != "high" {
-				t.Errorf("Expected high etcd impact, got '%s'", impact.EtcdImpact)
+			if impact.EtcdImpact != tc.expectEtcd {
+				t.Errorf("etcd impact = %s, want %s (%s)", impact.EtcdImpact, tc.expectEtcd, impact.Basis["etcd"])
 			}
-
-			// Verify valid impact levels
-			validLevels := map[string]bool{"low": true, "medium": true, "high": true}
-			if !validLevels[impact.EtcdImpact] {
-				t.Errorf("Invalid etcd impact level: %s", impact.EtcdImpact)
+			if impact.APIServerImpact != tc.expectAPI {
+				t.Errorf("API server impact = %s, want %s (%s)", impact.APIServerImpact, tc.expectAPI, impact.Basis["api_server"])
 			}
-			if !validLevels[impact.APIServerImpact] {
-				t.Errorf("Invalid API server impact level: %s", impact.APIServerImpact)
+			if impact.SchedulerImpact != tc.expectScheduler {
+				t.Errorf("scheduler impact = %s, want %s (%s)", impact.SchedulerImpact, tc.expectScheduler, impact.Basis["scheduler"])
 			}
-			if !validLevels[impact.SchedulerImpact] {
-				t.Errorf("Invalid scheduler impact level: %s", impact.SchedulerImpact)
+			for _, component := range []string{"etcd", "api_server", "scheduler"} {
+				if impact.Basis[component] == "" {
+					t.Errorf("Expected numeric basis for %s", component)
+				}
 			}
-
 			if impact.EstimatedOverhead == "" {
 				t.Error("Expected non-empty estimated overhead")
 			}
@@ -355,6 +374,30 @@ func TestAnalyzeScalingImpactTool_AnalyzeInfrastructureImpact(t *testing.T) {
 	}
 }
 
+func TestProjectControlPlaneLoad(t *testing.T) {
+	signals := ControlPlaneSignals{TotalObjects: 1200, PodCount: 100, PodObjectBytes: 4096, PodWatchers: 10}
+
+	p := projectControlPlaneLoad(2, 12, signals)
+
+	if p.ObjectDelta != 60 {
+		t.Errorf("object delta = %d, want 60 (pod + 5 events per replica)", p.ObjectDelta)
+	}
+	if p.StorageDeltaBytes != 10*(4096+5*1024) {
+		t.Errorf("storage delta = %d, want %d", p.StorageDeltaBytes, 10*(4096+5*1024))
+	}
+	if p.WatchEventDelta != 500 {
+		t.Errorf("watch event delta = %d, want 500", p.WatchEventDelta)
+	}
+	if p.ObjectGrowthPercent != 5 || p.PodGrowthPercent != 10 {
+		t.Errorf("growth = %.1f%% objects, %.1f%% pods; want 5%%, 10%%", p.ObjectGrowthPercent, p.PodGrowthPercent)
+	}
+
+	_, basis := rateEtcdImpact(p, ControlPlaneSignals{TotalObjects: 1200, EtcdDBSizeBytes: 1 << 30, EtcdQuotaBytes: 8 << 30})
+	if !contains(basis, "etcd DB 1Gi of 8Gi quota") {
+		t.Errorf("etcd basis missing DB utilization: %s", basis)
+	}
+}
+
 func TestAnalyzeScalingImpactTool_GenerateWarnings(t *testing.T) {
 	tool := &AnalyzeScalingImpactTool{}
 
@@ -746,7 +789,7 @@ func TestAnalyzeScalingImpactTool_Execute_Integration(t *testing.T) {
 
 		ceClient := clients.NewCoordinationEngineClient("http://localhost:8000")
 
-		tool := NewAnalyzeScalingImpactTool(ceClient, k8sClient)
+		tool := NewAnalyzeScalingImpactTool(ceClient, k8sClient, nil, nil)
 		ctx := context.Background()
 
 		result, err := tool.Execute(ctx, map[string]interface{}{
@@ -822,3 +865,42 @@ func toLower(s string) string {
 	}
 	return string(result)
 }
+
+func TestScalingCost(t *testing.T) {
+	rates := capacity.ResourceRates{CPUCoreHour: 0.04, MemoryGiBHour: 0.005}
+	current := CurrentState{Replicas: 2, CPUPerPodAvg: 500, MemoryPerPodAvg: 1024, TotalCPU: 1000, TotalMemory: 2048}
+	projected := ProjectedState{Replicas: 4, CPUPerPodEst: 500, MemoryPerPodEst: 1024, TotalCPU: 2000, TotalMemory: 4096}
+
+	cost := scalingCost(rates, "USD", "2 schedulable nodes", current, projected)
+	// 1 core + 2 GiB per 2 replicas: 29.20 + 7.30 a month
+	if cost.CurrentMonthly.Total != 36.5 || cost.ProjectedMonthly.Total != 73 || cost.MonthlyDelta.Total != 36.5 {
+		t.Errorf("cost = %+v", cost)
+	}
+
+	scenarios := []AlternativeScenario{{Replicas: 3}, {Replicas: 2}}
+	priceAlternativeScenarios(scenarios, rates, current, projected)
+	if d := scenarios[0].MonthlyCostDelta; d == nil || *d != 18.25 {
+		t.Errorf("3 replicas delta = %v, want 18.25", d)
+	}
+	if d := scenarios[1].MonthlyCostDelta; d == nil || *d != 0 {
+		t.Errorf("current replicas delta = %v, want 0", d)
+	}
+}
+
+func TestPricingNodes(t *testing.T) {
+	infra := corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "infra", Labels: map[string]string{"tier": "infra"}}}
+	worker := corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "worker", Labels: map[string]string{"tier": "app"}}}
+	cordoned := corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "cordoned", Labels: map[string]string{"tier": "app"}}}
+	cordoned.Spec.Unschedulable = true
+	nodes := []corev1.Node{infra, worker, cordoned}
+
+	if matched, basis := pricingNodes(nodes, map[string]string{"tier": "app"}); len(matched) != 1 || matched[0].Name != "worker" || !contains(basis, "node selector") {
+		t.Errorf("selector match = %v (%s)", matched, basis)
+	}
+	if matched, _ := pricingNodes(nodes, nil); len(matched) != 2 {
+		t.Errorf("expected the 2 schedulable nodes, got %d", len(matched))
+	}
+	if matched, basis := pricingNodes(nodes, map[string]string{"tier": "gpu"}); len(matched) != 3 || !contains(basis, "all 3") {
+		t.Errorf("no match should fall back to all nodes, got %d (%s)", len(matched), basis)
+	}
+}
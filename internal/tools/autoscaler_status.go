@@ -0,0 +1,475 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/KubeHeal/openshift-cluster-health-mcp/pkg/clients"
+)
+
+// Autoscaler issues
+const (
+	AutoscalerPinnedAtMax        = "pinned_at_max"
+	AutoscalerMetricsUnavailable = "metrics_unavailable"
+	AutoscalerUnableToScale      = "unable_to_scale"
+	AutoscalerFlapping           = "flapping"
+)
+
+const (
+	defaultFlapWindowMinutes = 60
+	maxFlapWindowMinutes     = 24 * 60
+	// flapRescaleThreshold is the number of rescales within the window that,
+	// across more than one size, counts as flapping
+	flapRescaleThreshold = 4
+)
+
+// rescaleSizePattern extracts the new size from SuccessfulRescale events
+var rescaleSizePattern = regexp.MustCompile(`New size: (\d+)`)
+
+// AutoscalerStatusTool reports HorizontalPodAutoscaler health via MCP
+type AutoscalerStatusTool struct {
+	k8sClient *clients.K8sClient
+}
+
+// NewAutoscalerStatusTool creates a new get-autoscaler-status tool
+func NewAutoscalerStatusTool(k8sClient *clients.K8sClient) *AutoscalerStatusTool {
+	return &AutoscalerStatusTool{
+		k8sClient: k8sClient,
+	}
+}
+
+// Name returns the tool name for MCP registration
+func (t *AutoscalerStatusTool) Name() string {
+	return "get-autoscaler-status"
+}
+
+// Description returns the tool description for MCP
+func (t *AutoscalerStatusTool) Description() string {
+	return "List HorizontalPodAutoscalers that need attention: pinned at maxReplicas, unable to fetch metrics or scale, or flapping (repeated rescales across sizes). Shows min/max bounds, current/desired replicas and each metric's current value against its target"
+}
+
+// InputSchema returns the JSON schema for tool inputs
+func (t *AutoscalerStatusTool) InputSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"namespace": map[string]interface{}{
+				"type":        "string",
+				"description": "Namespace to check (empty = all namespaces)",
+				"default":     "",
+			},
+			"include_healthy": map[string]interface{}{
+				"type":        "boolean",
+				"description": "Also list HPAs without issues",
+				"default":     false,
+			},
+			"flap_window_minutes": map[string]interface{}{
+				"type":        "integer",
+				"description": "Window of rescale events used to detect flapping",
+				"default":     defaultFlapWindowMinutes,
+				"minimum":     5,
+				"maximum":     maxFlapWindowMinutes,
+			},
+		},
+		"required": []string{},
+	}
+}
+
+// AutoscalerStatusInput represents the input parameters
+type AutoscalerStatusInput struct {
+	Namespace         string `json:"namespace"`
+	IncludeHealthy    bool   `json:"include_healthy"`
+	FlapWindowMinutes int    `json:"flap_window_minutes"`
+}
+
+// HPAMetric is one autoscaling metric with its target and current value
+type HPAMetric struct {
+	Type    string `json:"type"`
+	Name    string `json:"name"`
+	Target  string `json:"target"`
+	Current string `json:"current,omitempty"`
+}
+
+// AutoscalerStatus summarizes one HorizontalPodAutoscaler
+type AutoscalerStatus struct {
+	Namespace       string      `json:"namespace"`
+	Name            string      `json:"name"`
+	Target          string      `json:"target"`
+	MinReplicas     int32       `json:"min_replicas"`
+	MaxReplicas     int32       `json:"max_replicas"`
+	CurrentReplicas int32       `json:"current_replicas"`
+	DesiredReplicas int32       `json:"desired_replicas"`
+	Metrics         []HPAMetric `json:"metrics"`
+	RecentRescales  int32       `json:"recent_rescales"`
+	RescaleSizes    []int32     `json:"rescale_sizes,omitempty"`
+	LastScaleTime   *time.Time  `json:"last_scale_time,omitempty"`
+	Issues          []string    `json:"issues"`
+	Details         []string    `json:"details,omitempty"`
+}
+
+// AutoscalerStatusOutput represents the tool output
+type AutoscalerStatusOutput struct {
+	Namespace   string             `json:"namespace,omitempty"`
+	Total       int                `json:"total"`
+	Healthy     int                `json:"healthy"`
+	IssueCounts map[string]int     `json:"issue_counts"`
+	Autoscalers []AutoscalerStatus `json:"autoscalers"`
+	Warnings    []string           `json:"warnings,omitempty"`
+	Message     string             `json:"message"`
+}
+
+// Execute lists HPAs and their issues
+func (t *AutoscalerStatusTool) Execute(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+	input := AutoscalerStatusInput{FlapWindowMinutes: defaultFlapWindowMinutes}
+	if argsJSON, err := json.Marshal(args); err == nil {
+		_ = json.Unmarshal(argsJSON, &input) //nolint:errcheck // Intentionally ignore error, use defaults if unmarshal fails
+	}
+	if input.FlapWindowMinutes < 1 {
+		input.FlapWindowMinutes = defaultFlapWindowMinutes
+	}
+	if input.FlapWindowMinutes > maxFlapWindowMinutes {
+		input.FlapWindowMinutes = maxFlapWindowMinutes
+	}
+
+	hpas, err := t.k8sClient.ListHorizontalPodAutoscalers(ctx, input.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	var warnings []string
+	rescales, err := loadRescaleEvents(ctx, t.k8sClient, input.Namespace, time.Duration(input.FlapWindowMinutes)*time.Minute)
+	if err != nil {
+		warnings = append(warnings, fmt.Sprintf("Flapping not evaluated: %v", err))
+	}
+
+	output := summarizeAutoscalers(hpas.Items, rescales, input.IncludeHealthy)
+	output.Namespace = input.Namespace
+	output.Warnings = warnings
+	output.Message = autoscalerStatusMessage(&output, input.FlapWindowMinutes)
+	return output, nil
+}
+
+// loadRescaleEvents returns recent SuccessfulRescale events keyed by namespace/name
+func loadRescaleEvents(ctx context.Context, k8sClient *clients.K8sClient, namespace string, window time.Duration) (map[string][]clients.Event, error) {
+	events, err := k8sClient.ListEventTimeline(ctx, clients.EventFilter{
+		Namespace: namespace,
+		Kind:      "HorizontalPodAutoscaler",
+		Reason:    "SuccessfulRescale",
+		Since:     time.Now().Add(-window),
+	})
+	if err != nil {
+		return nil, err
+	}
+	byHPA := make(map[string][]clients.Event)
+	for _, e := range events {
+		key := e.Namespace + "/" + e.Name
+		byHPA[key] = append(byHPA[key], e)
+	}
+	return byHPA, nil
+}
+
+// summarizeAutoscalers analyzes each HPA, listing those with issues first
+func summarizeAutoscalers(hpas []autoscalingv2.HorizontalPodAutoscaler, rescales map[string][]clients.Event, includeHealthy bool) AutoscalerStatusOutput {
+	output := AutoscalerStatusOutput{
+		Total:       len(hpas),
+		IssueCounts: make(map[string]int),
+		Autoscalers: []AutoscalerStatus{},
+	}
+	for i := range hpas {
+		hpa := &hpas[i]
+		status := analyzeAutoscaler(hpa, rescales[hpa.Namespace+"/"+hpa.Name])
+		for _, issue := range status.Issues {
+			output.IssueCounts[issue]++
+		}
+		if len(status.Issues) == 0 {
+			output.Healthy++
+			if !includeHealthy {
+				continue
+			}
+		}
+		output.Autoscalers = append(output.Autoscalers, status)
+	}
+	sort.SliceStable(output.Autoscalers, func(i, j int) bool {
+		a, b := output.Autoscalers[i], output.Autoscalers[j]
+		if len(a.Issues) != len(b.Issues) {
+			return len(a.Issues) > len(b.Issues)
+		}
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		return a.Name < b.Name
+	})
+	return output
+}
+
+// analyzeAutoscaler derives bounds, metric values and issues from an HPA's
+// status, conditions and recent rescale events
+func analyzeAutoscaler(hpa *autoscalingv2.HorizontalPodAutoscaler, rescales []clients.Event) AutoscalerStatus {
+	status := AutoscalerStatus{
+		Namespace:       hpa.Namespace,
+		Name:            hpa.Name,
+		Target:          hpa.Spec.ScaleTargetRef.Kind + "/" + hpa.Spec.ScaleTargetRef.Name,
+		MinReplicas:     clients.DesiredReplicas(hpa.Spec.MinReplicas),
+		MaxReplicas:     hpa.Spec.MaxReplicas,
+		CurrentReplicas: hpa.Status.CurrentReplicas,
+		DesiredReplicas: hpa.Status.DesiredReplicas,
+		Metrics:         hpaMetrics(hpa),
+		Issues:          []string{},
+	}
+	if hpa.Status.LastScaleTime != nil {
+		last := hpa.Status.LastScaleTime.Time
+		status.LastScaleTime = &last
+	}
+
+	conditions := make(map[autoscalingv2.HorizontalPodAutoscalerConditionType]autoscalingv2.HorizontalPodAutoscalerCondition)
+	for _, c := range hpa.Status.Conditions {
+		conditions[c.Type] = c
+	}
+
+	limited, ok := conditions[autoscalingv2.ScalingLimited]
+	limitedAtMax := ok && limited.Status == corev1.ConditionTrue && limited.Reason == "TooManyReplicas"
+	if status.MaxReplicas > 0 && status.CurrentReplicas >= status.MaxReplicas && (status.DesiredReplicas >= status.MaxReplicas || limitedAtMax) {
+		status.Issues = append(status.Issues, AutoscalerPinnedAtMax)
+		detail := fmt.Sprintf("running at maxReplicas (%d)", status.MaxReplicas)
+		if limitedAtMax {
+			detail += ": " + limited.Message
+		}
+		status.Details = append(status.Details, detail)
+	}
+
+	active, ok := conditions[autoscalingv2.ScalingActive]
+	inactive := ok && active.Status == corev1.ConditionFalse
+	if inactive && active.Reason == "ScalingDisabled" {
+		// The target was scaled to zero: the HPA is paused, not failing
+		status.Details = append(status.Details, fmt.Sprintf("ScalingActive=False (%s): %s", active.Reason, active.Message))
+	} else if inactive {
+		status.Issues = append(status.Issues, AutoscalerMetricsUnavailable)
+		status.Details = append(status.Details, fmt.Sprintf("ScalingActive=False (%s): %s", active.Reason, active.Message))
+	} else if len(hpa.Spec.Metrics) > 0 && len(hpa.Status.CurrentMetrics) == 0 && status.CurrentReplicas > 0 {
+		status.Issues = append(status.Issues, AutoscalerMetricsUnavailable)
+		status.Details = append(status.Details, "no current metric values reported")
+	}
+
+	if able, ok := conditions[autoscalingv2.AbleToScale]; ok && able.Status == corev1.ConditionFalse {
+		status.Issues = append(status.Issues, AutoscalerUnableToScale)
+		status.Details = append(status.Details, fmt.Sprintf("AbleToScale=False (%s): %s", able.Reason, able.Message))
+	}
+
+	sizes := make(map[int32]bool)
+	for _, e := range rescales {
+		status.RecentRescales += e.Count
+		if m := rescaleSizePattern.FindStringSubmatch(e.Message); m != nil {
+			if size, err := strconv.ParseInt(m[1], 10, 32); err == nil && !sizes[int32(size)] {
+				sizes[int32(size)] = true
+				status.RescaleSizes = append(status.RescaleSizes, int32(size))
+			}
+		}
+	}
+	if status.RecentRescales >= flapRescaleThreshold && len(sizes) >= 2 {
+		status.Issues = append(status.Issues, AutoscalerFlapping)
+		status.Details = append(status.Details, fmt.Sprintf("%d rescales between sizes %s; consider a scale-down stabilization window (spec.behavior)",
+			status.RecentRescales, joinInt32(status.RescaleSizes)))
+	}
+	return status
+}
+
+// hpaMetrics pairs each metric spec with its current value
+func hpaMetrics(hpa *autoscalingv2.HorizontalPodAutoscaler) []HPAMetric {
+	current := make(map[string]autoscalingv2.MetricValueStatus)
+	for _, m := range hpa.Status.CurrentMetrics {
+		if name, value, ok := metricStatusValue(m); ok {
+			current[string(m.Type)+"/"+name] = value
+		}
+	}
+	metrics := make([]HPAMetric, 0, len(hpa.Spec.Metrics))
+	for _, spec := range hpa.Spec.Metrics {
+		name, target := metricSpecTarget(spec)
+		metric := HPAMetric{Type: string(spec.Type), Name: name, Target: formatMetricTarget(target)}
+		if value, ok := current[string(spec.Type)+"/"+name]; ok {
+			metric.Current = formatMetricValue(value)
+		}
+		metrics = append(metrics, metric)
+	}
+	return metrics
+}
+
+// metricSpecTarget returns a metric's name and target
+func metricSpecTarget(spec autoscalingv2.MetricSpec) (string, autoscalingv2.MetricTarget) {
+	switch {
+	case spec.Resource != nil:
+		return string(spec.Resource.Name), spec.Resource.Target
+	case spec.ContainerResource != nil:
+		return spec.ContainerResource.Container + "/" + string(spec.ContainerResource.Name), spec.ContainerResource.Target
+	case spec.Pods != nil:
+		return spec.Pods.Metric.Name, spec.Pods.Target
+	case spec.Object != nil:
+		return spec.Object.Metric.Name, spec.Object.Target
+	case spec.External != nil:
+		return spec.External.Metric.Name, spec.External.Target
+	}
+	return "", autoscalingv2.MetricTarget{}
+}
+
+// metricStatusValue returns a metric status' name and current value
+func metricStatusValue(status autoscalingv2.MetricStatus) (string, autoscalingv2.MetricValueStatus, bool) {
+	switch {
+	case status.Resource != nil:
+		return string(status.Resource.Name), status.Resource.Current, true
+	case status.ContainerResource != nil:
+		return status.ContainerResource.Container + "/" + string(status.ContainerResource.Name), status.ContainerResource.Current, true
+	case status.Pods != nil:
+		return status.Pods.Metric.Name, status.Pods.Current, true
+	case status.Object != nil:
+		return status.Object.Metric.Name, status.Object.Current, true
+	case status.External != nil:
+		return status.External.Metric.Name, status.External.Current, true
+	}
+	return "", autoscalingv2.MetricValueStatus{}, false
+}
+
+func formatMetricTarget(target autoscalingv2.MetricTarget) string {
+	switch {
+	case target.AverageUtilization != nil:
+		return fmt.Sprintf("%d%%", *target.AverageUtilization)
+	case target.AverageValue != nil:
+		return target.AverageValue.String() + " avg"
+	case target.Value != nil:
+		return target.Value.String()
+	}
+	return ""
+}
+
+func formatMetricValue(value autoscalingv2.MetricValueStatus) string {
+	switch {
+	case value.AverageUtilization != nil:
+		return fmt.Sprintf("%d%%", *value.AverageUtilization)
+	case value.AverageValue != nil:
+		return value.AverageValue.String() + " avg"
+	case value.Value != nil:
+		return value.Value.String()
+	}
+	return ""
+}
+
+// describeMetrics renders metrics as "cpu 85% / 70%" pairs
+func describeMetrics(metrics []HPAMetric) string {
+	parts := make([]string, 0, len(metrics))
+	for _, m := range metrics {
+		current := m.Current
+		if current == "" {
+			current = "unknown"
+		}
+		parts = append(parts, fmt.Sprintf("%s %s / target %s", m.Name, current, m.Target))
+	}
+	return strings.Join(parts, ", ")
+}
+
+func joinInt32(values []int32) string {
+	parts := make([]string, len(values))
+	for i, v := range values {
+		parts[i] = strconv.Itoa(int(v))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// autoscalerStatusMessage summarizes the HPA health
+func autoscalerStatusMessage(output *AutoscalerStatusOutput, flapWindowMinutes int) string {
+	if output.Total == 0 {
+		return "No HorizontalPodAutoscalers found"
+	}
+	if output.Healthy == output.Total {
+		return fmt.Sprintf("All %d HorizontalPodAutoscalers are healthy", output.Total)
+	}
+	msg := fmt.Sprintf("%d of %d HorizontalPodAutoscalers need attention:", output.Total-output.Healthy, output.Total)
+	var parts []string
+	for _, issue := range []string{AutoscalerPinnedAtMax, AutoscalerMetricsUnavailable, AutoscalerUnableToScale, AutoscalerFlapping} {
+		if n := output.IssueCounts[issue]; n > 0 {
+			label := strings.ReplaceAll(issue, "_", " ")
+			if issue == AutoscalerFlapping {
+				label += fmt.Sprintf(" (last %dm)", flapWindowMinutes)
+			}
+			parts = append(parts, fmt.Sprintf("%d %s", n, label))
+		}
+	}
+	return msg + " " + strings.Join(parts, ", ")
+}
+
+// HPA conflicts with a manual scaling target
+const (
+	HPAConflictAboveMax   = "above_max"
+	HPAConflictBelowMin   = "below_min"
+	HPAConflictHPAManaged = "hpa_managed"
+)
+
+// AutoscalerContext describes the HPA that manages a scaled deployment
+type AutoscalerContext struct {
+	AutoscalerStatus
+	// EffectiveReplicas is where the HPA will converge regardless of a
+	// manual change: its desired replicas within min/max
+	EffectiveReplicas int    `json:"effective_replicas"`
+	Conflict          string `json:"conflict"`
+}
+
+// findDeploymentHPA returns the HPA targeting a deployment, if any
+func findDeploymentHPA(hpas []autoscalingv2.HorizontalPodAutoscaler, deployment string) *autoscalingv2.HorizontalPodAutoscaler {
+	for i := range hpas {
+		ref := hpas[i].Spec.ScaleTargetRef
+		if ref.Kind == "Deployment" && ref.Name == deployment {
+			return &hpas[i]
+		}
+	}
+	return nil
+}
+
+// autoscalerConflict checks a manual target replica count against the HPA
+// and returns the context, warnings and a recommendation
+func autoscalerConflict(status AutoscalerStatus, targetReplicas int) (*AutoscalerContext, []string, string) {
+	desired := status.DesiredReplicas
+	if desired == 0 {
+		desired = status.CurrentReplicas
+	}
+	effective := min(max(int(desired), int(status.MinReplicas)), int(status.MaxReplicas))
+	hpaContext := &AutoscalerContext{AutoscalerStatus: status, EffectiveReplicas: effective}
+
+	bounds := fmt.Sprintf("HPA %s (min %d, max %d", status.Name, status.MinReplicas, status.MaxReplicas)
+	if len(status.Metrics) > 0 {
+		bounds += "; " + describeMetrics(status.Metrics)
+	}
+	bounds += ")"
+
+	var warnings []string
+	var recommendation string
+	switch {
+	case targetReplicas > int(status.MaxReplicas):
+		hpaContext.Conflict = HPAConflictAboveMax
+		warnings = append(warnings, fmt.Sprintf("CONFLICT: %s will scale the deployment back from %d to at most %d replicas", bounds, targetReplicas, status.MaxReplicas))
+		recommendation = fmt.Sprintf("Raise maxReplicas on HPA %s to at least %d instead of scaling the deployment directly.", status.Name, targetReplicas)
+	case targetReplicas < int(status.MinReplicas):
+		hpaContext.Conflict = HPAConflictBelowMin
+		warnings = append(warnings, fmt.Sprintf("CONFLICT: %s will scale the deployment back up from %d to at least %d replicas", bounds, targetReplicas, status.MinReplicas))
+		recommendation = fmt.Sprintf("Lower minReplicas on HPA %s to %d instead of scaling the deployment directly.", status.Name, targetReplicas)
+	default:
+		hpaContext.Conflict = HPAConflictHPAManaged
+		if targetReplicas != effective {
+			warnings = append(warnings, fmt.Sprintf("%s manages replicas: a manual scale to %d converges back to ~%d as the HPA re-evaluates its metrics", bounds, targetReplicas, effective))
+			recommendation = fmt.Sprintf("Adjust minReplicas or the metric targets on HPA %s to hold %d replicas.", status.Name, targetReplicas)
+		}
+	}
+	for _, issue := range status.Issues {
+		switch issue {
+		case AutoscalerPinnedAtMax:
+			warnings = append(warnings, fmt.Sprintf("HPA %s is already pinned at maxReplicas (%d)", status.Name, status.MaxReplicas))
+		case AutoscalerMetricsUnavailable:
+			warnings = append(warnings, fmt.Sprintf("HPA %s cannot fetch its metrics and is not scaling", status.Name))
+		}
+	}
+	return hpaContext, warnings, recommendation
+}
@@ -0,0 +1,205 @@
+package tools
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/KubeHeal/openshift-cluster-health-mcp/pkg/clients"
+)
+
+func testHPA(name string, min, max, current, desired int32, targetCPU int32, currentCPU *int32) autoscalingv2.HorizontalPodAutoscaler {
+	hpa := autoscalingv2.HorizontalPodAutoscaler{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "app"},
+		Spec: autoscalingv2.HorizontalPodAutoscalerSpec{
+			ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{Kind: "Deployment", Name: name},
+			MinReplicas:    &min,
+			MaxReplicas:    max,
+			Metrics: []autoscalingv2.MetricSpec{{
+				Type: autoscalingv2.ResourceMetricSourceType,
+				Resource: &autoscalingv2.ResourceMetricSource{
+					Name:   corev1.ResourceCPU,
+					Target: autoscalingv2.MetricTarget{Type: autoscalingv2.UtilizationMetricType, AverageUtilization: &targetCPU},
+				},
+			}},
+		},
+		Status: autoscalingv2.HorizontalPodAutoscalerStatus{CurrentReplicas: current, DesiredReplicas: desired},
+	}
+	if currentCPU != nil {
+		hpa.Status.CurrentMetrics = []autoscalingv2.MetricStatus{{
+			Type: autoscalingv2.ResourceMetricSourceType,
+			Resource: &autoscalingv2.ResourceMetricStatus{
+				Name:    corev1.ResourceCPU,
+				Current: autoscalingv2.MetricValueStatus{AverageUtilization: currentCPU},
+			},
+		}}
+	}
+	return hpa
+}
+
+func TestAutoscalerStatusTool_Metadata(t *testing.T) {
+	tool := NewAutoscalerStatusTool(nil)
+	if tool.Name() != "get-autoscaler-status" {
+		t.Errorf("unexpected name %s", tool.Name())
+	}
+	props := tool.InputSchema()["properties"].(map[string]interface{})
+	for _, key := range []string{"namespace", "include_healthy", "flap_window_minutes"} {
+		if _, ok := props[key]; !ok {
+			t.Errorf("missing input %s", key)
+		}
+	}
+}
+
+func TestAnalyzeAutoscaler(t *testing.T) {
+	util := int32(140)
+	pinned := testHPA("api", 2, 5, 5, 5, 70, &util)
+	pinned.Status.Conditions = []autoscalingv2.HorizontalPodAutoscalerCondition{{
+		Type: autoscalingv2.ScalingLimited, Status: corev1.ConditionTrue, Reason: "TooManyReplicas", Message: "the desired replica count is more than the maximum replica count",
+	}}
+
+	status := analyzeAutoscaler(&pinned, nil)
+	if len(status.Issues) != 1 || status.Issues[0] != AutoscalerPinnedAtMax {
+		t.Fatalf("expected pinned_at_max, got %v", status.Issues)
+	}
+	if status.Target != "Deployment/api" || status.MinReplicas != 2 || status.MaxReplicas != 5 {
+		t.Errorf("unexpected bounds: %+v", status)
+	}
+	if len(status.Metrics) != 1 || status.Metrics[0].Current != "140%" || status.Metrics[0].Target != "70%" {
+		t.Errorf("unexpected metrics: %+v", status.Metrics)
+	}
+
+	broken := testHPA("web", 1, 10, 3, 3, 80, nil)
+	broken.Status.Conditions = []autoscalingv2.HorizontalPodAutoscalerCondition{{
+		Type: autoscalingv2.ScalingActive, Status: corev1.ConditionFalse, Reason: "FailedGetResourceMetric", Message: "unable to get metrics for resource cpu",
+	}}
+	status = analyzeAutoscaler(&broken, nil)
+	if len(status.Issues) != 1 || status.Issues[0] != AutoscalerMetricsUnavailable {
+		t.Fatalf("expected metrics_unavailable, got %v", status.Issues)
+	}
+	if !strings.Contains(status.Details[0], "FailedGetResourceMetric") {
+		t.Errorf("expected condition reason in details, got %v", status.Details)
+	}
+
+	disabled := testHPA("batch", 1, 10, 0, 0, 80, nil)
+	disabled.Status.Conditions = []autoscalingv2.HorizontalPodAutoscalerCondition{{
+		Type: autoscalingv2.ScalingActive, Status: corev1.ConditionFalse, Reason: "ScalingDisabled", Message: "scaling is disabled since the replica count of the target is zero",
+	}}
+	status = analyzeAutoscaler(&disabled, nil)
+	if len(status.Issues) != 0 || len(status.Details) != 1 || !strings.Contains(status.Details[0], "ScalingDisabled") {
+		t.Errorf("expected a scaled-to-zero HPA to be reported without issues, got %v (%v)", status.Issues, status.Details)
+	}
+}
+
+func TestAnalyzeAutoscaler_Flapping(t *testing.T) {
+	util := int32(60)
+	hpa := testHPA("api", 2, 10, 4, 4, 70, &util)
+	now := time.Now()
+	rescales := []clients.Event{
+		{Namespace: "app", Name: "api", Reason: "SuccessfulRescale", Message: "New size: 6; reason: cpu resource utilization (percentage of request) above target", Count: 3, LastSeen: now},
+		{Namespace: "app", Name: "api", Reason: "SuccessfulRescale", Message: "New size: 4; reason: All metrics below target", Count: 2, LastSeen: now},
+	}
+
+	status := analyzeAutoscaler(&hpa, rescales)
+	if len(status.Issues) != 1 || status.Issues[0] != AutoscalerFlapping {
+		t.Fatalf("expected flapping, got %v", status.Issues)
+	}
+	if status.RecentRescales != 5 || len(status.RescaleSizes) != 2 {
+		t.Errorf("expected 5 rescales across 2 sizes, got %d across %v", status.RecentRescales, status.RescaleSizes)
+	}
+
+	// Repeated scale-ups to one size is growth, not flapping
+	status = analyzeAutoscaler(&hpa, rescales[:1])
+	if len(status.Issues) != 0 {
+		t.Errorf("expected no issues for a single size, got %v", status.Issues)
+	}
+}
+
+func TestSummarizeAutoscalers(t *testing.T) {
+	util := int32(50)
+	healthy := testHPA("healthy", 1, 5, 2, 2, 70, &util)
+	pinned := testHPA("pinned", 1, 3, 3, 3, 70, &util)
+
+	output := summarizeAutoscalers([]autoscalingv2.HorizontalPodAutoscaler{healthy, pinned}, nil, false)
+	if output.Total != 2 || output.Healthy != 1 || len(output.Autoscalers) != 1 || output.Autoscalers[0].Name != "pinned" {
+		t.Fatalf("unexpected summary: total %d healthy %d listed %d", output.Total, output.Healthy, len(output.Autoscalers))
+	}
+	msg := autoscalerStatusMessage(&output, 60)
+	if !strings.Contains(msg, "1 of 2") || !strings.Contains(msg, "1 pinned at max") {
+		t.Errorf("unexpected message: %s", msg)
+	}
+
+	all := summarizeAutoscalers([]autoscalingv2.HorizontalPodAutoscaler{healthy, pinned}, nil, true)
+	if len(all.Autoscalers) != 2 || all.Autoscalers[0].Name != "pinned" {
+		t.Errorf("expected both HPAs with issues first, got %d", len(all.Autoscalers))
+	}
+}
+
+func TestAutoscalerConflict(t *testing.T) {
+	util := int32(50)
+	status := analyzeAutoscaler(func() *autoscalingv2.HorizontalPodAutoscaler {
+		hpa := testHPA("api", 2, 5, 3, 3, 70, &util)
+		return &hpa
+	}(), nil)
+
+	tests := []struct {
+		name      string
+		target    int
+		conflict  string
+		warnings  int
+		recommend bool
+	}{
+		{"above max", 20, HPAConflictAboveMax, 1, true},
+		{"below min", 1, HPAConflictBelowMin, 1, true},
+		{"within bounds, away from desired", 4, HPAConflictHPAManaged, 1, true},
+		{"matches desired", 3, HPAConflictHPAManaged, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hpaContext, warnings, recommendation := autoscalerConflict(status, tt.target)
+			if hpaContext.Conflict != tt.conflict {
+				t.Errorf("conflict = %s, want %s", hpaContext.Conflict, tt.conflict)
+			}
+			if hpaContext.EffectiveReplicas != 3 {
+				t.Errorf("effective replicas = %d, want 3", hpaContext.EffectiveReplicas)
+			}
+			if len(warnings) != tt.warnings {
+				t.Errorf("warnings = %v, want %d", warnings, tt.warnings)
+			}
+			if (recommendation != "") != tt.recommend {
+				t.Errorf("recommendation = %q", recommendation)
+			}
+		})
+	}
+
+	_, warnings, _ := autoscalerConflict(status, 20)
+	if !strings.Contains(warnings[0], "at most 5") || !strings.Contains(warnings[0], "cpu 50% / target 70%") {
+		t.Errorf("unexpected conflict warning: %s", warnings[0])
+	}
+}
+
+func TestFindDeploymentHPA(t *testing.T) {
+	hpas := []autoscalingv2.HorizontalPodAutoscaler{testHPA("web", 1, 2, 1, 1, 70, nil), testHPA("api", 1, 2, 1, 1, 70, nil)}
+	hpas[0].Spec.ScaleTargetRef.Kind = "StatefulSet"
+	if hpa := findDeploymentHPA(hpas, "api"); hpa == nil || hpa.Name != "api" {
+		t.Error("expected the api HPA")
+	}
+	if hpa := findDeploymentHPA(hpas, "web"); hpa != nil {
+		t.Error("StatefulSet HPA must not match a deployment")
+	}
+}
+
+func TestFormatMetricTarget(t *testing.T) {
+	avg := resource.MustParse("100m")
+	if got := formatMetricTarget(autoscalingv2.MetricTarget{AverageValue: &avg}); got != "100m avg" {
+		t.Errorf("formatMetricTarget = %q", got)
+	}
+	value := resource.MustParse("30")
+	if got := formatMetricValue(autoscalingv2.MetricValueStatus{Value: &value}); got != "30" {
+		t.Errorf("formatMetricValue = %q", got)
+	}
+}
@@ -8,17 +8,21 @@ import (
 
 	"github.com/KubeHeal/openshift-cluster-health-mcp/pkg/capacity"
 	"github.com/KubeHeal/openshift-cluster-health-mcp/pkg/clients"
+	"github.com/KubeHeal/openshift-cluster-health-mcp/pkg/scheduling"
 )
 
 // CalculatePodCapacityTool provides MCP tool for calculating namespace/cluster pod capacity
 type CalculatePodCapacityTool struct {
 	k8sClient *clients.K8sClient
+	profiles  *PodProfileCatalog
 }
 
-// NewCalculatePodCapacityTool creates a new calculate-pod-capacity tool
-func NewCalculatePodCapacityTool(k8sClient *clients.K8sClient) *CalculatePodCapacityTool {
+// NewCalculatePodCapacityTool creates a new calculate-pod-capacity tool;
+// profiles may be nil, leaving only the builtin and deployment profiles
+func NewCalculatePodCapacityTool(k8sClient *clients.K8sClient, profiles *PodProfileCatalog) *CalculatePodCapacityTool {
 	return &CalculatePodCapacityTool{
 		k8sClient: k8sClient,
+		profiles:  profiles,
 	}
 }
 
@@ -37,12 +41,22 @@ RESPONSE INTERPRETATION:
 - recommended_limit.limiting_factor: What will run out first ("cpu", "memory", or "pod_count")
 - current_usage.cpu_percent / memory_percent: Current resource utilization percentage
 - available_capacity.cpu / memory / pod_slots: Raw available resources
+- namespace_quota.constraints (namespace only): for cpu, memory and pods, the ResourceQuota and key (requests.cpu, limits.memory, pods, ...) with the least headroom, or cluster_free_capacity when no quota bounds that resource
+  - Scoped quotas (BestEffort, NotTerminating, PriorityClass, ...) count only when their scopes match the pods; skipped ones are listed in namespace_quota.notes
+- node_placement (cluster only): first-fit-decreasing placement onto individual nodes
+  - aggregate_pods: pods that fit if all free capacity were pooled
+  - schedulable_pods: pods that actually fit node by node (honors taints, cordons, node roles and node_selector)
+  - fragmented_pods: the difference, i.e. capacity stranded in per-node remainders
+  - nodes[]: per-node free CPU/memory/pod slots and fitting_pods
 
 PRESENTATION TO USER:
 - Lead with capacity: "You can safely deploy approximately [safe_pod_count] more [pod_profile] pods"
 - Include limiting factor: "Limited by [limiting_factor]"
 - If cpu_percent or memory_percent > 80%: Warn about capacity constraints
 - If limiting_factor is "pod_count": Mention cluster pod limits, not just resources
+- Name the constraint behind the limiting factor, e.g. "ResourceQuota compute-resources requests.cpu"; if its source is cluster_free_capacity, say the namespace has no quota for it
+- If limiting_factor is "fragmentation": Free capacity is split across nodes; compare aggregate_pods with schedulable_pods
+- If limiting_factor is "node_eligibility": Taints, cordons, node roles or node_selector exclude nodes that have free capacity
 - Always mention both CPU and memory headroom for context
 - Include trending info if available: "At current growth rate, capacity exhaustion in [N] days"
 
@@ -57,6 +71,9 @@ POD PROFILES:
 - medium: 100m CPU, 256Mi memory - typical applications
 - large: 500m CPU, 1Gi memory - resource-intensive workloads
 - custom: User-specified CPU and memory requests
+- Operator-defined profiles (e.g. "java-service", "kafka-broker") with CPU, memory, replicas_per_unit and node_selector; an unknown name returns the list of available profiles
+- from_deployment: "namespace/name" sizes pods like an existing Deployment (all containers, init containers and overhead), honoring its node selector
+- profile.safe_unit_count / max_unit_count: capacity in units of replicas_per_unit pods (e.g. 3-broker Kafka clusters)
 
 Example questions this tool answers:
 - "How many more pods can I run?"
@@ -77,10 +94,14 @@ func (t *CalculatePodCapacityTool) InputSchema() map[string]interface{} {
 			},
 			"pod_profile": map[string]interface{}{
 				"type":        "string",
-				"description": "Pod resource profile to use for calculations.",
-				"enum":        []string{"small", "medium", "large", "custom"},
+				"description": "Pod resource profile to use for calculations: small, medium, large, custom, or any profile configured by the operator (e.g. 'java-service').",
+				"examples":    []string{"small", "medium", "large", "custom"},
 				"default":     "medium",
 			},
+			"from_deployment": map[string]interface{}{
+				"type":        "string",
+				"description": "Derive the profile from an existing Deployment's pod template, as 'namespace/name'. Overrides pod_profile.",
+			},
 			"custom_resources": map[string]interface{}{
 				"type":        "object",
 				"description": "Custom resource requirements. Required when pod_profile is 'custom'.",
@@ -102,6 +123,16 @@ func (t *CalculatePodCapacityTool) InputSchema() map[string]interface{} {
 				"maximum":     50,
 				"default":     15,
 			},
+			"node_selector": map[string]interface{}{
+				"type":                 "object",
+				"description":          "Cluster-wide only: node labels the simulated pods must match (e.g. {\"node-role.kubernetes.io/infra\": \"\"})",
+				"additionalProperties": map[string]interface{}{"type": "string"},
+			},
+			"node_roles": map[string]interface{}{
+				"type":        "array",
+				"description": "Cluster-wide only: restrict placement to nodes with any of these roles (e.g. ['worker']). Default: all roles, subject to taints",
+				"items":       map[string]interface{}{"type": "string"},
+			},
 			"include_trending": map[string]interface{}{
 				"type":        "boolean",
 				"description": "Include usage trend analysis and capacity exhaustion predictions. Default: true",
@@ -119,6 +150,9 @@ type CalculatePodCapacityInput struct {
 	CustomResources *CustomResourcesInput  `json:"custom_resources,omitempty"`
 	SafetyMargin    *float64               `json:"safety_margin,omitempty"`
 	IncludeTrending *bool                  `json:"include_trending,omitempty"`
+	NodeSelector    map[string]string      `json:"node_selector,omitempty"`
+	NodeRoles       []string               `json:"node_roles,omitempty"`
+	FromDeployment  string                 `json:"from_deployment,omitempty"`
 }
 
 // CustomResourcesInput represents custom pod resource requirements
@@ -137,14 +171,29 @@ type CalculatePodCapacityOutput struct {
 	PodEstimates      map[string]*PodEstimateOutput     `json:"pod_estimates"`
 	RecommendedLimit  *RecommendedLimitOutput           `json:"recommended_limit"`
 	Trending          *TrendingOutput                   `json:"trending,omitempty"`
+	NodePlacement     *capacity.PlacementResult         `json:"node_placement,omitempty"`
+	Profile           *ProfileCapacityOutput            `json:"profile"`
 	Recommendation    string                            `json:"recommendation"`
 }
 
+// ProfileCapacityOutput describes the profile capacity was calculated for,
+// with capacity expressed in units of replicas_per_unit pods
+type ProfileCapacityOutput struct {
+	capacity.ResolvedProfile
+	SafeUnitCount int `json:"safe_unit_count"`
+	MaxUnitCount  int `json:"max_unit_count"`
+}
+
 // NamespaceQuotaOutput represents namespace quota information
 type NamespaceQuotaOutput struct {
 	CPULimit       string `json:"cpu_limit"`
 	MemoryLimit    string `json:"memory_limit"`
 	PodCountLimit  int    `json:"pod_count_limit"`
+	HasQuota       bool   `json:"has_quota"`
+	// Constraints names the ResourceQuota (or cluster free capacity) behind
+	// each limit, keyed by cpu, memory and pods
+	Constraints map[string]capacity.QuotaConstraint `json:"constraints,omitempty"`
+	Notes       []string                            `json:"notes,omitempty"`
 }
 
 // CurrentUsageOutput represents current resource usage
@@ -207,25 +256,23 @@ func (t *CalculatePodCapacityTool) Execute(ctx context.Context, args map[string]
 		input.Namespace = "cluster"
 	}
 
+	profile, err := t.resolveProfile(ctx, input)
+	if err != nil {
+		return nil, fmt.Errorf("invalid input: %w", err)
+	}
+	podProfile, customResources := calculatorProfile(profile)
+
 	// Handle cluster-wide capacity
 	if strings.ToLower(input.Namespace) == "cluster" {
-		return t.calculateClusterCapacity(ctx, input)
+		return t.calculateClusterCapacity(ctx, input, profile)
 	}
 
-	// Get namespace quota
-	quota, err := t.getNamespaceCapacity(ctx, input.Namespace)
+	// Profile pods carry requests and run until stopped, which decides the
+	// scoped quotas that apply to them
+	opts := capacity.PlacementOptions{NodeSelector: profileNodeSelector(profile, input.NodeSelector)}
+	quota, err := loadNamespaceCapacity(ctx, t.k8sClient, input.Namespace, capacity.QuotaTarget{}, opts)
 	if err != nil {
-		// If no quota found, calculate based on available resources
-		quota = t.estimateNamespaceCapacity(ctx, input.Namespace)
-	}
-
-	// Parse custom resources if provided
-	var customResources *capacity.PodResources
-	if input.CustomResources != nil && input.PodProfile == "custom" {
-		customResources = &capacity.PodResources{
-			CPUMillicores: parseCPU(input.CustomResources.CPU),
-			MemoryMB:      parseMemoryMB(input.CustomResources.Memory),
-		}
+		return nil, fmt.Errorf("failed to determine namespace capacity: %w", err)
 	}
 
 	// Set default safety margin
@@ -237,17 +284,6 @@ func (t *CalculatePodCapacityTool) Execute(ctx context.Context, args map[string]
 	// Create calculator
 	calc := capacity.NewCalculator(safetyMargin / 100.0)
 
-	// Calculate capacity
-	podProfile := capacity.PodProfileMedium
-	switch input.PodProfile {
-	case "small":
-		podProfile = capacity.PodProfileSmall
-	case "large":
-		podProfile = capacity.PodProfileLarge
-	case "custom":
-		podProfile = capacity.PodProfileCustom
-	}
-
 	result, err := calc.CalculatePodCapacity(quota, podProfile, customResources, &safetyMargin)
 	if err != nil {
 		return nil, fmt.Errorf("failed to calculate capacity: %w", err)
@@ -261,6 +297,9 @@ func (t *CalculatePodCapacityTool) Execute(ctx context.Context, args map[string]
 			CPULimit:      result.NamespaceQuota.CPULimit,
 			MemoryLimit:   result.NamespaceQuota.MemoryLimit,
 			PodCountLimit: result.NamespaceQuota.PodCountLimit,
+			HasQuota:      quota.HasQuota,
+			Constraints:   quota.Constraints,
+			Notes:         quota.Notes,
 		},
 		CurrentUsage: &CurrentUsageOutput{
 			CPU:           result.CurrentUsage.CPU,
@@ -285,6 +324,8 @@ func (t *CalculatePodCapacityTool) Execute(ctx context.Context, args map[string]
 		Recommendation: result.Recommendation,
 	}
 
+	applyProfile(output, profile)
+
 	// Add trending if requested
 	includeTrending := true
 	if input.IncludeTrending != nil {
@@ -348,101 +389,8 @@ func (t *CalculatePodCapacityTool) parseInput(args map[string]interface{}) (*Cal
 	return input, nil
 }
 
-// getNamespaceCapacity retrieves namespace capacity information
-func (t *CalculatePodCapacityTool) getNamespaceCapacity(ctx context.Context, namespace string) (*capacity.NamespaceQuota, error) {
-	// Get resource quota
-	quota, err := t.k8sClient.GetResourceQuota(ctx, namespace)
-	if err != nil {
-		return nil, err
-	}
-
-	// Get current pod count
-	pods, err := t.k8sClient.ListPods(ctx, namespace)
-	podCount := 0
-	if err == nil {
-		for _, pod := range pods.Items {
-			if pod.Status.Phase == "Running" || pod.Status.Phase == "Pending" {
-				podCount++
-			}
-		}
-	}
-
-	// Estimate used resources from pods if not available in quota
-	cpuUsed := quota.CPUUsedMillicores
-	memoryUsed := quota.MemoryUsedBytes
-
-	if cpuUsed == 0 || memoryUsed == 0 {
-		cpuUsed, memoryUsed = t.calculatePodResourceUsage(ctx, namespace)
-	}
-
-	return &capacity.NamespaceQuota{
-		CPULimitMillicores:  quota.CPULimitMillicores,
-		MemoryLimitBytes:    quota.MemoryLimitBytes,
-		PodCountLimit:       100, // Default pod limit if not set
-		CPUUsedMillicores:   cpuUsed,
-		MemoryUsedBytes:     memoryUsed,
-		CurrentPodCount:     podCount,
-		HasQuota:            true,
-	}, nil
-}
-
-// estimateNamespaceCapacity estimates capacity when no quota is set
-func (t *CalculatePodCapacityTool) estimateNamespaceCapacity(ctx context.Context, namespace string) *capacity.NamespaceQuota {
-	// Get current pod count and resource usage
-	pods, _ := t.k8sClient.ListPods(ctx, namespace)
-	podCount := 0
-	if pods != nil {
-		for _, pod := range pods.Items {
-			if pod.Status.Phase == "Running" || pod.Status.Phase == "Pending" {
-				podCount++
-			}
-		}
-	}
-
-	cpuUsed, memoryUsed := t.calculatePodResourceUsage(ctx, namespace)
-
-	// Default unlimited quota (use node capacity estimates)
-	// Assume ~4 cores and 8GB per namespace without quota
-	return &capacity.NamespaceQuota{
-		CPULimitMillicores:  4000,                    // 4 cores
-		MemoryLimitBytes:    8 * 1024 * 1024 * 1024,  // 8 GB
-		PodCountLimit:       100,                     // Default pod limit
-		CPUUsedMillicores:   cpuUsed,
-		MemoryUsedBytes:     memoryUsed,
-		CurrentPodCount:     podCount,
-		HasQuota:            false,
-	}
-}
-
-// calculatePodResourceUsage calculates total resource usage from pods
-func (t *CalculatePodCapacityTool) calculatePodResourceUsage(ctx context.Context, namespace string) (int64, int64) {
-	pods, err := t.k8sClient.ListPods(ctx, namespace)
-	if err != nil {
-		return 0, 0
-	}
-
-	var totalCPU, totalMemory int64
-
-	for _, pod := range pods.Items {
-		if pod.Status.Phase != "Running" && pod.Status.Phase != "Pending" {
-			continue
-		}
-
-		for _, container := range pod.Spec.Containers {
-			if cpu := container.Resources.Requests.Cpu(); cpu != nil {
-				totalCPU += cpu.MilliValue()
-			}
-			if mem := container.Resources.Requests.Memory(); mem != nil {
-				totalMemory += mem.Value()
-			}
-		}
-	}
-
-	return totalCPU, totalMemory
-}
-
 // calculateClusterCapacity calculates cluster-wide capacity
-func (t *CalculatePodCapacityTool) calculateClusterCapacity(ctx context.Context, input *CalculatePodCapacityInput) (*CalculatePodCapacityOutput, error) {
+func (t *CalculatePodCapacityTool) calculateClusterCapacity(ctx context.Context, input *CalculatePodCapacityInput, profile capacity.ResolvedProfile) (*CalculatePodCapacityOutput, error) {
 	// Get all nodes to calculate cluster capacity
 	nodes, err := t.k8sClient.ListNodes(ctx)
 	if err != nil {
@@ -497,14 +445,9 @@ func (t *CalculatePodCapacityTool) calculateClusterCapacity(ctx context.Context,
 		}
 		podCount++
 
-		for _, container := range pod.Spec.Containers {
-			if cpu := container.Resources.Requests.Cpu(); cpu != nil {
-				usedCPU += cpu.MilliValue()
-			}
-			if mem := container.Resources.Requests.Memory(); mem != nil {
-				usedMemory += mem.Value()
-			}
-		}
+		requests := capacity.PodResourcesOf(&pod).Requests
+		usedCPU += requests.MilliCPU
+		usedMemory += requests.Memory
 	}
 
 	// Build cluster quota
@@ -518,15 +461,6 @@ func (t *CalculatePodCapacityTool) calculateClusterCapacity(ctx context.Context,
 		HasQuota:            true,
 	}
 
-	// Parse custom resources if provided
-	var customResources *capacity.PodResources
-	if input.CustomResources != nil && input.PodProfile == "custom" {
-		customResources = &capacity.PodResources{
-			CPUMillicores: parseCPU(input.CustomResources.CPU),
-			MemoryMB:      parseMemoryMB(input.CustomResources.Memory),
-		}
-	}
-
 	// Set safety margin
 	safetyMargin := 15.0
 	if input.SafetyMargin != nil {
@@ -535,17 +469,7 @@ func (t *CalculatePodCapacityTool) calculateClusterCapacity(ctx context.Context,
 
 	// Create calculator
 	calc := capacity.NewCalculator(safetyMargin / 100.0)
-
-	// Calculate capacity
-	podProfile := capacity.PodProfileMedium
-	switch input.PodProfile {
-	case "small":
-		podProfile = capacity.PodProfileSmall
-	case "large":
-		podProfile = capacity.PodProfileLarge
-	case "custom":
-		podProfile = capacity.PodProfileCustom
-	}
+	podProfile, customResources := calculatorProfile(profile)
 
 	result, err := calc.CalculatePodCapacity(quota, podProfile, customResources, &safetyMargin)
 	if err != nil {
@@ -584,6 +508,15 @@ func (t *CalculatePodCapacityTool) calculateClusterCapacity(ctx context.Context,
 		Recommendation: result.Recommendation,
 	}
 
+	// Aggregate capacity ignores fragmentation; simulate per-node placement
+	placement := calc.CalculateNodePlacement(
+		scheduling.NewNodeInfos(nodes.Items, pods.Items),
+		capacity.ResolveProfile(podProfile, customResources),
+		capacity.PlacementOptions{NodeSelector: profileNodeSelector(profile, input.NodeSelector), NodeRoles: input.NodeRoles},
+	)
+	applyNodePlacement(output, placement)
+	applyProfile(output, profile)
+
 	// Add trending if requested
 	includeTrending := true
 	if input.IncludeTrending != nil {
@@ -606,6 +539,99 @@ func (t *CalculatePodCapacityTool) calculateClusterCapacity(ctx context.Context,
 	return output, nil
 }
 
+// applyNodePlacement attaches the placement simulation and caps the
+// recommended limit at what actually fits on individual nodes
+func applyNodePlacement(output *CalculatePodCapacityOutput, placement *capacity.PlacementResult) {
+	output.NodePlacement = placement
+	limit := output.RecommendedLimit
+	if limit == nil || placement.SchedulablePods >= limit.MaxPodCount {
+		return
+	}
+	limit.MaxPodCount = placement.SchedulablePods
+	if placement.SafeSchedulablePods < limit.SafePodCount {
+		limit.SafePodCount = placement.SafeSchedulablePods
+	}
+	// Capacity is lost either to per-node remainders or to excluded nodes
+	limit.LimitingFactor = "node_eligibility"
+	if placement.FragmentedPods > 0 {
+		limit.LimitingFactor = "fragmentation"
+	}
+	limit.Explanation = placement.Explanation
+	output.Recommendation = fmt.Sprintf("Can safely run %d more %s-profile pods once per-node fit is considered (aggregate capacity suggests %d). %s",
+		limit.SafePodCount, limit.PodProfile, placement.AggregatePods, placement.Explanation)
+}
+
+// resolveProfile resolves the requested pod profile: a Deployment's
+// template, custom resources, a configured profile or a builtin one
+func (t *CalculatePodCapacityTool) resolveProfile(ctx context.Context, input *CalculatePodCapacityInput) (capacity.ResolvedProfile, error) {
+	if input.FromDeployment != "" {
+		profile, err := t.profiles.FromDeployment(ctx, input.FromDeployment, 1)
+		if err == nil && profile.Resources.CPUMillicores == 0 && profile.Resources.MemoryMB == 0 {
+			err = fmt.Errorf("deployment %s sets no resource requests", input.FromDeployment)
+		}
+		return profile, err
+	}
+	if input.PodProfile == string(capacity.PodProfileCustom) {
+		profile := capacity.ResolvedProfile{Name: input.PodProfile, Source: capacity.ProfileSourceCustom, ReplicasPerUnit: 1}
+		profile.Resources = capacity.DefaultPodProfiles[capacity.PodProfileMedium]
+		if input.CustomResources != nil {
+			profile.Resources = capacity.PodResources{
+				CPUMillicores: parseCPU(input.CustomResources.CPU),
+				MemoryMB:      parseMemoryMB(input.CustomResources.Memory),
+			}
+		}
+		return profile, nil
+	}
+	return t.profiles.Resolve(ctx, input.PodProfile)
+}
+
+// calculatorProfile maps a resolved profile onto the calculator's builtin
+// profiles, passing anything else as custom resources
+func calculatorProfile(profile capacity.ResolvedProfile) (capacity.PodProfile, *capacity.PodResources) {
+	if profile.Source == capacity.ProfileSourceBuiltin {
+		return capacity.PodProfile(profile.Name), nil
+	}
+	resources := profile.Resources
+	return capacity.PodProfileCustom, &resources
+}
+
+// profileNodeSelector merges the profile's node selector with the one given
+// in the request, which wins on conflicting keys
+func profileNodeSelector(profile capacity.ResolvedProfile, selector map[string]string) map[string]string {
+	if len(profile.NodeSelector) == 0 {
+		return selector
+	}
+	merged := make(map[string]string, len(profile.NodeSelector)+len(selector))
+	for k, v := range profile.NodeSelector {
+		merged[k] = v
+	}
+	for k, v := range selector {
+		merged[k] = v
+	}
+	return merged
+}
+
+// applyProfile labels the result with the profile name and converts the
+// recommended limit into units of replicas_per_unit pods
+func applyProfile(output *CalculatePodCapacityOutput, profile capacity.ResolvedProfile) {
+	limit := output.RecommendedLimit
+	if estimate, ok := output.PodEstimates[string(capacity.PodProfileCustom)]; ok && profile.Source != capacity.ProfileSourceCustom {
+		delete(output.PodEstimates, string(capacity.PodProfileCustom))
+		output.PodEstimates[profile.Name] = estimate
+	}
+	output.Profile = &ProfileCapacityOutput{ResolvedProfile: profile}
+	if limit == nil {
+		return
+	}
+	limit.PodProfile = profile.Name
+	perUnit := max(profile.ReplicasPerUnit, 1)
+	output.Profile.SafeUnitCount = limit.SafePodCount / perUnit
+	output.Profile.MaxUnitCount = limit.MaxPodCount / perUnit
+	if perUnit > 1 {
+		output.Recommendation += fmt.Sprintf(" That is %d %s unit(s) of %d pods.", output.Profile.SafeUnitCount, profile.Name, perUnit)
+	}
+}
+
 // convertPodEstimates converts capacity package estimates to output format
 func (t *CalculatePodCapacityTool) convertPodEstimates(estimates map[string]*capacity.PodEstimate) map[string]*PodEstimateOutput {
 	result := make(map[string]*PodEstimateOutput)
@@ -2,11 +2,15 @@ package tools
 
 import (
 	"context"
+	"os"
+	"path/filepath"
 	"testing"
+
+	"github.com/KubeHeal/openshift-cluster-health-mcp/pkg/capacity"
 )
 
 func TestCalculatePodCapacityToolMetadata(t *testing.T) {
-	tool := NewCalculatePodCapacityTool(nil)
+	tool := NewCalculatePodCapacityTool(nil, nil)
 
 	// Test Name
 	if tool.Name() != "calculate-pod-capacity" {
@@ -105,7 +109,7 @@ func TestParseMemoryMB(t *testing.T) {
 }
 
 func TestCalculatePodCapacityToolParseInput(t *testing.T) {
-	tool := NewCalculatePodCapacityTool(nil)
+	tool := NewCalculatePodCapacityTool(nil, nil)
 
 	tests := []struct {
 		name        string
@@ -249,7 +253,7 @@ func TestCalculatePodCapacityToolParseInput(t *testing.T) {
 func TestCalculatePodCapacityToolValidation(t *testing.T) {
 	// Test with nil k8sClient - should return an error gracefully
 	// rather than panic when trying to calculate capacity
-	tool := NewCalculatePodCapacityTool(nil)
+	tool := NewCalculatePodCapacityTool(nil, nil)
 	ctx := context.Background()
 
 	// Test that Execute returns an error when k8sClient is nil
@@ -277,7 +281,7 @@ func TestCalculatePodCapacityToolValidation(t *testing.T) {
 }
 
 func TestConvertPodEstimates(t *testing.T) {
-	tool := NewCalculatePodCapacityTool(nil)
+	tool := NewCalculatePodCapacityTool(nil, nil)
 
 	// Test that the tool can create output correctly
 	if tool == nil {
@@ -291,7 +295,7 @@ func TestConvertPodEstimates(t *testing.T) {
 }
 
 func TestPodProfileEnums(t *testing.T) {
-	tool := NewCalculatePodCapacityTool(nil)
+	tool := NewCalculatePodCapacityTool(nil, nil)
 	schema := tool.InputSchema()
 
 	props, ok := schema["properties"].(map[string]interface{})
@@ -304,9 +308,13 @@ func TestPodProfileEnums(t *testing.T) {
 		t.Fatal("schema should have pod_profile property")
 	}
 
-	enum, ok := podProfile["enum"].([]string)
+	// Configured profiles are accepted too, so builtins are examples rather than an enum
+	if _, ok := podProfile["enum"]; ok {
+		t.Error("pod_profile should not restrict values to an enum")
+	}
+	enum, ok := podProfile["examples"].([]string)
 	if !ok {
-		t.Fatal("pod_profile should have enum")
+		t.Fatal("pod_profile should list example profiles")
 	}
 
 	expectedProfiles := map[string]bool{
@@ -324,7 +332,102 @@ func TestPodProfileEnums(t *testing.T) {
 
 	for profile, found := range expectedProfiles {
 		if !found {
-			t.Errorf("pod_profile enum should include '%s'", profile)
+			t.Errorf("pod_profile examples should include '%s'", profile)
 		}
 	}
 }
+
+func TestApplyNodePlacement(t *testing.T) {
+	output := &CalculatePodCapacityOutput{
+		RecommendedLimit: &RecommendedLimitOutput{PodProfile: "large", SafePodCount: 8, MaxPodCount: 10, LimitingFactor: "cpu"},
+		Recommendation:   "Can safely run 8 more cpu-profile pods.",
+	}
+	applyNodePlacement(output, &capacity.PlacementResult{
+		AggregatePods:       10,
+		SchedulablePods:     4,
+		SafeSchedulablePods: 3,
+		FragmentedPods:      6,
+		Explanation:         "fragmented",
+	})
+
+	limit := output.RecommendedLimit
+	if limit.MaxPodCount != 4 || limit.SafePodCount != 3 {
+		t.Errorf("expected limits capped to 4/3, got %d/%d", limit.MaxPodCount, limit.SafePodCount)
+	}
+	if limit.LimitingFactor != "fragmentation" {
+		t.Errorf("expected fragmentation limiting factor, got %s", limit.LimitingFactor)
+	}
+	if output.NodePlacement == nil || !contains(output.Recommendation, "aggregate capacity suggests 10") {
+		t.Errorf("unexpected recommendation: %s", output.Recommendation)
+	}
+
+	// Placement that fits everything leaves the aggregate recommendation alone
+	output.RecommendedLimit = &RecommendedLimitOutput{SafePodCount: 2, MaxPodCount: 3, LimitingFactor: "memory"}
+	applyNodePlacement(output, &capacity.PlacementResult{AggregatePods: 3, SchedulablePods: 3})
+	if output.RecommendedLimit.LimitingFactor != "memory" || output.RecommendedLimit.MaxPodCount != 3 {
+		t.Errorf("expected unchanged limit, got %+v", output.RecommendedLimit)
+	}
+}
+
+func TestPodProfileCatalog(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "profiles.yaml")
+	data := "profiles:\n  java-service: {cpu: 500m, memory: 1Gi, replicas_per_unit: 2, node_selector: {tier: app}}\n"
+	if err := os.WriteFile(file, []byte(data), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	tool := NewCalculatePodCapacityTool(nil, NewPodProfileCatalog(nil, file, ""))
+	ctx := context.Background()
+
+	profile, err := tool.resolveProfile(ctx, &CalculatePodCapacityInput{PodProfile: "java-service"})
+	if err != nil {
+		t.Fatalf("resolveProfile: %v", err)
+	}
+	if profile.Resources.CPUMillicores != 500 || profile.ReplicasPerUnit != 2 {
+		t.Errorf("java-service = %+v", profile)
+	}
+	if kind, custom := calculatorProfile(profile); kind != capacity.PodProfileCustom || custom == nil || custom.MemoryMB != 1024 {
+		t.Errorf("calculatorProfile = %s %+v", kind, custom)
+	}
+
+	if large, _ := tool.resolveProfile(ctx, &CalculatePodCapacityInput{PodProfile: "large"}); large.Source != capacity.ProfileSourceBuiltin {
+		t.Errorf("large = %+v, want the builtin", large)
+	}
+	if _, err := tool.resolveProfile(ctx, &CalculatePodCapacityInput{PodProfile: "kafka"}); err == nil || !contains(err.Error(), "java-service") {
+		t.Errorf("unknown profile error = %v, want available profiles listed", err)
+	}
+	if _, err := tool.resolveProfile(ctx, &CalculatePodCapacityInput{FromDeployment: "app"}); err == nil {
+		t.Error("expected an error for a from_deployment without namespace")
+	}
+
+	selector := profileNodeSelector(profile, map[string]string{"tier": "batch", "zone": "a"})
+	if selector["tier"] != "batch" || selector["zone"] != "a" {
+		t.Errorf("request selector should win: %v", selector)
+	}
+
+	// Builtins still resolve when the definitions cannot be loaded
+	broken := NewPodProfileCatalog(nil, filepath.Join(t.TempDir(), "missing.yaml"), "")
+	if medium, err := broken.Resolve(ctx, "medium"); err != nil || medium.Source != capacity.ProfileSourceBuiltin {
+		t.Errorf("medium = %+v (%v), want the builtin", medium, err)
+	}
+	if _, err := broken.Resolve(ctx, "java-service"); err == nil || !contains(err.Error(), "pod profiles file") {
+		t.Errorf("java-service error = %v, want the load error", err)
+	}
+}
+
+func TestApplyProfile(t *testing.T) {
+	output := &CalculatePodCapacityOutput{
+		PodEstimates:     map[string]*PodEstimateOutput{"custom": {MaxPods: 7}},
+		RecommendedLimit: &RecommendedLimitOutput{PodProfile: "custom", SafePodCount: 7, MaxPodCount: 9},
+	}
+	applyProfile(output, capacity.ResolvedProfile{Name: "kafka-broker", Source: capacity.ProfileSourceConfig, ReplicasPerUnit: 3})
+
+	if output.RecommendedLimit.PodProfile != "kafka-broker" || output.PodEstimates["kafka-broker"] == nil {
+		t.Errorf("profile name not applied: %+v", output.RecommendedLimit)
+	}
+	if output.Profile.SafeUnitCount != 2 || output.Profile.MaxUnitCount != 3 {
+		t.Errorf("units = %d/%d, want 2/3", output.Profile.SafeUnitCount, output.Profile.MaxUnitCount)
+	}
+	if !contains(output.Recommendation, "2 kafka-broker unit(s) of 3 pods") {
+		t.Errorf("recommendation = %q", output.Recommendation)
+	}
+}
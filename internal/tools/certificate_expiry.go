@@ -0,0 +1,158 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/KubeHeal/openshift-cluster-health-mcp/pkg/certs"
+	"github.com/KubeHeal/openshift-cluster-health-mcp/pkg/clients"
+)
+
+// CertificateExpiryTool scans cluster certificates for upcoming expiry via MCP
+type CertificateExpiryTool struct {
+	k8sClient   *clients.K8sClient
+	scanSecrets bool
+}
+
+// NewCertificateExpiryTool creates a new scan-certificate-expiry tool;
+// kubernetes.io/tls Secrets are only read when scanSecrets is set
+func NewCertificateExpiryTool(k8sClient *clients.K8sClient, scanSecrets bool) *CertificateExpiryTool {
+	return &CertificateExpiryTool{
+		k8sClient:   k8sClient,
+		scanSecrets: scanSecrets,
+	}
+}
+
+// Name returns the tool name for MCP registration
+func (t *CertificateExpiryTool) Name() string {
+	return "scan-certificate-expiry"
+}
+
+// Description returns the tool description for MCP
+func (t *CertificateExpiryTool) Description() string {
+	return "Scan certificates in service CA bundle ConfigMaps, Route inline TLS and (if enabled on the server) kubernetes.io/tls Secrets, grouped by namespace and owner with warning/critical expiry thresholds. Reports metadata only (subject, SANs, issuer, notAfter, days remaining), never key material"
+}
+
+// InputSchema returns the JSON schema for tool inputs
+func (t *CertificateExpiryTool) InputSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"namespace": map[string]interface{}{
+				"type":        "string",
+				"description": "Only scan this namespace (empty = all namespaces)",
+				"default":     "",
+			},
+			"warning_days": map[string]interface{}{
+				"type":        "integer",
+				"description": "Certificates expiring within this many days are warnings",
+				"default":     certs.DefaultThresholds.WarningDays,
+				"minimum":     1,
+			},
+			"critical_days": map[string]interface{}{
+				"type":        "integer",
+				"description": "Certificates expiring within this many days (or expired) are critical",
+				"default":     certs.DefaultThresholds.CriticalDays,
+				"minimum":     0,
+			},
+			"only_problems": map[string]interface{}{
+				"type":        "boolean",
+				"description": "Only list warning and critical certificates (all certificates are still counted)",
+				"default":     true,
+			},
+		},
+		"required": []string{},
+	}
+}
+
+// CertificateExpiryInput represents the input parameters
+type CertificateExpiryInput struct {
+	Namespace    string `json:"namespace"`
+	WarningDays  int    `json:"warning_days"`
+	CriticalDays int    `json:"critical_days"`
+	OnlyProblems bool   `json:"only_problems"`
+}
+
+// CertificateExpiryOutput represents the tool output
+type CertificateExpiryOutput struct {
+	certs.Report
+	Thresholds     certs.Thresholds `json:"thresholds"`
+	SecretsScanned bool             `json:"secrets_scanned"`
+	Notes          []string         `json:"notes,omitempty"`
+	Message        string           `json:"message"`
+}
+
+// Execute scans the certificates
+func (t *CertificateExpiryTool) Execute(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+	input := CertificateExpiryInput{
+		WarningDays:  certs.DefaultThresholds.WarningDays,
+		CriticalDays: certs.DefaultThresholds.CriticalDays,
+		OnlyProblems: true,
+	}
+	if argsJSON, err := json.Marshal(args); err == nil {
+		_ = json.Unmarshal(argsJSON, &input) //nolint:errcheck // Intentionally ignore error, use defaults if unmarshal fails
+	}
+	thresholds := certificateThresholds(input)
+
+	sources, notes, err := t.k8sClient.CollectCertificateSources(ctx, input.Namespace, t.scanSecrets)
+	if err != nil {
+		return nil, err
+	}
+
+	output := CertificateExpiryOutput{
+		Report:         certs.Scan(sources, time.Now(), thresholds, input.OnlyProblems),
+		Thresholds:     thresholds,
+		SecretsScanned: t.scanSecrets,
+		Notes:          notes,
+	}
+	output.Message = certificateExpiryMessage(&output.Report)
+	return output, nil
+}
+
+// certificateThresholds validates the requested thresholds, falling back
+// to the defaults; the critical threshold never exceeds the warning one
+func certificateThresholds(input CertificateExpiryInput) certs.Thresholds {
+	thresholds := certs.Thresholds{WarningDays: input.WarningDays, CriticalDays: input.CriticalDays}
+	if thresholds.WarningDays <= 0 {
+		thresholds.WarningDays = certs.DefaultThresholds.WarningDays
+	}
+	if thresholds.CriticalDays < 0 {
+		thresholds.CriticalDays = certs.DefaultThresholds.CriticalDays
+	}
+	if thresholds.CriticalDays > thresholds.WarningDays {
+		thresholds.CriticalDays = thresholds.WarningDays
+	}
+	return thresholds
+}
+
+// certificateExpiryMessage summarizes the scan, naming the certificate that expires first
+func certificateExpiryMessage(report *certs.Report) string {
+	s := report.Summary
+	msg := fmt.Sprintf("Scanned %d certificates in %d sources: %d critical (%d expired), %d warning",
+		s.Certificates, s.Sources, s.Critical, s.Expired, s.Warning)
+	if s.ParseErrors > 0 {
+		msg += fmt.Sprintf(", %d unparseable", s.ParseErrors)
+	}
+
+	var first *certs.Finding
+	for i := range report.Namespaces {
+		for j := range report.Namespaces[i].Owners {
+			for k := range report.Namespaces[i].Owners[j].Findings {
+				f := &report.Namespaces[i].Owners[j].Findings[k]
+				if f.Severity != certs.SeverityOK && (first == nil || f.NotAfter.Before(first.NotAfter)) {
+					first = f
+				}
+			}
+		}
+	}
+	if first != nil {
+		verb := "expires"
+		if first.Status == certs.StatusExpired {
+			verb = "expired"
+		}
+		msg += fmt.Sprintf(". Earliest: %s %s/%s (%s) %s on %s", first.Kind, first.Namespace, first.Name, first.Subject, verb, first.NotAfter.Format("2006-01-02"))
+	}
+	return msg
+}
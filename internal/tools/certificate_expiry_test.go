@@ -0,0 +1,54 @@
+package tools
+
+import (
+	"testing"
+	"time"
+
+	"github.com/KubeHeal/openshift-cluster-health-mcp/pkg/certs"
+)
+
+func TestCertificateExpiryTool_Metadata(t *testing.T) {
+	tool := NewCertificateExpiryTool(nil, false)
+	if tool.Name() != "scan-certificate-expiry" {
+		t.Errorf("unexpected name %s", tool.Name())
+	}
+	props := tool.InputSchema()["properties"].(map[string]interface{})
+	for _, key := range []string{"namespace", "warning_days", "critical_days", "only_problems"} {
+		if _, ok := props[key]; !ok {
+			t.Errorf("schema missing %s", key)
+		}
+	}
+}
+
+func TestCertificateThresholds(t *testing.T) {
+	tests := []struct {
+		name  string
+		input CertificateExpiryInput
+		want  certs.Thresholds
+	}{
+		{"custom", CertificateExpiryInput{WarningDays: 60, CriticalDays: 14}, certs.Thresholds{WarningDays: 60, CriticalDays: 14}},
+		{"invalid", CertificateExpiryInput{WarningDays: 0, CriticalDays: -1}, certs.DefaultThresholds},
+		{"critical above warning", CertificateExpiryInput{WarningDays: 10, CriticalDays: 20}, certs.Thresholds{WarningDays: 10, CriticalDays: 10}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := certificateThresholds(tt.input); got != tt.want {
+				t.Errorf("thresholds = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCertificateExpiryMessage(t *testing.T) {
+	now := time.Now()
+	sources := []certs.Source{
+		{Kind: "Secret", Namespace: "shop", Name: "web-tls", Key: "tls.crt", PEM: []byte(selfSignedPEM(t, "web", now.Add(20*24*time.Hour)))},
+		{Kind: "Route", Namespace: "blog", Name: "blog", Key: "certificate", PEM: []byte(selfSignedPEM(t, "blog", now.Add(-24*time.Hour)))},
+	}
+	report := certs.Scan(sources, now, certs.DefaultThresholds, true)
+
+	msg := certificateExpiryMessage(&report)
+	if !contains(msg, "1 critical (1 expired), 1 warning") || !contains(msg, "Route blog/blog (CN=blog) expired on") {
+		t.Errorf("message = %q", msg)
+	}
+}
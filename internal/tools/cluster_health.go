@@ -7,6 +7,7 @@ import (
 
 	"github.com/KubeHeal/openshift-cluster-health-mcp/pkg/cache"
 	"github.com/KubeHeal/openshift-cluster-health-mcp/pkg/clients"
+	"github.com/KubeHeal/openshift-cluster-health-mcp/pkg/health"
 )
 
 // ClusterHealthTool provides cluster health information via MCP
@@ -30,7 +31,7 @@ func (t *ClusterHealthTool) Name() string {
 
 // Description returns the tool description for MCP
 func (t *ClusterHealthTool) Description() string {
-	return "Get comprehensive health summary of the OpenShift cluster including node status, pod health, and overall cluster state"
+	return "Get comprehensive health summary of the OpenShift cluster including node status, pod health, a weighted 0-100 health score per dimension (nodes, workloads, control plane, storage, networking), and the findings that explain each score"
 }
 
 // InputSchema returns the JSON schema for tool inputs
@@ -55,11 +56,14 @@ type ClusterHealthInput struct {
 
 // ClusterHealthOutput represents the tool output
 type ClusterHealthOutput struct {
-	Status  string                 `json:"status"`
-	Nodes   *clients.NodeHealth    `json:"nodes,omitempty"`
-	Pods    *clients.PodHealth     `json:"pods,omitempty"`
-	Message string                 `json:"message,omitempty"`
-	Details map[string]interface{} `json:"details,omitempty"`
+	Status     string                  `json:"status"`
+	Score      float64                 `json:"score"`
+	Nodes      *clients.NodeHealth     `json:"nodes,omitempty"`
+	Pods       *clients.PodHealth      `json:"pods,omitempty"`
+	Dimensions []health.DimensionScore `json:"dimensions,omitempty"`
+	Findings   []health.Finding        `json:"findings,omitempty"`
+	Message    string                  `json:"message,omitempty"`
+	Details    map[string]interface{}  `json:"details,omitempty"`
 }
 
 // Execute runs the cluster health check
@@ -88,40 +92,57 @@ func (t *ClusterHealthTool) Execute(ctx context.Context, args map[string]interfa
 	}
 
 	// Type assertion
-	health, ok := healthInterface.(*clients.ClusterHealth)
+	clusterHealth, ok := healthInterface.(*clients.ClusterHealth)
 	if !ok {
 		return nil, fmt.Errorf("unexpected cache value type")
 	}
 
 	// Build output
 	output := ClusterHealthOutput{
-		Status: health.Status,
+		Status: clusterHealth.Status,
+		Score:  clusterHealth.Score,
 	}
 
 	if input.IncludeDetails {
-		output.Nodes = &health.Nodes
-		output.Pods = &health.Pods
+		output.Nodes = &clusterHealth.Nodes
+		output.Pods = &clusterHealth.Pods
+		if clusterHealth.Scoring != nil {
+			output.Dimensions = clusterHealth.Scoring.Dimensions
+			output.Findings = clusterHealth.Scoring.Findings
+		}
 
 		// Add descriptive message
 		output.Message = fmt.Sprintf(
-			"Cluster is %s: %d/%d nodes ready, %d/%d pods running",
-			health.Status,
-			health.Nodes.Ready,
-			health.Nodes.Total,
-			health.Pods.Running,
-			health.Pods.Total,
+			"Cluster is %s (score %.1f/100): %d/%d nodes ready, %d/%d pods running",
+			clusterHealth.Status,
+			clusterHealth.Score,
+			clusterHealth.Nodes.Ready,
+			clusterHealth.Nodes.Total,
+			clusterHealth.Pods.Running,
+			clusterHealth.Pods.Total,
 		)
+		if len(output.Findings) > 0 {
+			output.Message += fmt.Sprintf("; top finding: %s", output.Findings[0].Message)
+		}
 
 		// Add additional details
 		output.Details = map[string]interface{}{
-			"node_ready_percentage": float64(health.Nodes.Ready) / float64(health.Nodes.Total) * 100,
-			"pod_success_rate":      float64(health.Pods.Running) / float64(health.Pods.Total) * 100,
-			"has_failed_pods":       health.Pods.Failed > 0,
-			"has_pending_pods":      health.Pods.Pending > 0,
+			"node_ready_percentage": percentage(clusterHealth.Nodes.Ready, clusterHealth.Nodes.Total),
+			"pod_success_rate":      percentage(clusterHealth.Pods.Running, clusterHealth.Pods.Total),
+			"has_failed_pods":       clusterHealth.Pods.Failed > 0,
+			"has_pending_pods":      clusterHealth.Pods.Pending > 0,
 		}
 	} else {
-		output.Message = fmt.Sprintf("Cluster status: %s", health.Status)
+		output.Message = fmt.Sprintf("Cluster status: %s (score %.1f/100)", clusterHealth.Status, clusterHealth.Score)
 	}
 
 	return output, nil
 }
+
+// percentage returns part/total*100, or 0 when total is 0
+func percentage(part, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(part) / float64(total) * 100
+}
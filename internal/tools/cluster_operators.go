@@ -0,0 +1,178 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+
+	"github.com/KubeHeal/openshift-cluster-health-mcp/pkg/cache"
+	"github.com/KubeHeal/openshift-cluster-health-mcp/pkg/clients"
+)
+
+// ClusterOperatorsTool reports OpenShift ClusterOperator and ClusterVersion health via MCP
+type ClusterOperatorsTool struct {
+	k8sClient *clients.K8sClient
+	cache     *cache.MemoryCache
+}
+
+// NewClusterOperatorsTool creates a new cluster operators tool
+func NewClusterOperatorsTool(k8sClient *clients.K8sClient, memoryCache *cache.MemoryCache) *ClusterOperatorsTool {
+	return &ClusterOperatorsTool{
+		k8sClient: k8sClient,
+		cache:     memoryCache,
+	}
+}
+
+// Name returns the tool name for MCP registration
+func (t *ClusterOperatorsTool) Name() string {
+	return "get-cluster-operators"
+}
+
+// Description returns the tool description for MCP
+func (t *ClusterOperatorsTool) Description() string {
+	return "Get OpenShift ClusterOperator health (Available/Progressing/Degraded conditions) and ClusterVersion status: current version, update history, failing conditions and available updates"
+}
+
+// InputSchema returns the JSON schema for tool inputs
+func (t *ClusterOperatorsTool) InputSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"only_unhealthy": map[string]interface{}{
+				"type":        "boolean",
+				"description": "Only return operators that are unavailable or degraded",
+				"default":     false,
+			},
+			"include_conditions": map[string]interface{}{
+				"type":        "boolean",
+				"description": "Include the full condition list of each operator",
+				"default":     false,
+			},
+			"history_limit": map[string]interface{}{
+				"type":        "integer",
+				"description": "Maximum number of ClusterVersion update history entries to return",
+				"default":     5,
+				"minimum":     0,
+			},
+		},
+		"required": []string{},
+	}
+}
+
+// ClusterOperatorsInput represents the input parameters
+type ClusterOperatorsInput struct {
+	OnlyUnhealthy     bool `json:"only_unhealthy"`
+	IncludeConditions bool `json:"include_conditions"`
+	HistoryLimit      int  `json:"history_limit"`
+}
+
+// ClusterOperatorsOutput represents the tool output
+type ClusterOperatorsOutput struct {
+	Status         string                    `json:"status"`
+	Updating       bool                      `json:"updating"`
+	ClusterVersion *clients.ClusterVersion   `json:"cluster_version,omitempty"`
+	Summary        clients.OperatorSummary   `json:"summary"`
+	Operators      []clients.ClusterOperator `json:"operators"`
+	Message        string                    `json:"message"`
+}
+
+// Execute runs the ClusterOperator health check
+func (t *ClusterOperatorsTool) Execute(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+	input := ClusterOperatorsInput{
+		HistoryLimit: 5,
+	}
+	if argsJSON, err := json.Marshal(args); err == nil {
+		_ = json.Unmarshal(argsJSON, &input) //nolint:errcheck // Intentionally ignore error, use defaults if unmarshal fails
+	}
+	if input.HistoryLimit < 0 {
+		input.HistoryLimit = 0
+	}
+
+	reportInterface, err := t.cache.GetOrSet(ctx, "cluster-operators", func() (interface{}, error) {
+		return t.k8sClient.GetOperatorStatus(ctx)
+	})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, fmt.Errorf("ClusterOperators not found: this does not appear to be an OpenShift cluster")
+		}
+		return nil, fmt.Errorf("failed to get cluster operators: %w", err)
+	}
+
+	report, ok := reportInterface.(*clients.OperatorStatusReport)
+	if !ok {
+		return nil, fmt.Errorf("unexpected cache value type")
+	}
+
+	return buildClusterOperatorsOutput(report, input), nil
+}
+
+// buildClusterOperatorsOutput filters a cached report without modifying it
+func buildClusterOperatorsOutput(report *clients.OperatorStatusReport, input ClusterOperatorsInput) ClusterOperatorsOutput {
+	output := ClusterOperatorsOutput{
+		Status:    report.Status,
+		Updating:  report.Updating,
+		Summary:   report.Summary,
+		Operators: make([]clients.ClusterOperator, 0, len(report.Operators)),
+	}
+
+	for _, op := range report.Operators {
+		if input.OnlyUnhealthy && op.Healthy() {
+			continue
+		}
+		if !input.IncludeConditions {
+			op.Conditions = nil
+		}
+		output.Operators = append(output.Operators, op)
+	}
+
+	if report.ClusterVersion != nil {
+		cv := *report.ClusterVersion
+		if !input.IncludeConditions {
+			cv.Conditions = nil
+		}
+		if len(cv.History) > input.HistoryLimit {
+			cv.History = cv.History[:input.HistoryLimit]
+		}
+		output.ClusterVersion = &cv
+	}
+
+	output.Message = operatorsMessage(report)
+	return output
+}
+
+// operatorsMessage summarizes the report in one sentence
+func operatorsMessage(report *clients.OperatorStatusReport) string {
+	var b strings.Builder
+
+	if cv := report.ClusterVersion; cv != nil {
+		fmt.Fprintf(&b, "OpenShift %s", cv.Version)
+		if report.Updating && cv.DesiredVersion != "" && cv.DesiredVersion != cv.Version {
+			fmt.Fprintf(&b, " (updating to %s)", cv.DesiredVersion)
+		}
+		b.WriteString(": ")
+	}
+
+	s := report.Summary
+	fmt.Fprintf(&b, "%d/%d cluster operators available", s.Available, s.Total)
+	if s.Degraded > 0 {
+		fmt.Fprintf(&b, ", %d degraded", s.Degraded)
+	}
+	if s.Progressing > 0 {
+		fmt.Fprintf(&b, ", %d progressing", s.Progressing)
+	}
+	if len(s.Unhealthy) > 0 {
+		fmt.Fprintf(&b, "; unhealthy: %s", strings.Join(s.Unhealthy, ", "))
+	}
+	if cv := report.ClusterVersion; cv != nil {
+		if len(cv.FailingConditions) > 0 {
+			fmt.Fprintf(&b, "; ClusterVersion %s: %s", cv.FailingConditions[0].Type, cv.FailingConditions[0].Message)
+		}
+		if len(cv.AvailableUpdates) > 0 {
+			fmt.Fprintf(&b, "; %d updates available", len(cv.AvailableUpdates))
+		}
+	}
+	return b.String()
+}
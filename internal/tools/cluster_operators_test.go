@@ -0,0 +1,83 @@
+package tools
+
+import (
+	"testing"
+
+	"github.com/KubeHeal/openshift-cluster-health-mcp/pkg/clients"
+)
+
+func TestClusterOperatorsTool_Metadata(t *testing.T) {
+	tool := &ClusterOperatorsTool{}
+
+	if tool.Name() != "get-cluster-operators" {
+		t.Errorf("Expected name 'get-cluster-operators', got '%s'", tool.Name())
+	}
+	if !contains(tool.Description(), "ClusterVersion") {
+		t.Error("Description should mention ClusterVersion")
+	}
+
+	schema := tool.InputSchema()
+	properties, ok := schema["properties"].(map[string]interface{})
+	if !ok {
+		t.Fatal("Expected properties to be a map")
+	}
+	for _, prop := range []string{"only_unhealthy", "include_conditions", "history_limit"} {
+		if _, exists := properties[prop]; !exists {
+			t.Errorf("Expected property '%s' in schema", prop)
+		}
+	}
+}
+
+func testOperatorReport() *clients.OperatorStatusReport {
+	operators := []clients.ClusterOperator{
+		{Name: "authentication", Available: true, Conditions: []clients.ClusterCondition{{Type: "Available", Status: "True"}}},
+		{Name: "ingress", Available: true, Degraded: true, Message: "router pods crashing"},
+	}
+	return &clients.OperatorStatusReport{
+		Status:   "degraded",
+		Updating: true,
+		ClusterVersion: &clients.ClusterVersion{
+			Version:          "4.16.3",
+			DesiredVersion:   "4.16.4",
+			History:          []clients.UpdateHistoryEntry{{Version: "4.16.4"}, {Version: "4.16.3"}, {Version: "4.16.2"}},
+			AvailableUpdates: []string{"4.16.5"},
+		},
+		Summary:   clients.SummarizeClusterOperators(operators),
+		Operators: operators,
+	}
+}
+
+func TestBuildClusterOperatorsOutput(t *testing.T) {
+	report := testOperatorReport()
+
+	output := buildClusterOperatorsOutput(report, ClusterOperatorsInput{OnlyUnhealthy: true, HistoryLimit: 1})
+
+	if len(output.Operators) != 1 || output.Operators[0].Name != "ingress" {
+		t.Errorf("Expected only the degraded operator, got %+v", output.Operators)
+	}
+	if len(output.ClusterVersion.History) != 1 {
+		t.Errorf("Expected history limited to 1 entry, got %d", len(output.ClusterVersion.History))
+	}
+	if len(report.ClusterVersion.History) != 3 {
+		t.Error("Filtering must not modify the cached report")
+	}
+	for _, expected := range []string{"4.16.3", "updating to 4.16.4", "1 degraded", "ingress", "1 updates available"} {
+		if !contains(output.Message, expected) {
+			t.Errorf("Expected message to contain %q, got %q", expected, output.Message)
+		}
+	}
+}
+
+func TestBuildClusterOperatorsOutput_Conditions(t *testing.T) {
+	report := testOperatorReport()
+
+	output := buildClusterOperatorsOutput(report, ClusterOperatorsInput{HistoryLimit: 5})
+	if len(output.Operators) != 2 || output.Operators[0].Conditions != nil {
+		t.Errorf("Expected all operators without conditions, got %+v", output.Operators)
+	}
+
+	output = buildClusterOperatorsOutput(report, ClusterOperatorsInput{IncludeConditions: true, HistoryLimit: 5})
+	if len(output.Operators[0].Conditions) != 1 {
+		t.Error("Expected conditions when include_conditions is true")
+	}
+}
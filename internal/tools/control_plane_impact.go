@@ -0,0 +1,231 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+
+	"github.com/KubeHeal/openshift-cluster-health-mcp/pkg/capacity"
+	"github.com/KubeHeal/openshift-cluster-health-mcp/pkg/clients"
+	"github.com/KubeHeal/openshift-cluster-health-mcp/pkg/scheduling"
+)
+
+// Control plane cost of one pod start. Events are short-lived (default TTL 1h)
+// but are written to etcd and fanned out to watchers like any other object.
+const (
+	eventsPerPodStart = 5    // Scheduled, Pulling/Pulled, Created, Started
+	podWritesPerStart = 5    // create, bind and ~3 status updates
+	eventObjectBytes  = 1024 // typical serialized Event
+	// defaultPodObjectBytes is used when no pod of the deployment can be measured
+	defaultPodObjectBytes = 8 * 1024
+)
+
+// Control plane signal sources
+const (
+	SignalSourcePrometheus = "prometheus"
+	SignalSourceAPI        = "api"
+)
+
+// ControlPlaneSignals are the observed inputs of the infrastructure impact model
+type ControlPlaneSignals struct {
+	ObjectSource string           `json:"object_source"`
+	ObjectCounts map[string]int64 `json:"object_counts"`
+	TotalObjects int64            `json:"total_objects"`
+	PodCount     int64            `json:"pod_count"`
+	// PodObjectBytes is the serialized size of one of the deployment's pods
+	PodObjectBytes int64 `json:"pod_object_bytes"`
+	// Prometheus-only signals; zero when unknown
+	EtcdDBSizeBytes     int64    `json:"etcd_db_size_bytes,omitempty"`
+	EtcdQuotaBytes      int64    `json:"etcd_quota_bytes,omitempty"`
+	APIServerP99Seconds float64  `json:"apiserver_p99_latency_seconds,omitempty"`
+	PodWatchers         int64    `json:"pod_watchers,omitempty"`
+	Gaps                []string `json:"gaps,omitempty"`
+}
+
+// ControlPlaneProjection is the modeled change in control plane load
+type ControlPlaneProjection struct {
+	PodDelta            int     `json:"pod_delta"`
+	ObjectDelta         int64   `json:"object_delta"`
+	StorageDeltaBytes   int64   `json:"storage_delta_bytes"`
+	WatchEventDelta     int64   `json:"watch_event_delta"`
+	ObjectGrowthPercent float64 `json:"object_growth_percent"`
+	PodGrowthPercent    float64 `json:"pod_growth_percent"`
+}
+
+// collectControlPlaneSignals gathers object counts, pod size and (when
+// Prometheus is enabled) etcd and API server metrics. Missing signals are
+// recorded as gaps rather than failing the analysis.
+func (t *AnalyzeScalingImpactTool) collectControlPlaneSignals(ctx context.Context, namespace, deployment string) ControlPlaneSignals {
+	signals := ControlPlaneSignals{ObjectCounts: make(map[string]int64), PodObjectBytes: defaultPodObjectBytes}
+
+	if t.prometheus != nil {
+		if samples, err := t.prometheus.Query(ctx, `max by (resource) (apiserver_storage_objects)`); err == nil && len(samples) > 0 {
+			for _, s := range samples {
+				if !math.IsNaN(s.Value) {
+					signals.ObjectCounts[s.Labels["resource"]] = int64(s.Value)
+				}
+			}
+			signals.ObjectSource = SignalSourcePrometheus
+		}
+		if v, ok := queryScalar(ctx, t.prometheus, `max(etcd_mvcc_db_total_size_in_bytes)`); ok {
+			signals.EtcdDBSizeBytes = int64(v)
+		} else {
+			signals.Gaps = append(signals.Gaps, "etcd DB size unavailable from Prometheus")
+		}
+		if v, ok := queryScalar(ctx, t.prometheus, `max(etcd_server_quota_backend_bytes)`); ok {
+			signals.EtcdQuotaBytes = int64(v)
+		}
+		if v, ok := queryScalar(ctx, t.prometheus, `histogram_quantile(0.99, sum by (le) (rate(apiserver_request_duration_seconds_bucket{verb!~"WATCH|CONNECT"}[5m])))`); ok {
+			signals.APIServerP99Seconds = v
+		} else {
+			signals.Gaps = append(signals.Gaps, "API server latency unavailable from Prometheus")
+		}
+		if v, ok := queryScalar(ctx, t.prometheus, `sum(apiserver_longrunning_requests{verb="WATCH",resource="pods"})`); ok {
+			signals.PodWatchers = int64(v)
+		}
+	} else {
+		signals.Gaps = append(signals.Gaps, "Prometheus not enabled: etcd DB size, API server latency and watch counts unavailable")
+	}
+
+	if signals.ObjectSource == "" {
+		for _, gvr := range clients.ObjectCountResources {
+			count, err := t.k8sClient.CountObjects(ctx, gvr)
+			if err != nil {
+				signals.Gaps = append(signals.Gaps, err.Error())
+				continue
+			}
+			signals.ObjectCounts[clients.ResourceKey(gvr)] = count
+		}
+		signals.ObjectSource = SignalSourceAPI
+	}
+	for _, count := range signals.ObjectCounts {
+		signals.TotalObjects += count
+	}
+	signals.PodCount = signals.ObjectCounts["pods"]
+
+	if pods, err := t.k8sClient.ListPods(ctx, namespace); err == nil {
+		for i := range pods.Items {
+			ref := capacity.WorkloadOf(&pods.Items[i])
+			if ref.Kind != "Deployment" || ref.Name != deployment {
+				continue
+			}
+			if raw, err := json.Marshal(&pods.Items[i]); err == nil {
+				signals.PodObjectBytes = int64(len(raw))
+				break
+			}
+		}
+	}
+	return signals
+}
+
+// queryScalar runs a query expected to return a single value
+func queryScalar(ctx context.Context, prometheus *clients.PrometheusClient, query string) (float64, bool) {
+	samples, err := prometheus.Query(ctx, query)
+	if err != nil || len(samples) == 0 || math.IsNaN(samples[0].Value) || math.IsInf(samples[0].Value, 0) {
+		return 0, false
+	}
+	return samples[0].Value, true
+}
+
+// projectControlPlaneLoad models the objects, storage and watch events added
+// (or removed) by changing the replica count
+func projectControlPlaneLoad(currentReplicas, targetReplicas int, signals ControlPlaneSignals) ControlPlaneProjection {
+	delta := targetReplicas - currentReplicas
+	p := ControlPlaneProjection{PodDelta: delta}
+	p.ObjectDelta = int64(delta) * (1 + eventsPerPodStart)
+	p.StorageDeltaBytes = int64(delta) * (signals.PodObjectBytes + eventsPerPodStart*eventObjectBytes)
+	p.WatchEventDelta = int64(delta) * podWritesPerStart * signals.PodWatchers
+	if signals.TotalObjects > 0 {
+		p.ObjectGrowthPercent = float64(p.ObjectDelta) / float64(signals.TotalObjects) * 100
+	}
+	if signals.PodCount > 0 {
+		p.PodGrowthPercent = float64(delta) / float64(signals.PodCount) * 100
+	}
+	return p
+}
+
+// rateEtcdImpact rates etcd impact from projected DB utilization when known,
+// otherwise from relative object growth
+func rateEtcdImpact(p ControlPlaneProjection, s ControlPlaneSignals) (string, string) {
+	basis := fmt.Sprintf("%+d objects (%+.1f%% of %d), %s storage", p.ObjectDelta, p.ObjectGrowthPercent, s.TotalObjects, formatSignedBytes(p.StorageDeltaBytes))
+	if p.PodDelta <= 0 {
+		return "low", basis
+	}
+	if s.EtcdDBSizeBytes > 0 && s.EtcdQuotaBytes > 0 {
+		current := float64(s.EtcdDBSizeBytes) / float64(s.EtcdQuotaBytes) * 100
+		projected := float64(s.EtcdDBSizeBytes+p.StorageDeltaBytes) / float64(s.EtcdQuotaBytes) * 100
+		basis += fmt.Sprintf("; etcd DB %s of %s quota (%.1f%% -> %.1f%%)",
+			scheduling.FormatBytes(s.EtcdDBSizeBytes), scheduling.FormatBytes(s.EtcdQuotaBytes), current, projected)
+		growth := float64(p.StorageDeltaBytes) / float64(s.EtcdDBSizeBytes) * 100
+		switch {
+		case projected >= 80 || growth >= 5:
+			return "high", basis
+		case projected >= 60 || growth >= 1:
+			return "medium", basis
+		}
+		return "low", basis
+	}
+	switch {
+	case p.ObjectGrowthPercent >= 10:
+		return "high", basis
+	case p.ObjectGrowthPercent >= 2:
+		return "medium", basis
+	}
+	return "low", basis
+}
+
+// rateAPIServerImpact rates API server impact from pod growth, watch fan-out
+// and current request latency
+func rateAPIServerImpact(p ControlPlaneProjection, s ControlPlaneSignals) (string, string) {
+	basis := fmt.Sprintf("%+d pods (%+.1f%% of %d)", p.PodDelta, p.PodGrowthPercent, s.PodCount)
+	if s.PodWatchers > 0 {
+		basis += fmt.Sprintf(", %+d watch events to %d pod watchers", p.WatchEventDelta, s.PodWatchers)
+	}
+	slow := s.APIServerP99Seconds >= 1
+	if s.APIServerP99Seconds > 0 {
+		basis += fmt.Sprintf("; API server p99 latency %.2fs", s.APIServerP99Seconds)
+	}
+	if p.PodDelta <= 0 {
+		return "low", basis
+	}
+	switch {
+	case p.PodGrowthPercent >= 20 || (slow && p.PodGrowthPercent >= 5):
+		return "high", basis
+	case p.PodGrowthPercent >= 5 || slow:
+		return "medium", basis
+	}
+	return "low", basis
+}
+
+// rateSchedulerImpact rates the scheduling burst relative to the cluster's pods
+func rateSchedulerImpact(p ControlPlaneProjection, s ControlPlaneSignals) (string, string) {
+	basis := fmt.Sprintf("%d pods to schedule (%.1f%% of %d running)", max(p.PodDelta, 0), math.Max(p.PodGrowthPercent, 0), s.PodCount)
+	switch {
+	case p.PodDelta <= 0:
+		return "low", basis
+	case p.PodGrowthPercent >= 25:
+		return "high", basis
+	case p.PodGrowthPercent >= 10:
+		return "medium", basis
+	}
+	return "low", basis
+}
+
+// formatSignedBytes renders a byte delta with an explicit sign
+func formatSignedBytes(b int64) string {
+	sign := "+"
+	if b < 0 {
+		sign = "-"
+		b = -b
+	}
+	switch {
+	case b >= 1024*1024*1024:
+		return fmt.Sprintf("%s%.1fGi", sign, float64(b)/(1024*1024*1024))
+	case b >= 1024*1024:
+		return fmt.Sprintf("%s%.1fMi", sign, float64(b)/(1024*1024))
+	case b >= 1024:
+		return fmt.Sprintf("%s%dKi", sign, b/1024)
+	}
+	return fmt.Sprintf("%s%dB", sign, b)
+}
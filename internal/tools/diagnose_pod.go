@@ -0,0 +1,686 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/KubeHeal/openshift-cluster-health-mcp/pkg/clients"
+	"github.com/KubeHeal/openshift-cluster-health-mcp/pkg/redact"
+)
+
+const (
+	defaultDiagnoseLogLines = 50
+	maxDiagnoseLogLines     = 500
+	diagnoseLogMaxBytes     = 32 * 1024
+	diagnoseMaxEvents       = 20
+)
+
+// Probable cause categories
+const (
+	CauseOOM           = "oom_killed"
+	CauseConfigError   = "config_error"
+	CauseLivenessProbe = "liveness_probe_failure"
+	CauseImagePull     = "image_pull_failure"
+	CauseAppPanic      = "application_crash"
+	CauseUnknown       = "unknown"
+)
+
+// Cause confidence levels
+const (
+	ConfidenceHigh   = "high"
+	ConfidenceMedium = "medium"
+	ConfidenceLow    = "low"
+)
+
+var (
+	// panicLinePattern matches crash signatures in application logs
+	panicLinePattern = regexp.MustCompile(`(?i)(^panic:|\bfatal error:|^Traceback \(most recent call last\)|Exception in thread|Unhandled exception|\bsegmentation fault\b|^\s*at .+\(.+:\d+\)$)`)
+	// configErrorPattern matches missing config references in messages and logs
+	configErrorPattern = regexp.MustCompile(`(?i)((secret|configmap)s? "[^"]+" not found|couldn't find key|no such file or directory|missing required|environment variable .* (not set|missing)|invalid configuration)`)
+	// imagePullReasons are waiting reasons caused by image pulls
+	imagePullReasons = map[string]bool{
+		"ErrImagePull":      true,
+		"ImagePullBackOff":  true,
+		"InvalidImageName":  true,
+		"ErrImageNeverPull": true,
+	}
+	// configErrorReasons are waiting reasons caused by bad pod configuration
+	configErrorReasons = map[string]bool{
+		"CreateContainerConfigError": true,
+		"CreateContainerError":       true,
+		"RunContainerError":          true,
+	}
+	// signalNames maps common signal numbers to names
+	signalNames = map[int32]string{
+		2:  "SIGINT",
+		6:  "SIGABRT",
+		9:  "SIGKILL",
+		11: "SIGSEGV",
+		15: "SIGTERM",
+	}
+)
+
+// DiagnosePodTool assembles root-cause evidence for a failing pod via MCP
+type DiagnosePodTool struct {
+	k8sClient *clients.K8sClient
+}
+
+// NewDiagnosePodTool creates a new diagnose-pod tool
+func NewDiagnosePodTool(k8sClient *clients.K8sClient) *DiagnosePodTool {
+	return &DiagnosePodTool{
+		k8sClient: k8sClient,
+	}
+}
+
+// Name returns the tool name for MCP registration
+func (t *DiagnosePodTool) Name() string {
+	return "diagnose-pod"
+}
+
+// Description returns the tool description for MCP
+func (t *DiagnosePodTool) Description() string {
+	return "Diagnose a crashing or failing pod in one call: last termination state (exit code, signal, OOMKilled), previous-container log tail, warning and probe-failure events, probe configuration, restart cadence and a classified probable cause with evidence"
+}
+
+// InputSchema returns the JSON schema for tool inputs
+func (t *DiagnosePodTool) InputSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"pod_name": map[string]interface{}{
+				"type":        "string",
+				"description": "Name of the pod",
+			},
+			"namespace": map[string]interface{}{
+				"type":        "string",
+				"description": "Namespace of the pod",
+			},
+			"log_lines": map[string]interface{}{
+				"type":        "integer",
+				"description": "Number of previous-container log lines to include per restarted container",
+				"default":     defaultDiagnoseLogLines,
+				"minimum":     0,
+				"maximum":     maxDiagnoseLogLines,
+			},
+		},
+		"required": []string{"pod_name", "namespace"},
+	}
+}
+
+// DiagnosePodInput represents the input parameters
+type DiagnosePodInput struct {
+	PodName   string `json:"pod_name"`
+	Namespace string `json:"namespace"`
+	LogLines  int64  `json:"log_lines"`
+}
+
+// TerminationInfo describes how a container instance ended
+type TerminationInfo struct {
+	ExitCode    int32      `json:"exit_code"`
+	Signal      string     `json:"signal,omitempty"`
+	Reason      string     `json:"reason,omitempty"`
+	Message     string     `json:"message,omitempty"`
+	OOMKilled   bool       `json:"oom_killed"`
+	StartedAt   *time.Time `json:"started_at,omitempty"`
+	FinishedAt  *time.Time `json:"finished_at,omitempty"`
+	RunDuration string     `json:"run_duration,omitempty"`
+}
+
+// ProbeInfo summarizes a container probe configuration
+type ProbeInfo struct {
+	Type                string `json:"type"` // liveness, readiness, startup
+	Handler             string `json:"handler"`
+	InitialDelaySeconds int32  `json:"initial_delay_seconds"`
+	PeriodSeconds       int32  `json:"period_seconds"`
+	TimeoutSeconds      int32  `json:"timeout_seconds"`
+	FailureThreshold    int32  `json:"failure_threshold"`
+}
+
+// ContainerDiagnosis is the per-container evidence
+type ContainerDiagnosis struct {
+	Name            string           `json:"name"`
+	Init            bool             `json:"init"`
+	Ready           bool             `json:"ready"`
+	RestartCount    int32            `json:"restart_count"`
+	State           string           `json:"state"`
+	Reason          string           `json:"reason,omitempty"`
+	Message         string           `json:"message,omitempty"`
+	LastTermination *TerminationInfo `json:"last_termination,omitempty"`
+	Probes          []ProbeInfo      `json:"probes,omitempty"`
+	MemoryLimit     string           `json:"memory_limit,omitempty"`
+	PreviousLogs    []string         `json:"previous_logs,omitempty"` // Log tail of the last terminated instance
+	LogError        string           `json:"log_error,omitempty"`
+
+	restarted bool // A previous instance exists, so its logs can be fetched
+}
+
+// RestartCadence describes how often the pod restarts
+type RestartCadence struct {
+	TotalRestarts   int32      `json:"total_restarts"`
+	PodAge          string     `json:"pod_age"`
+	RestartsPerHour float64    `json:"restarts_per_hour"`
+	LastRestart     *time.Time `json:"last_restart,omitempty"`
+	CrashLooping    bool       `json:"crash_looping"`
+}
+
+// ProbableCause is a classified failure cause with supporting evidence
+type ProbableCause struct {
+	Category   string   `json:"category"`
+	Confidence string   `json:"confidence"`
+	Container  string   `json:"container,omitempty"`
+	Summary    string   `json:"summary"`
+	Evidence   []string `json:"evidence"`
+}
+
+// DiagnosePodOutput represents the tool output
+type DiagnosePodOutput struct {
+	Pod           string               `json:"pod"`
+	Namespace     string               `json:"namespace"`
+	Phase         string               `json:"phase"`
+	Node          string               `json:"node,omitempty"`
+	Containers    []ContainerDiagnosis `json:"containers"`
+	Restarts      RestartCadence       `json:"restarts"`
+	WarningEvents []clients.Event      `json:"warning_events"`
+	ProbeFailures []clients.Event      `json:"probe_failures,omitempty"`
+	ProbableCause ProbableCause        `json:"probable_cause"`
+	OtherCauses   []ProbableCause      `json:"other_causes,omitempty"`
+	Message       string               `json:"message"`
+}
+
+// Execute gathers evidence and classifies the failure
+func (t *DiagnosePodTool) Execute(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+	input := DiagnosePodInput{
+		LogLines: defaultDiagnoseLogLines,
+	}
+	if argsJSON, err := json.Marshal(args); err == nil {
+		_ = json.Unmarshal(argsJSON, &input) //nolint:errcheck // Intentionally ignore error, use defaults if unmarshal fails
+	}
+
+	if input.PodName == "" {
+		return nil, fmt.Errorf("pod_name is required")
+	}
+	if input.Namespace == "" {
+		return nil, fmt.Errorf("namespace is required")
+	}
+	if input.LogLines < 0 {
+		input.LogLines = 0
+	}
+	if input.LogLines > maxDiagnoseLogLines {
+		input.LogLines = maxDiagnoseLogLines
+	}
+
+	pod, err := t.k8sClient.GetPod(ctx, input.Namespace, input.PodName)
+	if err != nil {
+		return nil, err
+	}
+
+	// Events are supporting evidence; diagnose without them if listing fails
+	events, err := t.k8sClient.ListEventTimeline(ctx, clients.EventFilter{
+		Namespace: input.Namespace,
+		Kind:      "Pod",
+		Name:      input.PodName,
+	})
+	if err != nil {
+		events = nil
+	}
+
+	now := time.Now()
+	output := buildPodDiagnosis(pod, events, now)
+
+	if input.LogLines > 0 {
+		for i := range output.Containers {
+			c := &output.Containers[i]
+			// A container that terminated without restarting has no previous
+			// instance: its own logs are those of the terminated run
+			if !c.restarted && c.LastTermination == nil {
+				continue
+			}
+			c.PreviousLogs, c.LogError = t.terminatedLogs(ctx, pod, c.Name, input.LogLines, c.restarted)
+		}
+	}
+
+	causes := classifyPodFailure(&output)
+	output.ProbableCause = causes[0]
+	if len(causes) > 1 {
+		output.OtherCauses = causes[1:]
+	}
+	output.Message = diagnosePodMessage(&output)
+	return output, nil
+}
+
+// terminatedLogs returns the redacted log tail of the previous container
+// instance, or of the current one when previous is false
+func (t *DiagnosePodTool) terminatedLogs(ctx context.Context, pod *corev1.Pod, container string, lines int64, previous bool) ([]string, string) {
+	stream, err := t.k8sClient.StreamPodLogs(ctx, pod.Namespace, pod.Name, &corev1.PodLogOptions{
+		Container: container,
+		Previous:  previous,
+		TailLines: &lines,
+	})
+	if err != nil {
+		return nil, err.Error()
+	}
+	defer func() {
+		_ = stream.Close() //nolint:errcheck // Best-effort close of a read-only stream
+	}()
+
+	raw, _, err := readTail(redact.NewReader(stream), diagnoseLogMaxBytes)
+	if err != nil {
+		return nil, fmt.Sprintf("failed to read logs: %v", err)
+	}
+	return splitLogLines(string(raw)), ""
+}
+
+// buildPodDiagnosis collects container state, probes, events and restart cadence
+func buildPodDiagnosis(pod *corev1.Pod, events []clients.Event, now time.Time) DiagnosePodOutput {
+	output := DiagnosePodOutput{
+		Pod:           pod.Name,
+		Namespace:     pod.Namespace,
+		Phase:         string(pod.Status.Phase),
+		Node:          pod.Spec.NodeName,
+		WarningEvents: []clients.Event{},
+	}
+
+	statuses := make(map[string]corev1.ContainerStatus)
+	for _, cs := range pod.Status.InitContainerStatuses {
+		statuses[cs.Name] = cs
+	}
+	for _, cs := range pod.Status.ContainerStatuses {
+		statuses[cs.Name] = cs
+	}
+
+	var lastRestart *time.Time
+	addContainer := func(c corev1.Container, init bool) {
+		diag := ContainerDiagnosis{
+			Name:   c.Name,
+			Init:   init,
+			State:  "Unknown",
+			Probes: probeInfos(&c),
+		}
+		if limit, ok := c.Resources.Limits[corev1.ResourceMemory]; ok {
+			diag.MemoryLimit = limit.String()
+		}
+
+		if cs, ok := statuses[c.Name]; ok {
+			diag.Ready = cs.Ready
+			diag.RestartCount = cs.RestartCount
+			diag.restarted = cs.RestartCount > 0 || cs.LastTerminationState.Terminated != nil
+			switch {
+			case cs.State.Running != nil:
+				diag.State = "Running"
+			case cs.State.Waiting != nil:
+				diag.State = "Waiting"
+				diag.Reason = cs.State.Waiting.Reason
+				diag.Message = cs.State.Waiting.Message
+			case cs.State.Terminated != nil:
+				diag.State = "Terminated"
+				diag.Reason = cs.State.Terminated.Reason
+				diag.Message = cs.State.Terminated.Message
+			}
+
+			// Prefer the previous instance; fall back to the current one if it has terminated
+			terminated := cs.LastTerminationState.Terminated
+			if terminated == nil {
+				terminated = cs.State.Terminated
+			}
+			if terminated != nil {
+				diag.LastTermination = terminationInfo(terminated)
+				if diag.LastTermination.FinishedAt != nil && cs.RestartCount > 0 &&
+					(lastRestart == nil || diag.LastTermination.FinishedAt.After(*lastRestart)) {
+					lastRestart = diag.LastTermination.FinishedAt
+				}
+			}
+
+			output.Restarts.TotalRestarts += cs.RestartCount
+			if cs.State.Waiting != nil && cs.State.Waiting.Reason == "CrashLoopBackOff" {
+				output.Restarts.CrashLooping = true
+			}
+		}
+		output.Containers = append(output.Containers, diag)
+	}
+	for _, c := range pod.Spec.InitContainers {
+		addContainer(c, true)
+	}
+	for _, c := range pod.Spec.Containers {
+		addContainer(c, false)
+	}
+
+	age := now.Sub(pod.CreationTimestamp.Time)
+	output.Restarts.PodAge = formatDuration(age)
+	output.Restarts.LastRestart = lastRestart
+	if age > 0 && output.Restarts.TotalRestarts > 0 {
+		output.Restarts.RestartsPerHour = float64(output.Restarts.TotalRestarts) / age.Hours()
+		output.Restarts.RestartsPerHour = float64(int(output.Restarts.RestartsPerHour*100)) / 100
+	}
+
+	// Newest events first, capped
+	sorted := append([]clients.Event{}, events...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].LastSeen.After(sorted[j].LastSeen)
+	})
+	for _, e := range sorted {
+		// Kubelet reports liveness restarts as a Normal Killing event
+		if isProbeFailureEvent(e) {
+			output.ProbeFailures = append(output.ProbeFailures, e)
+		}
+		if e.Type != "Warning" {
+			continue
+		}
+		if len(output.WarningEvents) < diagnoseMaxEvents {
+			output.WarningEvents = append(output.WarningEvents, e)
+		}
+	}
+	return output
+}
+
+// terminationInfo converts a terminated container state
+func terminationInfo(t *corev1.ContainerStateTerminated) *TerminationInfo {
+	info := &TerminationInfo{
+		ExitCode:  t.ExitCode,
+		Reason:    t.Reason,
+		Message:   strings.TrimSpace(t.Message),
+		OOMKilled: t.Reason == "OOMKilled",
+	}
+
+	signal := t.Signal
+	if signal == 0 && t.ExitCode > 128 && t.ExitCode < 160 {
+		signal = t.ExitCode - 128
+	}
+	if signal > 0 {
+		if name, ok := signalNames[signal]; ok {
+			info.Signal = name
+		} else {
+			info.Signal = fmt.Sprintf("signal %d", signal)
+		}
+	}
+
+	if !t.StartedAt.IsZero() {
+		started := t.StartedAt.Time
+		info.StartedAt = &started
+	}
+	if !t.FinishedAt.IsZero() {
+		finished := t.FinishedAt.Time
+		info.FinishedAt = &finished
+	}
+	if info.StartedAt != nil && info.FinishedAt != nil {
+		info.RunDuration = info.FinishedAt.Sub(*info.StartedAt).Round(time.Second).String()
+	}
+	return info
+}
+
+// probeInfos summarizes the probes configured on a container
+func probeInfos(c *corev1.Container) []ProbeInfo {
+	var probes []ProbeInfo
+	add := func(kind string, p *corev1.Probe) {
+		if p == nil {
+			return
+		}
+		probes = append(probes, ProbeInfo{
+			Type:                kind,
+			Handler:             probeHandler(p),
+			InitialDelaySeconds: p.InitialDelaySeconds,
+			PeriodSeconds:       p.PeriodSeconds,
+			TimeoutSeconds:      p.TimeoutSeconds,
+			FailureThreshold:    p.FailureThreshold,
+		})
+	}
+	add("startup", c.StartupProbe)
+	add("liveness", c.LivenessProbe)
+	add("readiness", c.ReadinessProbe)
+	return probes
+}
+
+// probeHandler describes a probe action
+func probeHandler(p *corev1.Probe) string {
+	switch {
+	case p.HTTPGet != nil:
+		return fmt.Sprintf("httpGet %s port %s", p.HTTPGet.Path, p.HTTPGet.Port.String())
+	case p.TCPSocket != nil:
+		return fmt.Sprintf("tcpSocket port %s", p.TCPSocket.Port.String())
+	case p.Exec != nil:
+		return "exec " + strings.Join(p.Exec.Command, " ")
+	case p.GRPC != nil:
+		return fmt.Sprintf("grpc port %d", p.GRPC.Port)
+	}
+	return "unknown"
+}
+
+// isProbeFailureEvent reports whether a kubelet event is a probe failure
+func isProbeFailureEvent(e clients.Event) bool {
+	if e.Reason == "Unhealthy" || e.Reason == "ProbeWarning" {
+		return true
+	}
+	return e.Reason == "Killing" && strings.Contains(strings.ToLower(e.Message), "probe")
+}
+
+// classifyPodFailure returns probable causes ordered by confidence; the
+// result always has at least one entry
+func classifyPodFailure(d *DiagnosePodOutput) []ProbableCause {
+	var causes []ProbableCause
+
+	for _, c := range d.Containers {
+		// Image pulls
+		if imagePullReasons[c.Reason] {
+			causes = append(causes, ProbableCause{
+				Category:   CauseImagePull,
+				Confidence: ConfidenceHigh,
+				Container:  c.Name,
+				Summary:    fmt.Sprintf("Container %s cannot pull its image", c.Name),
+				Evidence:   []string{fmt.Sprintf("container state Waiting/%s: %s", c.Reason, c.Message)},
+			})
+			continue
+		}
+
+		// Missing secrets/configmaps or invalid container config
+		if configErrorReasons[c.Reason] {
+			causes = append(causes, ProbableCause{
+				Category:   CauseConfigError,
+				Confidence: ConfidenceHigh,
+				Container:  c.Name,
+				Summary:    fmt.Sprintf("Container %s cannot start because of invalid or missing configuration", c.Name),
+				Evidence:   []string{fmt.Sprintf("container state Waiting/%s: %s", c.Reason, c.Message)},
+			})
+			continue
+		}
+
+		term := c.LastTermination
+		if term == nil {
+			continue
+		}
+
+		// OOM kills
+		if term.OOMKilled {
+			evidence := []string{fmt.Sprintf("last termination reason OOMKilled (exit code %d)", term.ExitCode)}
+			if c.MemoryLimit != "" {
+				evidence = append(evidence, "memory limit "+c.MemoryLimit)
+			}
+			if term.RunDuration != "" {
+				evidence = append(evidence, "ran for "+term.RunDuration+" before being killed")
+			}
+			causes = append(causes, ProbableCause{
+				Category:   CauseOOM,
+				Confidence: ConfidenceHigh,
+				Container:  c.Name,
+				Summary:    fmt.Sprintf("Container %s is killed for exceeding its memory limit", c.Name),
+				Evidence:   evidence,
+			})
+			continue
+		}
+
+		if term.ExitCode == 0 {
+			continue
+		}
+
+		// Liveness probe kills: kubelet sends SIGTERM/SIGKILL after probe failures
+		if killed := livenessKillEvidence(d, c); len(killed) > 0 {
+			causes = append(causes, ProbableCause{
+				Category:   CauseLivenessProbe,
+				Confidence: ConfidenceHigh,
+				Container:  c.Name,
+				Summary:    fmt.Sprintf("Container %s is restarted by kubelet after failing its liveness probe", c.Name),
+				Evidence:   append(killed, fmt.Sprintf("last exit code %d %s", term.ExitCode, term.Signal)),
+			})
+			continue
+		}
+
+		// Application crashes and config errors surfaced in logs
+		if cause := classifyFromLogs(c); cause != nil {
+			causes = append(causes, *cause)
+			continue
+		}
+
+		evidence := []string{fmt.Sprintf("last exit code %d (%s)", term.ExitCode, term.Reason)}
+		if term.Message != "" {
+			evidence = append(evidence, "termination message: "+term.Message)
+		}
+		causes = append(causes, ProbableCause{
+			Category:   CauseAppPanic,
+			Confidence: ConfidenceLow,
+			Container:  c.Name,
+			Summary:    fmt.Sprintf("Container %s exits with a non-zero code; no crash signature found in logs", c.Name),
+			Evidence:   evidence,
+		})
+	}
+
+	// Events may reveal causes that container status does not (e.g. FailedMount for a missing secret)
+	for _, e := range d.WarningEvents {
+		if e.Reason == "FailedMount" && configErrorPattern.MatchString(e.Message) {
+			causes = append(causes, ProbableCause{
+				Category:   CauseConfigError,
+				Confidence: ConfidenceHigh,
+				Summary:    "A volume references a missing Secret or ConfigMap",
+				Evidence:   []string{fmt.Sprintf("event %s x%d: %s", e.Reason, e.Count, e.Message)},
+			})
+			break
+		}
+	}
+
+	if len(causes) == 0 {
+		summary := "No failure signature found"
+		if d.Restarts.TotalRestarts > 0 {
+			summary = fmt.Sprintf("%d restarts without a recognizable cause", d.Restarts.TotalRestarts)
+		}
+		causes = append(causes, ProbableCause{
+			Category:   CauseUnknown,
+			Confidence: ConfidenceLow,
+			Summary:    summary,
+			Evidence:   []string{},
+		})
+	}
+
+	rank := map[string]int{ConfidenceHigh: 0, ConfidenceMedium: 1, ConfidenceLow: 2}
+	sort.SliceStable(causes, func(i, j int) bool {
+		return rank[causes[i].Confidence] < rank[causes[j].Confidence]
+	})
+	return causes
+}
+
+// livenessKillEvidence returns event evidence that kubelet killed the container
+// for failing its liveness probe
+func livenessKillEvidence(d *DiagnosePodOutput, c ContainerDiagnosis) []string {
+	hasLiveness := false
+	for _, p := range c.Probes {
+		if p.Type == "liveness" {
+			hasLiveness = true
+		}
+	}
+	if !hasLiveness {
+		return nil
+	}
+
+	var evidence []string
+	for _, e := range d.ProbeFailures {
+		msg := strings.ToLower(e.Message)
+		if !strings.Contains(msg, "liveness") {
+			continue
+		}
+		// Killing events name the container; skip those for other containers
+		if e.Reason == "Killing" && !strings.Contains(msg, strings.ToLower(c.Name)) {
+			continue
+		}
+		evidence = append(evidence, fmt.Sprintf("event %s x%d: %s", e.Reason, e.Count, e.Message))
+		if len(evidence) == 3 {
+			break
+		}
+	}
+	return evidence
+}
+
+// classifyFromLogs looks for crash or config error signatures in the previous logs
+func classifyFromLogs(c ContainerDiagnosis) *ProbableCause {
+	term := c.LastTermination
+	for i, line := range c.PreviousLogs {
+		if configErrorPattern.MatchString(line) {
+			return &ProbableCause{
+				Category:   CauseConfigError,
+				Confidence: ConfidenceMedium,
+				Container:  c.Name,
+				Summary:    fmt.Sprintf("Container %s exits on startup because of a configuration error", c.Name),
+				Evidence: []string{
+					fmt.Sprintf("previous log line %d: %s", i+1, line),
+					fmt.Sprintf("last exit code %d", term.ExitCode),
+				},
+			}
+		}
+	}
+	for i, line := range c.PreviousLogs {
+		if panicLinePattern.MatchString(line) {
+			return &ProbableCause{
+				Category:   CauseAppPanic,
+				Confidence: ConfidenceHigh,
+				Container:  c.Name,
+				Summary:    fmt.Sprintf("Container %s crashes with an application panic or unhandled exception", c.Name),
+				Evidence: []string{
+					fmt.Sprintf("previous log line %d: %s", i+1, line),
+					fmt.Sprintf("last exit code %d %s", term.ExitCode, term.Signal),
+				},
+			}
+		}
+	}
+	if term.Signal == "SIGSEGV" || term.Signal == "SIGABRT" {
+		return &ProbableCause{
+			Category:   CauseAppPanic,
+			Confidence: ConfidenceMedium,
+			Container:  c.Name,
+			Summary:    fmt.Sprintf("Container %s is terminated by %s", c.Name, term.Signal),
+			Evidence:   []string{fmt.Sprintf("last exit code %d (%s)", term.ExitCode, term.Signal)},
+		}
+	}
+	for i := len(c.PreviousLogs) - 1; i >= 0; i-- {
+		if errorLinePattern.MatchString(c.PreviousLogs[i]) {
+			return &ProbableCause{
+				Category:   CauseAppPanic,
+				Confidence: ConfidenceMedium,
+				Container:  c.Name,
+				Summary:    fmt.Sprintf("Container %s exits with code %d after logging errors", c.Name, term.ExitCode),
+				Evidence: []string{
+					fmt.Sprintf("previous log line %d: %s", i+1, c.PreviousLogs[i]),
+					fmt.Sprintf("last exit code %d", term.ExitCode),
+				},
+			}
+		}
+	}
+	return nil
+}
+
+// diagnosePodMessage summarizes the diagnosis
+func diagnosePodMessage(d *DiagnosePodOutput) string {
+	msg := fmt.Sprintf("Pod %s/%s (%s)", d.Namespace, d.Pod, d.Phase)
+	if d.Restarts.CrashLooping {
+		msg += " is in CrashLoopBackOff"
+	}
+	if d.Restarts.TotalRestarts > 0 {
+		msg += fmt.Sprintf(", %d restarts (%.2f/hour)", d.Restarts.TotalRestarts, d.Restarts.RestartsPerHour)
+	}
+	cause := d.ProbableCause
+	if cause.Category == CauseUnknown {
+		return msg + ": " + cause.Summary
+	}
+	return fmt.Sprintf("%s: probable cause %s (%s confidence) - %s", msg, cause.Category, cause.Confidence, cause.Summary)
+}
@@ -0,0 +1,196 @@
+package tools
+
+import (
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/KubeHeal/openshift-cluster-health-mcp/pkg/clients"
+)
+
+func crashingPod(now time.Time, last *corev1.ContainerStateTerminated, waitingReason string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "api-1",
+			Namespace:         "app",
+			CreationTimestamp: metav1.NewTime(now.Add(-2 * time.Hour)),
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{
+				Name: "app",
+				Resources: corev1.ResourceRequirements{
+					Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("256Mi")},
+				},
+				LivenessProbe: &corev1.Probe{
+					ProbeHandler: corev1.ProbeHandler{
+						HTTPGet: &corev1.HTTPGetAction{Path: "/healthz", Port: intstr.FromInt32(8080)},
+					},
+					PeriodSeconds:    10,
+					FailureThreshold: 3,
+				},
+			}},
+		},
+		Status: corev1.PodStatus{
+			Phase: corev1.PodRunning,
+			ContainerStatuses: []corev1.ContainerStatus{{
+				Name:                 "app",
+				RestartCount:         6,
+				State:                corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: waitingReason}},
+				LastTerminationState: corev1.ContainerState{Terminated: last},
+			}},
+		},
+	}
+}
+
+func terminated(exitCode int32, reason string, now time.Time) *corev1.ContainerStateTerminated {
+	return &corev1.ContainerStateTerminated{
+		ExitCode:   exitCode,
+		Reason:     reason,
+		StartedAt:  metav1.NewTime(now.Add(-90 * time.Second)),
+		FinishedAt: metav1.NewTime(now.Add(-time.Minute)),
+	}
+}
+
+func diagnose(pod *corev1.Pod, events []clients.Event, logs []string, now time.Time) DiagnosePodOutput {
+	output := buildPodDiagnosis(pod, events, now)
+	for i := range output.Containers {
+		output.Containers[i].PreviousLogs = logs
+	}
+	causes := classifyPodFailure(&output)
+	output.ProbableCause = causes[0]
+	return output
+}
+
+func TestDiagnosePodTool_Metadata(t *testing.T) {
+	tool := &DiagnosePodTool{}
+
+	if tool.Name() != "diagnose-pod" {
+		t.Errorf("Expected name 'diagnose-pod', got '%s'", tool.Name())
+	}
+
+	schema := tool.InputSchema()
+	required, ok := schema["required"].([]string)
+	if !ok || len(required) != 2 {
+		t.Errorf("Expected pod_name and namespace to be required, got %v", schema["required"])
+	}
+}
+
+func TestBuildPodDiagnosis_TerminationAndCadence(t *testing.T) {
+	now := time.Now()
+	pod := crashingPod(now, terminated(137, "OOMKilled", now), "CrashLoopBackOff")
+
+	output := buildPodDiagnosis(pod, nil, now)
+
+	if !output.Restarts.CrashLooping || output.Restarts.TotalRestarts != 6 || output.Restarts.RestartsPerHour != 3 {
+		t.Errorf("Unexpected restart cadence: %+v", output.Restarts)
+	}
+	c := output.Containers[0]
+	if c.LastTermination == nil || !c.LastTermination.OOMKilled || c.LastTermination.Signal != "SIGKILL" {
+		t.Fatalf("Expected OOMKilled termination with SIGKILL, got %+v", c.LastTermination)
+	}
+	if c.LastTermination.RunDuration != "30s" || c.MemoryLimit != "256Mi" {
+		t.Errorf("Unexpected termination details: %+v (limit %s)", c.LastTermination, c.MemoryLimit)
+	}
+	if len(c.Probes) != 1 || c.Probes[0].Handler != "httpGet /healthz port 8080" {
+		t.Errorf("Unexpected probes: %+v", c.Probes)
+	}
+}
+
+func TestBuildPodDiagnosis_TerminatedWithoutRestart(t *testing.T) {
+	now := time.Now()
+	pod := crashingPod(now, nil, "")
+	pod.Status.ContainerStatuses[0].RestartCount = 0
+	pod.Status.ContainerStatuses[0].State = corev1.ContainerState{Terminated: terminated(1, "Error", now)}
+
+	c := buildPodDiagnosis(pod, nil, now).Containers[0]
+	if c.restarted || c.LastTermination == nil || c.LastTermination.ExitCode != 1 {
+		t.Errorf("Expected the current termination without a previous instance, got %+v", c)
+	}
+
+	c = buildPodDiagnosis(crashingPod(now, terminated(137, "OOMKilled", now), "CrashLoopBackOff"), nil, now).Containers[0]
+	if !c.restarted {
+		t.Errorf("Expected a restarted container to have a previous instance")
+	}
+}
+
+func TestBuildPodDiagnosis_NormalKillingEvent(t *testing.T) {
+	now := time.Now()
+	// Kubelet emits the liveness restart as a Normal event, without an Unhealthy warning in view
+	events := []clients.Event{
+		{Kind: "Pod", Name: "api-1", Type: "Normal", Reason: "Killing", Message: "Container app failed liveness probe, will be restarted", Count: 6, LastSeen: now},
+		{Kind: "Pod", Name: "api-1", Type: "Normal", Reason: "Pulled", Message: "Container image already present", Count: 6, LastSeen: now},
+	}
+
+	output := diagnose(crashingPod(now, terminated(137, "Error", now), "CrashLoopBackOff"), events, nil, now)
+	if len(output.ProbeFailures) != 1 || len(output.WarningEvents) != 0 {
+		t.Errorf("Expected the Killing event as a probe failure only, got %+v / %+v", output.ProbeFailures, output.WarningEvents)
+	}
+	if output.ProbableCause.Category != CauseLivenessProbe {
+		t.Errorf("Expected a liveness probe cause, got %+v", output.ProbableCause)
+	}
+}
+
+func TestClassifyPodFailure(t *testing.T) {
+	now := time.Now()
+	livenessEvents := []clients.Event{
+		{Kind: "Pod", Name: "api-1", Type: "Warning", Reason: "Unhealthy", Message: "Liveness probe failed: HTTP probe failed with statuscode: 500", Count: 18, LastSeen: now},
+		{Kind: "Pod", Name: "api-1", Type: "Normal", Reason: "Killing", Message: "Container app failed liveness probe, will be restarted", Count: 6, LastSeen: now},
+	}
+	missingSecret := []clients.Event{
+		{Kind: "Pod", Name: "api-1", Type: "Warning", Reason: "FailedMount", Message: `MountVolume.SetUp failed for volume "creds" : secret "db-creds" not found`, Count: 4, LastSeen: now},
+	}
+
+	testCases := []struct {
+		name       string
+		pod        *corev1.Pod
+		events     []clients.Event
+		logs       []string
+		category   string
+		confidence string
+	}{
+		{"oom", crashingPod(now, terminated(137, "OOMKilled", now), "CrashLoopBackOff"), nil, nil, CauseOOM, ConfidenceHigh},
+		{"image pull", crashingPod(now, nil, "ImagePullBackOff"), nil, nil, CauseImagePull, ConfidenceHigh},
+		{"config error", crashingPod(now, nil, "CreateContainerConfigError"), nil, nil, CauseConfigError, ConfidenceHigh},
+		{"missing secret mount", crashingPod(now, nil, "ContainerCreating"), missingSecret, nil, CauseConfigError, ConfidenceHigh},
+		{"liveness probe", crashingPod(now, terminated(137, "Error", now), "CrashLoopBackOff"), livenessEvents, nil, CauseLivenessProbe, ConfidenceHigh},
+		{"panic", crashingPod(now, terminated(2, "Error", now), "CrashLoopBackOff"), nil,
+			[]string{"starting server", "panic: runtime error: invalid memory address", "goroutine 1 [running]:"}, CauseAppPanic, ConfidenceHigh},
+		{"config in logs", crashingPod(now, terminated(1, "Error", now), "CrashLoopBackOff"), nil,
+			[]string{"open /etc/app/config.yaml: no such file or directory"}, CauseConfigError, ConfidenceMedium},
+		{"bare exit code", crashingPod(now, terminated(1, "Error", now), "CrashLoopBackOff"), nil, []string{"bye"}, CauseAppPanic, ConfidenceLow},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			output := diagnose(tc.pod, tc.events, tc.logs, now)
+			cause := output.ProbableCause
+			if cause.Category != tc.category || cause.Confidence != tc.confidence {
+				t.Errorf("Expected %s/%s, got %s/%s (%s)", tc.category, tc.confidence, cause.Category, cause.Confidence, cause.Summary)
+			}
+			if len(cause.Evidence) == 0 {
+				t.Error("Expected evidence for the probable cause")
+			}
+		})
+	}
+}
+
+func TestClassifyPodFailure_Unknown(t *testing.T) {
+	now := time.Now()
+	pod := crashingPod(now, nil, "")
+	pod.Status.ContainerStatuses[0].RestartCount = 0
+	pod.Status.ContainerStatuses[0].State = corev1.ContainerState{Running: &corev1.ContainerStateRunning{}}
+
+	output := diagnose(pod, nil, nil, now)
+	output.Message = diagnosePodMessage(&output)
+
+	if output.ProbableCause.Category != CauseUnknown {
+		t.Errorf("Expected unknown cause for a healthy pod, got %+v", output.ProbableCause)
+	}
+	if !contains(output.Message, "No failure signature found") {
+		t.Errorf("Unexpected message: %q", output.Message)
+	}
+}
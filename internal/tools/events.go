@@ -0,0 +1,216 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/KubeHeal/openshift-cluster-health-mcp/pkg/clients"
+)
+
+const (
+	defaultEventsSinceMinutes = 60
+	defaultEventsLimit        = 100
+	maxEventsLimit            = 500
+	eventsTopReasons          = 5
+)
+
+// EventsTool returns a deduplicated Kubernetes event timeline via MCP
+type EventsTool struct {
+	k8sClient *clients.K8sClient
+}
+
+// NewEventsTool creates a new get-events tool
+func NewEventsTool(k8sClient *clients.K8sClient) *EventsTool {
+	return &EventsTool{
+		k8sClient: k8sClient,
+	}
+}
+
+// Name returns the tool name for MCP registration
+func (t *EventsTool) Name() string {
+	return "get-events"
+}
+
+// Description returns the tool description for MCP
+func (t *EventsTool) Description() string {
+	return "Get a deduplicated event timeline filtered by involved object (kind/name), namespace, type, reason and time window. Merges core/v1 and events.k8s.io/v1 events and collapses repeats into counts."
+}
+
+// InputSchema returns the JSON schema for tool inputs
+func (t *EventsTool) InputSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"namespace": map[string]interface{}{
+				"type":        "string",
+				"description": "Namespace to query (empty = all namespaces)",
+				"default":     "",
+			},
+			"kind": map[string]interface{}{
+				"type":        "string",
+				"description": "Involved object kind, e.g. Pod, Node, Deployment (case-insensitive)",
+				"default":     "",
+			},
+			"name": map[string]interface{}{
+				"type":        "string",
+				"description": "Involved object name",
+				"default":     "",
+			},
+			"type": map[string]interface{}{
+				"type":        "string",
+				"description": "Event type filter",
+				"enum":        []string{"", "Warning", "Normal"},
+				"default":     "",
+			},
+			"reason": map[string]interface{}{
+				"type":        "string",
+				"description": "Event reason filter, e.g. BackOff, FailedScheduling, OOMKilling",
+				"default":     "",
+			},
+			"since_minutes": map[string]interface{}{
+				"type":        "integer",
+				"description": "Only include events last seen within this many minutes (0 = no limit)",
+				"default":     defaultEventsSinceMinutes,
+				"minimum":     0,
+			},
+			"limit": map[string]interface{}{
+				"type":        "integer",
+				"description": "Maximum number of events to return; the most recent are kept",
+				"default":     defaultEventsLimit,
+				"minimum":     1,
+				"maximum":     maxEventsLimit,
+			},
+		},
+		"required": []string{},
+	}
+}
+
+// EventsInput represents the input parameters
+type EventsInput struct {
+	Namespace    string `json:"namespace"`
+	Kind         string `json:"kind"`
+	Name         string `json:"name"`
+	Type         string `json:"type"`
+	Reason       string `json:"reason"`
+	SinceMinutes int    `json:"since_minutes"`
+	Limit        int    `json:"limit"`
+}
+
+// EventsOutput represents the tool output
+type EventsOutput struct {
+	Total        int                          `json:"total"`
+	Returned     int                          `json:"returned"`
+	Truncated    bool                         `json:"truncated"`
+	WarningCount int                          `json:"warning_count"`
+	TopReasons   []clients.EventReasonSummary `json:"top_reasons,omitempty"`
+	Events       []clients.Event              `json:"events"`
+	Message      string                       `json:"message"`
+}
+
+// Execute lists, merges and filters events
+func (t *EventsTool) Execute(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+	input := EventsInput{
+		SinceMinutes: defaultEventsSinceMinutes,
+		Limit:        defaultEventsLimit,
+	}
+	if argsJSON, err := json.Marshal(args); err == nil {
+		_ = json.Unmarshal(argsJSON, &input) //nolint:errcheck // Intentionally ignore error, use defaults if unmarshal fails
+	}
+
+	if input.Type != "" && !strings.EqualFold(input.Type, "Warning") && !strings.EqualFold(input.Type, "Normal") {
+		return nil, fmt.Errorf("invalid type %q: must be Warning or Normal", input.Type)
+	}
+	if input.SinceMinutes < 0 {
+		return nil, fmt.Errorf("since_minutes must not be negative, got %d", input.SinceMinutes)
+	}
+	if input.Limit < 1 {
+		input.Limit = defaultEventsLimit
+	}
+	if input.Limit > maxEventsLimit {
+		input.Limit = maxEventsLimit
+	}
+
+	filter := clients.EventFilter{
+		Namespace: input.Namespace,
+		Kind:      input.Kind,
+		Name:      input.Name,
+		Type:      input.Type,
+		Reason:    input.Reason,
+	}
+	if input.SinceMinutes > 0 {
+		filter.Since = time.Now().Add(-time.Duration(input.SinceMinutes) * time.Minute)
+	}
+
+	events, err := t.k8sClient.ListEventTimeline(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	output := buildEventsOutput(events, input.Limit)
+	output.Message = eventsMessage(&output, input)
+	return output, nil
+}
+
+// buildEventsOutput keeps the newest limit events of an oldest-first timeline
+// and summarizes warning reasons across all matching events
+func buildEventsOutput(events []clients.Event, limit int) EventsOutput {
+	output := EventsOutput{
+		Total:  len(events),
+		Events: events,
+	}
+
+	var warnings []clients.Event
+	for _, e := range events {
+		if e.Type == "Warning" {
+			warnings = append(warnings, e)
+		}
+	}
+	output.WarningCount = len(warnings)
+
+	output.TopReasons = clients.SummarizeEventReasons(warnings)
+	if len(output.TopReasons) > eventsTopReasons {
+		output.TopReasons = output.TopReasons[:eventsTopReasons]
+	}
+
+	if len(events) > limit {
+		output.Events = events[len(events)-limit:]
+		output.Truncated = true
+	}
+	if output.Events == nil {
+		output.Events = []clients.Event{}
+	}
+	output.Returned = len(output.Events)
+	return output
+}
+
+// eventsMessage summarizes the timeline
+func eventsMessage(output *EventsOutput, input EventsInput) string {
+	scope := "all namespaces"
+	if input.Namespace != "" {
+		scope = "namespace " + input.Namespace
+	}
+	if input.Name != "" {
+		scope = strings.TrimSpace(input.Kind+" "+input.Name) + " in " + scope
+	}
+	window := "all time"
+	if input.SinceMinutes > 0 {
+		window = fmt.Sprintf("last %d minutes", input.SinceMinutes)
+	}
+
+	if output.Total == 0 {
+		return fmt.Sprintf("No matching events for %s (%s)", scope, window)
+	}
+
+	msg := fmt.Sprintf("%d events (%d warnings) for %s (%s)", output.Total, output.WarningCount, scope, window)
+	if len(output.TopReasons) > 0 {
+		top := output.TopReasons[0]
+		msg += fmt.Sprintf("; top warning: %s x%d", top.Reason, top.Count)
+	}
+	if output.Truncated {
+		msg += fmt.Sprintf("; showing the %d most recent", output.Returned)
+	}
+	return msg
+}
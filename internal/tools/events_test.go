@@ -0,0 +1,63 @@
+package tools
+
+import (
+	"testing"
+	"time"
+
+	"github.com/KubeHeal/openshift-cluster-health-mcp/pkg/clients"
+)
+
+func TestEventsTool_Metadata(t *testing.T) {
+	tool := &EventsTool{}
+
+	if tool.Name() != "get-events" {
+		t.Errorf("Expected name 'get-events', got '%s'", tool.Name())
+	}
+
+	schema := tool.InputSchema()
+	properties, ok := schema["properties"].(map[string]interface{})
+	if !ok {
+		t.Fatal("Expected properties to be a map")
+	}
+	for _, prop := range []string{"namespace", "kind", "name", "type", "reason", "since_minutes", "limit"} {
+		if _, exists := properties[prop]; !exists {
+			t.Errorf("Expected property '%s' in schema", prop)
+		}
+	}
+}
+
+func TestBuildEventsOutput(t *testing.T) {
+	now := time.Now()
+	events := []clients.Event{
+		{Kind: "Pod", Name: "api-1", Type: "Normal", Reason: "Pulled", Count: 1, LastSeen: now.Add(-3 * time.Minute)},
+		{Kind: "Pod", Name: "api-1", Type: "Warning", Reason: "BackOff", Count: 9, LastSeen: now.Add(-2 * time.Minute)},
+		{Kind: "Pod", Name: "api-2", Type: "Warning", Reason: "Unhealthy", Count: 2, LastSeen: now.Add(-time.Minute)},
+	}
+
+	output := buildEventsOutput(events, 2)
+
+	if output.Total != 3 || output.Returned != 2 || !output.Truncated {
+		t.Errorf("Expected 2 of 3 events returned, got %+v", output)
+	}
+	if output.Events[0].Reason != "BackOff" || output.Events[1].Reason != "Unhealthy" {
+		t.Errorf("Expected the most recent events kept in order, got %+v", output.Events)
+	}
+	if output.WarningCount != 2 || output.TopReasons[0].Reason != "BackOff" {
+		t.Errorf("Expected BackOff as top warning, got %+v", output.TopReasons)
+	}
+
+	msg := eventsMessage(&output, EventsInput{Namespace: "app", SinceMinutes: 60})
+	if !contains(msg, "top warning: BackOff x9") || !contains(msg, "namespace app") {
+		t.Errorf("Unexpected message: %q", msg)
+	}
+}
+
+func TestBuildEventsOutput_Empty(t *testing.T) {
+	output := buildEventsOutput(nil, 10)
+	if output.Events == nil || output.Returned != 0 {
+		t.Errorf("Expected empty non-nil events, got %+v", output)
+	}
+	if msg := eventsMessage(&output, EventsInput{}); !contains(msg, "No matching events") {
+		t.Errorf("Unexpected message: %q", msg)
+	}
+}
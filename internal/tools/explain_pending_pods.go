@@ -0,0 +1,373 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+
+	"github.com/KubeHeal/openshift-cluster-health-mcp/pkg/clients"
+	"github.com/KubeHeal/openshift-cluster-health-mcp/pkg/scheduling"
+)
+
+const (
+	defaultPendingPodsLimit = 10
+	maxPendingPodsLimit     = 50
+	maxRejectedNodesShown   = 20
+)
+
+// ExplainPendingPodsTool explains why pods are Pending by simulating scheduling via MCP
+type ExplainPendingPodsTool struct {
+	k8sClient *clients.K8sClient
+}
+
+// NewExplainPendingPodsTool creates a new explain-pending-pods tool
+func NewExplainPendingPodsTool(k8sClient *clients.K8sClient) *ExplainPendingPodsTool {
+	return &ExplainPendingPodsTool{
+		k8sClient: k8sClient,
+	}
+}
+
+// Name returns the tool name for MCP registration
+func (t *ExplainPendingPodsTool) Name() string {
+	return "explain-pending-pods"
+}
+
+// Description returns the tool description for MCP
+func (t *ExplainPendingPodsTool) Description() string {
+	return "Explain why pods are Pending: simulates scheduling against every node and reports per-node rejection reasons (insufficient CPU/memory, taints, node selector/affinity, topology spread, volume zones, pod limits), cross-checks FailedScheduling events and suggests the smallest change that makes the pod schedulable"
+}
+
+// InputSchema returns the JSON schema for tool inputs
+func (t *ExplainPendingPodsTool) InputSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"namespace": map[string]interface{}{
+				"type":        "string",
+				"description": "Namespace of pending pods to explain (empty = all namespaces)",
+				"default":     "",
+			},
+			"pod_name": map[string]interface{}{
+				"type":        "string",
+				"description": "Explain a single pending pod (requires namespace)",
+				"default":     "",
+			},
+			"limit": map[string]interface{}{
+				"type":        "integer",
+				"description": "Maximum number of pending pods to explain, oldest first",
+				"default":     defaultPendingPodsLimit,
+				"minimum":     1,
+				"maximum":     maxPendingPodsLimit,
+			},
+			"include_all_nodes": map[string]interface{}{
+				"type":        "boolean",
+				"description": fmt.Sprintf("Return rejection reasons for every node instead of the first %d", maxRejectedNodesShown),
+				"default":     false,
+			},
+		},
+		"required": []string{},
+	}
+}
+
+// ExplainPendingPodsInput represents the input parameters
+type ExplainPendingPodsInput struct {
+	Namespace       string `json:"namespace"`
+	PodName         string `json:"pod_name"`
+	Limit           int    `json:"limit"`
+	IncludeAllNodes bool   `json:"include_all_nodes"`
+}
+
+// SchedulerCrossCheck compares the simulation with the scheduler's own FailedScheduling event
+type SchedulerCrossCheck struct {
+	EventMessage        string     `json:"event_message"`
+	EventCount          int32      `json:"event_count"`
+	LastSeen            *time.Time `json:"last_seen,omitempty"`
+	EventPredicates     []string   `json:"event_predicates"`
+	SimulatedPredicates []string   `json:"simulated_predicates"`
+	Agrees              bool       `json:"agrees"`
+	Notes               []string   `json:"notes,omitempty"`
+}
+
+// PendingPodExplanation explains one pending pod
+type PendingPodExplanation struct {
+	Pod             string                  `json:"pod"`
+	Namespace       string                  `json:"namespace"`
+	PendingFor      string                  `json:"pending_for"`
+	Requests        scheduling.Resources    `json:"requests"`
+	SchedulingGates []string                `json:"scheduling_gates,omitempty"`
+	FeasibleNodes   []string                `json:"feasible_nodes"`
+	ReasonCounts    map[string]int          `json:"reason_counts"`
+	RejectedNodes   []scheduling.NodeResult `json:"rejected_nodes"`
+	NodesOmitted    int                     `json:"nodes_omitted,omitempty"`
+	PodIssues       []string                `json:"pod_issues,omitempty"`
+	Suggestion      *scheduling.Suggestion  `json:"suggestion,omitempty"`
+	SchedulerEvent  *SchedulerCrossCheck    `json:"scheduler_event,omitempty"`
+	Summary         string                  `json:"summary"`
+}
+
+// ExplainPendingPodsOutput represents the tool output
+type ExplainPendingPodsOutput struct {
+	PendingCount int                     `json:"pending_count"`
+	Explained    int                     `json:"explained"`
+	NodeCount    int                     `json:"node_count"`
+	Pods         []PendingPodExplanation `json:"pods"`
+	Message      string                  `json:"message"`
+}
+
+// Execute simulates scheduling for pending pods
+func (t *ExplainPendingPodsTool) Execute(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+	input := ExplainPendingPodsInput{
+		Limit: defaultPendingPodsLimit,
+	}
+	if argsJSON, err := json.Marshal(args); err == nil {
+		_ = json.Unmarshal(argsJSON, &input) //nolint:errcheck // Intentionally ignore error, use defaults if unmarshal fails
+	}
+	if input.PodName != "" && input.Namespace == "" {
+		return nil, fmt.Errorf("namespace is required when pod_name is set")
+	}
+	if input.Limit < 1 {
+		input.Limit = defaultPendingPodsLimit
+	}
+	if input.Limit > maxPendingPodsLimit {
+		input.Limit = maxPendingPodsLimit
+	}
+
+	nodes, err := t.k8sClient.ListNodes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// Node usage needs pods from every namespace
+	allPods, err := t.k8sClient.ListPods(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+
+	pending := selectPendingPods(allPods.Items, input)
+	sim := scheduling.NewSimulator(scheduling.NewNodeInfos(nodes.Items, allPods.Items))
+	loadVolumeConstraints(ctx, t.k8sClient, sim, input.Namespace)
+
+	output := ExplainPendingPodsOutput{
+		PendingCount: len(pending),
+		NodeCount:    len(nodes.Items),
+		Pods:         []PendingPodExplanation{},
+	}
+	if input.PodName != "" && len(pending) == 0 {
+		return nil, fmt.Errorf("pod %s/%s is not pending or does not exist", input.Namespace, input.PodName)
+	}
+
+	now := time.Now()
+	for i, pod := range pending {
+		if i == input.Limit {
+			break
+		}
+		explanation := explainPendingPod(sim, pod, now, input.IncludeAllNodes)
+
+		// FailedScheduling events are a cross-check only; ignore listing errors
+		events, err := t.k8sClient.ListEventTimeline(ctx, clients.EventFilter{
+			Namespace: pod.Namespace,
+			Kind:      "Pod",
+			Name:      pod.Name,
+			Reason:    "FailedScheduling",
+		})
+		if err == nil {
+			explanation.SchedulerEvent = crossCheckScheduler(events, explanation.ReasonCounts)
+		}
+		output.Pods = append(output.Pods, explanation)
+	}
+	output.Explained = len(output.Pods)
+	output.Message = pendingPodsMessage(&output)
+	return output, nil
+}
+
+// loadVolumeConstraints records bound PV node affinity and PVCs that will not
+// bind; failures (e.g. missing RBAC) leave volume checks disabled
+func loadVolumeConstraints(ctx context.Context, k8sClient *clients.K8sClient, sim *scheduling.Simulator, namespace string) {
+	pvcs, err := k8sClient.ListPersistentVolumeClaims(ctx, namespace)
+	if err != nil {
+		return
+	}
+	pvs, err := k8sClient.ListPersistentVolumes(ctx)
+	if err != nil {
+		return
+	}
+	var classes []storagev1.StorageClass
+	if list, err := k8sClient.ListStorageClasses(ctx); err == nil {
+		classes = list.Items
+	}
+	addVolumeConstraints(sim, pvcs.Items, pvs.Items, classes)
+}
+
+// addVolumeConstraints maps claims to PV node affinity and flags claims that
+// are pending with Immediate binding (the scheduler will not bind them)
+func addVolumeConstraints(sim *scheduling.Simulator, pvcs []corev1.PersistentVolumeClaim, pvs []corev1.PersistentVolume, classes []storagev1.StorageClass) {
+	pvByName := make(map[string]*corev1.PersistentVolume, len(pvs))
+	for i := range pvs {
+		pvByName[pvs[i].Name] = &pvs[i]
+	}
+	waitForConsumer := make(map[string]bool)
+	for _, sc := range classes {
+		if sc.VolumeBindingMode != nil && *sc.VolumeBindingMode == storagev1.VolumeBindingWaitForFirstConsumer {
+			waitForConsumer[sc.Name] = true
+		}
+	}
+
+	for _, pvc := range pvcs {
+		key := pvc.Namespace + "/" + pvc.Name
+		if pvc.Spec.VolumeName != "" {
+			if pv, ok := pvByName[pvc.Spec.VolumeName]; ok && pv.Spec.NodeAffinity != nil {
+				sim.VolumeNodeAffinity[key] = pv.Spec.NodeAffinity
+			}
+			continue
+		}
+		if pvc.Status.Phase == corev1.ClaimPending {
+			class := ""
+			if pvc.Spec.StorageClassName != nil {
+				class = *pvc.Spec.StorageClassName
+			}
+			if !waitForConsumer[class] {
+				sim.UnboundClaims[key] = true
+			}
+		}
+	}
+}
+
+// selectPendingPods returns unscheduled pods matching the input, oldest first
+func selectPendingPods(pods []corev1.Pod, input ExplainPendingPodsInput) []*corev1.Pod {
+	var pending []*corev1.Pod
+	for i := range pods {
+		pod := &pods[i]
+		if !scheduling.IsPending(pod) {
+			continue
+		}
+		if input.Namespace != "" && pod.Namespace != input.Namespace {
+			continue
+		}
+		if input.PodName != "" && pod.Name != input.PodName {
+			continue
+		}
+		pending = append(pending, pod)
+	}
+	sort.SliceStable(pending, func(i, j int) bool {
+		return pending[i].CreationTimestamp.Before(&pending[j].CreationTimestamp)
+	})
+	return pending
+}
+
+// explainPendingPod runs the simulation for one pod
+func explainPendingPod(sim *scheduling.Simulator, pod *corev1.Pod, now time.Time, includeAllNodes bool) PendingPodExplanation {
+	eval := sim.Evaluate(pod)
+	explanation := PendingPodExplanation{
+		Pod:           pod.Name,
+		Namespace:     pod.Namespace,
+		PendingFor:    formatDuration(now.Sub(pod.CreationTimestamp.Time)),
+		Requests:      eval.Requests,
+		FeasibleNodes: eval.FeasibleNodes,
+		ReasonCounts:  eval.ReasonCounts,
+		RejectedNodes: eval.Rejected,
+		PodIssues:     eval.PodIssues,
+		Suggestion:    eval.Suggestion,
+	}
+	for _, gate := range pod.Spec.SchedulingGates {
+		explanation.SchedulingGates = append(explanation.SchedulingGates, gate.Name)
+	}
+	if !includeAllNodes && len(explanation.RejectedNodes) > maxRejectedNodesShown {
+		explanation.NodesOmitted = len(explanation.RejectedNodes) - maxRejectedNodesShown
+		explanation.RejectedNodes = explanation.RejectedNodes[:maxRejectedNodesShown]
+	}
+
+	switch {
+	case len(explanation.SchedulingGates) > 0:
+		explanation.Summary = fmt.Sprintf("held by scheduling gates %s; the scheduler will not consider it until they are removed", strings.Join(explanation.SchedulingGates, ", "))
+	case len(eval.PodIssues) > 0:
+		explanation.Summary = strings.Join(eval.PodIssues, "; ")
+	case len(eval.FeasibleNodes) > 0:
+		explanation.Summary = fmt.Sprintf("fits on %d of %d nodes in simulation", len(eval.FeasibleNodes), len(sim.Nodes))
+	default:
+		explanation.Summary = fmt.Sprintf("0/%d nodes fit: %s", len(sim.Nodes), formatReasonCounts(eval.ReasonCounts))
+	}
+	return explanation
+}
+
+// crossCheckScheduler compares the latest FailedScheduling event with the simulation
+func crossCheckScheduler(events []clients.Event, reasonCounts map[string]int) *SchedulerCrossCheck {
+	if len(events) == 0 {
+		return nil
+	}
+	latest := events[len(events)-1] // timeline is oldest first
+	lastSeen := latest.LastSeen
+
+	check := &SchedulerCrossCheck{
+		EventMessage:        latest.Message,
+		EventCount:          latest.Count,
+		LastSeen:            &lastSeen,
+		EventPredicates:     scheduling.PredicatesFromSchedulerMessage(latest.Message),
+		SimulatedPredicates: make([]string, 0, len(reasonCounts)),
+	}
+	for predicate := range reasonCounts {
+		check.SimulatedPredicates = append(check.SimulatedPredicates, predicate)
+	}
+	sort.Strings(check.SimulatedPredicates)
+
+	simulated := make(map[string]bool)
+	for _, p := range check.SimulatedPredicates {
+		simulated[p] = true
+	}
+	reported := make(map[string]bool)
+	for _, p := range check.EventPredicates {
+		reported[p] = true
+		if !simulated[p] {
+			check.Notes = append(check.Notes, fmt.Sprintf("scheduler reported %s but the simulation did not reproduce it (cluster state may have changed since the event)", p))
+		}
+	}
+	for _, p := range check.SimulatedPredicates {
+		if !reported[p] {
+			check.Notes = append(check.Notes, fmt.Sprintf("simulation found %s, which the scheduler event does not mention", p))
+		}
+	}
+	if len(check.EventPredicates) == 0 {
+		check.Notes = append(check.Notes, "event message names constraints this simulation does not model (e.g. pod affinity, host ports or extended resources)")
+	}
+	check.Agrees = len(check.Notes) == 0
+	return check
+}
+
+// formatReasonCounts renders predicate counts, most common first
+func formatReasonCounts(counts map[string]int) string {
+	predicates := make([]string, 0, len(counts))
+	for p := range counts {
+		predicates = append(predicates, p)
+	}
+	sort.Slice(predicates, func(i, j int) bool {
+		if counts[predicates[i]] != counts[predicates[j]] {
+			return counts[predicates[i]] > counts[predicates[j]]
+		}
+		return predicates[i] < predicates[j]
+	})
+	parts := make([]string, 0, len(predicates))
+	for _, p := range predicates {
+		parts = append(parts, fmt.Sprintf("%d %s", counts[p], p))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// pendingPodsMessage summarizes the explanations
+func pendingPodsMessage(output *ExplainPendingPodsOutput) string {
+	if output.PendingCount == 0 {
+		return "No pending pods"
+	}
+	msg := fmt.Sprintf("%d pending pods, explained %d against %d nodes", output.PendingCount, output.Explained, output.NodeCount)
+	if len(output.Pods) > 0 {
+		first := output.Pods[0]
+		msg += fmt.Sprintf("; oldest %s/%s: %s", first.Namespace, first.Pod, first.Summary)
+		if first.Suggestion != nil && len(first.Suggestion.Changes) > 0 {
+			msg += " (" + first.Suggestion.Summary + ")"
+		}
+	}
+	return msg
+}
@@ -0,0 +1,185 @@
+package tools
+
+import (
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/KubeHeal/openshift-cluster-health-mcp/pkg/clients"
+	"github.com/KubeHeal/openshift-cluster-health-mcp/pkg/scheduling"
+)
+
+func schedulingNode(name, cpu, memory string) corev1.Node {
+	return corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Status: corev1.NodeStatus{
+			Allocatable: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse(cpu),
+				corev1.ResourceMemory: resource.MustParse(memory),
+				corev1.ResourcePods:   resource.MustParse("110"),
+			},
+			Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: corev1.ConditionTrue}},
+		},
+	}
+}
+
+func pendingPod(name, cpu string, created time.Time) corev1.Pod {
+	return corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "app", CreationTimestamp: metav1.NewTime(created)},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{
+				Name: "app",
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse(cpu)},
+				},
+			}},
+		},
+		Status: corev1.PodStatus{Phase: corev1.PodPending},
+	}
+}
+
+func TestSelectPendingPods(t *testing.T) {
+	now := time.Now()
+	newer := pendingPod("newer", "100m", now.Add(-time.Minute))
+	older := pendingPod("older", "100m", now.Add(-time.Hour))
+	bound := pendingPod("bound", "100m", now)
+	bound.Spec.NodeName = "worker-1"
+	other := pendingPod("other", "100m", now)
+	other.Namespace = "other"
+
+	pending := selectPendingPods([]corev1.Pod{newer, older, bound, other}, ExplainPendingPodsInput{Namespace: "app"})
+	if len(pending) != 2 {
+		t.Fatalf("expected 2 pending pods, got %d", len(pending))
+	}
+	if pending[0].Name != "older" {
+		t.Errorf("expected oldest pod first, got %s", pending[0].Name)
+	}
+
+	single := selectPendingPods([]corev1.Pod{newer, older}, ExplainPendingPodsInput{Namespace: "app", PodName: "newer"})
+	if len(single) != 1 || single[0].Name != "newer" {
+		t.Errorf("expected only pod newer, got %v", single)
+	}
+}
+
+func TestExplainPendingPod(t *testing.T) {
+	now := time.Now()
+	nodes := []corev1.Node{schedulingNode("worker-1", "2", "8Gi"), schedulingNode("worker-2", "2", "8Gi")}
+	pod := pendingPod("big", "3", now.Add(-10*time.Minute))
+	sim := scheduling.NewSimulator(scheduling.NewNodeInfos(nodes, nil))
+
+	explanation := explainPendingPod(sim, &pod, now, false)
+	if len(explanation.FeasibleNodes) != 0 {
+		t.Errorf("expected no feasible nodes, got %v", explanation.FeasibleNodes)
+	}
+	if explanation.ReasonCounts[scheduling.PredicateCPU] != 2 {
+		t.Errorf("expected 2 nodes rejected for CPU, got %v", explanation.ReasonCounts)
+	}
+	if explanation.Suggestion == nil || len(explanation.Suggestion.Changes) == 0 {
+		t.Fatalf("expected a suggestion, got %+v", explanation.Suggestion)
+	}
+	if !contains(explanation.Summary, "0/2 nodes fit") {
+		t.Errorf("unexpected summary: %s", explanation.Summary)
+	}
+
+	gated := pendingPod("gated", "100m", now)
+	gated.Spec.SchedulingGates = []corev1.PodSchedulingGate{{Name: "example.com/quota"}}
+	explanation = explainPendingPod(sim, &gated, now, false)
+	if len(explanation.SchedulingGates) != 1 || !contains(explanation.Summary, "scheduling gates") {
+		t.Errorf("expected scheduling gate summary, got %q", explanation.Summary)
+	}
+}
+
+func TestExplainPendingPodTruncatesNodes(t *testing.T) {
+	now := time.Now()
+	var nodes []corev1.Node
+	for i := 0; i < maxRejectedNodesShown+5; i++ {
+		nodes = append(nodes, schedulingNode("worker-"+string(rune('a'+i)), "1", "4Gi"))
+	}
+	pod := pendingPod("big", "2", now)
+	sim := scheduling.NewSimulator(scheduling.NewNodeInfos(nodes, nil))
+
+	explanation := explainPendingPod(sim, &pod, now, false)
+	if len(explanation.RejectedNodes) != maxRejectedNodesShown || explanation.NodesOmitted != 5 {
+		t.Errorf("expected %d nodes shown and 5 omitted, got %d and %d", maxRejectedNodesShown, len(explanation.RejectedNodes), explanation.NodesOmitted)
+	}
+
+	explanation = explainPendingPod(sim, &pod, now, true)
+	if len(explanation.RejectedNodes) != len(nodes) || explanation.NodesOmitted != 0 {
+		t.Errorf("expected all nodes with include_all_nodes, got %d", len(explanation.RejectedNodes))
+	}
+}
+
+func TestAddVolumeConstraints(t *testing.T) {
+	immediate := storagev1.VolumeBindingImmediate
+	waitForConsumer := storagev1.VolumeBindingWaitForFirstConsumer
+	classes := []storagev1.StorageClass{
+		{ObjectMeta: metav1.ObjectMeta{Name: "fast"}, VolumeBindingMode: &immediate},
+		{ObjectMeta: metav1.ObjectMeta{Name: "local"}, VolumeBindingMode: &waitForConsumer},
+	}
+	fast, local := "fast", "local"
+	affinity := &corev1.VolumeNodeAffinity{Required: &corev1.NodeSelector{}}
+	pvs := []corev1.PersistentVolume{{
+		ObjectMeta: metav1.ObjectMeta{Name: "pv-1"},
+		Spec:       corev1.PersistentVolumeSpec{NodeAffinity: affinity},
+	}}
+	pvcs := []corev1.PersistentVolumeClaim{
+		{ObjectMeta: metav1.ObjectMeta{Name: "bound", Namespace: "app"}, Spec: corev1.PersistentVolumeClaimSpec{VolumeName: "pv-1"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "stuck", Namespace: "app"}, Spec: corev1.PersistentVolumeClaimSpec{StorageClassName: &fast}, Status: corev1.PersistentVolumeClaimStatus{Phase: corev1.ClaimPending}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "waiting", Namespace: "app"}, Spec: corev1.PersistentVolumeClaimSpec{StorageClassName: &local}, Status: corev1.PersistentVolumeClaimStatus{Phase: corev1.ClaimPending}},
+	}
+
+	sim := scheduling.NewSimulator(nil)
+	addVolumeConstraints(sim, pvcs, pvs, classes)
+
+	if sim.VolumeNodeAffinity["app/bound"] != affinity {
+		t.Error("expected bound claim to carry PV node affinity")
+	}
+	if !sim.UnboundClaims["app/stuck"] {
+		t.Error("expected pending Immediate claim to be unbound")
+	}
+	if sim.UnboundClaims["app/waiting"] {
+		t.Error("WaitForFirstConsumer claims are bound by the scheduler and should not be flagged")
+	}
+}
+
+func TestCrossCheckScheduler(t *testing.T) {
+	if crossCheckScheduler(nil, map[string]int{}) != nil {
+		t.Error("expected no cross-check without events")
+	}
+
+	events := []clients.Event{
+		{Reason: "FailedScheduling", Message: "0/3 nodes are available: 3 node(s) had untolerated taint {dedicated: infra}.", Count: 1},
+		{Reason: "FailedScheduling", Message: "0/3 nodes are available: 3 Insufficient cpu.", Count: 7, LastSeen: time.Now()},
+	}
+	check := crossCheckScheduler(events, map[string]int{scheduling.PredicateCPU: 3})
+	if !check.Agrees {
+		t.Errorf("expected agreement with latest event, got notes %v", check.Notes)
+	}
+	if check.EventCount != 7 {
+		t.Errorf("expected latest event count 7, got %d", check.EventCount)
+	}
+
+	check = crossCheckScheduler(events, map[string]int{scheduling.PredicateMemory: 3})
+	if check.Agrees || len(check.Notes) != 2 {
+		t.Errorf("expected disagreement in both directions, got %v", check.Notes)
+	}
+}
+
+func TestPendingPodsMessage(t *testing.T) {
+	if msg := pendingPodsMessage(&ExplainPendingPodsOutput{}); msg != "No pending pods" {
+		t.Errorf("unexpected message: %s", msg)
+	}
+	msg := pendingPodsMessage(&ExplainPendingPodsOutput{
+		PendingCount: 3,
+		Explained:    1,
+		NodeCount:    4,
+		Pods:         []PendingPodExplanation{{Pod: "big", Namespace: "app", Summary: "0/4 nodes fit: 4 " + scheduling.PredicateCPU}},
+	})
+	if !contains(msg, "3 pending pods") || !contains(msg, "app/big") {
+		t.Errorf("unexpected message: %s", msg)
+	}
+}
@@ -0,0 +1,314 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+
+	"github.com/KubeHeal/openshift-cluster-health-mcp/pkg/capacity"
+	"github.com/KubeHeal/openshift-cluster-health-mcp/pkg/clients"
+	"github.com/KubeHeal/openshift-cluster-health-mcp/pkg/scheduling"
+)
+
+const (
+	defaultMaxUnschedulableListed = 25
+	maxUnschedulableListed        = 200
+)
+
+// Resilience verdicts
+const (
+	ResilienceSurvives = "survives"
+	ResilienceDegraded = "degraded"
+	ResilienceFails    = "fails"
+)
+
+// FailureResilienceTool simulates node or zone loss via MCP
+type FailureResilienceTool struct {
+	k8sClient *clients.K8sClient
+}
+
+// NewFailureResilienceTool creates a new analyze-failure-resilience tool
+func NewFailureResilienceTool(k8sClient *clients.K8sClient) *FailureResilienceTool {
+	return &FailureResilienceTool{
+		k8sClient: k8sClient,
+	}
+}
+
+// Name returns the tool name for MCP registration
+func (t *FailureResilienceTool) Name() string {
+	return "analyze-failure-resilience"
+}
+
+// Description returns the tool description for MCP
+func (t *FailureResilienceTool) Description() string {
+	return "Simulate losing the largest node, a named node, or a whole topology.kubernetes.io/zone: reschedules the displaced pods' requests onto the remaining nodes, reports what would not fit, and flags workloads whose replicas all sit on one node/zone or whose PodDisruptionBudgets would block eviction"
+}
+
+// InputSchema returns the JSON schema for tool inputs
+func (t *FailureResilienceTool) InputSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"scenario": map[string]interface{}{
+				"type":        "string",
+				"description": "Failure to simulate. Inferred from node_name or zone when omitted",
+				"enum":        []string{capacity.ScenarioLargestNode, capacity.ScenarioNode, capacity.ScenarioZone},
+				"default":     capacity.ScenarioLargestNode,
+			},
+			"node_name": map[string]interface{}{
+				"type":        "string",
+				"description": "Node to remove (scenario 'node')",
+			},
+			"zone": map[string]interface{}{
+				"type":        "string",
+				"description": "Zone whose nodes are all removed (scenario 'zone')",
+			},
+			"namespace": map[string]interface{}{
+				"type":        "string",
+				"description": "Limit workload placement and PDB findings to this namespace (empty = all namespaces). The simulation always uses the whole cluster",
+				"default":     "",
+			},
+			"max_unschedulable": map[string]interface{}{
+				"type":        "integer",
+				"description": "Maximum number of unschedulable pods to list",
+				"default":     defaultMaxUnschedulableListed,
+				"minimum":     1,
+				"maximum":     maxUnschedulableListed,
+			},
+		},
+		"required": []string{},
+	}
+}
+
+// FailureResilienceInput represents the input parameters
+type FailureResilienceInput struct {
+	Scenario         string `json:"scenario"`
+	NodeName         string `json:"node_name"`
+	Zone             string `json:"zone"`
+	Namespace        string `json:"namespace"`
+	MaxUnschedulable int    `json:"max_unschedulable"`
+}
+
+// FailureResilienceOutput represents the tool output
+type FailureResilienceOutput struct {
+	Scenario              string                      `json:"scenario"`
+	Target                string                      `json:"target"`
+	Zones                 []string                    `json:"zones"`
+	Verdict               string                      `json:"verdict"`
+	Simulation            *capacity.FailureSimulation `json:"simulation"`
+	UnschedulableOmitted  int                         `json:"unschedulable_omitted,omitempty"`
+	WorkloadOutages       []string                    `json:"workload_outages"`
+	ConcentratedWorkloads []capacity.WorkloadSpread   `json:"concentrated_workloads"`
+	BlockingPDBs          []capacity.PDBBlock         `json:"blocking_pdbs"`
+	Recommendations       []string                    `json:"recommendations"`
+	Warnings              []string                    `json:"warnings,omitempty"`
+	Message               string                      `json:"message"`
+}
+
+// Execute runs the failure simulation
+func (t *FailureResilienceTool) Execute(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+	input := FailureResilienceInput{
+		MaxUnschedulable: defaultMaxUnschedulableListed,
+	}
+	if argsJSON, err := json.Marshal(args); err == nil {
+		_ = json.Unmarshal(argsJSON, &input) //nolint:errcheck // Intentionally ignore error, use defaults if unmarshal fails
+	}
+	if input.MaxUnschedulable < 1 {
+		input.MaxUnschedulable = defaultMaxUnschedulableListed
+	}
+	if input.MaxUnschedulable > maxUnschedulableListed {
+		input.MaxUnschedulable = maxUnschedulableListed
+	}
+
+	nodes, err := t.k8sClient.ListNodes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	scenario, target, failed, err := resolveFailureScenario(nodes.Items, input)
+	if err != nil {
+		return nil, err
+	}
+	pods, err := t.k8sClient.ListPods(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+
+	var warnings []string
+	var pdbs []policyv1.PodDisruptionBudget
+	if list, err := t.k8sClient.ListPodDisruptionBudgets(ctx, input.Namespace); err != nil {
+		warnings = append(warnings, fmt.Sprintf("PodDisruptionBudgets not checked: %v", err))
+	} else {
+		pdbs = list.Items
+	}
+	volumes := scheduling.NewSimulator(nil)
+	loadVolumeConstraints(ctx, t.k8sClient, volumes, "")
+
+	output := analyzeFailureResilience(nodes.Items, pods.Items, pdbs, failed, volumes.VolumeNodeAffinity, input)
+	output.Scenario = scenario
+	output.Target = target
+	output.Warnings = warnings
+	output.Message = failureResilienceMessage(&output)
+	return output, nil
+}
+
+// resolveFailureScenario picks the nodes to remove for the requested scenario
+func resolveFailureScenario(nodes []corev1.Node, input FailureResilienceInput) (string, string, map[string]bool, error) {
+	scenario := input.Scenario
+	if scenario == "" {
+		switch {
+		case input.NodeName != "":
+			scenario = capacity.ScenarioNode
+		case input.Zone != "":
+			scenario = capacity.ScenarioZone
+		default:
+			scenario = capacity.ScenarioLargestNode
+		}
+	}
+
+	failed := make(map[string]bool)
+	switch scenario {
+	case capacity.ScenarioLargestNode:
+		name := capacity.LargestNode(nodes)
+		if name == "" {
+			return "", "", nil, fmt.Errorf("no Ready nodes to simulate losing")
+		}
+		failed[name] = true
+		return scenario, name, failed, nil
+	case capacity.ScenarioNode:
+		if input.NodeName == "" {
+			return "", "", nil, fmt.Errorf("node_name is required for scenario %q", scenario)
+		}
+		for _, node := range nodes {
+			if node.Name == input.NodeName {
+				failed[node.Name] = true
+				return scenario, node.Name, failed, nil
+			}
+		}
+		return "", "", nil, fmt.Errorf("node %s not found", input.NodeName)
+	case capacity.ScenarioZone:
+		zones := capacity.Zones(nodes)
+		if input.Zone == "" {
+			return "", "", nil, fmt.Errorf("zone is required for scenario %q (available zones: %s)", scenario, strings.Join(zones, ", "))
+		}
+		names := capacity.NodesInZone(nodes, input.Zone)
+		if len(names) == 0 {
+			return "", "", nil, fmt.Errorf("no nodes in zone %s (available zones: %s)", input.Zone, strings.Join(zones, ", "))
+		}
+		for _, name := range names {
+			failed[name] = true
+		}
+		return scenario, input.Zone, failed, nil
+	}
+	return "", "", nil, fmt.Errorf("unknown scenario %q (valid: %s, %s, %s)", scenario, capacity.ScenarioLargestNode, capacity.ScenarioNode, capacity.ScenarioZone)
+}
+
+// analyzeFailureResilience simulates the failure and gathers workload risks
+func analyzeFailureResilience(nodes []corev1.Node, pods []corev1.Pod, pdbs []policyv1.PodDisruptionBudget, failed map[string]bool, volumeAffinity map[string]*corev1.VolumeNodeAffinity, input FailureResilienceInput) FailureResilienceOutput {
+	sim := capacity.SimulateFailure(nodes, pods, failed, volumeAffinity)
+	evicted, _ := capacity.EvictablePods(pods, failed)
+
+	output := FailureResilienceOutput{
+		Zones:                 capacity.Zones(nodes),
+		Simulation:            sim,
+		WorkloadOutages:       workloadsLosingAllReplicas(pods, evicted, input.Namespace),
+		ConcentratedWorkloads: []capacity.WorkloadSpread{},
+		BlockingPDBs:          capacity.BlockingPDBs(pdbs, evicted),
+		Recommendations:       []string{},
+	}
+	if output.BlockingPDBs == nil {
+		output.BlockingPDBs = []capacity.PDBBlock{}
+	}
+	for _, spread := range capacity.ConcentratedWorkloads(nodes, pods) {
+		if input.Namespace == "" || spread.Workload.Namespace == input.Namespace {
+			output.ConcentratedWorkloads = append(output.ConcentratedWorkloads, spread)
+		}
+	}
+	if len(sim.Unschedulable) > input.MaxUnschedulable {
+		output.UnschedulableOmitted = len(sim.Unschedulable) - input.MaxUnschedulable
+		sim.Unschedulable = sim.Unschedulable[:input.MaxUnschedulable]
+	}
+
+	switch {
+	case !sim.Survives:
+		output.Verdict = ResilienceFails
+	case len(output.WorkloadOutages) > 0 || len(output.BlockingPDBs) > 0:
+		output.Verdict = ResilienceDegraded
+	default:
+		output.Verdict = ResilienceSurvives
+	}
+	output.Recommendations = resilienceRecommendations(&output)
+	return output
+}
+
+// workloadsLosingAllReplicas returns workloads whose every running replica
+// is displaced, i.e. that are fully down until their pods are rescheduled
+func workloadsLosingAllReplicas(pods []corev1.Pod, evicted []*corev1.Pod, namespace string) []string {
+	evictedCount := make(map[string]int)
+	for _, pod := range evicted {
+		if ref := capacity.WorkloadOf(pod).String(); ref != "" {
+			evictedCount[ref]++
+		}
+	}
+	runningCount := make(map[string]int)
+	for i := range pods {
+		pod := &pods[i]
+		if pod.Spec.NodeName == "" || scheduling.IsTerminal(pod) {
+			continue
+		}
+		if ref := capacity.WorkloadOf(pod).String(); evictedCount[ref] > 0 {
+			runningCount[ref]++
+		}
+	}
+
+	outages := []string{}
+	for ref, n := range evictedCount {
+		if n < runningCount[ref] {
+			continue
+		}
+		if namespace != "" && !strings.HasPrefix(ref, namespace+"/") {
+			continue
+		}
+		outages = append(outages, ref)
+	}
+	sort.Strings(outages)
+	return outages
+}
+
+// resilienceRecommendations suggests fixes for the findings
+func resilienceRecommendations(output *FailureResilienceOutput) []string {
+	recs := []string{}
+	if sim := output.Simulation; !sim.Survives {
+		total := len(sim.Unschedulable) + output.UnschedulableOmitted
+		recs = append(recs, fmt.Sprintf("Add capacity equivalent to the lost nodes (%dm CPU, %s memory allocatable) or lower requests; %d pods would stay Pending", sim.LostAllocatable.MilliCPU, scheduling.FormatBytes(sim.LostAllocatable.Memory), total))
+	}
+	if len(output.WorkloadOutages) > 0 {
+		recs = append(recs, fmt.Sprintf("Run more replicas spread across nodes for %d workloads that go fully down in this scenario", len(output.WorkloadOutages)))
+	}
+	if len(output.ConcentratedWorkloads) > 0 {
+		recs = append(recs, "Add pod anti-affinity or topologySpreadConstraints (kubernetes.io/hostname, topology.kubernetes.io/zone) to concentrated workloads")
+	}
+	if len(output.BlockingPDBs) > 0 {
+		recs = append(recs, "Review PodDisruptionBudgets that allow too few disruptions; they will stall drains and upgrades of these nodes")
+	}
+	return recs
+}
+
+// failureResilienceMessage summarizes the analysis
+func failureResilienceMessage(output *FailureResilienceOutput) string {
+	msg := fmt.Sprintf("Losing %s %s (%s): %s", strings.ReplaceAll(output.Scenario, "_", " "), output.Target, output.Verdict, output.Simulation.Summary())
+	if n := len(output.WorkloadOutages); n > 0 {
+		msg += fmt.Sprintf(" %d workloads lose all replicas.", n)
+	}
+	if n := len(output.BlockingPDBs); n > 0 {
+		msg += fmt.Sprintf(" %d PodDisruptionBudgets would block eviction.", n)
+	}
+	if n := len(output.ConcentratedWorkloads); n > 0 {
+		msg += fmt.Sprintf(" %d workloads have all replicas on one node or zone.", n)
+	}
+	return msg
+}
@@ -0,0 +1,102 @@
+package tools
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/KubeHeal/openshift-cluster-health-mcp/pkg/capacity"
+)
+
+func zoneNode(name, zone, cpu string) corev1.Node {
+	node := schedulingNode(name, cpu, "16Gi")
+	node.Labels = map[string]string{corev1.LabelTopologyZone: zone}
+	return node
+}
+
+func runningPod(name, node, cpu string, owner string) corev1.Pod {
+	pod := pendingPod(name, cpu, metav1.Now().Time)
+	pod.Spec.NodeName = node
+	pod.Status.Phase = corev1.PodRunning
+	pod.Labels = map[string]string{"app": owner}
+	if owner != "" {
+		controller := true
+		pod.OwnerReferences = []metav1.OwnerReference{{Kind: "StatefulSet", Name: owner, Controller: &controller}}
+	}
+	return pod
+}
+
+func TestResolveFailureScenario(t *testing.T) {
+	nodes := []corev1.Node{zoneNode("a", "z1", "4"), zoneNode("b", "z2", "8"), zoneNode("c", "z2", "2")}
+
+	scenario, target, failed, err := resolveFailureScenario(nodes, FailureResilienceInput{})
+	if err != nil || scenario != capacity.ScenarioLargestNode || target != "b" || !failed["b"] {
+		t.Errorf("expected largest node b, got %s %s %v %v", scenario, target, failed, err)
+	}
+
+	scenario, _, failed, err = resolveFailureScenario(nodes, FailureResilienceInput{Zone: "z2"})
+	if err != nil || scenario != capacity.ScenarioZone || len(failed) != 2 {
+		t.Errorf("expected zone z2 with 2 nodes, got %s %v %v", scenario, failed, err)
+	}
+
+	if _, _, _, err = resolveFailureScenario(nodes, FailureResilienceInput{NodeName: "missing"}); err == nil {
+		t.Error("expected error for unknown node")
+	}
+	_, _, _, err = resolveFailureScenario(nodes, FailureResilienceInput{Scenario: capacity.ScenarioZone})
+	if err == nil || !contains(err.Error(), "z1, z2") {
+		t.Errorf("expected error listing zones, got %v", err)
+	}
+}
+
+func TestAnalyzeFailureResilience(t *testing.T) {
+	nodes := []corev1.Node{zoneNode("a", "z1", "4"), zoneNode("b", "z2", "4")}
+	pods := []corev1.Pod{
+		runningPod("db-0", "a", "500m", "db"),
+		runningPod("db-1", "a", "500m", "db"),
+		runningPod("web-0", "a", "500m", "web"),
+		runningPod("web-1", "b", "500m", "web"),
+	}
+	pdbs := []policyv1.PodDisruptionBudget{{
+		ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "app"},
+		Spec:       policyv1.PodDisruptionBudgetSpec{Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "db"}}},
+		Status:     policyv1.PodDisruptionBudgetStatus{DisruptionsAllowed: 1},
+	}}
+	input := FailureResilienceInput{MaxUnschedulable: defaultMaxUnschedulableListed}
+
+	output := analyzeFailureResilience(nodes, pods, pdbs, map[string]bool{"a": true}, nil, input)
+	if !output.Simulation.Survives {
+		t.Errorf("expected displaced pods to fit on node b, got %+v", output.Simulation.Unschedulable)
+	}
+	if output.Verdict != ResilienceDegraded {
+		t.Errorf("expected degraded verdict, got %s", output.Verdict)
+	}
+	if len(output.WorkloadOutages) != 1 || output.WorkloadOutages[0] != "app/StatefulSet/db" {
+		t.Errorf("expected db outage, got %v", output.WorkloadOutages)
+	}
+	if len(output.BlockingPDBs) != 1 || len(output.ConcentratedWorkloads) != 1 {
+		t.Errorf("expected 1 blocking PDB and 1 concentrated workload, got %d and %d", len(output.BlockingPDBs), len(output.ConcentratedWorkloads))
+	}
+	if len(output.Recommendations) == 0 {
+		t.Error("expected recommendations")
+	}
+
+	// Node b keeps 500m free: one of node a's three pods fits, two do not
+	pods = append(pods, runningPod("hog", "b", "3", ""))
+	pods[len(pods)-1].Spec.Containers[0].Resources.Requests[corev1.ResourceCPU] = resource.MustParse("3")
+	input.MaxUnschedulable = 1
+	output = analyzeFailureResilience(nodes, pods, nil, map[string]bool{"a": true}, nil, input)
+	if output.Verdict != ResilienceFails {
+		t.Errorf("expected fails verdict, got %s", output.Verdict)
+	}
+	if len(output.Simulation.Unschedulable) != 1 || output.UnschedulableOmitted != 1 {
+		t.Errorf("expected 1 listed and 1 omitted unschedulable pods, got %d and %d", len(output.Simulation.Unschedulable), output.UnschedulableOmitted)
+	}
+
+	output.Scenario, output.Target = capacity.ScenarioNode, "a"
+	if msg := failureResilienceMessage(&output); !contains(msg, "Losing node a (fails)") {
+		t.Errorf("unexpected message: %s", msg)
+	}
+}
@@ -0,0 +1,161 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/KubeHeal/openshift-cluster-health-mcp/pkg/history"
+)
+
+// HealthTimelineTool exposes the in-process health history via MCP
+type HealthTimelineTool struct {
+	sampler *history.Sampler
+}
+
+// NewHealthTimelineTool creates a new get-health-timeline tool
+func NewHealthTimelineTool(sampler *history.Sampler) *HealthTimelineTool {
+	return &HealthTimelineTool{
+		sampler: sampler,
+	}
+}
+
+// Name returns the tool name for MCP registration
+func (t *HealthTimelineTool) Name() string {
+	return "get-health-timeline"
+}
+
+// Description returns the tool description for MCP
+func (t *HealthTimelineTool) Description() string {
+	return `Get the cluster health timeline recorded by the server's background sampler, downsampled to a step size, including every status transition (healthy/degraded/unhealthy) with the counters that changed.
+
+Use this to answer "when did the cluster become degraded?" or "was anything wrong overnight?".
+- current_status / status_since: how long the cluster has been in its current state
+- transitions: exact timestamps of status changes (detected on raw samples, never hidden by the step)
+- points: one entry per step with the worst status seen in that step
+
+History only covers the time since the server started (or the retained persisted window).`
+}
+
+// InputSchema returns the JSON schema for tool inputs
+func (t *HealthTimelineTool) InputSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"range": map[string]interface{}{
+				"type":        "string",
+				"description": "How far back to look as a Go duration (e.g., '30m', '6h', '24h'). Default: '1h'",
+				"default":     "1h",
+			},
+			"step": map[string]interface{}{
+				"type":        "string",
+				"description": "Downsampling step as a Go duration (e.g., '1m', '5m'). Default: '5m'",
+				"default":     "5m",
+			},
+			"include_restarters": map[string]interface{}{
+				"type":        "boolean",
+				"description": "Include the top restarting pods from the most recent sample. Default: true",
+				"default":     true,
+			},
+		},
+		"required": []string{},
+	}
+}
+
+// HealthTimelineInput represents the input parameters
+type HealthTimelineInput struct {
+	Range             string `json:"range"`
+	Step              string `json:"step"`
+	IncludeRestarters bool   `json:"include_restarters"`
+}
+
+// HealthTimelineOutput represents the tool output
+type HealthTimelineOutput struct {
+	Status         string              `json:"status"`
+	SampleInterval string              `json:"sample_interval"`
+	Timeline       *history.Timeline   `json:"timeline"`
+	TopRestarters  []history.Restarter `json:"top_restarters,omitempty"`
+	Message        string              `json:"message"`
+}
+
+// Execute builds the health timeline for the requested range
+func (t *HealthTimelineTool) Execute(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+	if t.sampler == nil {
+		return nil, fmt.Errorf("health history sampler not enabled")
+	}
+
+	input, window, step, err := t.parseInput(args)
+	if err != nil {
+		return nil, fmt.Errorf("invalid input: %w", err)
+	}
+
+	to := time.Now().UTC()
+	from := to.Add(-window)
+	store := t.sampler.Store()
+	timeline := history.BuildTimeline(store.Range(from, to), from, to, step)
+
+	output := HealthTimelineOutput{
+		Status:         "success",
+		SampleInterval: t.sampler.Interval().String(),
+		Timeline:       timeline,
+		Message:        summarizeTimeline(timeline, input.Range),
+	}
+
+	if input.IncludeRestarters {
+		if latest, ok := store.Latest(); ok {
+			output.TopRestarters = latest.TopRestarters
+		}
+	}
+
+	return output, nil
+}
+
+// parseInput parses the range and step durations
+func (t *HealthTimelineTool) parseInput(args map[string]interface{}) (*HealthTimelineInput, time.Duration, time.Duration, error) {
+	input := &HealthTimelineInput{
+		Range:             "1h",
+		Step:              "5m",
+		IncludeRestarters: true,
+	}
+
+	if argsJSON, err := json.Marshal(args); err == nil {
+		_ = json.Unmarshal(argsJSON, input) //nolint:errcheck // Intentionally ignore error, use defaults if unmarshal fails
+	}
+
+	window, err := time.ParseDuration(input.Range)
+	if err != nil || window <= 0 {
+		return nil, 0, 0, fmt.Errorf("range must be a positive duration (e.g., '1h'), got %q", input.Range)
+	}
+	step, err := time.ParseDuration(input.Step)
+	if err != nil || step <= 0 {
+		return nil, 0, 0, fmt.Errorf("step must be a positive duration (e.g., '5m'), got %q", input.Step)
+	}
+	if step > window {
+		step = window
+	}
+
+	return input, window, step, nil
+}
+
+// summarizeTimeline creates a human-readable summary of the timeline
+func summarizeTimeline(timeline *history.Timeline, window string) string {
+	if timeline.SampleCount == 0 {
+		return fmt.Sprintf("No health samples recorded in the last %s", window)
+	}
+
+	msg := fmt.Sprintf("Cluster is %s", timeline.CurrentStatus)
+	if timeline.StatusSince != nil {
+		msg += fmt.Sprintf(" since %s", timeline.StatusSince.Format(time.RFC3339))
+	}
+
+	switch n := len(timeline.Transitions); n {
+	case 0:
+		msg += fmt.Sprintf("; no status transitions in the last %s", window)
+	case 1:
+		msg += fmt.Sprintf("; 1 status transition in the last %s", window)
+	default:
+		msg += fmt.Sprintf("; %d status transitions in the last %s", n, window)
+	}
+	return msg
+}
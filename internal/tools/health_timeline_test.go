@@ -0,0 +1,114 @@
+package tools
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/KubeHeal/openshift-cluster-health-mcp/pkg/history"
+)
+
+func TestHealthTimelineTool_Metadata(t *testing.T) {
+	tool := &HealthTimelineTool{}
+
+	if tool.Name() != "get-health-timeline" {
+		t.Errorf("Expected name 'get-health-timeline', got '%s'", tool.Name())
+	}
+	if !contains(tool.Description(), "transition") {
+		t.Error("Description should mention status transitions")
+	}
+
+	schema := tool.InputSchema()
+	properties, ok := schema["properties"].(map[string]interface{})
+	if !ok {
+		t.Fatal("Expected properties to be a map")
+	}
+	for _, prop := range []string{"range", "step", "include_restarters"} {
+		if _, exists := properties[prop]; !exists {
+			t.Errorf("Expected property '%s' in schema", prop)
+		}
+	}
+}
+
+func TestHealthTimelineTool_ParseInput(t *testing.T) {
+	tool := &HealthTimelineTool{}
+
+	testCases := []struct {
+		name        string
+		args        map[string]interface{}
+		window      time.Duration
+		step        time.Duration
+		expectError bool
+	}{
+		{"defaults", map[string]interface{}{}, time.Hour, 5 * time.Minute, false},
+		{"custom", map[string]interface{}{"range": "6h", "step": "15m"}, 6 * time.Hour, 15 * time.Minute, false},
+		{"step clamped to range", map[string]interface{}{"range": "10m", "step": "1h"}, 10 * time.Minute, 10 * time.Minute, false},
+		{"invalid range", map[string]interface{}{"range": "yesterday"}, 0, 0, true},
+		{"negative step", map[string]interface{}{"step": "-5m"}, 0, 0, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, window, step, err := tool.parseInput(tc.args)
+			if tc.expectError {
+				if err == nil {
+					t.Error("Expected error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if window != tc.window || step != tc.step {
+				t.Errorf("Expected window=%v step=%v, got window=%v step=%v", tc.window, tc.step, window, step)
+			}
+		})
+	}
+}
+
+func TestHealthTimelineTool_Execute(t *testing.T) {
+	statuses := []string{"healthy", "degraded"}
+	i := 0
+	collect := func(ctx context.Context) (*history.Sample, error) {
+		status := statuses[i%len(statuses)]
+		i++
+		return &history.Sample{Status: status}, nil
+	}
+
+	sampler := history.NewSampler(collect, history.SamplerConfig{Capacity: 10})
+	for n := 0; n < 2; n++ {
+		if err := sampler.SampleOnce(context.Background()); err != nil {
+			t.Fatalf("SampleOnce failed: %v", err)
+		}
+	}
+
+	tool := NewHealthTimelineTool(sampler)
+	result, err := tool.Execute(context.Background(), map[string]interface{}{"range": "1h", "step": "1m"})
+	if err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+
+	output, ok := result.(HealthTimelineOutput)
+	if !ok {
+		t.Fatal("Expected HealthTimelineOutput type")
+	}
+	if output.Timeline.SampleCount != 2 {
+		t.Errorf("Expected 2 samples, got %d", output.Timeline.SampleCount)
+	}
+	if output.Timeline.CurrentStatus != "degraded" {
+		t.Errorf("Expected current status degraded, got %s", output.Timeline.CurrentStatus)
+	}
+	if len(output.Timeline.Transitions) != 1 {
+		t.Errorf("Expected 1 transition, got %d", len(output.Timeline.Transitions))
+	}
+	if output.Message == "" {
+		t.Error("Expected message to be set")
+	}
+}
+
+func TestHealthTimelineTool_Execute_NoSampler(t *testing.T) {
+	tool := &HealthTimelineTool{}
+	if _, err := tool.Execute(context.Background(), map[string]interface{}{}); err == nil {
+		t.Error("Expected error when sampler is not configured")
+	}
+}
@@ -96,6 +96,10 @@ type ContainerInfo struct {
 	RestartCount int32  `json:"restart_count"`
 	State        string `json:"state"` // Running, Waiting, Terminated
 	Reason       string `json:"reason,omitempty"`
+	ExitCode     *int32 `json:"exit_code,omitempty"`
+	// Last termination of the previous instance (e.g. OOMKilled before CrashLoopBackOff)
+	LastTerminationReason string `json:"last_termination_reason,omitempty"`
+	LastExitCode          *int32 `json:"last_exit_code,omitempty"`
 }
 
 // ListPodsOutput represents the tool output
@@ -244,6 +248,13 @@ func (t *ListPodsTool) podToPodInfo(pod *corev1.Pod) PodInfo {
 		} else if cs.State.Terminated != nil {
 			containerInfo.State = "Terminated"
 			containerInfo.Reason = cs.State.Terminated.Reason
+			exitCode := cs.State.Terminated.ExitCode
+			containerInfo.ExitCode = &exitCode
+		}
+		if last := cs.LastTerminationState.Terminated; last != nil {
+			containerInfo.LastTerminationReason = last.Reason
+			lastExitCode := last.ExitCode
+			containerInfo.LastExitCode = &lastExitCode
 		}
 
 		containers = append(containers, containerInfo)
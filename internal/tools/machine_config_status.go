@@ -0,0 +1,336 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+
+	"github.com/KubeHeal/openshift-cluster-health-mcp/pkg/clients"
+)
+
+// MachineConfigStatusTool reports MachineConfigPool rollout state and per-node
+// rendered config drift so MCO-driven reboots can be told apart from outages
+type MachineConfigStatusTool struct {
+	k8sClient *clients.K8sClient
+}
+
+// NewMachineConfigStatusTool creates a new machine config status tool
+func NewMachineConfigStatusTool(k8sClient *clients.K8sClient) *MachineConfigStatusTool {
+	return &MachineConfigStatusTool{
+		k8sClient: k8sClient,
+	}
+}
+
+// Name returns the tool name for MCP registration
+func (t *MachineConfigStatusTool) Name() string {
+	return "get-machine-config-status"
+}
+
+// Description returns the tool description for MCP
+func (t *MachineConfigStatusTool) Description() string {
+	return "Get MachineConfigPool health (updated/updating/degraded machine counts, paused pools), per-node rendered config mismatches and nodes stuck cordoned. Explains whether NotReady nodes are caused by an MCO rollout or a real outage."
+}
+
+// InputSchema returns the JSON schema for tool inputs
+func (t *MachineConfigStatusTool) InputSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"pool": map[string]interface{}{
+				"type":        "string",
+				"description": "Only report this MachineConfigPool (e.g. 'worker', 'master'). Leave empty for all pools.",
+				"default":     "",
+			},
+			"stuck_threshold_minutes": map[string]interface{}{
+				"type":        "integer",
+				"description": "Minutes a node may stay cordoned (SchedulingDisabled) before it is reported as stuck",
+				"default":     30,
+				"minimum":     1,
+			},
+			"include_all_nodes": map[string]interface{}{
+				"type":        "boolean",
+				"description": "Include every node, not only nodes that are updating, cordoned, NotReady or degraded",
+				"default":     false,
+			},
+		},
+		"required": []string{},
+	}
+}
+
+// MachineConfigStatusInput represents the input parameters
+type MachineConfigStatusInput struct {
+	Pool                  string `json:"pool"`
+	StuckThresholdMinutes int    `json:"stuck_threshold_minutes"`
+	IncludeAllNodes       bool   `json:"include_all_nodes"`
+}
+
+// NodeConfigStatus is the MCO view of a single node
+type NodeConfigStatus struct {
+	Name           string `json:"name"`
+	Pool           string `json:"pool,omitempty"`
+	Ready          bool   `json:"ready"`
+	Unschedulable  bool   `json:"unschedulable"`
+	CordonedFor    string `json:"cordoned_for,omitempty"`
+	CurrentConfig  string `json:"current_config,omitempty"`
+	DesiredConfig  string `json:"desired_config,omitempty"`
+	ConfigMismatch bool   `json:"config_mismatch"`
+	MCDState       string `json:"mcd_state,omitempty"` // machine-config-daemon state: Done, Working, Degraded
+	MCDReason      string `json:"mcd_reason,omitempty"`
+	Stuck          bool   `json:"stuck"`
+	Explanation    string `json:"explanation,omitempty"`
+}
+
+// MachineConfigSummary counts pools and nodes by state
+type MachineConfigSummary struct {
+	Pools           int      `json:"pools"`
+	UpdatingPools   []string `json:"updating_pools,omitempty"`
+	DegradedPools   []string `json:"degraded_pools,omitempty"`
+	PausedPools     []string `json:"paused_pools,omitempty"`
+	MismatchedNodes int      `json:"mismatched_nodes"`
+	CordonedNodes   int      `json:"cordoned_nodes"`
+	StuckNodes      int      `json:"stuck_nodes"`
+	NotReadyNodes   int      `json:"not_ready_nodes"`
+	OutageNodes     int      `json:"outage_nodes"` // NotReady nodes not explained by an MCO update
+}
+
+// MachineConfigStatusOutput represents the tool output
+type MachineConfigStatusOutput struct {
+	Status            string                      `json:"status"` // healthy, updating, degraded
+	RolloutInProgress bool                        `json:"rollout_in_progress"`
+	Summary           MachineConfigSummary        `json:"summary"`
+	Pools             []clients.MachineConfigPool `json:"pools"`
+	Nodes             []NodeConfigStatus          `json:"nodes"`
+	Message           string                      `json:"message"`
+}
+
+// Execute runs the MachineConfigPool health check
+func (t *MachineConfigStatusTool) Execute(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+	input := MachineConfigStatusInput{
+		StuckThresholdMinutes: 30,
+	}
+	if argsJSON, err := json.Marshal(args); err == nil {
+		_ = json.Unmarshal(argsJSON, &input) //nolint:errcheck // Intentionally ignore error, use defaults if unmarshal fails
+	}
+	if input.StuckThresholdMinutes < 1 {
+		return nil, fmt.Errorf("stuck_threshold_minutes must be at least 1, got %d", input.StuckThresholdMinutes)
+	}
+
+	pools, err := t.k8sClient.ListMachineConfigPools(ctx)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, fmt.Errorf("MachineConfigPools not found: this does not appear to be an OpenShift cluster")
+		}
+		return nil, fmt.Errorf("failed to get machine config pools: %w", err)
+	}
+
+	nodes, err := t.k8sClient.ListNodes(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list nodes: %w", err)
+	}
+
+	if input.Pool != "" {
+		found := false
+		for _, pool := range pools {
+			if pool.Name == input.Pool {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("machine config pool %q not found", input.Pool)
+		}
+	}
+
+	threshold := time.Duration(input.StuckThresholdMinutes) * time.Minute
+	return analyzeMachineConfig(pools, nodes.Items, input, threshold, time.Now()), nil
+}
+
+// analyzeMachineConfig correlates pools and nodes into the tool output
+func analyzeMachineConfig(pools []clients.MachineConfigPool, nodes []corev1.Node, input MachineConfigStatusInput, threshold time.Duration, now time.Time) MachineConfigStatusOutput {
+	output := MachineConfigStatusOutput{
+		Pools: make([]clients.MachineConfigPool, 0, len(pools)),
+		Nodes: make([]NodeConfigStatus, 0),
+	}
+
+	poolsByName := make(map[string]*clients.MachineConfigPool, len(pools))
+	for i := range pools {
+		pool := pools[i]
+		poolsByName[pool.Name] = &pools[i]
+		if input.Pool != "" && pool.Name != input.Pool {
+			continue
+		}
+		pool.Conditions = nil
+		output.Pools = append(output.Pools, pool)
+
+		output.Summary.Pools++
+		// A paused pool with pending machines is held back, not rolling out
+		if !pool.Paused && (pool.Updating || pool.UpdatedMachineCount < pool.MachineCount) {
+			output.Summary.UpdatingPools = append(output.Summary.UpdatingPools, pool.Name)
+		}
+		if pool.Degraded || pool.DegradedMachineCount > 0 {
+			output.Summary.DegradedPools = append(output.Summary.DegradedPools, pool.Name)
+		}
+		if pool.Paused {
+			output.Summary.PausedPools = append(output.Summary.PausedPools, pool.Name)
+		}
+	}
+
+	for i := range nodes {
+		status := nodeConfigStatus(&nodes[i], pools, threshold, now)
+		if input.Pool != "" && status.Pool != input.Pool {
+			continue
+		}
+		status.Explanation = explainNode(&status, poolsByName[status.Pool])
+
+		if status.ConfigMismatch {
+			output.Summary.MismatchedNodes++
+		}
+		if status.Unschedulable {
+			output.Summary.CordonedNodes++
+		}
+		if status.Stuck {
+			output.Summary.StuckNodes++
+		}
+		if !status.Ready {
+			output.Summary.NotReadyNodes++
+			if !status.ConfigMismatch && status.MCDState != "Working" {
+				output.Summary.OutageNodes++
+			}
+		}
+
+		interesting := !status.Ready || status.Unschedulable || status.ConfigMismatch ||
+			(status.MCDState != "" && status.MCDState != "Done")
+		if input.IncludeAllNodes || interesting {
+			output.Nodes = append(output.Nodes, status)
+		}
+	}
+
+	output.RolloutInProgress = len(output.Summary.UpdatingPools) > 0
+
+	switch {
+	case len(output.Summary.DegradedPools) > 0 || output.Summary.StuckNodes > 0 || output.Summary.OutageNodes > 0:
+		output.Status = "degraded"
+	case output.RolloutInProgress:
+		output.Status = "updating"
+	default:
+		output.Status = "healthy"
+	}
+
+	output.Message = machineConfigMessage(&output)
+	return output
+}
+
+// nodeConfigStatus reads the machine-config-daemon annotations and cordon state of a node
+func nodeConfigStatus(node *corev1.Node, pools []clients.MachineConfigPool, threshold time.Duration, now time.Time) NodeConfigStatus {
+	status := NodeConfigStatus{
+		Name:          node.Name,
+		Pool:          poolForNode(node, pools),
+		Ready:         isNodeReady(node),
+		Unschedulable: node.Spec.Unschedulable,
+		CurrentConfig: node.Annotations[clients.MCOCurrentConfigAnnotation],
+		DesiredConfig: node.Annotations[clients.MCODesiredConfigAnnotation],
+		MCDState:      node.Annotations[clients.MCOStateAnnotation],
+		MCDReason:     node.Annotations[clients.MCOReasonAnnotation],
+	}
+	status.ConfigMismatch = status.CurrentConfig != "" && status.DesiredConfig != "" &&
+		status.CurrentConfig != status.DesiredConfig
+
+	if status.Unschedulable {
+		for _, taint := range node.Spec.Taints {
+			if taint.Key == corev1.TaintNodeUnschedulable && taint.TimeAdded != nil {
+				cordoned := now.Sub(taint.TimeAdded.Time)
+				status.CordonedFor = cordoned.Round(time.Minute).String()
+				status.Stuck = cordoned > threshold
+				break
+			}
+		}
+	}
+
+	return status
+}
+
+// isNodeReady reports whether the node's Ready condition is True
+func isNodeReady(node *corev1.Node) bool {
+	for _, condition := range node.Status.Conditions {
+		if condition.Type == corev1.NodeReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// poolForNode returns the pool a node belongs to. Nodes in a custom pool
+// also match the worker selector; the MCO assigns them to the custom pool.
+func poolForNode(node *corev1.Node, pools []clients.MachineConfigPool) string {
+	match := ""
+	for i := range pools {
+		if !pools[i].MatchesNode(node.Labels) {
+			continue
+		}
+		if match == "" || match == "worker" {
+			match = pools[i].Name
+		}
+	}
+	return match
+}
+
+// explainNode describes why a node is in its current state
+func explainNode(status *NodeConfigStatus, pool *clients.MachineConfigPool) string {
+	switch {
+	case status.MCDState == "Degraded":
+		return fmt.Sprintf("machine-config-daemon is Degraded: %s", status.MCDReason)
+	case status.ConfigMismatch && pool != nil && pool.Paused:
+		return fmt.Sprintf("Pending update to %s is held back because pool %s is paused", status.DesiredConfig, pool.Name)
+	case status.Stuck && status.ConfigMismatch:
+		return fmt.Sprintf("MCO update to %s appears stuck: node cordoned for %s", status.DesiredConfig, status.CordonedFor)
+	case status.Stuck:
+		return fmt.Sprintf("Node cordoned for %s with no MCO update pending (manual cordon or failed drain?)", status.CordonedFor)
+	case !status.Ready && (status.ConfigMismatch || status.MCDState == "Working"):
+		return fmt.Sprintf("NotReady while the MCO applies %s (reboot expected during rollout)", status.DesiredConfig)
+	case !status.Ready:
+		return "NotReady with no MCO update in progress: not explained by a rollout, investigate as an outage"
+	case status.ConfigMismatch || status.MCDState == "Working":
+		return fmt.Sprintf("Updating from %s to %s", status.CurrentConfig, status.DesiredConfig)
+	case status.Unschedulable:
+		return "Cordoned (SchedulingDisabled)"
+	}
+	return ""
+}
+
+// machineConfigMessage summarizes the output in one sentence
+func machineConfigMessage(output *MachineConfigStatusOutput) string {
+	s := output.Summary
+	parts := []string{fmt.Sprintf("%d machine config pools", s.Pools)}
+
+	if len(s.UpdatingPools) > 0 {
+		var progress []string
+		for _, pool := range output.Pools {
+			for _, name := range s.UpdatingPools {
+				if pool.Name == name {
+					progress = append(progress, fmt.Sprintf("%s %d/%d updated", pool.Name, pool.UpdatedMachineCount, pool.MachineCount))
+				}
+			}
+		}
+		parts = append(parts, "MCO rollout in progress ("+strings.Join(progress, ", ")+")")
+	}
+	if len(s.DegradedPools) > 0 {
+		parts = append(parts, "degraded pools: "+strings.Join(s.DegradedPools, ", "))
+	}
+	if len(s.PausedPools) > 0 {
+		parts = append(parts, "paused pools: "+strings.Join(s.PausedPools, ", "))
+	}
+	if s.StuckNodes > 0 {
+		parts = append(parts, fmt.Sprintf("%d nodes stuck cordoned", s.StuckNodes))
+	}
+	if s.NotReadyNodes > 0 {
+		explained := s.NotReadyNodes - s.OutageNodes
+		parts = append(parts, fmt.Sprintf("%d NotReady nodes (%d explained by the rollout, %d not)", s.NotReadyNodes, explained, s.OutageNodes))
+	}
+
+	return strings.Join(parts, "; ")
+}
@@ -0,0 +1,186 @@
+package tools
+
+import (
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/KubeHeal/openshift-cluster-health-mcp/pkg/clients"
+)
+
+func mcoNode(name, role string, ready bool, current, desired, state string) corev1.Node {
+	status := corev1.ConditionFalse
+	if ready {
+		status = corev1.ConditionTrue
+	}
+	return corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   name,
+			Labels: map[string]string{"node-role.kubernetes.io/" + role: ""},
+			Annotations: map[string]string{
+				clients.MCOCurrentConfigAnnotation: current,
+				clients.MCODesiredConfigAnnotation: desired,
+				clients.MCOStateAnnotation:         state,
+			},
+		},
+		Status: corev1.NodeStatus{
+			Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: status}},
+		},
+	}
+}
+
+func mcoPool(name string, machines, updated int64) clients.MachineConfigPool {
+	selector, _ := metav1.LabelSelectorAsSelector(&metav1.LabelSelector{
+		MatchLabels: map[string]string{"node-role.kubernetes.io/" + name: ""},
+	})
+	return clients.MachineConfigPool{
+		Name:                name,
+		MachineCount:        machines,
+		UpdatedMachineCount: updated,
+		Updating:            updated < machines,
+		Updated:             updated == machines,
+		NodeSelector:        selector,
+	}
+}
+
+func cordon(node *corev1.Node, since time.Time) {
+	node.Spec.Unschedulable = true
+	added := metav1.NewTime(since)
+	node.Spec.Taints = append(node.Spec.Taints, corev1.Taint{
+		Key:       corev1.TaintNodeUnschedulable,
+		Effect:    corev1.TaintEffectNoSchedule,
+		TimeAdded: &added,
+	})
+}
+
+func TestMachineConfigStatusTool_Metadata(t *testing.T) {
+	tool := &MachineConfigStatusTool{}
+
+	if tool.Name() != "get-machine-config-status" {
+		t.Errorf("Expected name 'get-machine-config-status', got '%s'", tool.Name())
+	}
+
+	schema := tool.InputSchema()
+	properties, ok := schema["properties"].(map[string]interface{})
+	if !ok {
+		t.Fatal("Expected properties to be a map")
+	}
+	for _, prop := range []string{"pool", "stuck_threshold_minutes", "include_all_nodes"} {
+		if _, exists := properties[prop]; !exists {
+			t.Errorf("Expected property '%s' in schema", prop)
+		}
+	}
+}
+
+func TestAnalyzeMachineConfig_RolloutExplainsNotReady(t *testing.T) {
+	now := time.Now()
+	pools := []clients.MachineConfigPool{mcoPool("master", 3, 3), mcoPool("worker", 3, 1)}
+
+	rebooting := mcoNode("worker-1", "worker", false, "rendered-worker-a", "rendered-worker-b", "Working")
+	cordon(&rebooting, now.Add(-5*time.Minute))
+	nodes := []corev1.Node{
+		mcoNode("master-0", "master", true, "rendered-master-a", "rendered-master-a", "Done"),
+		mcoNode("worker-0", "worker", true, "rendered-worker-b", "rendered-worker-b", "Done"),
+		rebooting,
+		mcoNode("worker-2", "worker", true, "rendered-worker-a", "rendered-worker-b", "Done"),
+	}
+
+	output := analyzeMachineConfig(pools, nodes, MachineConfigStatusInput{}, 30*time.Minute, now)
+
+	if output.Status != "updating" || !output.RolloutInProgress {
+		t.Errorf("Expected updating rollout, got status=%s rollout=%v", output.Status, output.RolloutInProgress)
+	}
+	if output.Summary.MismatchedNodes != 2 || output.Summary.NotReadyNodes != 1 || output.Summary.OutageNodes != 0 {
+		t.Errorf("Unexpected summary: %+v", output.Summary)
+	}
+	if output.Summary.StuckNodes != 0 {
+		t.Error("A node cordoned for 5 minutes should not be stuck")
+	}
+	if len(output.Nodes) != 2 {
+		t.Errorf("Expected only the 2 updating nodes, got %d", len(output.Nodes))
+	}
+	for _, n := range output.Nodes {
+		if n.Name == "worker-1" && (n.Pool != "worker" || !contains(n.Explanation, "reboot expected")) {
+			t.Errorf("Expected worker-1 to be explained by the rollout, got %+v", n)
+		}
+	}
+	if !contains(output.Message, "worker 1/3 updated") {
+		t.Errorf("Expected rollout progress in message, got %q", output.Message)
+	}
+}
+
+func TestAnalyzeMachineConfig_OutageAndStuck(t *testing.T) {
+	now := time.Now()
+	pools := []clients.MachineConfigPool{mcoPool("worker", 2, 2)}
+
+	cordoned := mcoNode("worker-1", "worker", true, "rendered-worker-a", "rendered-worker-a", "Done")
+	cordon(&cordoned, now.Add(-2*time.Hour))
+	nodes := []corev1.Node{
+		mcoNode("worker-0", "worker", false, "rendered-worker-a", "rendered-worker-a", "Done"),
+		cordoned,
+	}
+
+	output := analyzeMachineConfig(pools, nodes, MachineConfigStatusInput{}, 30*time.Minute, now)
+
+	if output.Status != "degraded" || output.RolloutInProgress {
+		t.Errorf("Expected degraded without rollout, got status=%s rollout=%v", output.Status, output.RolloutInProgress)
+	}
+	if output.Summary.OutageNodes != 1 || output.Summary.StuckNodes != 1 {
+		t.Errorf("Unexpected summary: %+v", output.Summary)
+	}
+	for _, n := range output.Nodes {
+		switch n.Name {
+		case "worker-0":
+			if !contains(n.Explanation, "outage") {
+				t.Errorf("Expected outage explanation, got %q", n.Explanation)
+			}
+		case "worker-1":
+			if !n.Stuck || !contains(n.Explanation, "no MCO update pending") {
+				t.Errorf("Expected stuck cordon explanation, got %+v", n)
+			}
+		}
+	}
+}
+
+func TestAnalyzeMachineConfig_PausedPoolAndFilter(t *testing.T) {
+	now := time.Now()
+	worker := mcoPool("worker", 2, 1)
+	worker.Paused = true
+	worker.Updating = false
+	pools := []clients.MachineConfigPool{mcoPool("master", 1, 1), worker}
+	nodes := []corev1.Node{
+		mcoNode("master-0", "master", true, "m", "m", "Done"),
+		mcoNode("worker-0", "worker", true, "rendered-worker-a", "rendered-worker-b", "Done"),
+	}
+
+	output := analyzeMachineConfig(pools, nodes, MachineConfigStatusInput{Pool: "worker"}, 30*time.Minute, now)
+
+	if len(output.Pools) != 1 || output.Pools[0].Name != "worker" {
+		t.Errorf("Expected only the worker pool, got %+v", output.Pools)
+	}
+	if output.RolloutInProgress || len(output.Summary.PausedPools) != 1 {
+		t.Errorf("Expected paused pool without rollout, got %+v", output.Summary)
+	}
+	if len(output.Nodes) != 1 || !contains(output.Nodes[0].Explanation, "paused") {
+		t.Errorf("Expected paused explanation for worker-0, got %+v", output.Nodes)
+	}
+}
+
+func TestPoolForNode_PrefersCustomPool(t *testing.T) {
+	infraSelector, _ := labels.Parse("node-role.kubernetes.io/infra")
+	pools := []clients.MachineConfigPool{
+		{Name: "infra", NodeSelector: infraSelector},
+		mcoPool("worker", 1, 1),
+	}
+	node := corev1.Node{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{
+		"node-role.kubernetes.io/worker": "",
+		"node-role.kubernetes.io/infra":  "",
+	}}}
+
+	if pool := poolForNode(&node, pools); pool != "infra" {
+		t.Errorf("Expected custom pool 'infra', got %q", pool)
+	}
+}
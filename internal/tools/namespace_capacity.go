@@ -0,0 +1,89 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/KubeHeal/openshift-cluster-health-mcp/pkg/capacity"
+	"github.com/KubeHeal/openshift-cluster-health-mcp/pkg/clients"
+	"github.com/KubeHeal/openshift-cluster-health-mcp/pkg/scheduling"
+)
+
+// loadNamespaceCapacity computes the effective capacity of a namespace for
+// the target pods: the tightest of every applicable ResourceQuota per
+// resource, with the cluster's free capacity standing in for resources no
+// quota bounds, limited to nodes matching opts. LimitRange defaults are
+// applied to containers without requests when summing namespace usage.
+func loadNamespaceCapacity(ctx context.Context, k8sClient *clients.K8sClient, namespace string, target capacity.QuotaTarget, opts capacity.PlacementOptions) (*capacity.NamespaceQuota, error) {
+	quotas, err := k8sClient.ListResourceQuotas(ctx, namespace)
+	if err != nil {
+		return nil, err
+	}
+	pods, err := k8sClient.ListPods(ctx, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	var notes []string
+	var defaults capacity.LimitRangeDefaults
+	if limitRanges, err := k8sClient.ListLimitRanges(ctx, namespace); err == nil {
+		defaults = capacity.LimitRangeDefaultsFrom(limitRanges.Items)
+	} else {
+		notes = append(notes, fmt.Sprintf("LimitRange defaults not applied: %v", err))
+	}
+	if len(defaults.Sources) > 0 {
+		notes = append(notes, fmt.Sprintf("LimitRange defaults from %s applied to containers without requests", strings.Join(defaults.Sources, ", ")))
+	}
+
+	// Admission fills LimitRange defaults in, so such pods are not BestEffort
+	if target.BestEffort && (defaults.CPURequestMillicores > 0 || defaults.MemoryRequestBytes > 0) {
+		target.BestEffort = false
+	}
+	constraints, quotaNotes := capacity.AggregateResourceQuotas(quotas.Items, target, defaults)
+	notes = append(notes, quotaNotes...)
+
+	// Only resources no quota bounds need the cluster's free capacity
+	var free *capacity.ClusterFreeCapacity
+	if len(constraints) < len(capacity.QuotaResources) {
+		if f, err := clusterFreeCapacity(ctx, k8sClient, opts); err == nil {
+			free = &f
+		} else {
+			notes = append(notes, fmt.Sprintf("cluster free capacity unavailable: %v", err))
+		}
+	}
+
+	usage := calculatePodResourceUsage(pods.Items, defaults)
+	return capacity.BuildNamespaceQuota(constraints, notes, usage, free), nil
+}
+
+// clusterFreeCapacity sums the unrequested capacity of schedulable nodes
+func clusterFreeCapacity(ctx context.Context, k8sClient *clients.K8sClient, opts capacity.PlacementOptions) (capacity.ClusterFreeCapacity, error) {
+	nodes, err := k8sClient.ListNodes(ctx)
+	if err != nil {
+		return capacity.ClusterFreeCapacity{}, err
+	}
+	pods, err := k8sClient.ListPods(ctx, "")
+	if err != nil {
+		return capacity.ClusterFreeCapacity{}, err
+	}
+	return capacity.FreeClusterCapacity(scheduling.NewNodeInfos(nodes.Items, pods.Items), opts), nil
+}
+
+// calculatePodResourceUsage sums the requests of running and pending pods
+func calculatePodResourceUsage(pods []corev1.Pod, defaults capacity.LimitRangeDefaults) capacity.NamespaceUsage {
+	var usage capacity.NamespaceUsage
+	for i := range pods {
+		pod := &pods[i]
+		if pod.Status.Phase != corev1.PodRunning && pod.Status.Phase != corev1.PodPending {
+			continue
+		}
+		requests := capacity.PodRequestsWithDefaults(pod, defaults)
+		usage.CPUMillicores += requests.MilliCPU
+		usage.MemoryBytes += requests.Memory
+		usage.Pods++
+	}
+	return usage
+}
@@ -0,0 +1,299 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/KubeHeal/openshift-cluster-health-mcp/pkg/capacity"
+	"github.com/KubeHeal/openshift-cluster-health-mcp/pkg/clients"
+)
+
+const (
+	defaultNamespaceCostLimit = 20
+	maxNamespaceCostLimit     = 200
+)
+
+// Namespace cost sort orders
+const (
+	CostSortRequests = "requests"
+	CostSortUsage    = "usage"
+	CostSortIdle     = "idle"
+)
+
+// NamespaceCostsTool allocates cluster cost to namespaces via MCP
+type NamespaceCostsTool struct {
+	k8sClient  *clients.K8sClient
+	prometheus *clients.PrometheusClient
+	pricing    *PricingSource
+}
+
+// NewNamespaceCostsTool creates a new get-namespace-costs tool; prometheus may
+// be nil, in which case usage is a single metrics API snapshot
+func NewNamespaceCostsTool(k8sClient *clients.K8sClient, prometheus *clients.PrometheusClient, pricing *PricingSource) *NamespaceCostsTool {
+	return &NamespaceCostsTool{
+		k8sClient:  k8sClient,
+		prometheus: prometheus,
+		pricing:    pricing,
+	}
+}
+
+// Name returns the tool name for MCP registration
+func (t *NamespaceCostsTool) Name() string {
+	return "get-namespace-costs"
+}
+
+// Description returns the tool description for MCP
+func (t *NamespaceCostsTool) Description() string {
+	return "Estimate monthly cost per namespace from the configured pricing model (per vCPU-hour and GiB-hour, optionally per node instance type): requests-based allocation, usage-based allocation (Prometheus average over a window, or a metrics API snapshot), idle cost of requested but unused resources, and the cluster cost not allocated to any requests"
+}
+
+// InputSchema returns the JSON schema for tool inputs
+func (t *NamespaceCostsTool) InputSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"namespace": map[string]interface{}{
+				"type":        "string",
+				"description": "Only report this namespace (empty = all namespaces)",
+				"default":     "",
+			},
+			"window": map[string]interface{}{
+				"type":        "string",
+				"description": "Prometheus window usage is averaged over (e.g. '24h', '7d')",
+				"default":     defaultUsageWindow,
+			},
+			"sort_by": map[string]interface{}{
+				"type":        "string",
+				"description": "Rank namespaces by requests, usage or idle cost",
+				"enum":        []string{CostSortRequests, CostSortUsage, CostSortIdle},
+				"default":     CostSortRequests,
+			},
+			"limit": map[string]interface{}{
+				"type":        "integer",
+				"description": "Maximum number of namespaces to return",
+				"default":     defaultNamespaceCostLimit,
+				"minimum":     1,
+				"maximum":     maxNamespaceCostLimit,
+			},
+		},
+		"required": []string{},
+	}
+}
+
+// NamespaceCostsInput represents the input parameters
+type NamespaceCostsInput struct {
+	Namespace string `json:"namespace"`
+	Window    string `json:"window"`
+	SortBy    string `json:"sort_by"`
+	Limit     int    `json:"limit"`
+}
+
+// NamespaceCostsOutput represents the tool output
+type NamespaceCostsOutput struct {
+	Currency     string                   `json:"currency"`
+	BlendedRates capacity.ResourceRates   `json:"blended_rates"`
+	UsageSource  string                   `json:"usage_source"`
+	Window       string                   `json:"window,omitempty"`
+	Namespaces   []capacity.NamespaceCost `json:"namespaces"`
+	Omitted      int                      `json:"omitted,omitempty"`
+	// Totals cover every matching namespace, including omitted ones
+	TotalRequestsCost capacity.MonthlyCost `json:"total_requests_cost"`
+	TotalUsageCost    capacity.MonthlyCost `json:"total_usage_cost"`
+	TotalIdleCost     capacity.MonthlyCost `json:"total_idle_cost"`
+	// ClusterCost prices every node's allocatable capacity; UnallocatedCost
+	// is the part no pod requests (cluster-wide only)
+	ClusterCost     capacity.MonthlyCost  `json:"cluster_cost"`
+	UnallocatedCost *capacity.MonthlyCost `json:"unallocated_cost,omitempty"`
+	Warnings        []string              `json:"warnings,omitempty"`
+	Message         string                `json:"message"`
+}
+
+// Execute allocates monthly cost to namespaces
+func (t *NamespaceCostsTool) Execute(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+	input := NamespaceCostsInput{
+		Window: defaultUsageWindow,
+		SortBy: CostSortRequests,
+		Limit:  defaultNamespaceCostLimit,
+	}
+	if argsJSON, err := json.Marshal(args); err == nil {
+		_ = json.Unmarshal(argsJSON, &input) //nolint:errcheck // Intentionally ignore error, use defaults if unmarshal fails
+	}
+	if err := normalizeNamespaceCostsInput(&input); err != nil {
+		return nil, err
+	}
+	if !t.pricing.Configured() {
+		return nil, fmt.Errorf("no pricing model configured: set PRICING_FILE or PRICING_CONFIGMAP")
+	}
+
+	pricer, err := t.pricing.NodePricer(ctx)
+	if err != nil {
+		return nil, err
+	}
+	pods, err := t.k8sClient.ListPods(ctx, input.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	var warnings []string
+	source := UsageSourcePrometheus
+	var usage map[string]capacity.PodUsage
+	if t.prometheus != nil {
+		usage, err = t.prometheusPodUsage(ctx, input)
+		if err != nil {
+			warnings = append(warnings, fmt.Sprintf("Prometheus unavailable, falling back to a metrics API snapshot: %v", err))
+		}
+	}
+	if usage == nil {
+		source = UsageSourceMetricsAPI
+		metrics, err := t.k8sClient.ListContainerMetrics(ctx, input.Namespace)
+		if err != nil {
+			warnings = append(warnings, fmt.Sprintf("Usage-based costs unavailable: %v", err))
+		} else {
+			warnings = append(warnings, "Usage is a single metrics API snapshot, not an average; treat usage and idle costs as indicative")
+		}
+		usage = sumContainerMetrics(metrics)
+	}
+
+	costs := capacity.AllocateNamespaceCosts(pricer, pods.Items, usage)
+	output := summarizeNamespaceCosts(costs, pricer, input)
+	output.UsageSource = source
+	if source == UsageSourcePrometheus {
+		output.Window = input.Window
+	}
+	output.Warnings = warnings
+	output.Message = namespaceCostsMessage(&output)
+	return output, nil
+}
+
+// normalizeNamespaceCostsInput validates and clamps the input
+func normalizeNamespaceCostsInput(input *NamespaceCostsInput) error {
+	if !promDurationPattern.MatchString(input.Window) {
+		return fmt.Errorf("invalid window %q: use a Prometheus duration such as 24h or 7d", input.Window)
+	}
+	switch input.SortBy {
+	case "":
+		input.SortBy = CostSortRequests
+	case CostSortRequests, CostSortUsage, CostSortIdle:
+	default:
+		return fmt.Errorf("invalid sort_by %q: use %s, %s or %s", input.SortBy, CostSortRequests, CostSortUsage, CostSortIdle)
+	}
+	if input.Limit < 1 {
+		input.Limit = defaultNamespaceCostLimit
+	}
+	if input.Limit > maxNamespaceCostLimit {
+		input.Limit = maxNamespaceCostLimit
+	}
+	return nil
+}
+
+// prometheusPodUsage averages per-pod CPU and memory usage over the window
+func (t *NamespaceCostsTool) prometheusPodUsage(ctx context.Context, input NamespaceCostsInput) (map[string]capacity.PodUsage, error) {
+	selector := `container!="",container!="POD"`
+	if input.Namespace != "" {
+		selector += fmt.Sprintf(`,namespace=%q`, input.Namespace)
+	}
+	cpu, err := t.prometheus.Query(ctx, fmt.Sprintf(`sum by (namespace, pod) (avg_over_time(rate(container_cpu_usage_seconds_total{%s}[5m])[%s:5m]))`, selector, input.Window))
+	if err != nil {
+		return nil, err
+	}
+	memory, err := t.prometheus.Query(ctx, fmt.Sprintf(`sum by (namespace, pod) (avg_over_time(container_memory_working_set_bytes{%s}[%s]))`, selector, input.Window))
+	if err != nil {
+		return nil, err
+	}
+	return mergePodUsage(cpu, memory), nil
+}
+
+// mergePodUsage combines CPU (cores) and memory (bytes) samples per pod;
+// NaN/Inf values are dropped
+func mergePodUsage(cpu, memory []clients.PrometheusSample) map[string]capacity.PodUsage {
+	usage := make(map[string]capacity.PodUsage)
+	for _, s := range cpu {
+		if math.IsNaN(s.Value) || math.IsInf(s.Value, 0) {
+			continue
+		}
+		key := s.Labels["namespace"] + "/" + s.Labels["pod"]
+		u := usage[key]
+		u.CPUMillicores = s.Value * 1000
+		usage[key] = u
+	}
+	for _, s := range memory {
+		if math.IsNaN(s.Value) || math.IsInf(s.Value, 0) {
+			continue
+		}
+		key := s.Labels["namespace"] + "/" + s.Labels["pod"]
+		u := usage[key]
+		u.MemoryBytes = s.Value
+		usage[key] = u
+	}
+	return usage
+}
+
+// sumContainerMetrics sums a metrics API snapshot per pod
+func sumContainerMetrics(metrics []clients.ContainerMetrics) map[string]capacity.PodUsage {
+	usage := make(map[string]capacity.PodUsage)
+	for _, m := range metrics {
+		key := m.Namespace + "/" + m.Pod
+		u := usage[key]
+		u.CPUMillicores += float64(m.CPUMillicores)
+		u.MemoryBytes += float64(m.MemoryBytes)
+		usage[key] = u
+	}
+	return usage
+}
+
+// summarizeNamespaceCosts totals, ranks and limits namespace costs
+func summarizeNamespaceCosts(costs []capacity.NamespaceCost, pricer *capacity.NodePricer, input NamespaceCostsInput) NamespaceCostsOutput {
+	output := NamespaceCostsOutput{
+		Currency:     pricer.Currency(),
+		BlendedRates: pricer.Blended(),
+		ClusterCost:  pricer.ClusterCost(),
+		Namespaces:   []capacity.NamespaceCost{},
+	}
+	for _, ns := range costs {
+		output.TotalRequestsCost = output.TotalRequestsCost.Add(ns.RequestsCost)
+		output.TotalUsageCost = output.TotalUsageCost.Add(ns.UsageCost)
+		output.TotalIdleCost = output.TotalIdleCost.Add(ns.IdleCost)
+	}
+	if input.Namespace == "" {
+		unallocated := output.ClusterCost.Sub(output.TotalRequestsCost)
+		output.UnallocatedCost = &unallocated
+	}
+
+	ranked := append([]capacity.NamespaceCost(nil), costs...)
+	key := func(ns capacity.NamespaceCost) float64 {
+		switch input.SortBy {
+		case CostSortUsage:
+			return ns.UsageCost.Total
+		case CostSortIdle:
+			return ns.IdleCost.Total
+		}
+		return ns.RequestsCost.Total
+	}
+	sort.SliceStable(ranked, func(i, j int) bool { return key(ranked[i]) > key(ranked[j]) })
+	if len(ranked) > input.Limit {
+		output.Omitted = len(ranked) - input.Limit
+		ranked = ranked[:input.Limit]
+	}
+	output.Namespaces = append(output.Namespaces, ranked...)
+	return output
+}
+
+// namespaceCostsMessage summarizes the allocation
+func namespaceCostsMessage(output *NamespaceCostsOutput) string {
+	if len(output.Namespaces) == 0 {
+		return "No scheduled pods found to allocate cost to"
+	}
+	msg := fmt.Sprintf("Requests cost %.2f %s/month across %d namespaces (usage %.2f, idle %.2f)",
+		output.TotalRequestsCost.Total, output.Currency, len(output.Namespaces)+output.Omitted,
+		output.TotalUsageCost.Total, output.TotalIdleCost.Total)
+	if output.UnallocatedCost != nil {
+		msg += fmt.Sprintf("; cluster capacity costs %.2f, of which %.2f is not requested by any pod",
+			output.ClusterCost.Total, output.UnallocatedCost.Total)
+	}
+	top := output.Namespaces[0]
+	msg += fmt.Sprintf(". Top: %s (%.2f requests, %.2f idle)", top.Namespace, top.RequestsCost.Total, top.IdleCost.Total)
+	return msg
+}
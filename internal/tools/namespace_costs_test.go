@@ -0,0 +1,118 @@
+package tools
+
+import (
+	"context"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/KubeHeal/openshift-cluster-health-mcp/pkg/capacity"
+	"github.com/KubeHeal/openshift-cluster-health-mcp/pkg/clients"
+)
+
+func TestNamespaceCostsTool_Metadata(t *testing.T) {
+	tool := NewNamespaceCostsTool(nil, nil, nil)
+	if tool.Name() != "get-namespace-costs" {
+		t.Errorf("unexpected name %s", tool.Name())
+	}
+	props := tool.InputSchema()["properties"].(map[string]interface{})
+	for _, key := range []string{"namespace", "window", "sort_by", "limit"} {
+		if _, ok := props[key]; !ok {
+			t.Errorf("schema missing %s", key)
+		}
+	}
+	if _, err := tool.Execute(context.Background(), nil); err == nil || !contains(err.Error(), "PRICING_FILE") {
+		t.Errorf("Execute without pricing = %v, want configuration hint", err)
+	}
+}
+
+func TestNormalizeNamespaceCostsInput(t *testing.T) {
+	input := NamespaceCostsInput{Window: "7d", Limit: 1000}
+	if err := normalizeNamespaceCostsInput(&input); err != nil {
+		t.Fatal(err)
+	}
+	if input.SortBy != CostSortRequests || input.Limit != maxNamespaceCostLimit {
+		t.Errorf("normalized input = %+v", input)
+	}
+	if err := normalizeNamespaceCostsInput(&NamespaceCostsInput{Window: "1 day"}); err == nil {
+		t.Error("expected an error for an invalid window")
+	}
+	if err := normalizeNamespaceCostsInput(&NamespaceCostsInput{Window: "24h", SortBy: "name"}); err == nil {
+		t.Error("expected an error for an invalid sort_by")
+	}
+}
+
+func TestPodUsageAggregation(t *testing.T) {
+	cpu := []clients.PrometheusSample{
+		{Labels: map[string]string{"namespace": "app", "pod": "api"}, Value: 0.25},
+		{Labels: map[string]string{"namespace": "app", "pod": "idle"}, Value: math.NaN()},
+	}
+	memory := []clients.PrometheusSample{{Labels: map[string]string{"namespace": "app", "pod": "api"}, Value: 1 << 30}}
+	usage := mergePodUsage(cpu, memory)
+	if u := usage["app/api"]; u.CPUMillicores != 250 || u.MemoryBytes != 1<<30 {
+		t.Errorf("app/api usage = %+v", u)
+	}
+	if _, ok := usage["app/idle"]; ok {
+		t.Error("NaN sample should be dropped")
+	}
+
+	snapshot := sumContainerMetrics([]clients.ContainerMetrics{
+		{Namespace: "app", Pod: "api", Container: "app", CPUMillicores: 100, MemoryBytes: 100},
+		{Namespace: "app", Pod: "api", Container: "proxy", CPUMillicores: 20, MemoryBytes: 50},
+	})
+	if u := snapshot["app/api"]; u.CPUMillicores != 120 || u.MemoryBytes != 150 {
+		t.Errorf("summed snapshot = %+v", u)
+	}
+}
+
+func TestSummarizeNamespaceCosts(t *testing.T) {
+	model := &capacity.PricingModel{Currency: "EUR", Default: capacity.ResourceRates{CPUCoreHour: 0.04}}
+	pricer := capacity.NewNodePricer(model, []corev1.Node{schedulingNode("n1", "4", "8Gi")})
+	costs := []capacity.NamespaceCost{
+		{Namespace: "big", RequestsCost: capacity.MonthlyCost{CPU: 50, Total: 50}, IdleCost: capacity.MonthlyCost{CPU: 5, Total: 5}},
+		{Namespace: "wasteful", RequestsCost: capacity.MonthlyCost{CPU: 30, Total: 30}, IdleCost: capacity.MonthlyCost{CPU: 25, Total: 25}},
+	}
+
+	output := summarizeNamespaceCosts(costs, pricer, NamespaceCostsInput{SortBy: CostSortIdle, Limit: 1})
+	if len(output.Namespaces) != 1 || output.Namespaces[0].Namespace != "wasteful" || output.Omitted != 1 {
+		t.Fatalf("namespaces = %+v, want wasteful ranked first by idle cost", output.Namespaces)
+	}
+	if output.TotalRequestsCost.Total != 80 || output.TotalIdleCost.Total != 30 {
+		t.Errorf("totals = %+v / %+v, want omitted namespaces included", output.TotalRequestsCost, output.TotalIdleCost)
+	}
+	// 4 cores * 0.04 * 730 = 116.80 of capacity, 80 requested
+	if output.ClusterCost.Total != 116.8 || output.UnallocatedCost == nil || output.UnallocatedCost.Total != 36.8 {
+		t.Errorf("cluster = %+v unallocated = %+v", output.ClusterCost, output.UnallocatedCost)
+	}
+	msg := namespaceCostsMessage(&output)
+	for _, want := range []string{"80.00 EUR/month across 2 namespaces", "36.80 is not requested", "Top: wasteful"} {
+		if !contains(msg, want) {
+			t.Errorf("message %q missing %q", msg, want)
+		}
+	}
+
+	scoped := summarizeNamespaceCosts(costs[:1], pricer, NamespaceCostsInput{Namespace: "big", SortBy: CostSortRequests, Limit: 10})
+	if scoped.UnallocatedCost != nil {
+		t.Error("unallocated cost only applies cluster-wide")
+	}
+}
+
+func TestPricingSource(t *testing.T) {
+	if NewPricingSource(nil, "", "").Configured() {
+		t.Error("empty pricing source should not be configured")
+	}
+	file := filepath.Join(t.TempDir(), "pricing.yaml")
+	if err := os.WriteFile(file, []byte("currency: EUR\ndefault: {cpu_core_hour: 0.03, memory_gib_hour: 0.004}\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	model, err := NewPricingSource(nil, file, "").Model(context.Background())
+	if err != nil || model == nil || model.Currency != "EUR" {
+		t.Fatalf("Model = %+v, %v", model, err)
+	}
+	if _, err := NewPricingSource(nil, filepath.Join(t.TempDir(), "missing.yaml"), "").Model(context.Background()); err == nil {
+		t.Error("expected an error for a missing pricing file")
+	}
+}
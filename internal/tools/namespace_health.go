@@ -0,0 +1,560 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+
+	"github.com/KubeHeal/openshift-cluster-health-mcp/pkg/clients"
+	"github.com/KubeHeal/openshift-cluster-health-mcp/pkg/health"
+)
+
+const (
+	defaultNamespaceHealthLimit = 20
+	maxNamespaceHealthLimit     = 200
+	namespaceRankTopFindings    = 3
+)
+
+// systemNamespacePrefixes identify platform namespaces left out of the
+// cluster overview unless include_system_namespaces is set
+var systemNamespacePrefixes = []string{"openshift-", "kube-"}
+
+// NamespaceHealthTool scores namespaces and ranks them by badness via MCP
+type NamespaceHealthTool struct {
+	k8sClient *clients.K8sClient
+}
+
+// NewNamespaceHealthTool creates a new get-namespace-health tool
+func NewNamespaceHealthTool(k8sClient *clients.K8sClient) *NamespaceHealthTool {
+	return &NamespaceHealthTool{
+		k8sClient: k8sClient,
+	}
+}
+
+// Name returns the tool name for MCP registration
+func (t *NamespaceHealthTool) Name() string {
+	return "get-namespace-health"
+}
+
+// Description returns the tool description for MCP
+func (t *NamespaceHealthTool) Description() string {
+	return "Score the health of a namespace (0-100) from workload rollouts, pod phases and waiting reasons, restart hotspots and recent Warning events, quota utilization, PVC binding and Service endpoint readiness, with prioritized findings. Without a namespace, ranks all namespaces worst first for a cluster overview."
+}
+
+// InputSchema returns the JSON schema for tool inputs
+func (t *NamespaceHealthTool) InputSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"namespace": map[string]interface{}{
+				"type":        "string",
+				"description": "Namespace to report in detail (empty = rank all namespaces)",
+				"default":     "",
+			},
+			"limit": map[string]interface{}{
+				"type":        "integer",
+				"description": "Maximum number of namespaces to rank",
+				"default":     defaultNamespaceHealthLimit,
+				"minimum":     1,
+				"maximum":     maxNamespaceHealthLimit,
+			},
+			"include_system_namespaces": map[string]interface{}{
+				"type":        "boolean",
+				"description": "Include openshift-* and kube-* namespaces in the ranking",
+				"default":     false,
+			},
+			"event_window_minutes": map[string]interface{}{
+				"type":        "integer",
+				"description": "Only count Warning events seen in the last N minutes",
+				"default":     defaultEventsSinceMinutes,
+				"minimum":     1,
+			},
+		},
+		"required": []string{},
+	}
+}
+
+// NamespaceHealthInput represents the input parameters
+type NamespaceHealthInput struct {
+	Namespace               string `json:"namespace"`
+	Limit                   int    `json:"limit"`
+	IncludeSystemNamespaces bool   `json:"include_system_namespaces"`
+	EventWindowMinutes      int    `json:"event_window_minutes"`
+}
+
+// NamespacePodSummary counts pods by phase and container waiting reason
+type NamespacePodSummary struct {
+	Total          int            `json:"total"`
+	ByPhase        map[string]int `json:"by_phase"`
+	WaitingReasons map[string]int `json:"waiting_reasons,omitempty"`
+}
+
+// NamespacePVCSummary counts PVCs by binding phase
+type NamespacePVCSummary struct {
+	Total   int `json:"total"`
+	Bound   int `json:"bound"`
+	Pending int `json:"pending"`
+	Lost    int `json:"lost"`
+}
+
+// NamespaceServiceSummary lists Services whose endpoints are not all ready
+type NamespaceServiceSummary struct {
+	Total            int      `json:"total"`
+	Selected         int      `json:"selected"` // Services with a selector, whose endpoints are checked
+	NoReadyEndpoints []string `json:"no_ready_endpoints,omitempty"`
+	PartiallyReady   []string `json:"partially_ready,omitempty"`
+}
+
+// QuotaUtilization is one ResourceQuota resource with its utilization
+type QuotaUtilization struct {
+	health.QuotaUsage
+	Percent float64 `json:"percent"`
+}
+
+// NamespaceHealthDetail is the detailed health of one namespace
+type NamespaceHealthDetail struct {
+	*health.NamespaceReport
+	Workloads       WorkloadHealthSummary        `json:"workloads"`
+	Pods            NamespacePodSummary          `json:"pods"`
+	RestartHotspots []health.RestartHotspot      `json:"restart_hotspots,omitempty"`
+	WarningEvents   []clients.EventReasonSummary `json:"warning_events,omitempty"`
+	Quotas          []QuotaUtilization           `json:"quotas,omitempty"`
+	PVCs            NamespacePVCSummary          `json:"pvcs"`
+	Services        NamespaceServiceSummary      `json:"services"`
+}
+
+// NamespaceHealthRank is one namespace in the cluster overview
+type NamespaceHealthRank struct {
+	Namespace   string   `json:"namespace"`
+	Score       float64  `json:"score"`
+	Status      string   `json:"status"`
+	Critical    int      `json:"critical"`
+	Warnings    int      `json:"warnings"`
+	TopFindings []string `json:"top_findings,omitempty"`
+}
+
+// NamespaceHealthSummary counts ranked namespaces by status
+type NamespaceHealthSummary struct {
+	Evaluated int `json:"evaluated"`
+	Healthy   int `json:"healthy"`
+	Degraded  int `json:"degraded"`
+	Unhealthy int `json:"unhealthy"`
+}
+
+// NamespaceHealthOutput represents the tool output
+type NamespaceHealthOutput struct {
+	Namespace  *NamespaceHealthDetail  `json:"namespace,omitempty"`
+	Summary    *NamespaceHealthSummary `json:"summary,omitempty"`
+	Namespaces []NamespaceHealthRank   `json:"namespaces,omitempty"`
+	Omitted    int                     `json:"omitted,omitempty"`
+	Notes      []string                `json:"notes,omitempty"`
+	Message    string                  `json:"message"`
+}
+
+// namespaceInventory holds the objects needed to score namespaces; optional
+// sources that could not be listed leave their observed flag false
+type namespaceInventory struct {
+	namespaces []string
+	workloads  []WorkloadHealth
+	pods       []corev1.Pod
+	pvcs       []corev1.PersistentVolumeClaim
+	services   []corev1.Service
+	slices     []discoveryv1.EndpointSlice
+	quotas     []corev1.ResourceQuota
+	events     []clients.Event
+
+	pvcsObserved     bool
+	servicesObserved bool
+	quotasObserved   bool
+	eventsObserved   bool
+	notes            []string
+}
+
+// Execute scores one namespace in detail or ranks all namespaces
+func (t *NamespaceHealthTool) Execute(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+	input := NamespaceHealthInput{
+		Limit:              defaultNamespaceHealthLimit,
+		EventWindowMinutes: defaultEventsSinceMinutes,
+	}
+	if argsJSON, err := json.Marshal(args); err == nil {
+		_ = json.Unmarshal(argsJSON, &input) //nolint:errcheck // Intentionally ignore error, use defaults if unmarshal fails
+	}
+	if input.Limit < 1 {
+		input.Limit = defaultNamespaceHealthLimit
+	}
+	if input.Limit > maxNamespaceHealthLimit {
+		input.Limit = maxNamespaceHealthLimit
+	}
+	if input.EventWindowMinutes < 1 {
+		input.EventWindowMinutes = defaultEventsSinceMinutes
+	}
+
+	now := time.Now()
+	inv, err := t.loadInventory(ctx, input, now)
+	if err != nil {
+		return nil, err
+	}
+	snapshots := namespaceSnapshots(inv)
+	cfg := t.k8sClient.HealthConfig()
+
+	var output NamespaceHealthOutput
+	if input.Namespace != "" {
+		output.Namespace = namespaceHealthDetail(snapshots[input.Namespace], inv, cfg)
+	} else {
+		output = rankNamespaces(snapshots, cfg, input)
+	}
+	output.Notes = inv.notes
+	output.Message = namespaceHealthMessage(&output)
+	return output, nil
+}
+
+// loadInventory lists the namespace objects once (cluster-wide in ranking
+// mode); events, quotas, PVCs and Services are optional
+func (t *NamespaceHealthTool) loadInventory(ctx context.Context, input NamespaceHealthInput, now time.Time) (*namespaceInventory, error) {
+	ns := input.Namespace
+	inv := &namespaceInventory{}
+
+	if ns != "" {
+		if _, err := t.k8sClient.GetNamespace(ctx, ns); err != nil {
+			return nil, err
+		}
+		inv.namespaces = []string{ns}
+	} else {
+		namespaces, err := t.k8sClient.ListNamespaces(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, n := range namespaces.Items {
+			inv.namespaces = append(inv.namespaces, n.Name)
+		}
+	}
+
+	workloadInput := WorkloadHealthInput{Namespace: ns, StuckThresholdMinutes: defaultRolloutStuckMinutes}
+	workloads, err := loadWorkloadInventory(ctx, t.k8sClient, workloadInput)
+	if err != nil {
+		return nil, err
+	}
+	inv.workloads = analyzeWorkloads(workloads, workloadInput, now).Workloads
+	inv.pods = workloads.pods
+
+	if pvcs, err := t.k8sClient.ListPersistentVolumeClaims(ctx, ns); err != nil {
+		inv.notes = append(inv.notes, fmt.Sprintf("PVC binding not checked: %v", err))
+	} else {
+		inv.pvcs = pvcs.Items
+		inv.pvcsObserved = true
+	}
+
+	if quotas, err := t.k8sClient.ListResourceQuotas(ctx, ns); err != nil {
+		inv.notes = append(inv.notes, fmt.Sprintf("Quota utilization not checked: %v", err))
+	} else {
+		inv.quotas = quotas.Items
+		inv.quotasObserved = true
+	}
+
+	services, err := t.k8sClient.ListServices(ctx, ns)
+	if err == nil {
+		var slices *discoveryv1.EndpointSliceList
+		if slices, err = t.k8sClient.ListEndpointSlices(ctx, ns); err == nil {
+			inv.services = services.Items
+			inv.slices = slices.Items
+			inv.servicesObserved = true
+		}
+	}
+	if err != nil {
+		inv.notes = append(inv.notes, fmt.Sprintf("Service endpoint readiness not checked: %v", err))
+	}
+
+	since := now.Add(-time.Duration(input.EventWindowMinutes) * time.Minute)
+	if events, err := t.k8sClient.ListEventTimeline(ctx, clients.EventFilter{Namespace: ns, Type: "Warning", Since: since}); err != nil {
+		inv.notes = append(inv.notes, fmt.Sprintf("Warning events not checked: %v", err))
+	} else {
+		inv.events = events
+		inv.eventsObserved = true
+	}
+
+	return inv, nil
+}
+
+// namespaceSnapshots groups the inventory into one snapshot per namespace
+func namespaceSnapshots(inv *namespaceInventory) map[string]*health.NamespaceSnapshot {
+	snapshots := make(map[string]*health.NamespaceSnapshot, len(inv.namespaces))
+	get := func(namespace string) *health.NamespaceSnapshot {
+		s, ok := snapshots[namespace]
+		if !ok {
+			s = &health.NamespaceSnapshot{
+				Namespace:        namespace,
+				PVCsObserved:     inv.pvcsObserved,
+				ServicesObserved: inv.servicesObserved,
+				QuotasObserved:   inv.quotasObserved,
+				EventsObserved:   inv.eventsObserved,
+			}
+			snapshots[namespace] = s
+		}
+		return s
+	}
+	for _, ns := range inv.namespaces {
+		get(ns)
+	}
+
+	for _, w := range inv.workloads {
+		state := health.WorkloadState{Kind: w.Kind, Name: w.Name, Status: w.Status, Available: w.Replicas.Available}
+		if len(w.Issues) > 0 {
+			state.Issue = w.Issues[0]
+		}
+		s := get(w.Namespace)
+		s.Workloads = append(s.Workloads, state)
+	}
+	for _, pod := range inv.pods {
+		s := get(pod.Namespace)
+		s.Pods = append(s.Pods, pod)
+	}
+	for _, pvc := range inv.pvcs {
+		s := get(pvc.Namespace)
+		s.PVCs = append(s.PVCs, pvc)
+	}
+	for namespace, states := range serviceStates(inv.services, inv.slices) {
+		get(namespace).Services = states
+	}
+	for _, quota := range inv.quotas {
+		s := get(quota.Namespace)
+		s.Quotas = append(s.Quotas, quotaUsages(&quota)...)
+	}
+	for namespace, events := range eventsByNamespace(inv.events) {
+		s := get(namespace)
+		for _, r := range clients.SummarizeEventReasons(events) {
+			s.WarningEvents = append(s.WarningEvents, health.EventReason{
+				Reason:  r.Reason,
+				Count:   r.Count,
+				Objects: r.Objects,
+				Message: r.LatestMessage,
+			})
+		}
+	}
+	return snapshots
+}
+
+// serviceStates counts ready and not-ready endpoints per Service with a
+// selector, grouped by namespace; Services without a selector manage their
+// own endpoints and are skipped
+func serviceStates(services []corev1.Service, slices []discoveryv1.EndpointSlice) map[string][]health.ServiceState {
+	endpoints := collectServiceEndpoints(slices)
+	states := make(map[string][]health.ServiceState)
+	for _, svc := range services {
+		if len(svc.Spec.Selector) == 0 || svc.Spec.Type == corev1.ServiceTypeExternalName {
+			continue
+		}
+		state := health.ServiceState{Name: svc.Name}
+		if e, ok := endpoints[svc.Namespace+"/"+svc.Name]; ok {
+			state.Ready, state.NotReady = e.ready, e.notReady
+		}
+		states[svc.Namespace] = append(states[svc.Namespace], state)
+	}
+	return states
+}
+
+// quotaUsages flattens a ResourceQuota into one usage per hard limit
+func quotaUsages(quota *corev1.ResourceQuota) []health.QuotaUsage {
+	names := make([]string, 0, len(quota.Status.Hard))
+	for name := range quota.Status.Hard {
+		names = append(names, string(name))
+	}
+	sort.Strings(names)
+
+	usages := make([]health.QuotaUsage, 0, len(names))
+	for _, name := range names {
+		hard := quota.Status.Hard[corev1.ResourceName(name)]
+		used := quota.Status.Used[corev1.ResourceName(name)]
+		usages = append(usages, health.QuotaUsage{
+			Quota:    quota.Name,
+			Resource: name,
+			Used:     used.AsApproximateFloat64(),
+			Hard:     hard.AsApproximateFloat64(),
+		})
+	}
+	return usages
+}
+
+// eventsByNamespace groups namespaced events
+func eventsByNamespace(events []clients.Event) map[string][]clients.Event {
+	grouped := make(map[string][]clients.Event)
+	for _, e := range events {
+		if e.Namespace != "" {
+			grouped[e.Namespace] = append(grouped[e.Namespace], e)
+		}
+	}
+	return grouped
+}
+
+// namespaceHealthDetail scores a namespace and adds the summaries behind the score
+func namespaceHealthDetail(snapshot *health.NamespaceSnapshot, inv *namespaceInventory, cfg health.Config) *NamespaceHealthDetail {
+	detail := &NamespaceHealthDetail{
+		NamespaceReport: health.EvaluateNamespace(snapshot, cfg),
+		Pods:            NamespacePodSummary{ByPhase: map[string]int{}},
+		RestartHotspots: health.RestartHotspots(snapshot.Pods),
+	}
+
+	for _, w := range snapshot.Workloads {
+		detail.Workloads.Total++
+		switch w.Status {
+		case WorkloadHealthy:
+			detail.Workloads.Healthy++
+		case WorkloadProgressing:
+			detail.Workloads.Progressing++
+		case WorkloadStuck:
+			detail.Workloads.Stuck++
+		case WorkloadDegraded:
+			detail.Workloads.Degraded++
+		}
+	}
+
+	for _, pod := range snapshot.Pods {
+		detail.Pods.Total++
+		detail.Pods.ByPhase[string(pod.Status.Phase)]++
+		statuses := append(append([]corev1.ContainerStatus{}, pod.Status.InitContainerStatuses...), pod.Status.ContainerStatuses...)
+		for _, cs := range statuses {
+			if cs.State.Waiting != nil && cs.State.Waiting.Reason != "" {
+				if detail.Pods.WaitingReasons == nil {
+					detail.Pods.WaitingReasons = map[string]int{}
+				}
+				detail.Pods.WaitingReasons[cs.State.Waiting.Reason]++
+			}
+		}
+	}
+
+	reasons := clients.SummarizeEventReasons(eventsByNamespace(inv.events)[snapshot.Namespace])
+	if len(reasons) > eventsTopReasons {
+		reasons = reasons[:eventsTopReasons]
+	}
+	detail.WarningEvents = reasons
+
+	for _, q := range snapshot.Quotas {
+		detail.Quotas = append(detail.Quotas, QuotaUtilization{QuotaUsage: q, Percent: math.Round(q.Ratio()*1000) / 10})
+	}
+
+	for _, pvc := range snapshot.PVCs {
+		detail.PVCs.Total++
+		switch pvc.Status.Phase {
+		case corev1.ClaimBound:
+			detail.PVCs.Bound++
+		case corev1.ClaimPending:
+			detail.PVCs.Pending++
+		case corev1.ClaimLost:
+			detail.PVCs.Lost++
+		}
+	}
+
+	for _, svc := range inv.services {
+		if svc.Namespace == snapshot.Namespace {
+			detail.Services.Total++
+		}
+	}
+	for _, svc := range snapshot.Services {
+		detail.Services.Selected++
+		switch {
+		case svc.Ready == 0:
+			detail.Services.NoReadyEndpoints = append(detail.Services.NoReadyEndpoints, svc.Name)
+		case svc.NotReady > 0:
+			detail.Services.PartiallyReady = append(detail.Services.PartiallyReady, svc.Name)
+		}
+	}
+	return detail
+}
+
+// rankNamespaces scores every namespace and orders them worst first
+func rankNamespaces(snapshots map[string]*health.NamespaceSnapshot, cfg health.Config, input NamespaceHealthInput) NamespaceHealthOutput {
+	summary := &NamespaceHealthSummary{}
+	ranked := []NamespaceHealthRank{}
+	for namespace, snapshot := range snapshots {
+		if !input.IncludeSystemNamespaces && (isSystemNamespace(namespace) || cfg.IsNamespaceIgnored(namespace)) {
+			continue
+		}
+		report := health.EvaluateNamespace(snapshot, cfg)
+		rank := NamespaceHealthRank{Namespace: namespace, Score: report.Score, Status: report.Status}
+		for _, f := range report.Findings {
+			switch f.Severity {
+			case health.SeverityCritical:
+				rank.Critical++
+			case health.SeverityWarning:
+				rank.Warnings++
+			}
+			if len(rank.TopFindings) < namespaceRankTopFindings {
+				rank.TopFindings = append(rank.TopFindings, f.Message)
+			}
+		}
+
+		summary.Evaluated++
+		switch report.Status {
+		case health.StatusHealthy:
+			summary.Healthy++
+		case health.StatusDegraded:
+			summary.Degraded++
+		case health.StatusUnhealthy:
+			summary.Unhealthy++
+		}
+		ranked = append(ranked, rank)
+	}
+
+	sort.Slice(ranked, func(i, j int) bool {
+		a, b := ranked[i], ranked[j]
+		if a.Score != b.Score {
+			return a.Score < b.Score
+		}
+		if a.Critical != b.Critical {
+			return a.Critical > b.Critical
+		}
+		if a.Warnings != b.Warnings {
+			return a.Warnings > b.Warnings
+		}
+		return a.Namespace < b.Namespace
+	})
+
+	output := NamespaceHealthOutput{Summary: summary}
+	if len(ranked) > input.Limit {
+		output.Omitted = len(ranked) - input.Limit
+		ranked = ranked[:input.Limit]
+	}
+	output.Namespaces = ranked
+	return output
+}
+
+// isSystemNamespace reports whether a namespace belongs to the platform
+func isSystemNamespace(namespace string) bool {
+	if namespace == "openshift" {
+		return true
+	}
+	for _, prefix := range systemNamespacePrefixes {
+		if strings.HasPrefix(namespace, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// namespaceHealthMessage summarizes the detail or the ranking
+func namespaceHealthMessage(output *NamespaceHealthOutput) string {
+	if d := output.Namespace; d != nil {
+		msg := fmt.Sprintf("Namespace %s is %s (score %.1f)", d.NamespaceReport.Namespace, d.Status, d.Score)
+		if len(d.Findings) > 0 {
+			msg += fmt.Sprintf(" with %d findings; top: %s", len(d.Findings), d.Findings[0].Message)
+		}
+		return msg
+	}
+
+	s := output.Summary
+	if s == nil || s.Evaluated == 0 {
+		return "No namespaces to evaluate"
+	}
+	msg := fmt.Sprintf("Evaluated %d namespaces: %d healthy, %d degraded, %d unhealthy",
+		s.Evaluated, s.Healthy, s.Degraded, s.Unhealthy)
+	if worst := output.Namespaces[0]; worst.Status != health.StatusHealthy {
+		msg += fmt.Sprintf(". Worst: %s (score %.1f, %s)", worst.Namespace, worst.Score, worst.Status)
+	}
+	return msg
+}
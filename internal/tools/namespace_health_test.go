@@ -0,0 +1,128 @@
+package tools
+
+import (
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/KubeHeal/openshift-cluster-health-mcp/pkg/clients"
+	"github.com/KubeHeal/openshift-cluster-health-mcp/pkg/health"
+)
+
+func selectedService(namespace, name string) corev1.Service {
+	return corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+		Spec:       corev1.ServiceSpec{Selector: map[string]string{"app": name}},
+	}
+}
+
+func endpointSlice(namespace, service string, ready ...bool) discoveryv1.EndpointSlice {
+	slice := discoveryv1.EndpointSlice{ObjectMeta: metav1.ObjectMeta{
+		Namespace: namespace,
+		Name:      service + "-abc",
+		Labels:    map[string]string{discoveryv1.LabelServiceName: service},
+	}}
+	for _, r := range ready {
+		r := r
+		slice.Endpoints = append(slice.Endpoints, discoveryv1.Endpoint{Conditions: discoveryv1.EndpointConditions{Ready: &r}})
+	}
+	return slice
+}
+
+func TestNamespaceHealthTool_Metadata(t *testing.T) {
+	tool := NewNamespaceHealthTool(nil)
+	if tool.Name() != "get-namespace-health" {
+		t.Errorf("unexpected name %s", tool.Name())
+	}
+	props := tool.InputSchema()["properties"].(map[string]interface{})
+	for _, key := range []string{"namespace", "limit", "include_system_namespaces", "event_window_minutes"} {
+		if _, ok := props[key]; !ok {
+			t.Errorf("schema missing %s", key)
+		}
+	}
+}
+
+func TestServiceStates(t *testing.T) {
+	external := selectedService("app", "external")
+	external.Spec.Type = corev1.ServiceTypeExternalName
+	headless := corev1.Service{ObjectMeta: metav1.ObjectMeta{Namespace: "app", Name: "manual"}}
+
+	states := serviceStates(
+		[]corev1.Service{selectedService("app", "api"), selectedService("app", "worker"), external, headless},
+		[]discoveryv1.EndpointSlice{endpointSlice("app", "api", true, false), endpointSlice("app", "api", true)},
+	)
+	app := states["app"]
+	if len(app) != 2 {
+		t.Fatalf("states = %+v, want only services with a selector", app)
+	}
+	if app[0].Ready != 2 || app[0].NotReady != 1 {
+		t.Errorf("api = %+v, want endpoints summed across slices", app[0])
+	}
+	if app[1].Ready != 0 || app[1].NotReady != 0 {
+		t.Errorf("worker = %+v, want no endpoints", app[1])
+	}
+}
+
+func TestQuotaUsages(t *testing.T) {
+	quota := &corev1.ResourceQuota{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "app", Name: "compute"},
+		Status: corev1.ResourceQuotaStatus{
+			Hard: corev1.ResourceList{corev1.ResourcePods: resource.MustParse("10"), corev1.ResourceRequestsCPU: resource.MustParse("4")},
+			Used: corev1.ResourceList{corev1.ResourcePods: resource.MustParse("9"), corev1.ResourceRequestsCPU: resource.MustParse("500m")},
+		},
+	}
+	usages := quotaUsages(quota)
+	if len(usages) != 2 || usages[0].Resource != "pods" || usages[1].Used != 0.5 {
+		t.Errorf("usages = %+v", usages)
+	}
+}
+
+func TestNamespaceSnapshotsAndRanking(t *testing.T) {
+	now := time.Now()
+	crashing := workloadPod("api-1", nil, nil, false, "CrashLoopBackOff", now.Add(-time.Hour))
+	crashing.Namespace = "broken"
+	inv := &namespaceInventory{
+		namespaces:       []string{"broken", "fine", "openshift-monitoring"},
+		workloads:        []WorkloadHealth{{Kind: KindDeployment, Name: "api", Namespace: "broken", Status: WorkloadDegraded}},
+		pods:             []corev1.Pod{crashing},
+		services:         []corev1.Service{selectedService("broken", "api")},
+		events:           []clients.Event{{Namespace: "broken", Reason: "BackOff", Count: 12, LastSeen: now}},
+		servicesObserved: true,
+		quotasObserved:   true,
+		pvcsObserved:     true,
+		eventsObserved:   true,
+	}
+	snapshots := namespaceSnapshots(inv)
+	if len(snapshots) != 3 || len(snapshots["broken"].WarningEvents) != 1 || len(snapshots["fine"].Pods) != 0 {
+		t.Fatalf("snapshots = %+v", snapshots)
+	}
+
+	output := rankNamespaces(snapshots, health.DefaultConfig(), NamespaceHealthInput{Limit: 1})
+	if output.Summary.Evaluated != 2 || output.Omitted != 1 {
+		t.Errorf("summary = %+v omitted = %d, want system namespaces skipped", output.Summary, output.Omitted)
+	}
+	worst := output.Namespaces[0]
+	if worst.Namespace != "broken" || worst.Status == health.StatusHealthy || worst.Critical == 0 || len(worst.TopFindings) != namespaceRankTopFindings {
+		t.Errorf("worst = %+v", worst)
+	}
+	if msg := namespaceHealthMessage(&output); !contains(msg, "Worst: broken") {
+		t.Errorf("message = %q", msg)
+	}
+
+	all := rankNamespaces(snapshots, health.DefaultConfig(), NamespaceHealthInput{Limit: 10, IncludeSystemNamespaces: true})
+	if all.Summary.Evaluated != 3 {
+		t.Errorf("evaluated = %d, want system namespaces included", all.Summary.Evaluated)
+	}
+
+	detail := namespaceHealthDetail(snapshots["broken"], inv, health.DefaultConfig())
+	if detail.Pods.ByPhase["Running"] != 1 || detail.Pods.WaitingReasons["CrashLoopBackOff"] != 1 {
+		t.Errorf("pods = %+v", detail.Pods)
+	}
+	if detail.Workloads.Degraded != 1 || len(detail.Services.NoReadyEndpoints) != 1 || len(detail.WarningEvents) != 1 {
+		t.Errorf("detail = %+v", detail)
+	}
+}
@@ -0,0 +1,262 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/KubeHeal/openshift-cluster-health-mcp/pkg/capacity"
+	"github.com/KubeHeal/openshift-cluster-health-mcp/pkg/clients"
+	"github.com/KubeHeal/openshift-cluster-health-mcp/pkg/scheduling"
+)
+
+// Drain verdicts
+const (
+	DrainReady          = "ready"
+	DrainNeedsAttention = "needs_attention"
+	DrainBlocked        = "blocked"
+)
+
+// NodeDrainTool previews the impact of draining a node via MCP; it never
+// cordons or evicts anything
+type NodeDrainTool struct {
+	k8sClient *clients.K8sClient
+}
+
+// NewNodeDrainTool creates a new preview-node-drain tool
+func NewNodeDrainTool(k8sClient *clients.K8sClient) *NodeDrainTool {
+	return &NodeDrainTool{
+		k8sClient: k8sClient,
+	}
+}
+
+// Name returns the tool name for MCP registration
+func (t *NodeDrainTool) Name() string {
+	return "preview-node-drain"
+}
+
+// Description returns the tool description for MCP
+func (t *NodeDrainTool) Description() string {
+	return "Preview draining a node (read-only): lists pods that would be evicted, DaemonSet and static pods that would be skipped, pods with emptyDir data that would be lost and unmanaged pods that would not be recreated, PodDisruptionBudgets that would block eviction, and whether the evicted pods fit on the remaining schedulable nodes"
+}
+
+// InputSchema returns the JSON schema for tool inputs
+func (t *NodeDrainTool) InputSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"node_name": map[string]interface{}{
+				"type":        "string",
+				"description": "Node to preview draining",
+			},
+		},
+		"required": []string{"node_name"},
+	}
+}
+
+// NodeDrainInput represents the input parameters
+type NodeDrainInput struct {
+	NodeName string `json:"node_name"`
+}
+
+// DrainPod is a pod affected by the drain
+type DrainPod struct {
+	Namespace string               `json:"namespace"`
+	Name      string               `json:"name"`
+	Workload  string               `json:"workload,omitempty"`
+	Requests  scheduling.Resources `json:"requests"`
+	Note      string               `json:"note,omitempty"`
+}
+
+// NodeDrainOutput represents the tool output
+type NodeDrainOutput struct {
+	Node             string                      `json:"node"`
+	Cordoned         bool                        `json:"cordoned"`
+	Verdict          string                      `json:"verdict"`
+	EvictedPods      []DrainPod                  `json:"evicted_pods"`
+	DaemonSetPods    []DrainPod                  `json:"daemonset_pods_skipped"`
+	StaticPods       []DrainPod                  `json:"static_pods_skipped"`
+	LocalStoragePods []DrainPod                  `json:"local_storage_pods"`
+	UnmanagedPods    []DrainPod                  `json:"unmanaged_pods"`
+	BlockingPDBs     []capacity.PDBBlock         `json:"blocking_pdbs"`
+	Reschedule       *capacity.FailureSimulation `json:"reschedule"`
+	DrainFlags       []string                    `json:"required_drain_flags"`
+	Warnings         []string                    `json:"warnings,omitempty"`
+	Message          string                      `json:"message"`
+}
+
+// Execute previews the drain
+func (t *NodeDrainTool) Execute(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+	var input NodeDrainInput
+	if argsJSON, err := json.Marshal(args); err == nil {
+		_ = json.Unmarshal(argsJSON, &input) //nolint:errcheck // Intentionally ignore error, use defaults if unmarshal fails
+	}
+	if input.NodeName == "" {
+		return nil, fmt.Errorf("node_name is required")
+	}
+
+	nodes, err := t.k8sClient.ListNodes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	found := false
+	for _, node := range nodes.Items {
+		if node.Name == input.NodeName {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return nil, fmt.Errorf("node %s not found", input.NodeName)
+	}
+	pods, err := t.k8sClient.ListPods(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+
+	var warnings []string
+	var pdbs []policyv1.PodDisruptionBudget
+	if list, err := t.k8sClient.ListPodDisruptionBudgets(ctx, ""); err != nil {
+		warnings = append(warnings, fmt.Sprintf("PodDisruptionBudgets not checked: %v", err))
+	} else {
+		pdbs = list.Items
+	}
+	volumes := scheduling.NewSimulator(nil)
+	loadVolumeConstraints(ctx, t.k8sClient, volumes, "")
+
+	output := previewNodeDrain(input.NodeName, nodes.Items, pods.Items, pdbs, volumes.VolumeNodeAffinity)
+	output.Warnings = warnings
+	output.Message = nodeDrainMessage(&output)
+	return output, nil
+}
+
+// previewNodeDrain classifies the pods on a node the way kubectl drain does
+// and simulates rescheduling the pods that controllers will recreate
+func previewNodeDrain(nodeName string, nodes []corev1.Node, pods []corev1.Pod, pdbs []policyv1.PodDisruptionBudget, volumeAffinity map[string]*corev1.VolumeNodeAffinity) NodeDrainOutput {
+	output := NodeDrainOutput{
+		Node:             nodeName,
+		EvictedPods:      []DrainPod{},
+		DaemonSetPods:    []DrainPod{},
+		StaticPods:       []DrainPod{},
+		LocalStoragePods: []DrainPod{},
+		UnmanagedPods:    []DrainPod{},
+		DrainFlags:       []string{},
+	}
+	for _, node := range nodes {
+		if node.Name == nodeName {
+			output.Cordoned = node.Spec.Unschedulable
+		}
+	}
+
+	// Unmanaged pods are deleted, not recreated, so they do not need room elsewhere
+	var evicted []*corev1.Pod
+	unmanaged := make(map[string]bool)
+	for i := range pods {
+		pod := &pods[i]
+		if pod.Spec.NodeName != nodeName || scheduling.IsTerminal(pod) {
+			continue
+		}
+		entry := DrainPod{
+			Namespace: pod.Namespace,
+			Name:      pod.Name,
+			Workload:  capacity.WorkloadOf(pod).String(),
+			Requests:  scheduling.PodRequests(pod),
+		}
+		if _, ok := pod.Annotations[corev1.MirrorPodAnnotationKey]; ok {
+			entry.Note = "static pod managed by the kubelet; not evicted"
+			output.StaticPods = append(output.StaticPods, entry)
+			continue
+		}
+		if capacity.IsDaemonSetPod(pod) {
+			entry.Note = "DaemonSet pod; skipped by drain and stays on the node"
+			output.DaemonSetPods = append(output.DaemonSetPods, entry)
+			continue
+		}
+		if volumes := emptyDirVolumes(pod); len(volumes) > 0 {
+			local := entry
+			local.Note = fmt.Sprintf("emptyDir data in %s is deleted on eviction", strings.Join(volumes, ", "))
+			output.LocalStoragePods = append(output.LocalStoragePods, local)
+		}
+		if metav1.GetControllerOf(pod) == nil {
+			bare := entry
+			bare.Note = "no controller; the pod is deleted and not recreated"
+			output.UnmanagedPods = append(output.UnmanagedPods, bare)
+			unmanaged[pod.Namespace+"/"+pod.Name] = true
+		}
+		output.EvictedPods = append(output.EvictedPods, entry)
+		evicted = append(evicted, pod)
+	}
+
+	output.BlockingPDBs = capacity.BlockingPDBs(pdbs, evicted)
+	if output.BlockingPDBs == nil {
+		output.BlockingPDBs = []capacity.PDBBlock{}
+	}
+
+	recreated := make([]corev1.Pod, 0, len(pods))
+	for _, pod := range pods {
+		if !unmanaged[pod.Namespace+"/"+pod.Name] {
+			recreated = append(recreated, pod)
+		}
+	}
+	output.Reschedule = capacity.SimulateFailure(nodes, recreated, map[string]bool{nodeName: true}, volumeAffinity)
+
+	if len(output.DaemonSetPods) > 0 {
+		output.DrainFlags = append(output.DrainFlags, "--ignore-daemonsets")
+	}
+	if len(output.LocalStoragePods) > 0 {
+		output.DrainFlags = append(output.DrainFlags, "--delete-emptydir-data")
+	}
+	if len(output.UnmanagedPods) > 0 {
+		output.DrainFlags = append(output.DrainFlags, "--force")
+	}
+
+	switch {
+	case len(output.BlockingPDBs) > 0 || !output.Reschedule.Survives:
+		output.Verdict = DrainBlocked
+	case len(output.LocalStoragePods) > 0 || len(output.UnmanagedPods) > 0:
+		output.Verdict = DrainNeedsAttention
+	default:
+		output.Verdict = DrainReady
+	}
+	return output
+}
+
+// emptyDirVolumes returns the names of a pod's emptyDir volumes
+func emptyDirVolumes(pod *corev1.Pod) []string {
+	var names []string
+	for _, v := range pod.Spec.Volumes {
+		if v.EmptyDir != nil {
+			names = append(names, v.Name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// nodeDrainMessage summarizes the preview
+func nodeDrainMessage(output *NodeDrainOutput) string {
+	msg := fmt.Sprintf("Draining %s (%s): %d pods evicted, %d DaemonSet and %d static pods skipped.",
+		output.Node, output.Verdict, len(output.EvictedPods), len(output.DaemonSetPods), len(output.StaticPods))
+	if n := len(output.BlockingPDBs); n > 0 {
+		msg += fmt.Sprintf(" %d PodDisruptionBudgets would block eviction.", n)
+	}
+	if n := len(output.Reschedule.Unschedulable); n > 0 {
+		msg += fmt.Sprintf(" %d evicted pods would not fit on the remaining schedulable nodes.", n)
+	}
+	if n := len(output.LocalStoragePods); n > 0 {
+		msg += fmt.Sprintf(" %d pods lose emptyDir data.", n)
+	}
+	if n := len(output.UnmanagedPods); n > 0 {
+		msg += fmt.Sprintf(" %d unmanaged pods will not be recreated.", n)
+	}
+	if len(output.DrainFlags) > 0 {
+		msg += fmt.Sprintf(" Requires: oc adm drain %s %s.", output.Node, strings.Join(output.DrainFlags, " "))
+	}
+	return msg
+}
@@ -0,0 +1,74 @@
+package tools
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestPreviewNodeDrain(t *testing.T) {
+	nodes := []corev1.Node{schedulingNode("a", "4", "16Gi"), schedulingNode("b", "4", "16Gi")}
+	controller := true
+
+	daemon := runningPod("agent-a", "a", "100m", "")
+	daemon.OwnerReferences = []metav1.OwnerReference{{Kind: "DaemonSet", Name: "agent", Controller: &controller}}
+	static := runningPod("etcd-a", "a", "100m", "")
+	static.Annotations = map[string]string{corev1.MirrorPodAnnotationKey: "hash"}
+	cache := runningPod("cache-0", "a", "500m", "cache")
+	cache.Spec.Volumes = []corev1.Volume{{Name: "scratch", VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}}}
+	bare := runningPod("debug", "a", "3", "")
+	web := runningPod("web-0", "a", "500m", "web")
+	elsewhere := runningPod("web-1", "b", "500m", "web")
+
+	pods := []corev1.Pod{daemon, static, cache, bare, web, elsewhere}
+	output := previewNodeDrain("a", nodes, pods, nil, nil)
+
+	if len(output.EvictedPods) != 3 {
+		t.Errorf("expected 3 evicted pods, got %d", len(output.EvictedPods))
+	}
+	if len(output.DaemonSetPods) != 1 || len(output.StaticPods) != 1 {
+		t.Errorf("expected 1 DaemonSet and 1 static pod skipped, got %d and %d", len(output.DaemonSetPods), len(output.StaticPods))
+	}
+	if len(output.LocalStoragePods) != 1 || !contains(output.LocalStoragePods[0].Note, "scratch") {
+		t.Errorf("expected cache-0 with emptyDir, got %+v", output.LocalStoragePods)
+	}
+	if len(output.UnmanagedPods) != 1 || output.UnmanagedPods[0].Name != "debug" {
+		t.Errorf("expected debug as unmanaged, got %+v", output.UnmanagedPods)
+	}
+	// The 3-core unmanaged pod is not recreated, so the rest fit on node b
+	if !output.Reschedule.Survives || output.Reschedule.DisplacedPods != 2 {
+		t.Errorf("expected 2 recreated pods to fit, got %+v", output.Reschedule)
+	}
+	if output.Verdict != DrainNeedsAttention {
+		t.Errorf("expected needs_attention verdict, got %s", output.Verdict)
+	}
+	if len(output.DrainFlags) != 3 {
+		t.Errorf("expected all three drain flags, got %v", output.DrainFlags)
+	}
+	if msg := nodeDrainMessage(&output); !contains(msg, "--delete-emptydir-data") {
+		t.Errorf("unexpected message: %s", msg)
+	}
+
+	pdbs := []policyv1.PodDisruptionBudget{{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "app"},
+		Spec:       policyv1.PodDisruptionBudgetSpec{Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}}},
+		Status:     policyv1.PodDisruptionBudgetStatus{DisruptionsAllowed: 0, CurrentHealthy: 1, DesiredHealthy: 2, ExpectedPods: 2},
+	}}
+	output = previewNodeDrain("a", nodes, pods, pdbs, nil)
+	if output.Verdict != DrainBlocked || len(output.BlockingPDBs) != 1 {
+		t.Errorf("expected drain blocked by PDB, got %s with %d blocks", output.Verdict, len(output.BlockingPDBs))
+	}
+}
+
+func TestEmptyDirVolumes(t *testing.T) {
+	pod := &corev1.Pod{Spec: corev1.PodSpec{Volumes: []corev1.Volume{
+		{Name: "z", VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}},
+		{Name: "cfg", VolumeSource: corev1.VolumeSource{ConfigMap: &corev1.ConfigMapVolumeSource{}}},
+		{Name: "a", VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{Medium: corev1.StorageMediumMemory}}},
+	}}}
+	if got := emptyDirVolumes(pod); len(got) != 2 || got[0] != "a" {
+		t.Errorf("expected [a z], got %v", got)
+	}
+}
@@ -0,0 +1,416 @@
+package tools
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/KubeHeal/openshift-cluster-health-mcp/pkg/clients"
+	"github.com/KubeHeal/openshift-cluster-health-mcp/pkg/redact"
+)
+
+const (
+	defaultLogTailLines = 100
+	maxLogTailLines     = 5000
+	defaultLogMaxBytes  = 64 * 1024
+	maxLogMaxBytes      = 1024 * 1024
+	defaultLogContext   = 3
+	maxLogHighlights    = 20
+
+	// defaultContainerAnnotation selects the default container like `oc logs`
+	defaultContainerAnnotation = "kubectl.kubernetes.io/default-container"
+)
+
+// errorLinePattern matches log lines that indicate errors, exceptions or panics
+var errorLinePattern = regexp.MustCompile(`(?i)(\b(error|exception|panic|fatal|traceback|segmentation fault|oomkilled|caused by)\b|level=(error|fatal)|"level":"(error|fatal)"|^E\d{4} )`)
+
+// PodLogsTool retrieves container logs via MCP
+type PodLogsTool struct {
+	k8sClient *clients.K8sClient
+}
+
+// NewPodLogsTool creates a new get-pod-logs tool
+func NewPodLogsTool(k8sClient *clients.K8sClient) *PodLogsTool {
+	return &PodLogsTool{
+		k8sClient: k8sClient,
+	}
+}
+
+// Name returns the tool name for MCP registration
+func (t *PodLogsTool) Name() string {
+	return "get-pod-logs"
+}
+
+// Description returns the tool description for MCP
+func (t *PodLogsTool) Description() string {
+	return "Retrieve pod container logs (optionally from the previous, crashed container) with error/exception/panic lines highlighted in context. Secrets such as tokens and passwords are redacted."
+}
+
+// InputSchema returns the JSON schema for tool inputs
+func (t *PodLogsTool) InputSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"pod_name": map[string]interface{}{
+				"type":        "string",
+				"description": "Name of the pod",
+			},
+			"namespace": map[string]interface{}{
+				"type":        "string",
+				"description": "Namespace of the pod",
+			},
+			"container": map[string]interface{}{
+				"type":        "string",
+				"description": "Container name (defaults to the pod's default container, or the first container)",
+				"default":     "",
+			},
+			"tail_lines": map[string]interface{}{
+				"type":        "integer",
+				"description": "Number of lines from the end of the log to return",
+				"default":     defaultLogTailLines,
+				"minimum":     1,
+				"maximum":     maxLogTailLines,
+			},
+			"since_seconds": map[string]interface{}{
+				"type":        "integer",
+				"description": "Only return logs newer than this many seconds (0 = no limit)",
+				"default":     0,
+				"minimum":     0,
+			},
+			"previous": map[string]interface{}{
+				"type":        "boolean",
+				"description": "Return logs of the previous terminated container instance (use for CrashLoopBackOff)",
+				"default":     false,
+			},
+			"timestamps": map[string]interface{}{
+				"type":        "boolean",
+				"description": "Prefix each line with its timestamp",
+				"default":     false,
+			},
+			"max_bytes": map[string]interface{}{
+				"type":        "integer",
+				"description": "Maximum bytes of log text to return; the newest lines are kept",
+				"default":     defaultLogMaxBytes,
+				"minimum":     1024,
+				"maximum":     maxLogMaxBytes,
+			},
+			"context_lines": map[string]interface{}{
+				"type":        "integer",
+				"description": "Lines of context around each highlighted error line",
+				"default":     defaultLogContext,
+				"minimum":     0,
+				"maximum":     20,
+			},
+		},
+		"required": []string{"pod_name", "namespace"},
+	}
+}
+
+// PodLogsInput represents the input parameters
+type PodLogsInput struct {
+	PodName      string `json:"pod_name"`
+	Namespace    string `json:"namespace"`
+	Container    string `json:"container"`
+	TailLines    int64  `json:"tail_lines"`
+	SinceSeconds int64  `json:"since_seconds"`
+	Previous     bool   `json:"previous"`
+	Timestamps   bool   `json:"timestamps"`
+	MaxBytes     int    `json:"max_bytes"`
+	ContextLines int    `json:"context_lines"`
+}
+
+// LogHighlight is a block of log lines around one or more error lines
+type LogHighlight struct {
+	StartLine  int      `json:"start_line"` // 1-based line number in the returned logs
+	EndLine    int      `json:"end_line"`
+	MatchLines []int    `json:"match_lines"`
+	Lines      []string `json:"lines"`
+}
+
+// PodLogsOutput represents the tool output
+type PodLogsOutput struct {
+	Pod        string         `json:"pod"`
+	Namespace  string         `json:"namespace"`
+	Container  string         `json:"container"`
+	Containers []string       `json:"containers"`
+	Previous   bool           `json:"previous"`
+	LineCount  int            `json:"line_count"`
+	Bytes      int            `json:"bytes"`
+	Truncated  bool           `json:"truncated"`
+	Redactions int            `json:"redactions"`
+	ErrorLines int            `json:"error_lines"`
+	Highlights []LogHighlight `json:"highlights,omitempty"`
+	Logs       string         `json:"logs"`
+	Message    string         `json:"message"`
+}
+
+// Execute retrieves and post-processes the logs
+func (t *PodLogsTool) Execute(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+	input, err := t.parseInput(args)
+	if err != nil {
+		return nil, err
+	}
+
+	pod, err := t.k8sClient.GetPod(ctx, input.Namespace, input.PodName)
+	if err != nil {
+		return nil, err
+	}
+
+	container, containers, err := selectContainer(pod, input.Container)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.Previous && !hasPreviousInstance(pod, container) {
+		return nil, fmt.Errorf("container %s in pod %s/%s has no previous terminated instance (restart count is 0)", container, input.Namespace, input.PodName)
+	}
+
+	opts := &corev1.PodLogOptions{
+		Container:  container,
+		TailLines:  &input.TailLines,
+		Previous:   input.Previous,
+		Timestamps: input.Timestamps,
+	}
+	if input.SinceSeconds > 0 {
+		opts.SinceSeconds = &input.SinceSeconds
+	}
+
+	stream, err := t.k8sClient.StreamPodLogs(ctx, input.Namespace, input.PodName, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = stream.Close() //nolint:errcheck // Best-effort close of a read-only stream
+	}()
+
+	// Redact before truncating so a cut inside a secret cannot expose part of it
+	redacted := redact.NewReader(stream)
+	raw, truncated, err := readTail(redacted, input.MaxBytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read logs: %w", err)
+	}
+
+	logs, redactions := string(raw), redacted.Count()
+	lines := splitLogLines(logs)
+	highlights, errorLines := highlightErrors(lines, input.ContextLines, maxLogHighlights)
+
+	output := PodLogsOutput{
+		Pod:        input.PodName,
+		Namespace:  input.Namespace,
+		Container:  container,
+		Containers: containers,
+		Previous:   input.Previous,
+		LineCount:  len(lines),
+		Bytes:      len(logs),
+		Truncated:  truncated,
+		Redactions: redactions,
+		ErrorLines: errorLines,
+		Highlights: highlights,
+		Logs:       logs,
+	}
+	output.Message = podLogsMessage(&output)
+	return output, nil
+}
+
+// parseInput validates arguments and applies defaults and bounds
+func (t *PodLogsTool) parseInput(args map[string]interface{}) (PodLogsInput, error) {
+	input := PodLogsInput{
+		TailLines:    defaultLogTailLines,
+		MaxBytes:     defaultLogMaxBytes,
+		ContextLines: defaultLogContext,
+	}
+	if argsJSON, err := json.Marshal(args); err == nil {
+		_ = json.Unmarshal(argsJSON, &input) //nolint:errcheck // Intentionally ignore error, use defaults if unmarshal fails
+	}
+
+	if input.PodName == "" {
+		return input, fmt.Errorf("pod_name is required")
+	}
+	if input.Namespace == "" {
+		return input, fmt.Errorf("namespace is required")
+	}
+	if input.TailLines < 1 {
+		return input, fmt.Errorf("tail_lines must be at least 1, got %d", input.TailLines)
+	}
+	if input.TailLines > maxLogTailLines {
+		input.TailLines = maxLogTailLines
+	}
+	if input.SinceSeconds < 0 {
+		return input, fmt.Errorf("since_seconds must not be negative, got %d", input.SinceSeconds)
+	}
+	if input.MaxBytes < 1024 {
+		input.MaxBytes = 1024
+	}
+	if input.MaxBytes > maxLogMaxBytes {
+		input.MaxBytes = maxLogMaxBytes
+	}
+	if input.ContextLines < 0 {
+		input.ContextLines = 0
+	}
+	if input.ContextLines > 20 {
+		input.ContextLines = 20
+	}
+	return input, nil
+}
+
+// selectContainer resolves the requested container and lists all containers
+// (init containers included, since their failures block pod startup)
+func selectContainer(pod *corev1.Pod, requested string) (string, []string, error) {
+	var containers []string
+	for _, c := range pod.Spec.InitContainers {
+		containers = append(containers, c.Name)
+	}
+	for _, c := range pod.Spec.Containers {
+		containers = append(containers, c.Name)
+	}
+
+	if requested != "" {
+		for _, name := range containers {
+			if name == requested {
+				return requested, containers, nil
+			}
+		}
+		return "", containers, fmt.Errorf("container %q not found in pod %s/%s (available: %s)",
+			requested, pod.Namespace, pod.Name, strings.Join(containers, ", "))
+	}
+
+	if name := pod.Annotations[defaultContainerAnnotation]; name != "" {
+		for _, c := range pod.Spec.Containers {
+			if c.Name == name {
+				return name, containers, nil
+			}
+		}
+	}
+	if len(pod.Spec.Containers) == 0 {
+		return "", containers, fmt.Errorf("pod %s/%s has no containers", pod.Namespace, pod.Name)
+	}
+	return pod.Spec.Containers[0].Name, containers, nil
+}
+
+// hasPreviousInstance reports whether a container has terminated at least once
+func hasPreviousInstance(pod *corev1.Pod, container string) bool {
+	statuses := append(append([]corev1.ContainerStatus{}, pod.Status.InitContainerStatuses...), pod.Status.ContainerStatuses...)
+	for _, cs := range statuses {
+		if cs.Name == container {
+			return cs.RestartCount > 0 || cs.LastTerminationState.Terminated != nil
+		}
+	}
+	// Unknown status: let the API server decide
+	return true
+}
+
+// readTail reads the stream keeping only the last maxBytes bytes, starting
+// at a line boundary, and reports whether earlier output was dropped
+func readTail(r io.Reader, maxBytes int) ([]byte, bool, error) {
+	var buf bytes.Buffer
+	truncated := false
+	chunk := make([]byte, 32*1024)
+
+	for {
+		n, err := r.Read(chunk)
+		if n > 0 {
+			buf.Write(chunk[:n])
+			if buf.Len() > 2*maxBytes {
+				keep := buf.Bytes()[buf.Len()-maxBytes:]
+				buf = *bytes.NewBuffer(append([]byte{}, keep...))
+				truncated = true
+			}
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, truncated, err
+		}
+	}
+
+	data := buf.Bytes()
+	if len(data) > maxBytes {
+		data = data[len(data)-maxBytes:]
+		truncated = true
+	}
+	// Drop the partial first line left by truncation
+	if truncated {
+		if i := bytes.IndexByte(data, '\n'); i >= 0 && i < len(data)-1 {
+			data = data[i+1:]
+		}
+	}
+	return data, truncated, nil
+}
+
+// splitLogLines splits logs into lines without a trailing empty line
+func splitLogLines(logs string) []string {
+	logs = strings.TrimRight(logs, "\n")
+	if logs == "" {
+		return []string{}
+	}
+	return strings.Split(logs, "\n")
+}
+
+// highlightErrors finds error lines and merges their context windows into
+// highlight blocks, returning at most maxBlocks blocks (newest kept) and the
+// total number of error lines
+func highlightErrors(lines []string, contextLines, maxBlocks int) ([]LogHighlight, int) {
+	var highlights []LogHighlight
+	errorLines := 0
+
+	for i, line := range lines {
+		if !errorLinePattern.MatchString(line) {
+			continue
+		}
+		errorLines++
+
+		start := max(0, i-contextLines)
+		end := min(len(lines)-1, i+contextLines)
+
+		// Merge with the previous block when windows overlap or touch
+		if n := len(highlights); n > 0 && start <= highlights[n-1].EndLine {
+			last := &highlights[n-1]
+			for j := last.EndLine; j <= end; j++ {
+				last.Lines = append(last.Lines, lines[j])
+			}
+			last.EndLine = max(last.EndLine, end+1)
+			last.MatchLines = append(last.MatchLines, i+1)
+			continue
+		}
+
+		highlights = append(highlights, LogHighlight{
+			StartLine:  start + 1,
+			EndLine:    end + 1,
+			MatchLines: []int{i + 1},
+			Lines:      append([]string{}, lines[start:end+1]...),
+		})
+	}
+
+	if len(highlights) > maxBlocks {
+		highlights = highlights[len(highlights)-maxBlocks:]
+	}
+	return highlights, errorLines
+}
+
+// podLogsMessage summarizes the retrieved logs
+func podLogsMessage(output *PodLogsOutput) string {
+	instance := "current"
+	if output.Previous {
+		instance = "previous"
+	}
+	msg := fmt.Sprintf("%d lines from %s container %s", output.LineCount, instance, output.Container)
+	if output.ErrorLines > 0 {
+		msg += fmt.Sprintf("; %d error lines in %d highlighted blocks", output.ErrorLines, len(output.Highlights))
+	} else {
+		msg += "; no error lines found"
+	}
+	if output.Truncated {
+		msg += "; output truncated to the newest lines"
+	}
+	if output.Redactions > 0 {
+		msg += fmt.Sprintf("; %d secrets redacted", output.Redactions)
+	}
+	return msg
+}
@@ -0,0 +1,120 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/KubeHeal/openshift-cluster-health-mcp/pkg/capacity"
+	"github.com/KubeHeal/openshift-cluster-health-mcp/pkg/clients"
+)
+
+// PodProfilesConfigMapKey is the ConfigMap key holding pod profile definitions
+const PodProfilesConfigMapKey = "profiles.yaml"
+
+// PodProfileCatalog resolves pod profiles: the builtin small/medium/large,
+// operator-defined profiles from a file and/or ConfigMap, and profiles
+// derived from a Deployment's pod template. Definitions are re-read on every
+// lookup so edits to a mounted file or the ConfigMap apply without a restart;
+// ConfigMap entries override file entries with the same name.
+type PodProfileCatalog struct {
+	k8sClient *clients.K8sClient
+	file      string
+	configMap string // namespace/name
+}
+
+// NewPodProfileCatalog creates a catalog; file and configMap may be empty
+func NewPodProfileCatalog(k8sClient *clients.K8sClient, file, configMap string) *PodProfileCatalog {
+	return &PodProfileCatalog{k8sClient: k8sClient, file: file, configMap: configMap}
+}
+
+// Definitions returns the operator-defined profiles
+func (c *PodProfileCatalog) Definitions(ctx context.Context) (map[string]capacity.ProfileDefinition, error) {
+	defs := make(map[string]capacity.ProfileDefinition)
+	if c == nil {
+		return defs, nil
+	}
+	if c.file != "" {
+		data, err := os.ReadFile(c.file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read pod profiles file: %w", err)
+		}
+		parsed, err := capacity.ParseProfileDefinitions(data)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", c.file, err)
+		}
+		for name, def := range parsed {
+			defs[name] = def
+		}
+	}
+	if c.configMap != "" && c.k8sClient != nil {
+		namespace, name, err := capacity.SplitNamespacedName(c.configMap)
+		if err != nil {
+			return nil, fmt.Errorf("pod profiles configmap: %w", err)
+		}
+		cm, err := c.k8sClient.GetConfigMap(ctx, namespace, name)
+		if err != nil {
+			return nil, err
+		}
+		parsed, err := capacity.ParseProfileDefinitions([]byte(cm.Data[PodProfilesConfigMapKey]))
+		if err != nil {
+			return nil, fmt.Errorf("configmap %s key %s: %w", c.configMap, PodProfilesConfigMapKey, err)
+		}
+		for name, def := range parsed {
+			defs[name] = def
+		}
+	}
+	return defs, nil
+}
+
+// Resolve looks up a profile by name. Builtins are resolved without loading
+// the definitions, so they keep working when the file or ConfigMap is broken.
+func (c *PodProfileCatalog) Resolve(ctx context.Context, name string) (capacity.ResolvedProfile, error) {
+	if profile, ok := capacity.BuiltinProfile(name); ok {
+		return profile, nil
+	}
+	defs, err := c.Definitions(ctx)
+	if err != nil {
+		return capacity.ResolvedProfile{}, err
+	}
+	def, ok := defs[name]
+	if !ok {
+		return capacity.ResolvedProfile{}, fmt.Errorf("unknown pod profile %q (available: %s)", name, strings.Join(capacity.ProfileNames(defs), ", "))
+	}
+	if def.FromDeployment == "" {
+		return def.Resolve(name), nil
+	}
+	profile, err := c.FromDeployment(ctx, def.FromDeployment, def.Units())
+	if err != nil {
+		return capacity.ResolvedProfile{}, fmt.Errorf("pod profile %s: %w", name, err)
+	}
+	profile.Name = name
+	profile.Description = def.Description
+	if def.NodeSelector != nil {
+		profile.NodeSelector = def.NodeSelector
+	}
+	return profile, nil
+}
+
+// FromDeployment derives a profile from a Deployment ("namespace/name"): its
+// effective pod requests (sidecars, init containers and overhead included)
+// and its node selector
+func (c *PodProfileCatalog) FromDeployment(ctx context.Context, ref string, replicasPerUnit int) (capacity.ResolvedProfile, error) {
+	namespace, name, err := capacity.SplitNamespacedName(ref)
+	if err != nil {
+		return capacity.ResolvedProfile{}, err
+	}
+	if c == nil || c.k8sClient == nil {
+		return capacity.ResolvedProfile{}, fmt.Errorf("kubernetes client not initialized")
+	}
+	deployment, err := c.k8sClient.GetDeployment(ctx, namespace, name)
+	if err != nil {
+		return capacity.ResolvedProfile{}, err
+	}
+	requests := capacity.PodResources{
+		CPUMillicores: deployment.CPURequest,
+		MemoryMB:      deployment.MemoryRequest / (1024 * 1024),
+	}
+	return capacity.DeploymentProfile(ref, ref, requests, deployment.NodeSelector, replicasPerUnit), nil
+}
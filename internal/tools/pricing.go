@@ -0,0 +1,83 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/KubeHeal/openshift-cluster-health-mcp/pkg/capacity"
+	"github.com/KubeHeal/openshift-cluster-health-mcp/pkg/clients"
+)
+
+// PricingConfigMapKey is the ConfigMap key holding the pricing model
+const PricingConfigMapKey = "pricing.yaml"
+
+// PricingSource loads the cost pricing model from a file and/or ConfigMap.
+// The model is re-read on every call so rate changes apply without a
+// restart; a configured ConfigMap takes precedence over the file.
+type PricingSource struct {
+	k8sClient *clients.K8sClient
+	file      string
+	configMap string // namespace/name
+}
+
+// NewPricingSource creates a pricing source; file and configMap may be empty
+func NewPricingSource(k8sClient *clients.K8sClient, file, configMap string) *PricingSource {
+	return &PricingSource{k8sClient: k8sClient, file: file, configMap: configMap}
+}
+
+// Configured reports whether a pricing model is set up
+func (s *PricingSource) Configured() bool {
+	return s != nil && (s.file != "" || s.configMap != "")
+}
+
+// Model returns the pricing model, or nil without one configured
+func (s *PricingSource) Model(ctx context.Context) (*capacity.PricingModel, error) {
+	if !s.Configured() {
+		return nil, nil
+	}
+	if s.configMap != "" && s.k8sClient != nil {
+		namespace, name, err := capacity.SplitNamespacedName(s.configMap)
+		if err != nil {
+			return nil, fmt.Errorf("pricing configmap: %w", err)
+		}
+		cm, err := s.k8sClient.GetConfigMap(ctx, namespace, name)
+		if err != nil {
+			return nil, err
+		}
+		model, err := capacity.ParsePricingModel([]byte(cm.Data[PricingConfigMapKey]))
+		if err != nil {
+			return nil, fmt.Errorf("configmap %s key %s: %w", s.configMap, PricingConfigMapKey, err)
+		}
+		return model, nil
+	}
+	if s.file == "" {
+		return nil, nil
+	}
+	data, err := os.ReadFile(s.file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read pricing file: %w", err)
+	}
+	model, err := capacity.ParsePricingModel(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", s.file, err)
+	}
+	return model, nil
+}
+
+// NodePricer loads the model and prices the cluster's nodes; it returns nil
+// without a configured model
+func (s *PricingSource) NodePricer(ctx context.Context) (*capacity.NodePricer, error) {
+	model, err := s.Model(ctx)
+	if err != nil || model == nil {
+		return nil, err
+	}
+	if s.k8sClient == nil {
+		return nil, fmt.Errorf("kubernetes client not initialized")
+	}
+	nodes, err := s.k8sClient.ListNodes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return capacity.NewNodePricer(model, nodes.Items), nil
+}
@@ -0,0 +1,622 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/KubeHeal/openshift-cluster-health-mcp/pkg/capacity"
+	"github.com/KubeHeal/openshift-cluster-health-mcp/pkg/clients"
+	"github.com/KubeHeal/openshift-cluster-health-mcp/pkg/scheduling"
+)
+
+const (
+	defaultRecommendationLimit = 20
+	maxRecommendationLimit     = 100
+	defaultUsageWindow         = "24h"
+	defaultMetricsSamples      = 3
+	maxMetricsSamples          = 10
+	defaultSampleInterval      = 10
+	maxSampleInterval          = 60
+)
+
+// Usage sources
+const (
+	UsageSourcePrometheus = "prometheus"
+	UsageSourceMetricsAPI = "metrics-api"
+)
+
+// promDurationPattern validates Prometheus range durations such as 24h or 7d
+var promDurationPattern = regexp.MustCompile(`^[0-9]+[smhdw]$`)
+
+// ResourceRecommendationsTool recommends container requests from observed usage via MCP
+type ResourceRecommendationsTool struct {
+	k8sClient  *clients.K8sClient
+	prometheus *clients.PrometheusClient
+	pricing    *PricingSource
+}
+
+// NewResourceRecommendationsTool creates a new recommend-resource-requests tool;
+// prometheus may be nil, in which case usage is sampled from the metrics API,
+// and pricing may be nil, in which case idle costs are not reported
+func NewResourceRecommendationsTool(k8sClient *clients.K8sClient, prometheus *clients.PrometheusClient, pricing *PricingSource) *ResourceRecommendationsTool {
+	return &ResourceRecommendationsTool{
+		k8sClient:  k8sClient,
+		prometheus: prometheus,
+		pricing:    pricing,
+	}
+}
+
+// Name returns the tool name for MCP registration
+func (t *ResourceRecommendationsTool) Name() string {
+	return "recommend-resource-requests"
+}
+
+// Description returns the tool description for MCP
+func (t *ResourceRecommendationsTool) Description() string {
+	return "Right-size container CPU/memory requests and limits from actual usage: compares per-container usage percentiles over a window (Prometheus, or metrics API samples as a fallback) with current requests/limits, and returns recommendations ranked by reclaimable resources with savings estimates, monthly idle cost (when a pricing model is configured) and OOM/CPU throttling risk flags. Works per deployment, namespace or cluster-wide"
+}
+
+// InputSchema returns the JSON schema for tool inputs
+func (t *ResourceRecommendationsTool) InputSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"namespace": map[string]interface{}{
+				"type":        "string",
+				"description": "Namespace to analyze (empty = cluster-wide)",
+				"default":     "",
+			},
+			"deployment": map[string]interface{}{
+				"type":        "string",
+				"description": "Analyze a single Deployment (requires namespace)",
+				"default":     "",
+			},
+			"percentile": map[string]interface{}{
+				"type":        "number",
+				"description": "Usage percentile the requests are sized for",
+				"default":     95,
+				"minimum":     50,
+				"maximum":     99.9,
+			},
+			"window": map[string]interface{}{
+				"type":        "string",
+				"description": "Prometheus usage window (e.g. '24h', '7d')",
+				"default":     defaultUsageWindow,
+			},
+			"limit": map[string]interface{}{
+				"type":        "integer",
+				"description": "Maximum number of recommendations, ranked by reclaimable resources",
+				"default":     defaultRecommendationLimit,
+				"minimum":     1,
+				"maximum":     maxRecommendationLimit,
+			},
+			"samples": map[string]interface{}{
+				"type":        "integer",
+				"description": "Metrics API fallback only: number of usage samples to take",
+				"default":     defaultMetricsSamples,
+				"minimum":     1,
+				"maximum":     maxMetricsSamples,
+			},
+			"sample_interval_seconds": map[string]interface{}{
+				"type":        "integer",
+				"description": "Metrics API fallback only: seconds between samples",
+				"default":     defaultSampleInterval,
+				"minimum":     1,
+				"maximum":     maxSampleInterval,
+			},
+		},
+		"required": []string{},
+	}
+}
+
+// ResourceRecommendationsInput represents the input parameters
+type ResourceRecommendationsInput struct {
+	Namespace             string  `json:"namespace"`
+	Deployment            string  `json:"deployment"`
+	Percentile            float64 `json:"percentile"`
+	Window                string  `json:"window"`
+	Limit                 int     `json:"limit"`
+	Samples               int     `json:"samples"`
+	SampleIntervalSeconds int     `json:"sample_interval_seconds"`
+}
+
+// ContainerRecommendation is the right-sizing result for one workload container
+type ContainerRecommendation struct {
+	Namespace string                      `json:"namespace"`
+	Workload  string                      `json:"workload"`
+	Container string                      `json:"container"`
+	Replicas  int                         `json:"replicas"`
+	Current   capacity.ContainerResources `json:"current"`
+	Usage     capacity.ContainerUsage     `json:"usage"`
+	capacity.ResourceRecommendation
+	// Savings across all replicas
+	CPUSavingsMillicores int64 `json:"cpu_savings_millicores"`
+	MemorySavingsBytes   int64 `json:"memory_savings_bytes"`
+	// IdleCost prices requested but unused resources, SavingsCost the
+	// reclaimable part; both monthly across replicas, set with a pricing model
+	IdleCost    *capacity.MonthlyCost `json:"idle_monthly_cost,omitempty"`
+	SavingsCost *capacity.MonthlyCost `json:"savings_monthly_cost,omitempty"`
+	Summary     string                `json:"summary"`
+}
+
+// ResourceRecommendationsOutput represents the tool output
+type ResourceRecommendationsOutput struct {
+	Source               string                    `json:"source"`
+	Percentile           float64                   `json:"percentile"`
+	Window               string                    `json:"window"`
+	ContainersAnalyzed   int                       `json:"containers_analyzed"`
+	Recommendations      []ContainerRecommendation `json:"recommendations"`
+	Omitted              int                       `json:"omitted,omitempty"`
+	TotalCPUSavings      string                    `json:"total_cpu_savings"`
+	TotalMemorySavings   string                    `json:"total_memory_savings"`
+	TotalCPUShortfall    string                    `json:"total_cpu_shortfall"`
+	TotalMemoryShortfall string                    `json:"total_memory_shortfall"`
+	ContainersWithRisks  int                       `json:"containers_with_risks"`
+	Currency             string                    `json:"currency,omitempty"`
+	TotalIdleCost        *capacity.MonthlyCost     `json:"total_idle_monthly_cost,omitempty"`
+	TotalSavingsCost     *capacity.MonthlyCost     `json:"total_savings_monthly_cost,omitempty"`
+	Warnings             []string                  `json:"warnings,omitempty"`
+	Message              string                    `json:"message"`
+}
+
+// containerKey identifies a running container
+type containerKey struct {
+	namespace, pod, container string
+}
+
+// Execute computes right-sizing recommendations
+func (t *ResourceRecommendationsTool) Execute(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+	input := ResourceRecommendationsInput{
+		Percentile:            95,
+		Window:                defaultUsageWindow,
+		Limit:                 defaultRecommendationLimit,
+		Samples:               defaultMetricsSamples,
+		SampleIntervalSeconds: defaultSampleInterval,
+	}
+	if argsJSON, err := json.Marshal(args); err == nil {
+		_ = json.Unmarshal(argsJSON, &input) //nolint:errcheck // Intentionally ignore error, use defaults if unmarshal fails
+	}
+	if err := normalizeRecommendationInput(&input); err != nil {
+		return nil, err
+	}
+
+	pods, err := t.k8sClient.ListPods(ctx, input.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	selected := selectRightsizingPods(pods.Items, input.Deployment)
+	if input.Deployment != "" && len(selected) == 0 {
+		return nil, fmt.Errorf("no running pods found for deployment %s/%s", input.Namespace, input.Deployment)
+	}
+
+	var warnings []string
+	source := UsageSourcePrometheus
+	var usage map[containerKey]capacity.ContainerUsage
+	if t.prometheus != nil {
+		usage, err = t.prometheusUsage(ctx, input)
+		if err != nil {
+			warnings = append(warnings, fmt.Sprintf("Prometheus unavailable, falling back to metrics API samples: %v", err))
+		}
+	}
+	if usage == nil {
+		source = UsageSourceMetricsAPI
+		usage, err = t.metricsAPIUsage(ctx, input)
+		if err != nil {
+			return nil, err
+		}
+		warnings = append(warnings, fmt.Sprintf("Usage is based on %d metrics API samples over %ds, not a long-term window; treat recommendations as low confidence", input.Samples, (input.Samples-1)*input.SampleIntervalSeconds))
+	}
+
+	pricer, err := t.pricing.NodePricer(ctx)
+	if err != nil {
+		warnings = append(warnings, fmt.Sprintf("Idle costs not computed: %v", err))
+	}
+
+	opts := capacity.DefaultRightsizingOptions()
+	opts.Percentile = input.Percentile
+	output := buildRecommendations(selected, usage, opts, input.Limit, pricer)
+	output.Source = source
+	output.Percentile = input.Percentile
+	if source == UsageSourcePrometheus {
+		output.Window = input.Window
+	} else {
+		output.Window = fmt.Sprintf("%ds", (input.Samples-1)*input.SampleIntervalSeconds)
+	}
+	output.Warnings = warnings
+	output.Message = recommendationsMessage(&output)
+	return output, nil
+}
+
+// normalizeRecommendationInput validates and clamps the input
+func normalizeRecommendationInput(input *ResourceRecommendationsInput) error {
+	if input.Deployment != "" && input.Namespace == "" {
+		return fmt.Errorf("namespace is required when deployment is set")
+	}
+	if input.Percentile < 50 || input.Percentile > 99.9 {
+		return fmt.Errorf("percentile must be between 50 and 99.9, got %g", input.Percentile)
+	}
+	if !promDurationPattern.MatchString(input.Window) {
+		return fmt.Errorf("invalid window %q: use a Prometheus duration such as 24h or 7d", input.Window)
+	}
+	if input.Limit < 1 {
+		input.Limit = defaultRecommendationLimit
+	}
+	if input.Limit > maxRecommendationLimit {
+		input.Limit = maxRecommendationLimit
+	}
+	if input.Samples < 1 {
+		input.Samples = defaultMetricsSamples
+	}
+	if input.Samples > maxMetricsSamples {
+		input.Samples = maxMetricsSamples
+	}
+	if input.SampleIntervalSeconds < 1 {
+		input.SampleIntervalSeconds = defaultSampleInterval
+	}
+	if input.SampleIntervalSeconds > maxSampleInterval {
+		input.SampleIntervalSeconds = maxSampleInterval
+	}
+	return nil
+}
+
+// prometheusUsage queries usage percentiles, peak memory and CPU throttling
+func (t *ResourceRecommendationsTool) prometheusUsage(ctx context.Context, input ResourceRecommendationsInput) (map[containerKey]capacity.ContainerUsage, error) {
+	selector := `container!="",container!="POD"`
+	if input.Namespace != "" {
+		selector += fmt.Sprintf(`,namespace=%q`, input.Namespace)
+	}
+	q := input.Percentile / 100
+	by := "namespace, pod, container"
+	queries := map[string]string{
+		"cpu":      fmt.Sprintf(`max by (%s) (quantile_over_time(%g, rate(container_cpu_usage_seconds_total{%s}[5m])[%s:1m]))`, by, q, selector, input.Window),
+		"memory":   fmt.Sprintf(`max by (%s) (quantile_over_time(%g, container_memory_working_set_bytes{%s}[%s]))`, by, q, selector, input.Window),
+		"peak":     fmt.Sprintf(`max by (%s) (max_over_time(container_memory_working_set_bytes{%s}[%s]))`, by, selector, input.Window),
+		"throttle": fmt.Sprintf(`sum by (%s) (increase(container_cpu_cfs_throttled_periods_total{%s}[%s])) / sum by (%s) (increase(container_cpu_cfs_periods_total{%s}[%s]))`, by, selector, input.Window, by, selector, input.Window),
+	}
+
+	results := make(map[string][]clients.PrometheusSample, len(queries))
+	for name, query := range queries {
+		samples, err := t.prometheus.Query(ctx, query)
+		if err != nil {
+			if name == "throttle" {
+				continue // optional: older kubelets do not export CFS metrics
+			}
+			return nil, err
+		}
+		results[name] = samples
+	}
+	return mergePrometheusUsage(results), nil
+}
+
+// mergePrometheusUsage combines per-metric samples into per-container usage;
+// NaN/Inf values (e.g. 0/0 throttling ratios for idle containers) are dropped
+func mergePrometheusUsage(results map[string][]clients.PrometheusSample) map[containerKey]capacity.ContainerUsage {
+	for name, samples := range results {
+		valid := samples[:0]
+		for _, s := range samples {
+			if !math.IsNaN(s.Value) && !math.IsInf(s.Value, 0) {
+				valid = append(valid, s)
+			}
+		}
+		results[name] = valid
+	}
+	usage := make(map[containerKey]capacity.ContainerUsage)
+	get := func(s clients.PrometheusSample) (containerKey, capacity.ContainerUsage) {
+		key := containerKey{s.Labels["namespace"], s.Labels["pod"], s.Labels["container"]}
+		u, ok := usage[key]
+		if !ok {
+			u.ThrottledRatio = -1
+		}
+		return key, u
+	}
+	for _, s := range results["cpu"] {
+		key, u := get(s)
+		u.CPUMillicores = s.Value * 1000
+		u.Samples = 1
+		usage[key] = u
+	}
+	for _, s := range results["memory"] {
+		key, u := get(s)
+		u.MemoryBytes = s.Value
+		u.Samples = 1
+		usage[key] = u
+	}
+	for _, s := range results["peak"] {
+		key, u := get(s)
+		u.PeakMemoryBytes = s.Value
+		usage[key] = u
+	}
+	for _, s := range results["throttle"] {
+		if _, ok := usage[containerKey{s.Labels["namespace"], s.Labels["pod"], s.Labels["container"]}]; !ok {
+			continue
+		}
+		key, u := get(s)
+		u.ThrottledRatio = s.Value
+		usage[key] = u
+	}
+	return usage
+}
+
+// metricsAPIUsage samples the metrics API and computes percentiles per container
+func (t *ResourceRecommendationsTool) metricsAPIUsage(ctx context.Context, input ResourceRecommendationsInput) (map[containerKey]capacity.ContainerUsage, error) {
+	var samples [][]clients.ContainerMetrics
+	for i := 0; i < input.Samples; i++ {
+		if i > 0 {
+			select {
+			case <-ctx.Done():
+				return aggregateMetricsSamples(samples, input.Percentile), nil
+			case <-time.After(time.Duration(input.SampleIntervalSeconds) * time.Second):
+			}
+		}
+		metrics, err := t.k8sClient.ListContainerMetrics(ctx, input.Namespace)
+		if err != nil {
+			if len(samples) > 0 {
+				break
+			}
+			return nil, err
+		}
+		samples = append(samples, metrics)
+	}
+	return aggregateMetricsSamples(samples, input.Percentile), nil
+}
+
+// aggregateMetricsSamples turns repeated metrics API snapshots into usage percentiles
+func aggregateMetricsSamples(snapshots [][]clients.ContainerMetrics, percentile float64) map[containerKey]capacity.ContainerUsage {
+	cpu := make(map[containerKey][]float64)
+	memory := make(map[containerKey][]float64)
+	for _, snapshot := range snapshots {
+		for _, m := range snapshot {
+			key := containerKey{m.Namespace, m.Pod, m.Container}
+			cpu[key] = append(cpu[key], float64(m.CPUMillicores))
+			memory[key] = append(memory[key], float64(m.MemoryBytes))
+		}
+	}
+	usage := make(map[containerKey]capacity.ContainerUsage, len(cpu))
+	for key, values := range cpu {
+		usage[key] = capacity.ContainerUsage{
+			CPUMillicores:   capacity.Percentile(values, percentile),
+			MemoryBytes:     capacity.Percentile(memory[key], percentile),
+			PeakMemoryBytes: capacity.Percentile(memory[key], 100),
+			ThrottledRatio:  -1,
+			Samples:         len(values),
+		}
+	}
+	return usage
+}
+
+// selectRightsizingPods returns running pods, optionally of one Deployment
+func selectRightsizingPods(pods []corev1.Pod, deployment string) []*corev1.Pod {
+	var selected []*corev1.Pod
+	for i := range pods {
+		pod := &pods[i]
+		if pod.Status.Phase != corev1.PodRunning {
+			continue
+		}
+		if deployment != "" {
+			ref := capacity.WorkloadOf(pod)
+			if ref.Kind != "Deployment" || ref.Name != deployment {
+				continue
+			}
+		}
+		selected = append(selected, pod)
+	}
+	return selected
+}
+
+// workloadContainer aggregates one container across a workload's pods
+type workloadContainer struct {
+	namespace, workload, container string
+	current                        capacity.ContainerResources
+	usage                          capacity.ContainerUsage
+	replicas                       int
+	measured                       bool
+	rates                          capacity.ResourceRates // summed over replicas
+}
+
+// buildRecommendations aggregates usage per workload container (the highest
+// replica percentile, to stay safe for the busiest replica), recommends
+// resources and ranks them by reclaimable resources across replicas. With a
+// pricer, idle and reclaimable resources are priced at the average rates of
+// the nodes the replicas run on.
+func buildRecommendations(pods []*corev1.Pod, usage map[containerKey]capacity.ContainerUsage, opts capacity.RightsizingOptions, limit int, pricer *capacity.NodePricer) ResourceRecommendationsOutput {
+	groups := make(map[string]*workloadContainer)
+	var order []string
+	for _, pod := range pods {
+		workload := capacity.WorkloadOf(pod)
+		name := workload.Kind + "/" + workload.Name
+		if workload.Kind == "" {
+			name = "Pod/" + pod.Name
+		}
+		oomKilled := make(map[string]bool)
+		for _, status := range pod.Status.ContainerStatuses {
+			if term := status.LastTerminationState.Terminated; term != nil && term.Reason == "OOMKilled" {
+				oomKilled[status.Name] = true
+			}
+		}
+		for _, c := range pod.Spec.Containers {
+			id := pod.Namespace + "/" + name + "/" + c.Name
+			group, ok := groups[id]
+			if !ok {
+				group = &workloadContainer{
+					namespace: pod.Namespace,
+					workload:  name,
+					container: c.Name,
+					current:   containerResources(&c),
+					usage:     capacity.ContainerUsage{ThrottledRatio: -1},
+				}
+				groups[id] = group
+				order = append(order, id)
+			}
+			group.replicas++
+			if pricer != nil {
+				rates := pricer.NodeRates(pod.Spec.NodeName)
+				group.rates.CPUCoreHour += rates.CPUCoreHour
+				group.rates.MemoryGiBHour += rates.MemoryGiBHour
+			}
+			if oomKilled[c.Name] {
+				group.usage.OOMKilled = true
+			}
+			u, ok := usage[containerKey{pod.Namespace, pod.Name, c.Name}]
+			if !ok {
+				continue
+			}
+			group.measured = true
+			group.usage.CPUMillicores = math.Max(group.usage.CPUMillicores, u.CPUMillicores)
+			group.usage.MemoryBytes = math.Max(group.usage.MemoryBytes, u.MemoryBytes)
+			group.usage.PeakMemoryBytes = math.Max(group.usage.PeakMemoryBytes, u.PeakMemoryBytes)
+			group.usage.ThrottledRatio = math.Max(group.usage.ThrottledRatio, u.ThrottledRatio)
+			group.usage.Samples += u.Samples
+		}
+	}
+
+	output := ResourceRecommendationsOutput{Recommendations: []ContainerRecommendation{}}
+	var all []ContainerRecommendation
+	var cpuSavings, memorySavings, cpuShortfall, memoryShortfall int64
+	var idleCost, savingsCost capacity.MonthlyCost
+	for _, id := range order {
+		group := groups[id]
+		if !group.measured {
+			continue
+		}
+		rec := ContainerRecommendation{
+			Namespace:              group.namespace,
+			Workload:               group.workload,
+			Container:              group.container,
+			Replicas:               group.replicas,
+			Current:                group.current,
+			Usage:                  group.usage,
+			ResourceRecommendation: capacity.RecommendResources(group.current, group.usage, opts),
+		}
+		rec.CPUSavingsMillicores = rec.CPUReclaimableMillicores * int64(rec.Replicas)
+		rec.MemorySavingsBytes = rec.MemoryReclaimableBytes * int64(rec.Replicas)
+		if pricer != nil {
+			priceRecommendation(&rec, group.rates)
+			idleCost = idleCost.Add(*rec.IdleCost)
+			savingsCost = savingsCost.Add(*rec.SavingsCost)
+		}
+		rec.Summary = recommendationSummary(&rec)
+		if rec.CPUSavingsMillicores > 0 {
+			cpuSavings += rec.CPUSavingsMillicores
+		} else {
+			cpuShortfall -= rec.CPUSavingsMillicores
+		}
+		if rec.MemorySavingsBytes > 0 {
+			memorySavings += rec.MemorySavingsBytes
+		} else {
+			memoryShortfall -= rec.MemorySavingsBytes
+		}
+		if len(rec.Risks) > 0 {
+			output.ContainersWithRisks++
+		}
+		all = append(all, rec)
+	}
+
+	sort.SliceStable(all, func(i, j int) bool {
+		return capacity.ReclaimableScore(all[i].CPUSavingsMillicores, all[i].MemorySavingsBytes) >
+			capacity.ReclaimableScore(all[j].CPUSavingsMillicores, all[j].MemorySavingsBytes)
+	})
+	output.ContainersAnalyzed = len(all)
+	if len(all) > limit {
+		output.Omitted = len(all) - limit
+		all = all[:limit]
+	}
+	output.Recommendations = append(output.Recommendations, all...)
+	output.TotalCPUSavings = fmt.Sprintf("%dm", cpuSavings)
+	output.TotalMemorySavings = scheduling.FormatBytes(memorySavings)
+	output.TotalCPUShortfall = fmt.Sprintf("%dm", cpuShortfall)
+	output.TotalMemoryShortfall = scheduling.FormatBytes(memoryShortfall)
+	if pricer != nil {
+		output.Currency = pricer.Currency()
+		output.TotalIdleCost = &idleCost
+		output.TotalSavingsCost = &savingsCost
+	}
+	return output
+}
+
+// priceRecommendation prices the idle (requested minus used) and reclaimable
+// resources of a recommendation across replicas; rates are summed over the
+// replicas' nodes. Memory of an OOMKilled container is not idle: its usage
+// samples miss the peak that exhausted it.
+func priceRecommendation(rec *ContainerRecommendation, rates capacity.ResourceRates) {
+	replicas := float64(rec.Replicas)
+	avg := capacity.ResourceRates{CPUCoreHour: rates.CPUCoreHour / replicas, MemoryGiBHour: rates.MemoryGiBHour / replicas}
+	idleCPU := math.Max(float64(rec.Current.CPURequestMillicores)-rec.Usage.CPUMillicores, 0)
+	idleMemory := math.Max(float64(rec.Current.MemoryRequestBytes)-rec.Usage.MemoryBytes, 0)
+	if rec.Usage.OOMKilled {
+		idleMemory = 0
+	}
+	idle := avg.MonthlyCost(idleCPU*replicas, idleMemory*replicas)
+	savings := avg.MonthlyCost(math.Max(float64(rec.CPUSavingsMillicores), 0), math.Max(float64(rec.MemorySavingsBytes), 0))
+	rec.IdleCost = &idle
+	rec.SavingsCost = &savings
+}
+
+// containerResources reads a container's requests and limits
+func containerResources(c *corev1.Container) capacity.ContainerResources {
+	var r capacity.ContainerResources
+	if q, ok := c.Resources.Requests[corev1.ResourceCPU]; ok {
+		r.CPURequestMillicores = q.MilliValue()
+	}
+	if q, ok := c.Resources.Limits[corev1.ResourceCPU]; ok {
+		r.CPULimitMillicores = q.MilliValue()
+	}
+	if q, ok := c.Resources.Requests[corev1.ResourceMemory]; ok {
+		r.MemoryRequestBytes = q.Value()
+	}
+	if q, ok := c.Resources.Limits[corev1.ResourceMemory]; ok {
+		r.MemoryLimitBytes = q.Value()
+	}
+	return r
+}
+
+// recommendationSummary renders the change for one container
+func recommendationSummary(rec *ContainerRecommendation) string {
+	summary := fmt.Sprintf("cpu %s -> %dm, memory %s -> %s",
+		requestString(rec.Current.CPURequestMillicores, fmt.Sprintf("%dm", rec.Current.CPURequestMillicores)),
+		rec.Recommended.CPURequestMillicores,
+		requestString(rec.Current.MemoryRequestBytes, scheduling.FormatBytes(rec.Current.MemoryRequestBytes)),
+		scheduling.FormatBytes(rec.Recommended.MemoryRequestBytes))
+	if len(rec.Risks) > 0 {
+		summary += " [" + strings.Join(rec.Risks, ", ") + "]"
+	}
+	return summary
+}
+
+func requestString(v int64, formatted string) string {
+	if v == 0 {
+		return "unset"
+	}
+	return formatted
+}
+
+// recommendationsMessage summarizes the recommendations
+func recommendationsMessage(output *ResourceRecommendationsOutput) string {
+	if output.ContainersAnalyzed == 0 {
+		return fmt.Sprintf("No container usage data found (source: %s)", output.Source)
+	}
+	msg := fmt.Sprintf("Analyzed %d containers at p%g over %s (%s): reclaimable %s CPU and %s memory",
+		output.ContainersAnalyzed, output.Percentile, output.Window, output.Source, output.TotalCPUSavings, output.TotalMemorySavings)
+	if output.TotalCPUShortfall != "0m" || output.TotalMemoryShortfall != scheduling.FormatBytes(0) {
+		msg += fmt.Sprintf("; under-requested by %s CPU and %s memory", output.TotalCPUShortfall, output.TotalMemoryShortfall)
+	}
+	if output.TotalIdleCost != nil {
+		msg += fmt.Sprintf("; idle requests cost %.2f %s/month, right-sizing saves %.2f %s/month",
+			output.TotalIdleCost.Total, output.Currency, output.TotalSavingsCost.Total, output.Currency)
+	}
+	if output.ContainersWithRisks > 0 {
+		msg += fmt.Sprintf("; %d containers flagged with risks", output.ContainersWithRisks)
+	}
+	if len(output.Recommendations) > 0 {
+		top := output.Recommendations[0]
+		msg += fmt.Sprintf(". Top: %s/%s %s (%s)", top.Namespace, top.Workload, top.Container, top.Summary)
+	}
+	return msg
+}
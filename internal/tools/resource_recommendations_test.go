@@ -0,0 +1,239 @@
+package tools
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/KubeHeal/openshift-cluster-health-mcp/pkg/capacity"
+	"github.com/KubeHeal/openshift-cluster-health-mcp/pkg/clients"
+)
+
+func sizedPod(name string, owner []metav1.OwnerReference, labels map[string]string, cpu, memory string) corev1.Pod {
+	pod := workloadPod(name, owner, labels, true, "", metav1.Now().Time)
+	pod.Spec.Containers = []corev1.Container{{
+		Name: "app",
+		Resources: corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse(cpu),
+				corev1.ResourceMemory: resource.MustParse(memory),
+			},
+		},
+	}}
+	return pod
+}
+
+func TestResourceRecommendationsTool_Metadata(t *testing.T) {
+	tool := NewResourceRecommendationsTool(nil, nil, nil)
+	if tool.Name() != "recommend-resource-requests" {
+		t.Errorf("unexpected name %s", tool.Name())
+	}
+	props := tool.InputSchema()["properties"].(map[string]interface{})
+	for _, key := range []string{"namespace", "deployment", "percentile", "window", "limit"} {
+		if _, ok := props[key]; !ok {
+			t.Errorf("missing input %s", key)
+		}
+	}
+}
+
+func TestNormalizeRecommendationInput(t *testing.T) {
+	input := ResourceRecommendationsInput{Percentile: 95, Window: "7d", Limit: 500, Samples: 50, SampleIntervalSeconds: 0}
+	if err := normalizeRecommendationInput(&input); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if input.Limit != maxRecommendationLimit || input.Samples != maxMetricsSamples || input.SampleIntervalSeconds != defaultSampleInterval {
+		t.Errorf("input not clamped: %+v", input)
+	}
+
+	invalid := []ResourceRecommendationsInput{
+		{Deployment: "api", Percentile: 95, Window: "24h"},
+		{Percentile: 20, Window: "24h"},
+		{Percentile: 95, Window: "1 day"},
+		{Percentile: 95, Window: "24h]) or vector(1"},
+	}
+	for _, in := range invalid {
+		if err := normalizeRecommendationInput(&in); err == nil {
+			t.Errorf("expected error for %+v", in)
+		}
+	}
+}
+
+func TestMergePrometheusUsage(t *testing.T) {
+	labels := map[string]string{"namespace": "app", "pod": "api-1", "container": "app"}
+	idle := map[string]string{"namespace": "app", "pod": "idle-1", "container": "app"}
+	usage := mergePrometheusUsage(map[string][]clients.PrometheusSample{
+		"cpu":      {{Labels: labels, Value: 0.2}, {Labels: idle, Value: 0}},
+		"memory":   {{Labels: labels, Value: 100}, {Labels: idle, Value: 10}},
+		"peak":     {{Labels: labels, Value: 150}},
+		"throttle": {{Labels: labels, Value: 0.3}, {Labels: idle, Value: math.NaN()}},
+	})
+
+	got := usage[containerKey{"app", "api-1", "app"}]
+	if got.CPUMillicores != 200 || got.MemoryBytes != 100 || got.PeakMemoryBytes != 150 || got.ThrottledRatio != 0.3 {
+		t.Errorf("unexpected usage: %+v", got)
+	}
+	if ratio := usage[containerKey{"app", "idle-1", "app"}].ThrottledRatio; ratio != -1 {
+		t.Errorf("NaN throttling should be unknown (-1), got %g", ratio)
+	}
+}
+
+func TestAggregateMetricsSamples(t *testing.T) {
+	sample := func(cpu, memory int64) []clients.ContainerMetrics {
+		return []clients.ContainerMetrics{{Namespace: "app", Pod: "api-1", Container: "app", CPUMillicores: cpu, MemoryBytes: memory}}
+	}
+	usage := aggregateMetricsSamples([][]clients.ContainerMetrics{sample(100, 10), sample(300, 30), sample(200, 20)}, 50)
+
+	got := usage[containerKey{"app", "api-1", "app"}]
+	if got.CPUMillicores != 200 || got.MemoryBytes != 20 || got.PeakMemoryBytes != 30 {
+		t.Errorf("unexpected usage: %+v", got)
+	}
+	if got.Samples != 3 || got.ThrottledRatio != -1 {
+		t.Errorf("expected 3 samples and unknown throttling, got %+v", got)
+	}
+}
+
+func TestBuildRecommendations(t *testing.T) {
+	apiOwner := ownedBy("ReplicaSet", "api-7d9f", "rs-1")
+	apiLabels := map[string]string{"pod-template-hash": "7d9f"}
+	api1 := sizedPod("api-7d9f-a", apiOwner, apiLabels, "1", "2Gi")
+	api2 := sizedPod("api-7d9f-b", apiOwner, apiLabels, "1", "2Gi")
+	api2.Status.ContainerStatuses = []corev1.ContainerStatus{{
+		Name:                 "app",
+		LastTerminationState: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{Reason: "OOMKilled"}},
+	}}
+	db := sizedPod("db-0", ownedBy("StatefulSet", "db", "sts-1"), nil, "100m", "256Mi")
+	unmeasured := sizedPod("job-x", nil, nil, "500m", "1Gi")
+
+	mi := float64(1024 * 1024)
+	usage := map[containerKey]capacity.ContainerUsage{
+		{"app", "api-7d9f-a", "app"}: {CPUMillicores: 100, MemoryBytes: 200 * mi, PeakMemoryBytes: 250 * mi, ThrottledRatio: -1},
+		{"app", "api-7d9f-b", "app"}: {CPUMillicores: 150, MemoryBytes: 300 * mi, PeakMemoryBytes: 350 * mi, ThrottledRatio: -1},
+		{"app", "db-0", "app"}:       {CPUMillicores: 300, MemoryBytes: 400 * mi, PeakMemoryBytes: 400 * mi, ThrottledRatio: -1},
+	}
+	pods := []*corev1.Pod{&api1, &api2, &db, &unmeasured}
+
+	output := buildRecommendations(pods, usage, capacity.DefaultRightsizingOptions(), 10, nil)
+
+	if output.ContainersAnalyzed != 2 {
+		t.Fatalf("expected 2 measured workload containers, got %d", output.ContainersAnalyzed)
+	}
+	top := output.Recommendations[0]
+	if top.Workload != "Deployment/api" || top.Replicas != 2 {
+		t.Fatalf("expected api Deployment ranked first with 2 replicas, got %s (%d)", top.Workload, top.Replicas)
+	}
+	// Sized for the busiest replica: 150m + 15% -> 175m; memory keeps its 2Gi
+	// request since a replica was OOMKilled
+	if top.Recommended.CPURequestMillicores != 175 || top.Recommended.MemoryRequestBytes != 2*1024*1024*1024 {
+		t.Errorf("unexpected recommendation: %+v", top.Recommended)
+	}
+	if top.CPUSavingsMillicores != 2*(1000-175) {
+		t.Errorf("cpu savings = %d, want %d", top.CPUSavingsMillicores, 2*(1000-175))
+	}
+	if top.MemorySavingsBytes != 0 {
+		t.Errorf("memory savings = %d, want none for an OOMKilled container", top.MemorySavingsBytes)
+	}
+	if len(top.Risks) != 1 || top.Risks[0] != capacity.RiskOOM {
+		t.Errorf("expected OOM risk from the OOMKilled replica, got %v", top.Risks)
+	}
+
+	dbRec := output.Recommendations[1]
+	if dbRec.CPUSavingsMillicores >= 0 {
+		t.Errorf("under-requested db should have negative savings, got %d", dbRec.CPUSavingsMillicores)
+	}
+	if output.TotalCPUSavings != "1650m" || output.TotalCPUShortfall != "245m" {
+		t.Errorf("unexpected totals: savings %s, shortfall %s", output.TotalCPUSavings, output.TotalCPUShortfall)
+	}
+	if output.ContainersWithRisks != 2 {
+		t.Errorf("expected 2 containers with risks, got %d", output.ContainersWithRisks)
+	}
+
+	limited := buildRecommendations(pods, usage, capacity.DefaultRightsizingOptions(), 1, nil)
+	if len(limited.Recommendations) != 1 || limited.Omitted != 1 {
+		t.Errorf("expected 1 recommendation and 1 omitted, got %d/%d", len(limited.Recommendations), limited.Omitted)
+	}
+
+	output.Source = UsageSourcePrometheus
+	output.Percentile = 95
+	output.Window = "24h"
+	msg := recommendationsMessage(&output)
+	for _, want := range []string{"Analyzed 2 containers at p95 over 24h", "reclaimable 1650m CPU", "under-requested", "Top: app/Deployment/api"} {
+		if !strings.Contains(msg, want) {
+			t.Errorf("message %q missing %q", msg, want)
+		}
+	}
+}
+
+func TestSelectRightsizingPods(t *testing.T) {
+	api := sizedPod("api-7d9f-a", ownedBy("ReplicaSet", "api-7d9f", "rs-1"), map[string]string{"pod-template-hash": "7d9f"}, "1", "1Gi")
+	other := sizedPod("web-5c8b-a", ownedBy("ReplicaSet", "web-5c8b", "rs-2"), map[string]string{"pod-template-hash": "5c8b"}, "1", "1Gi")
+	pending := sizedPod("api-7d9f-b", ownedBy("ReplicaSet", "api-7d9f", "rs-1"), map[string]string{"pod-template-hash": "7d9f"}, "1", "1Gi")
+	pending.Status.Phase = corev1.PodPending
+
+	if got := selectRightsizingPods([]corev1.Pod{api, other, pending}, ""); len(got) != 2 {
+		t.Errorf("expected 2 running pods, got %d", len(got))
+	}
+	got := selectRightsizingPods([]corev1.Pod{api, other, pending}, "api")
+	if len(got) != 1 || got[0].Name != "api-7d9f-a" {
+		t.Errorf("expected only the running api pod, got %d", len(got))
+	}
+}
+
+func TestBuildRecommendationsIdleCost(t *testing.T) {
+	owner := ownedBy("ReplicaSet", "api-7d9f", "rs-1")
+	labels := map[string]string{"pod-template-hash": "7d9f"}
+	api1 := sizedPod("api-7d9f-a", owner, labels, "1", "1Gi")
+	api2 := sizedPod("api-7d9f-b", owner, labels, "1", "1Gi")
+	usage := map[containerKey]capacity.ContainerUsage{
+		{"app", "api-7d9f-a", "app"}: {CPUMillicores: 100, MemoryBytes: 256 * 1024 * 1024, ThrottledRatio: -1},
+		{"app", "api-7d9f-b", "app"}: {CPUMillicores: 150, MemoryBytes: 256 * 1024 * 1024, ThrottledRatio: -1},
+	}
+	model := &capacity.PricingModel{Currency: "USD", Default: capacity.ResourceRates{CPUCoreHour: 0.04}}
+	pricer := capacity.NewNodePricer(model, []corev1.Node{schedulingNode("n1", "4", "8Gi")})
+
+	output := buildRecommendations([]*corev1.Pod{&api1, &api2}, usage, capacity.DefaultRightsizingOptions(), 10, pricer)
+	rec := output.Recommendations[0]
+	// Idle: 2 x (1000m - 150m) = 1.7 cores * 0.04 * 730; reclaimable: 2 x (1000m - 175m)
+	if rec.IdleCost == nil || rec.IdleCost.Total != 49.64 {
+		t.Errorf("idle cost = %+v, want 49.64", rec.IdleCost)
+	}
+	if rec.SavingsCost == nil || rec.SavingsCost.Total != 48.18 {
+		t.Errorf("savings cost = %+v, want 48.18", rec.SavingsCost)
+	}
+	if output.Currency != "USD" || output.TotalIdleCost.Total != 49.64 {
+		t.Errorf("totals = %s %+v", output.Currency, output.TotalIdleCost)
+	}
+	output.Source = UsageSourcePrometheus
+	if msg := recommendationsMessage(&output); !strings.Contains(msg, "idle requests cost 49.64 USD/month") {
+		t.Errorf("message %q missing idle cost", msg)
+	}
+
+	unpriced := buildRecommendations([]*corev1.Pod{&api1, &api2}, usage, capacity.DefaultRightsizingOptions(), 10, nil)
+	if unpriced.TotalIdleCost != nil || unpriced.Recommendations[0].IdleCost != nil {
+		t.Error("costs should be omitted without a pricing model")
+	}
+}
+
+func TestPriceRecommendation_OOMKilledMemoryNotIdle(t *testing.T) {
+	gi := int64(1024 * 1024 * 1024)
+	rec := ContainerRecommendation{
+		Replicas: 1,
+		Current:  capacity.ContainerResources{MemoryRequestBytes: 2 * gi},
+		Usage:    capacity.ContainerUsage{MemoryBytes: float64(gi)},
+	}
+	rates := capacity.ResourceRates{MemoryGiBHour: 0.01}
+
+	priceRecommendation(&rec, rates)
+	if rec.IdleCost.Total == 0 {
+		t.Fatalf("expected idle memory cost, got %+v", rec.IdleCost)
+	}
+
+	rec.Usage.OOMKilled = true
+	priceRecommendation(&rec, rates)
+	if rec.IdleCost.Total != 0 {
+		t.Errorf("idle cost = %+v, want none for an OOMKilled container", rec.IdleCost)
+	}
+}
@@ -0,0 +1,372 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+
+	"github.com/KubeHeal/openshift-cluster-health-mcp/pkg/certs"
+	"github.com/KubeHeal/openshift-cluster-health-mcp/pkg/clients"
+)
+
+const defaultCertWarningDays = 30
+
+// Route health statuses
+const (
+	RouteHealthy   = "healthy"
+	RouteDegraded  = "degraded"
+	RouteUnhealthy = "unhealthy"
+)
+
+// RouteHealthTool checks OpenShift Routes and IngressControllers via MCP
+type RouteHealthTool struct {
+	k8sClient *clients.K8sClient
+}
+
+// NewRouteHealthTool creates a new check-route-health tool
+func NewRouteHealthTool(k8sClient *clients.K8sClient) *RouteHealthTool {
+	return &RouteHealthTool{
+		k8sClient: k8sClient,
+	}
+}
+
+// Name returns the tool name for MCP registration
+func (t *RouteHealthTool) Name() string {
+	return "check-route-health"
+}
+
+// Description returns the tool description for MCP
+func (t *RouteHealthTool) Description() string {
+	return "Check why an app URL is down: OpenShift Route admission per router shard, backend Service existence and endpoint readiness, TLS termination and inline certificate expiry (edge/reencrypt), plus IngressController conditions"
+}
+
+// InputSchema returns the JSON schema for tool inputs
+func (t *RouteHealthTool) InputSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"namespace": map[string]interface{}{
+				"type":        "string",
+				"description": "Namespace of the Routes (empty = all namespaces)",
+				"default":     "",
+			},
+			"route": map[string]interface{}{
+				"type":        "string",
+				"description": "Only check this Route (requires namespace)",
+				"default":     "",
+			},
+			"only_unhealthy": map[string]interface{}{
+				"type":        "boolean",
+				"description": "Only return Routes that are not healthy",
+				"default":     false,
+			},
+			"cert_warning_days": map[string]interface{}{
+				"type":        "integer",
+				"description": "Report certificates expiring within this many days",
+				"default":     defaultCertWarningDays,
+				"minimum":     1,
+			},
+		},
+		"required": []string{},
+	}
+}
+
+// RouteHealthInput represents the input parameters
+type RouteHealthInput struct {
+	Namespace       string `json:"namespace"`
+	Route           string `json:"route"`
+	OnlyUnhealthy   bool   `json:"only_unhealthy"`
+	CertWarningDays int    `json:"cert_warning_days"`
+}
+
+// CertificateExpiry is certificate metadata with its expiry status
+type CertificateExpiry struct {
+	certs.Info
+	DaysRemaining int    `json:"days_remaining"`
+	Status        string `json:"status"` // valid, expiring, expired or not_yet_valid
+}
+
+// RouteBackendHealth is the readiness of one Route backend Service
+type RouteBackendHealth struct {
+	Service           string `json:"service"`
+	Weight            *int64 `json:"weight,omitempty"`
+	Exists            bool   `json:"exists"`
+	ReadyEndpoints    int    `json:"ready_endpoints"`
+	NotReadyEndpoints int    `json:"not_ready_endpoints"`
+}
+
+// RouteTLSHealth is a Route's TLS termination and inline certificate expiry
+type RouteTLSHealth struct {
+	Termination                   string             `json:"termination"`
+	InsecureEdgeTerminationPolicy string             `json:"insecure_edge_termination_policy,omitempty"`
+	Certificate                   *CertificateExpiry `json:"certificate,omitempty"`
+	DestinationCACertificate      *CertificateExpiry `json:"destination_ca_certificate,omitempty"`
+}
+
+// RouteHealth is the health of one Route
+type RouteHealth struct {
+	Name      string                 `json:"name"`
+	Namespace string                 `json:"namespace"`
+	Host      string                 `json:"host"`
+	Path      string                 `json:"path,omitempty"`
+	Status    string                 `json:"status"`
+	Admission []clients.RouteIngress `json:"admission"`
+	Backends  []RouteBackendHealth   `json:"backends"`
+	TLS       *RouteTLSHealth        `json:"tls,omitempty"`
+	Issues    []string               `json:"issues,omitempty"`
+}
+
+// RouteHealthSummary counts Routes by status
+type RouteHealthSummary struct {
+	Total     int `json:"total"`
+	Healthy   int `json:"healthy"`
+	Degraded  int `json:"degraded"`
+	Unhealthy int `json:"unhealthy"`
+}
+
+// RouteHealthOutput represents the tool output
+type RouteHealthOutput struct {
+	Summary            RouteHealthSummary          `json:"summary"`
+	Routes             []RouteHealth               `json:"routes"`
+	IngressControllers []clients.IngressController `json:"ingress_controllers,omitempty"`
+	Notes              []string                    `json:"notes,omitempty"`
+	Message            string                      `json:"message"`
+}
+
+// Execute checks Route and IngressController health
+func (t *RouteHealthTool) Execute(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+	input := RouteHealthInput{CertWarningDays: defaultCertWarningDays}
+	if argsJSON, err := json.Marshal(args); err == nil {
+		_ = json.Unmarshal(argsJSON, &input) //nolint:errcheck // Intentionally ignore error, use defaults if unmarshal fails
+	}
+	if input.Route != "" && input.Namespace == "" {
+		return nil, fmt.Errorf("namespace is required when route is set")
+	}
+	if input.CertWarningDays < 1 {
+		input.CertWarningDays = defaultCertWarningDays
+	}
+
+	routes, err := t.k8sClient.ListRoutes(ctx, input.Namespace)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, fmt.Errorf("routes not found: this does not appear to be an OpenShift cluster")
+		}
+		return nil, err
+	}
+	if input.Route != "" {
+		var matched []clients.Route
+		for _, r := range routes {
+			if r.Name == input.Route {
+				matched = append(matched, r)
+			}
+		}
+		if len(matched) == 0 {
+			return nil, fmt.Errorf("route %s/%s not found", input.Namespace, input.Route)
+		}
+		routes = matched
+	}
+
+	services, err := t.k8sClient.ListServices(ctx, input.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	slices, err := t.k8sClient.ListEndpointSlices(ctx, input.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	var notes []string
+	controllers, err := t.k8sClient.ListIngressControllers(ctx)
+	if err != nil {
+		notes = append(notes, fmt.Sprintf("IngressController conditions not available: %v", err))
+	}
+
+	output := analyzeRoutes(routes, services.Items, slices.Items, controllers, input, time.Now())
+	output.Notes = notes
+	output.Message = routeHealthMessage(&output)
+	return output, nil
+}
+
+// analyzeRoutes checks each Route against its backends, router shards and certificates
+func analyzeRoutes(routes []clients.Route, services []corev1.Service, slices []discoveryv1.EndpointSlice, controllers []clients.IngressController, input RouteHealthInput, now time.Time) RouteHealthOutput {
+	existing := make(map[string]bool, len(services))
+	for _, svc := range services {
+		existing[svc.Namespace+"/"+svc.Name] = true
+	}
+	endpoints := collectServiceEndpoints(slices)
+	routers := make(map[string]*clients.IngressController, len(controllers))
+	for i := range controllers {
+		routers[controllers[i].Name] = &controllers[i]
+	}
+	warning := time.Duration(input.CertWarningDays) * 24 * time.Hour
+
+	output := RouteHealthOutput{Routes: []RouteHealth{}, IngressControllers: controllers}
+	for _, route := range routes {
+		result := checkRoute(&route, existing, endpoints, routers, now, warning)
+		output.Summary.Total++
+		switch result.Status {
+		case RouteHealthy:
+			output.Summary.Healthy++
+		case RouteDegraded:
+			output.Summary.Degraded++
+		case RouteUnhealthy:
+			output.Summary.Unhealthy++
+		}
+		if input.OnlyUnhealthy && result.Status == RouteHealthy {
+			continue
+		}
+		output.Routes = append(output.Routes, result)
+	}
+
+	rank := map[string]int{RouteUnhealthy: 0, RouteDegraded: 1, RouteHealthy: 2}
+	sort.SliceStable(output.Routes, func(i, j int) bool {
+		return rank[output.Routes[i].Status] < rank[output.Routes[j].Status]
+	})
+	return output
+}
+
+// checkRoute evaluates one Route. Unhealthy means the URL cannot work: no
+// router admitted it, no weighted backend has ready endpoints, or its
+// certificate expired. Anything less is degraded.
+func checkRoute(route *clients.Route, existing map[string]bool, endpoints map[string]*serviceEndpoints, routers map[string]*clients.IngressController, now time.Time, warning time.Duration) RouteHealth {
+	result := RouteHealth{
+		Name:      route.Name,
+		Namespace: route.Namespace,
+		Host:      route.Host,
+		Path:      route.Path,
+		Status:    RouteHealthy,
+		Admission: route.Ingress,
+		Backends:  []RouteBackendHealth{},
+	}
+	if result.Admission == nil {
+		result.Admission = []clients.RouteIngress{}
+	}
+	unhealthy := false
+	// Issues that make the Route unhealthy are listed first
+	issue := func(critical bool, format string, args ...interface{}) {
+		message := fmt.Sprintf(format, args...)
+		if critical {
+			result.Issues = append([]string{message}, result.Issues...)
+			unhealthy = true
+			return
+		}
+		result.Issues = append(result.Issues, message)
+	}
+
+	admitted := 0
+	for _, ingress := range route.Ingress {
+		if !ingress.Admitted {
+			issue(false, "not admitted by router %s: %s %s", ingress.RouterName, ingress.Reason, ingress.Message)
+			continue
+		}
+		admitted++
+		if ic, ok := routers[ingress.RouterName]; ok && !ic.Healthy() {
+			issue(false, "admitted by router %s whose IngressController is not healthy: %s", ingress.RouterName, ic.Message)
+		}
+	}
+	if admitted == 0 {
+		issue(true, "not admitted by any router")
+	}
+
+	serving := 0
+	for _, b := range route.Backends {
+		backend := RouteBackendHealth{Service: b.Service, Weight: b.Weight}
+		key := route.Namespace + "/" + b.Service
+		backend.Exists = existing[key]
+		if e, ok := endpoints[key]; ok {
+			backend.ReadyEndpoints, backend.NotReadyEndpoints = e.ready, e.notReady
+		}
+		result.Backends = append(result.Backends, backend)
+
+		if b.Weight != nil && *b.Weight == 0 {
+			continue
+		}
+		switch {
+		case !backend.Exists:
+			issue(false, "backend service %s does not exist", b.Service)
+		case backend.ReadyEndpoints == 0:
+			issue(false, "backend service %s has no ready endpoints", b.Service)
+		default:
+			serving++
+		}
+	}
+	if serving == 0 {
+		issue(true, "no backend service with ready endpoints")
+	}
+
+	if route.TLS != nil {
+		result.TLS = &RouteTLSHealth{
+			Termination:                   route.TLS.Termination,
+			InsecureEdgeTerminationPolicy: route.TLS.InsecureEdgeTerminationPolicy,
+		}
+		if route.TLS.Termination == "edge" || route.TLS.Termination == "reencrypt" {
+			result.TLS.Certificate = routeCertificate(route.TLS.Certificate, "certificate", now, warning, issue)
+			result.TLS.DestinationCACertificate = routeCertificate(route.TLS.DestinationCACertificate, "destination CA certificate", now, warning, issue)
+		}
+	}
+
+	switch {
+	case unhealthy:
+		result.Status = RouteUnhealthy
+	case len(result.Issues) > 0:
+		result.Status = RouteDegraded
+	}
+	return result
+}
+
+// routeCertificate checks an inline PEM certificate; an empty PEM means
+// the router's default certificate is used, which is not checked here
+func routeCertificate(pemData, name string, now time.Time, warning time.Duration, issue func(bool, string, ...interface{})) *CertificateExpiry {
+	if pemData == "" {
+		return nil
+	}
+	infos, err := certs.ParsePEM([]byte(pemData))
+	if err != nil {
+		issue(false, "%s cannot be parsed: %v", name, err)
+		return nil
+	}
+	expiry := certificateExpiry(infos[0], now, warning)
+	switch expiry.Status {
+	case certs.StatusExpired:
+		issue(true, "%s expired on %s", name, expiry.NotAfter.Format("2006-01-02"))
+	case certs.StatusNotYetValid:
+		issue(true, "%s is not valid before %s", name, expiry.NotBefore.Format("2006-01-02"))
+	case certs.StatusExpiring:
+		issue(false, "%s expires in %d days", name, expiry.DaysRemaining)
+	}
+	return expiry
+}
+
+// certificateExpiry adds the expiry status to certificate metadata
+func certificateExpiry(info certs.Info, now time.Time, warning time.Duration) *CertificateExpiry {
+	return &CertificateExpiry{
+		Info:          info,
+		DaysRemaining: info.DaysRemaining(now),
+		Status:        info.Status(now, warning),
+	}
+}
+
+// routeHealthMessage summarizes the check
+func routeHealthMessage(output *RouteHealthOutput) string {
+	s := output.Summary
+	msg := "No routes found"
+	if s.Total > 0 {
+		msg = fmt.Sprintf("%d routes: %d healthy, %d degraded, %d unhealthy", s.Total, s.Healthy, s.Degraded, s.Unhealthy)
+		if len(output.Routes) > 0 && output.Routes[0].Status == RouteUnhealthy {
+			worst := output.Routes[0]
+			msg += fmt.Sprintf(". %s/%s (%s): %s", worst.Namespace, worst.Name, worst.Host, worst.Issues[0])
+		}
+	}
+	for _, ic := range output.IngressControllers {
+		if !ic.Healthy() {
+			msg += fmt.Sprintf(". IngressController %s is not healthy: %s", ic.Name, ic.Message)
+		}
+	}
+	return msg
+}
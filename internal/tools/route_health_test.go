@@ -0,0 +1,156 @@
+package tools
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+
+	"github.com/KubeHeal/openshift-cluster-health-mcp/pkg/clients"
+)
+
+func selfSignedPEM(t *testing.T, cn string, notAfter time.Time) string {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: cn},
+		NotBefore:    notAfter.Add(-365 * 24 * time.Hour),
+		NotAfter:     notAfter,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+}
+
+func admittedRoute(name, service string) clients.Route {
+	return clients.Route{
+		Name:      name,
+		Namespace: "app",
+		Host:      name + ".apps.example.com",
+		Backends:  []clients.RouteBackend{{Service: service}},
+		Ingress:   []clients.RouteIngress{{RouterName: "default", Admitted: true}},
+	}
+}
+
+func readySlice(service string, ready ...bool) []discoveryv1.EndpointSlice {
+	return []discoveryv1.EndpointSlice{endpointSlice("app", service, ready...)}
+}
+
+func TestRouteHealthTool_Metadata(t *testing.T) {
+	tool := NewRouteHealthTool(nil)
+	if tool.Name() != "check-route-health" {
+		t.Errorf("unexpected name %s", tool.Name())
+	}
+	props := tool.InputSchema()["properties"].(map[string]interface{})
+	for _, key := range []string{"namespace", "route", "only_unhealthy", "cert_warning_days"} {
+		if _, ok := props[key]; !ok {
+			t.Errorf("schema missing %s", key)
+		}
+	}
+}
+
+func TestAnalyzeRoutes_Backends(t *testing.T) {
+	now := time.Now()
+	missing := admittedRoute("missing", "gone")
+	canary := admittedRoute("canary", "shop")
+	zero := int64(0)
+	canary.Backends = append(canary.Backends, clients.RouteBackend{Service: "gone", Weight: &zero})
+	unready := admittedRoute("unready", "shop-v2")
+
+	services := []corev1.Service{selectedService("app", "shop"), selectedService("app", "shop-v2")}
+	slices := append(readySlice("shop", true, true), readySlice("shop-v2", false)...)
+	output := analyzeRoutes([]clients.Route{missing, canary, unready}, services, slices, nil, RouteHealthInput{CertWarningDays: 30}, now)
+
+	status := map[string]RouteHealth{}
+	for _, r := range output.Routes {
+		status[r.Name] = r
+	}
+	if r := status["missing"]; r.Status != RouteUnhealthy || r.Issues[0] != "no backend service with ready endpoints" || !contains(r.Issues[1], "does not exist") {
+		t.Errorf("missing = %+v", r)
+	}
+	if r := status["canary"]; r.Status != RouteHealthy || r.Backends[0].ReadyEndpoints != 2 || r.Backends[1].Exists {
+		t.Errorf("canary = %+v, want zero-weight backend ignored", r)
+	}
+	if r := status["unready"]; r.Status != RouteUnhealthy || r.Backends[0].NotReadyEndpoints != 1 {
+		t.Errorf("unready = %+v", r)
+	}
+	if output.Routes[0].Status != RouteUnhealthy || output.Summary.Unhealthy != 2 {
+		t.Errorf("summary = %+v, want unhealthy routes first", output.Summary)
+	}
+}
+
+func TestAnalyzeRoutes_AdmissionAndIngressControllers(t *testing.T) {
+	sharded := admittedRoute("sharded", "shop")
+	sharded.Ingress = append(sharded.Ingress, clients.RouteIngress{RouterName: "internal", Reason: "HostAlreadyClaimed"})
+	rejected := admittedRoute("rejected", "shop")
+	rejected.Ingress = []clients.RouteIngress{{RouterName: "default", Reason: "HostAlreadyClaimed"}}
+	controllers := []clients.IngressController{
+		{Name: "default", Available: true},
+		{Name: "internal", Available: true, Degraded: true, Message: "router pods crashing"},
+	}
+	onInternal := admittedRoute("internal-only", "shop")
+	onInternal.Ingress[0].RouterName = "internal"
+
+	output := analyzeRoutes([]clients.Route{sharded, rejected, onInternal}, []corev1.Service{selectedService("app", "shop")},
+		readySlice("shop", true), controllers, RouteHealthInput{OnlyUnhealthy: true, CertWarningDays: 30}, time.Now())
+	status := map[string]RouteHealth{}
+	for _, r := range output.Routes {
+		status[r.Name] = r
+	}
+	if r := status["sharded"]; r.Status != RouteDegraded || !contains(r.Issues[0], "router internal") {
+		t.Errorf("sharded = %+v, want degraded by one rejecting shard", r)
+	}
+	if r := status["rejected"]; r.Status != RouteUnhealthy || r.Issues[0] != "not admitted by any router" {
+		t.Errorf("rejected = %+v", r)
+	}
+	if r := status["internal-only"]; r.Status != RouteDegraded || !contains(r.Issues[0], "router pods crashing") {
+		t.Errorf("internal-only = %+v, want the degraded IngressController reported", r)
+	}
+	if msg := routeHealthMessage(&output); !contains(msg, "IngressController internal is not healthy") {
+		t.Errorf("message = %q", msg)
+	}
+}
+
+func TestAnalyzeRoutes_Certificates(t *testing.T) {
+	now := time.Now()
+	expiring := admittedRoute("expiring", "shop")
+	expiring.TLS = &clients.RouteTLS{Termination: "edge", Certificate: selfSignedPEM(t, "expiring", now.Add(10*24*time.Hour+time.Hour))}
+	expired := admittedRoute("expired", "shop")
+	expired.TLS = &clients.RouteTLS{
+		Termination:              "reencrypt",
+		Certificate:              selfSignedPEM(t, "ok", now.Add(200*24*time.Hour)),
+		DestinationCACertificate: selfSignedPEM(t, "backend-ca", now.Add(-24*time.Hour)),
+	}
+	passthrough := admittedRoute("passthrough", "shop")
+	passthrough.TLS = &clients.RouteTLS{Termination: "passthrough"}
+
+	output := analyzeRoutes([]clients.Route{expiring, expired, passthrough}, []corev1.Service{selectedService("app", "shop")},
+		readySlice("shop", true), nil, RouteHealthInput{CertWarningDays: 30}, now)
+	status := map[string]RouteHealth{}
+	for _, r := range output.Routes {
+		status[r.Name] = r
+	}
+	if r := status["expiring"]; r.Status != RouteDegraded || r.TLS.Certificate.DaysRemaining != 10 || r.TLS.Certificate.Subject != "CN=expiring" {
+		t.Errorf("expiring = %+v", r)
+	}
+	if r := status["expired"]; r.Status != RouteUnhealthy || r.TLS.Certificate.Status != "valid" || !contains(r.Issues[0], "destination CA certificate expired") {
+		t.Errorf("expired = %+v", r)
+	}
+	if r := status["passthrough"]; r.Status != RouteHealthy || r.TLS.Certificate != nil {
+		t.Errorf("passthrough = %+v, want the backend certificate left unchecked", r)
+	}
+}
@@ -0,0 +1,518 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/KubeHeal/openshift-cluster-health-mcp/pkg/capacity"
+	"github.com/KubeHeal/openshift-cluster-health-mcp/pkg/clients"
+)
+
+// Security posture checks; these IDs are used in suppressions
+const (
+	SecurityCheckPrivileged       = "privileged"
+	SecurityCheckHostNetwork      = "host_network"
+	SecurityCheckHostPID          = "host_pid"
+	SecurityCheckHostIPC          = "host_ipc"
+	SecurityCheckHostPath         = "host_path"
+	SecurityCheckRunAsRoot        = "run_as_root"
+	SecurityCheckCapabilities     = "added_capabilities"
+	SecurityCheckSeccomp          = "missing_seccomp"
+	SecurityCheckSCC              = "permissive_scc"
+	SecurityCheckLatestTag        = "latest_tag"
+	SecurityCheckNoDigest         = "no_digest"
+	SecurityCheckAutomountedToken = "automounted_token"
+)
+
+// Security finding severities
+const (
+	SecurityCritical = "critical"
+	SecurityHigh     = "high"
+	SecurityMedium   = "medium"
+	SecurityLow      = "low"
+)
+
+// securitySeverities lists the severities from most to least severe
+var securitySeverities = []string{SecurityCritical, SecurityHigh, SecurityMedium, SecurityLow}
+
+// sccAnnotation records the SecurityContextConstraints admitting a pod
+const sccAnnotation = "openshift.io/scc"
+
+// permissiveSCCs are the builtin SCCs that grant host access or arbitrary
+// UIDs, with the severity of a pod running under them
+var permissiveSCCs = map[string]string{
+	"privileged":       SecurityHigh,
+	"hostaccess":       SecurityHigh,
+	"hostmount-anyuid": SecurityHigh,
+	"hostnetwork":      SecurityMedium,
+	"hostnetwork-v2":   SecurityMedium,
+	"node-exporter":    SecurityMedium,
+	"anyuid":           SecurityMedium,
+}
+
+// dangerousCapabilities effectively grant root on the node or network
+var dangerousCapabilities = map[corev1.Capability]bool{
+	"ALL":             true,
+	"SYS_ADMIN":       true,
+	"NET_ADMIN":       true,
+	"SYS_PTRACE":      true,
+	"SYS_MODULE":      true,
+	"DAC_READ_SEARCH": true,
+	"SYS_RAWIO":       true,
+}
+
+// SecurityPostureTool scans pod specs for risky security settings via MCP
+type SecurityPostureTool struct {
+	k8sClient    *clients.K8sClient
+	suppressions map[string][]string
+}
+
+// NewSecurityPostureTool creates a new scan-security-posture tool.
+// suppressions maps namespace patterns (exact or '*'-suffixed prefixes)
+// to the check IDs ignored there ("*" = all checks).
+func NewSecurityPostureTool(k8sClient *clients.K8sClient, suppressions map[string][]string) *SecurityPostureTool {
+	return &SecurityPostureTool{
+		k8sClient:    k8sClient,
+		suppressions: suppressions,
+	}
+}
+
+// Name returns the tool name for MCP registration
+func (t *SecurityPostureTool) Name() string {
+	return "scan-security-posture"
+}
+
+// Description returns the tool description for MCP
+func (t *SecurityPostureTool) Description() string {
+	return "Scan pod specs for risky security settings: privileged containers, host network/PID/IPC and hostPath mounts, running as root, added capabilities, missing seccomp, the OpenShift SCC actually applied, :latest or undigested images and automounted ServiceAccount tokens on pods using the default ServiceAccount. Findings are grouped by namespace and severity; per-namespace suppressions are configured on the server"
+}
+
+// InputSchema returns the JSON schema for tool inputs
+func (t *SecurityPostureTool) InputSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"namespace": map[string]interface{}{
+				"type":        "string",
+				"description": "Only scan this namespace (empty = all namespaces)",
+				"default":     "",
+			},
+			"include_system_namespaces": map[string]interface{}{
+				"type":        "boolean",
+				"description": "Include openshift-* and kube-* namespaces when scanning all namespaces (platform components legitimately run privileged)",
+				"default":     false,
+			},
+			"min_severity": map[string]interface{}{
+				"type":        "string",
+				"description": "Only report findings at or above this severity",
+				"enum":        securitySeverities,
+				"default":     SecurityLow,
+			},
+			"checks": map[string]interface{}{
+				"type":        "array",
+				"description": "Only run these checks (default: all)",
+				"items": map[string]interface{}{
+					"type": "string",
+					"enum": []string{
+						SecurityCheckPrivileged, SecurityCheckHostNetwork, SecurityCheckHostPID, SecurityCheckHostIPC,
+						SecurityCheckHostPath, SecurityCheckRunAsRoot, SecurityCheckCapabilities, SecurityCheckSeccomp,
+						SecurityCheckSCC, SecurityCheckLatestTag, SecurityCheckNoDigest, SecurityCheckAutomountedToken,
+					},
+				},
+			},
+		},
+		"required": []string{},
+	}
+}
+
+// SecurityPostureInput represents the input parameters
+type SecurityPostureInput struct {
+	Namespace               string   `json:"namespace"`
+	IncludeSystemNamespaces bool     `json:"include_system_namespaces"`
+	MinSeverity             string   `json:"min_severity"`
+	Checks                  []string `json:"checks"`
+}
+
+// SecurityFinding is one risky setting of a workload. Identical findings
+// from replicas of the same workload are reported once.
+type SecurityFinding struct {
+	Check     string `json:"check"`
+	Severity  string `json:"severity"`
+	Workload  string `json:"workload"` // Kind/name of the owning controller, or Pod/name
+	Container string `json:"container,omitempty"`
+	Detail    string `json:"detail"`
+	Pods      int    `json:"pods"`
+}
+
+// SecuritySeverityGroup holds the findings of one severity
+type SecuritySeverityGroup struct {
+	Severity string            `json:"severity"`
+	Findings []SecurityFinding `json:"findings"`
+}
+
+// NamespaceSecurityPosture holds the findings of one namespace
+type NamespaceSecurityPosture struct {
+	Namespace  string                  `json:"namespace"`
+	Pods       int                     `json:"pods"`
+	Critical   int                     `json:"critical"`
+	High       int                     `json:"high"`
+	Medium     int                     `json:"medium"`
+	Low        int                     `json:"low"`
+	Suppressed int                     `json:"suppressed,omitempty"`
+	SCCs       map[string]int          `json:"sccs,omitempty"` // Pods per applied SCC
+	Severities []SecuritySeverityGroup `json:"severities"`     // Most severe first
+}
+
+// SecurityPostureSummary counts findings cluster-wide
+type SecurityPostureSummary struct {
+	Namespaces int            `json:"namespaces"`
+	Pods       int            `json:"pods"`
+	Findings   int            `json:"findings"`
+	Critical   int            `json:"critical"`
+	High       int            `json:"high"`
+	Medium     int            `json:"medium"`
+	Low        int            `json:"low"`
+	Suppressed int            `json:"suppressed"`
+	ByCheck    map[string]int `json:"by_check"`
+	SCCs       map[string]int `json:"sccs,omitempty"`
+}
+
+// SecurityPostureOutput represents the tool output
+type SecurityPostureOutput struct {
+	Summary    SecurityPostureSummary     `json:"summary"`
+	Namespaces []NamespaceSecurityPosture `json:"namespaces"` // Namespaces with findings, worst first
+	Message    string                     `json:"message"`
+}
+
+// Execute scans the pods
+func (t *SecurityPostureTool) Execute(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+	input := SecurityPostureInput{MinSeverity: SecurityLow}
+	if argsJSON, err := json.Marshal(args); err == nil {
+		_ = json.Unmarshal(argsJSON, &input) //nolint:errcheck // Intentionally ignore error, use defaults if unmarshal fails
+	}
+	if securitySeverityRank(input.MinSeverity) < 0 {
+		return nil, fmt.Errorf("invalid min_severity %q: must be one of %s", input.MinSeverity, strings.Join(securitySeverities, ", "))
+	}
+
+	pods, err := t.k8sClient.ListPods(ctx, input.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	output := analyzeSecurityPosture(pods.Items, input, t.suppressions)
+	output.Message = securityPostureMessage(&output)
+	return output, nil
+}
+
+// analyzeSecurityPosture checks active pods and groups the findings by
+// namespace and severity. Suppressed findings are only counted.
+func analyzeSecurityPosture(pods []corev1.Pod, input SecurityPostureInput, suppressions map[string][]string) SecurityPostureOutput {
+	output := SecurityPostureOutput{
+		Summary:    SecurityPostureSummary{ByCheck: make(map[string]int)},
+		Namespaces: []NamespaceSecurityPosture{},
+	}
+	checks := make(map[string]bool, len(input.Checks))
+	for _, c := range input.Checks {
+		checks[c] = true
+	}
+	minRank := securitySeverityRank(input.MinSeverity)
+
+	type findingKey struct{ workload, container, check, detail string }
+	namespaces := make(map[string]*NamespaceSecurityPosture)
+	findings := make(map[string]map[findingKey]*SecurityFinding)
+	for i := range pods {
+		pod := &pods[i]
+		if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		if input.Namespace == "" && !input.IncludeSystemNamespaces && isSystemNamespace(pod.Namespace) {
+			continue
+		}
+
+		ns, ok := namespaces[pod.Namespace]
+		if !ok {
+			ns = &NamespaceSecurityPosture{Namespace: pod.Namespace}
+			namespaces[pod.Namespace] = ns
+			findings[pod.Namespace] = make(map[findingKey]*SecurityFinding)
+		}
+		ns.Pods++
+		output.Summary.Pods++
+		if scc := pod.Annotations[sccAnnotation]; scc != "" {
+			if ns.SCCs == nil {
+				ns.SCCs = make(map[string]int)
+			}
+			if output.Summary.SCCs == nil {
+				output.Summary.SCCs = make(map[string]int)
+			}
+			ns.SCCs[scc]++
+			output.Summary.SCCs[scc]++
+		}
+
+		workload := "Pod/" + pod.Name
+		if ref := capacity.WorkloadOf(pod); ref.Name != "" {
+			workload = ref.Kind + "/" + ref.Name
+		}
+		for _, f := range podSecurityFindings(pod) {
+			if len(checks) > 0 && !checks[f.Check] {
+				continue
+			}
+			if securitySeverityRank(f.Severity) > minRank {
+				continue
+			}
+			if isSecuritySuppressed(suppressions, pod.Namespace, f.Check) {
+				ns.Suppressed++
+				output.Summary.Suppressed++
+				continue
+			}
+			key := findingKey{workload, f.Container, f.Check, f.Detail}
+			if existing, ok := findings[pod.Namespace][key]; ok {
+				existing.Pods++
+				continue
+			}
+			f.Workload = workload
+			f.Pods = 1
+			findings[pod.Namespace][key] = &f
+		}
+	}
+
+	for name, ns := range namespaces {
+		output.Summary.Namespaces++
+		if len(findings[name]) == 0 {
+			continue
+		}
+		bySeverity := make(map[string][]SecurityFinding)
+		for _, f := range findings[name] {
+			bySeverity[f.Severity] = append(bySeverity[f.Severity], *f)
+			output.Summary.ByCheck[f.Check]++
+			output.Summary.Findings++
+			switch f.Severity {
+			case SecurityCritical:
+				ns.Critical++
+				output.Summary.Critical++
+			case SecurityHigh:
+				ns.High++
+				output.Summary.High++
+			case SecurityMedium:
+				ns.Medium++
+				output.Summary.Medium++
+			default:
+				ns.Low++
+				output.Summary.Low++
+			}
+		}
+		for _, severity := range securitySeverities {
+			group := bySeverity[severity]
+			if len(group) == 0 {
+				continue
+			}
+			sort.Slice(group, func(i, j int) bool {
+				if group[i].Check != group[j].Check {
+					return group[i].Check < group[j].Check
+				}
+				if group[i].Workload != group[j].Workload {
+					return group[i].Workload < group[j].Workload
+				}
+				if group[i].Container != group[j].Container {
+					return group[i].Container < group[j].Container
+				}
+				return group[i].Detail < group[j].Detail
+			})
+			ns.Severities = append(ns.Severities, SecuritySeverityGroup{Severity: severity, Findings: group})
+		}
+		output.Namespaces = append(output.Namespaces, *ns)
+	}
+
+	sort.Slice(output.Namespaces, func(i, j int) bool {
+		a, b := output.Namespaces[i], output.Namespaces[j]
+		for _, pair := range [][2]int{{a.Critical, b.Critical}, {a.High, b.High}, {a.Medium, b.Medium}, {a.Low, b.Low}} {
+			if pair[0] != pair[1] {
+				return pair[0] > pair[1]
+			}
+		}
+		return a.Namespace < b.Namespace
+	})
+	return output
+}
+
+// podSecurityFindings runs every check against a pod. Container settings
+// override the pod security context, as the kubelet applies them.
+func podSecurityFindings(pod *corev1.Pod) []SecurityFinding {
+	var findings []SecurityFinding
+	add := func(check, severity, container, format string, args ...interface{}) {
+		findings = append(findings, SecurityFinding{
+			Check:     check,
+			Severity:  severity,
+			Container: container,
+			Detail:    fmt.Sprintf(format, args...),
+		})
+	}
+	spec := &pod.Spec
+	podSC := spec.SecurityContext
+	if podSC == nil {
+		podSC = &corev1.PodSecurityContext{}
+	}
+
+	if spec.HostNetwork {
+		add(SecurityCheckHostNetwork, SecurityHigh, "", "shares the node network namespace")
+	}
+	if spec.HostPID {
+		add(SecurityCheckHostPID, SecurityHigh, "", "shares the node PID namespace")
+	}
+	if spec.HostIPC {
+		add(SecurityCheckHostIPC, SecurityHigh, "", "shares the node IPC namespace")
+	}
+	for _, v := range spec.Volumes {
+		if v.HostPath != nil {
+			add(SecurityCheckHostPath, SecurityHigh, "", "mounts host path %s (volume %s)", v.HostPath.Path, v.Name)
+		}
+	}
+	if scc := pod.Annotations[sccAnnotation]; scc != "" {
+		if severity, ok := permissiveSCCs[scc]; ok {
+			add(SecurityCheckSCC, severity, "", "admitted by SCC %s", scc)
+		}
+	}
+	if serviceAccountTokenMounted(pod) && (spec.ServiceAccountName == "" || spec.ServiceAccountName == "default") {
+		add(SecurityCheckAutomountedToken, SecurityLow, "", "mounts a token for the default ServiceAccount; set automountServiceAccountToken: false if the pod does not call the API")
+	}
+
+	containers := append(append([]corev1.Container{}, spec.InitContainers...), spec.Containers...)
+	for i := range containers {
+		c := &containers[i]
+		sc := c.SecurityContext
+		if sc == nil {
+			sc = &corev1.SecurityContext{}
+		}
+
+		if sc.Privileged != nil && *sc.Privileged {
+			add(SecurityCheckPrivileged, SecurityCritical, c.Name, "runs privileged")
+		}
+
+		runAsUser, runAsNonRoot := podSC.RunAsUser, podSC.RunAsNonRoot
+		if sc.RunAsUser != nil {
+			runAsUser = sc.RunAsUser
+		}
+		if sc.RunAsNonRoot != nil {
+			runAsNonRoot = sc.RunAsNonRoot
+		}
+		switch {
+		case runAsUser != nil && *runAsUser == 0:
+			add(SecurityCheckRunAsRoot, SecurityHigh, c.Name, "runs as UID 0")
+		case runAsUser == nil && (runAsNonRoot == nil || !*runAsNonRoot):
+			add(SecurityCheckRunAsRoot, SecurityMedium, c.Name, "no runAsUser or runAsNonRoot: runs as the image user, which may be root")
+		}
+
+		if sc.Capabilities != nil {
+			for _, capability := range sc.Capabilities.Add {
+				severity := SecurityMedium
+				if dangerousCapabilities[capability] {
+					severity = SecurityHigh
+				}
+				add(SecurityCheckCapabilities, severity, c.Name, "adds capability %s", capability)
+			}
+		}
+
+		seccomp := podSC.SeccompProfile
+		if sc.SeccompProfile != nil {
+			seccomp = sc.SeccompProfile
+		}
+		switch {
+		case seccomp == nil:
+			add(SecurityCheckSeccomp, SecurityLow, c.Name, "no seccomp profile")
+		case seccomp.Type == corev1.SeccompProfileTypeUnconfined:
+			add(SecurityCheckSeccomp, SecurityMedium, c.Name, "seccomp profile is Unconfined")
+		}
+
+		switch tag, digest := imageTagAndDigest(c.Image); {
+		case digest:
+		case tag == "":
+			add(SecurityCheckLatestTag, SecurityMedium, c.Name, "image %s has no tag (defaults to :latest)", c.Image)
+		case tag == "latest":
+			add(SecurityCheckLatestTag, SecurityMedium, c.Name, "image %s uses :latest", c.Image)
+		default:
+			add(SecurityCheckNoDigest, SecurityLow, c.Name, "image %s is not pinned by digest", c.Image)
+		}
+	}
+	return findings
+}
+
+// imageTagAndDigest returns the tag of an image reference and whether it is
+// pinned by digest. Registry ports ("host:5000/app") are not tags.
+func imageTagAndDigest(image string) (string, bool) {
+	if strings.Contains(image, "@") {
+		return "", true
+	}
+	name := image[strings.LastIndex(image, "/")+1:]
+	if _, tag, ok := strings.Cut(name, ":"); ok {
+		return tag, false
+	}
+	return "", false
+}
+
+// serviceAccountTokenMounted reports whether the pod has a projected
+// ServiceAccount token volume, as injected when automounting is enabled
+func serviceAccountTokenMounted(pod *corev1.Pod) bool {
+	for _, v := range pod.Spec.Volumes {
+		if v.Projected == nil {
+			continue
+		}
+		for _, source := range v.Projected.Sources {
+			if source.ServiceAccountToken != nil {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// isSecuritySuppressed reports whether a check is suppressed in a namespace.
+// Namespace patterns are exact names or prefixes ending in '*'.
+func isSecuritySuppressed(suppressions map[string][]string, namespace, check string) bool {
+	for pattern, checks := range suppressions {
+		if strings.HasSuffix(pattern, "*") {
+			if !strings.HasPrefix(namespace, strings.TrimSuffix(pattern, "*")) {
+				continue
+			}
+		} else if namespace != pattern {
+			continue
+		}
+		for _, c := range checks {
+			if c == "*" || c == check {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// securitySeverityRank returns the position of a severity in
+// securitySeverities (0 = critical), or -1 if unknown
+func securitySeverityRank(severity string) int {
+	for i, s := range securitySeverities {
+		if s == severity {
+			return i
+		}
+	}
+	return -1
+}
+
+// securityPostureMessage summarizes the scan
+func securityPostureMessage(output *SecurityPostureOutput) string {
+	s := output.Summary
+	msg := fmt.Sprintf("Scanned %d pods in %d namespaces: %d findings (%d critical, %d high, %d medium, %d low)",
+		s.Pods, s.Namespaces, s.Findings, s.Critical, s.High, s.Medium, s.Low)
+	if s.Suppressed > 0 {
+		msg += fmt.Sprintf(", %d suppressed", s.Suppressed)
+	}
+	if n := s.ByCheck[SecurityCheckPrivileged]; n > 0 {
+		msg += fmt.Sprintf(". %d workload containers run privileged", n)
+	}
+	if len(output.Namespaces) > 0 && (output.Namespaces[0].Critical > 0 || output.Namespaces[0].High > 0) {
+		msg += fmt.Sprintf(". Worst namespace: %s", output.Namespaces[0].Namespace)
+	}
+	return msg
+}
@@ -0,0 +1,145 @@
+package tools
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func securedPod(namespace, name, image string) corev1.Pod {
+	nonRoot := true
+	return corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Spec: corev1.PodSpec{
+			ServiceAccountName: "app",
+			SecurityContext: &corev1.PodSecurityContext{
+				RunAsNonRoot:   &nonRoot,
+				SeccompProfile: &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeRuntimeDefault},
+			},
+			Containers: []corev1.Container{{Name: "main", Image: image}},
+		},
+		Status: corev1.PodStatus{Phase: corev1.PodRunning},
+	}
+}
+
+func TestSecurityPostureTool_Metadata(t *testing.T) {
+	tool := NewSecurityPostureTool(nil, nil)
+	if tool.Name() != "scan-security-posture" {
+		t.Errorf("unexpected name %s", tool.Name())
+	}
+	props := tool.InputSchema()["properties"].(map[string]interface{})
+	for _, key := range []string{"namespace", "include_system_namespaces", "min_severity", "checks"} {
+		if _, ok := props[key]; !ok {
+			t.Errorf("schema missing %s", key)
+		}
+	}
+}
+
+func TestPodSecurityFindings(t *testing.T) {
+	clean := securedPod("app", "clean", "quay.io/app/api@sha256:abc")
+	if findings := podSecurityFindings(&clean); len(findings) != 0 {
+		t.Errorf("clean pod findings = %+v", findings)
+	}
+
+	privileged, root := true, int64(0)
+	risky := securedPod("app", "risky", "registry.local:5000/app/agent")
+	risky.Annotations = map[string]string{sccAnnotation: "privileged"}
+	risky.Spec.ServiceAccountName = ""
+	risky.Spec.HostNetwork = true
+	risky.Spec.HostPID = true
+	risky.Spec.Volumes = []corev1.Volume{
+		{Name: "root", VolumeSource: corev1.VolumeSource{HostPath: &corev1.HostPathVolumeSource{Path: "/"}}},
+		{Name: "kube-api-access", VolumeSource: corev1.VolumeSource{Projected: &corev1.ProjectedVolumeSource{
+			Sources: []corev1.VolumeProjection{{ServiceAccountToken: &corev1.ServiceAccountTokenProjection{Path: "token"}}},
+		}}},
+	}
+	risky.Spec.InitContainers = []corev1.Container{{Name: "init", Image: "busybox:1.36"}}
+	risky.Spec.Containers[0].SecurityContext = &corev1.SecurityContext{
+		Privileged:     &privileged,
+		RunAsUser:      &root,
+		Capabilities:   &corev1.Capabilities{Add: []corev1.Capability{"SYS_ADMIN", "NET_BIND_SERVICE"}},
+		SeccompProfile: &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeUnconfined},
+	}
+
+	got := map[string]string{}
+	for _, f := range podSecurityFindings(&risky) {
+		got[f.Check+"/"+f.Container+"/"+f.Severity] = f.Detail
+	}
+	for _, want := range []string{
+		"host_network//high", "host_pid//high", "host_path//high", "permissive_scc//high", "automounted_token//low",
+		"privileged/main/critical", "run_as_root/main/high", "added_capabilities/main/high", "added_capabilities/main/medium",
+		"missing_seccomp/main/medium", "latest_tag/main/medium", "no_digest/init/low",
+	} {
+		if _, ok := got[want]; !ok {
+			t.Errorf("missing finding %s in %v", want, got)
+		}
+	}
+	if d := got["latest_tag/main/medium"]; !contains(d, "has no tag") {
+		t.Errorf("latest_tag detail = %q, want the registry port not taken as a tag", d)
+	}
+	if _, ok := got["run_as_root/init/medium"]; ok {
+		t.Errorf("init container inherits runAsNonRoot from the pod: %v", got)
+	}
+}
+
+func TestAnalyzeSecurityPosture(t *testing.T) {
+	privileged := true
+	replica := func(name string) corev1.Pod {
+		p := securedPod("shop", name, "quay.io/shop/api:latest")
+		p.Labels = map[string]string{"pod-template-hash": "5d9f"}
+		p.OwnerReferences = []metav1.OwnerReference{{Kind: "ReplicaSet", Name: "api-5d9f", Controller: &privileged}}
+		p.Spec.Containers[0].SecurityContext = &corev1.SecurityContext{Privileged: &privileged}
+		return p
+	}
+	monitoring := securedPod("monitoring", "exporter", "quay.io/exporter@sha256:abc")
+	monitoring.Spec.HostNetwork = true
+	system := securedPod("openshift-sdn", "sdn", "quay.io/sdn@sha256:abc")
+	system.Spec.HostNetwork = true
+	done := replica("api-old")
+	done.Status.Phase = corev1.PodSucceeded
+	pods := []corev1.Pod{replica("api-1"), replica("api-2"), monitoring, system, done}
+
+	suppressions := map[string][]string{"monitor*": {"host_network"}}
+	output := analyzeSecurityPosture(pods, SecurityPostureInput{MinSeverity: SecurityLow}, suppressions)
+
+	if output.Summary.Pods != 3 || output.Summary.Namespaces != 2 || output.Summary.Suppressed != 1 {
+		t.Errorf("summary = %+v, want system and completed pods skipped", output.Summary)
+	}
+	if len(output.Namespaces) != 1 || output.Namespaces[0].Namespace != "shop" {
+		t.Fatalf("namespaces = %+v, want only shop with findings", output.Namespaces)
+	}
+	shop := output.Namespaces[0]
+	if shop.Critical != 1 || shop.Medium != 1 || shop.Severities[0].Severity != SecurityCritical {
+		t.Errorf("shop = %+v", shop)
+	}
+	if f := shop.Severities[0].Findings[0]; f.Workload != "Deployment/api" || f.Pods != 2 || f.Container != "main" {
+		t.Errorf("finding = %+v, want both replicas collapsed onto the Deployment", f)
+	}
+
+	high := analyzeSecurityPosture(pods, SecurityPostureInput{MinSeverity: SecurityHigh, IncludeSystemNamespaces: true}, suppressions)
+	if high.Summary.Findings != 2 || high.Summary.ByCheck[SecurityCheckHostNetwork] != 1 {
+		t.Errorf("summary = %+v, want privileged and the system host_network finding", high.Summary)
+	}
+	if msg := securityPostureMessage(&output); !contains(msg, "1 workload containers run privileged") || !contains(msg, "Worst namespace: shop") {
+		t.Errorf("message = %q", msg)
+	}
+}
+
+func TestIsSecuritySuppressed(t *testing.T) {
+	suppressions := map[string][]string{"ci-*": {"*"}, "monitoring": {"host_path"}}
+	tests := []struct {
+		namespace, check string
+		want             bool
+	}{
+		{"ci-42", "privileged", true},
+		{"monitoring", "host_path", true},
+		{"monitoring", "privileged", false},
+		{"monitoring-2", "host_path", false},
+	}
+	for _, tt := range tests {
+		if got := isSecuritySuppressed(suppressions, tt.namespace, tt.check); got != tt.want {
+			t.Errorf("isSecuritySuppressed(%s, %s) = %v, want %v", tt.namespace, tt.check, got, tt.want)
+		}
+	}
+}
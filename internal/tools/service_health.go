@@ -0,0 +1,460 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/KubeHeal/openshift-cluster-health-mcp/pkg/clients"
+)
+
+// Service health statuses
+const (
+	ServiceHealthy   = "healthy"
+	ServiceDegraded  = "degraded"
+	ServiceUnhealthy = "unhealthy"
+	ServiceExternal  = "external" // ExternalName services have no endpoints to check
+)
+
+// ServiceHealthTool checks Service endpoints, selectors and target ports via MCP
+type ServiceHealthTool struct {
+	k8sClient *clients.K8sClient
+}
+
+// NewServiceHealthTool creates a new check-service-health tool
+func NewServiceHealthTool(k8sClient *clients.K8sClient) *ServiceHealthTool {
+	return &ServiceHealthTool{
+		k8sClient: k8sClient,
+	}
+}
+
+// Name returns the tool name for MCP registration
+func (t *ServiceHealthTool) Name() string {
+	return "check-service-health"
+}
+
+// Description returns the tool description for MCP
+func (t *ServiceHealthTool) Description() string {
+	return "Check the Services of a namespace (or one Service): ready and not-ready endpoints from EndpointSlices, selectors matching no pods, target ports no container exposes, and pods excluded from endpoints by failing readiness, traced back to their readiness probes and probe-failure events"
+}
+
+// InputSchema returns the JSON schema for tool inputs
+func (t *ServiceHealthTool) InputSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"namespace": map[string]interface{}{
+				"type":        "string",
+				"description": "Namespace of the Services",
+			},
+			"service": map[string]interface{}{
+				"type":        "string",
+				"description": "Only check this Service (empty = all Services in the namespace)",
+				"default":     "",
+			},
+			"only_unhealthy": map[string]interface{}{
+				"type":        "boolean",
+				"description": "Only return Services that are not healthy",
+				"default":     false,
+			},
+		},
+		"required": []string{"namespace"},
+	}
+}
+
+// ServiceHealthInput represents the input parameters
+type ServiceHealthInput struct {
+	Namespace     string `json:"namespace"`
+	Service       string `json:"service"`
+	OnlyUnhealthy bool   `json:"only_unhealthy"`
+}
+
+// ServicePortCheck is the resolution of one Service port to container ports
+type ServicePortCheck struct {
+	Name       string `json:"name,omitempty"`
+	Port       int32  `json:"port"`
+	TargetPort string `json:"target_port"`
+	Protocol   string `json:"protocol"`
+	// Exposed is false when no selected pod declares the target port
+	Exposed bool   `json:"exposed"`
+	Issue   string `json:"issue,omitempty"`
+}
+
+// ExcludedPod is a selected pod that does not serve ready endpoints
+type ExcludedPod struct {
+	Name               string      `json:"name"`
+	Phase              string      `json:"phase"`
+	Node               string      `json:"node,omitempty"`
+	Reason             string      `json:"reason,omitempty"`
+	Message            string      `json:"message,omitempty"`
+	NotReadyContainers []string    `json:"not_ready_containers,omitempty"`
+	ReadinessProbes    []ProbeInfo `json:"readiness_probes,omitempty"`
+	// ProbeFailure is the latest readiness probe failure event message
+	ProbeFailure  string `json:"probe_failure,omitempty"`
+	ProbeFailures int32  `json:"probe_failures,omitempty"`
+}
+
+// ServiceHealth is the endpoint health of one Service
+type ServiceHealth struct {
+	Name              string             `json:"name"`
+	Namespace         string             `json:"namespace"`
+	Type              string             `json:"type"`
+	Status            string             `json:"status"`
+	Selector          map[string]string  `json:"selector,omitempty"`
+	ReadyEndpoints    int                `json:"ready_endpoints"`
+	NotReadyEndpoints int                `json:"not_ready_endpoints"`
+	SelectedPods      int                `json:"selected_pods"`
+	Ports             []ServicePortCheck `json:"ports,omitempty"`
+	ExcludedPods      []ExcludedPod      `json:"excluded_pods,omitempty"`
+	Issues            []string           `json:"issues,omitempty"`
+}
+
+// ServiceHealthSummary counts Services by status
+type ServiceHealthSummary struct {
+	Total     int `json:"total"`
+	Healthy   int `json:"healthy"`
+	Degraded  int `json:"degraded"`
+	Unhealthy int `json:"unhealthy"`
+}
+
+// ServiceHealthOutput represents the tool output
+type ServiceHealthOutput struct {
+	Summary  ServiceHealthSummary `json:"summary"`
+	Services []ServiceHealth      `json:"services"`
+	Notes    []string             `json:"notes,omitempty"`
+	Message  string               `json:"message"`
+}
+
+// serviceEndpoints aggregates the EndpointSlices of one Service
+type serviceEndpoints struct {
+	ready    int
+	notReady int
+	// readyPods holds the names of pods backing a ready endpoint
+	readyPods map[string]bool
+}
+
+// Execute checks Service endpoint health
+func (t *ServiceHealthTool) Execute(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+	var input ServiceHealthInput
+	if argsJSON, err := json.Marshal(args); err == nil {
+		_ = json.Unmarshal(argsJSON, &input) //nolint:errcheck // Intentionally ignore error, use defaults if unmarshal fails
+	}
+	if input.Namespace == "" {
+		return nil, fmt.Errorf("namespace is required")
+	}
+
+	services, err := t.k8sClient.ListServices(ctx, input.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	var selected []corev1.Service
+	for _, svc := range services.Items {
+		if input.Service == "" || svc.Name == input.Service {
+			selected = append(selected, svc)
+		}
+	}
+	if input.Service != "" && len(selected) == 0 {
+		return nil, fmt.Errorf("service %s/%s not found", input.Namespace, input.Service)
+	}
+
+	slices, err := t.k8sClient.ListEndpointSlices(ctx, input.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	pods, err := t.k8sClient.ListPods(ctx, input.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	var notes []string
+	events, err := t.k8sClient.ListEventTimeline(ctx, clients.EventFilter{Namespace: input.Namespace, Kind: "Pod", Reason: "Unhealthy"})
+	if err != nil {
+		notes = append(notes, fmt.Sprintf("Readiness probe failure events not available: %v", err))
+	}
+
+	output := analyzeServices(selected, slices.Items, pods.Items, events, input.OnlyUnhealthy)
+	output.Notes = notes
+	output.Message = serviceHealthMessage(&output)
+	return output, nil
+}
+
+// collectServiceEndpoints aggregates EndpointSlices per "namespace/service".
+// Endpoints without a ready condition are ready, per the EndpointSlice API.
+func collectServiceEndpoints(slices []discoveryv1.EndpointSlice) map[string]*serviceEndpoints {
+	endpoints := make(map[string]*serviceEndpoints)
+	for _, slice := range slices {
+		name := slice.Labels[discoveryv1.LabelServiceName]
+		if name == "" {
+			continue
+		}
+		key := slice.Namespace + "/" + name
+		e, ok := endpoints[key]
+		if !ok {
+			e = &serviceEndpoints{readyPods: make(map[string]bool)}
+			endpoints[key] = e
+		}
+		for _, ep := range slice.Endpoints {
+			if ep.Conditions.Ready != nil && !*ep.Conditions.Ready {
+				e.notReady++
+				continue
+			}
+			e.ready++
+			if ep.TargetRef != nil && ep.TargetRef.Kind == "Pod" {
+				e.readyPods[ep.TargetRef.Name] = true
+			}
+		}
+	}
+	return endpoints
+}
+
+// analyzeServices checks each Service against its EndpointSlices and selected pods
+func analyzeServices(services []corev1.Service, slices []discoveryv1.EndpointSlice, pods []corev1.Pod, events []clients.Event, onlyUnhealthy bool) ServiceHealthOutput {
+	endpoints := collectServiceEndpoints(slices)
+
+	// Latest readiness probe failure per pod
+	probeFailures := make(map[string]clients.Event)
+	probeCounts := make(map[string]int32)
+	for _, e := range events {
+		if !isProbeFailureEvent(e) || !strings.Contains(strings.ToLower(e.Message), "readiness probe") {
+			continue
+		}
+		probeCounts[e.Name] += e.Count
+		if latest, ok := probeFailures[e.Name]; !ok || e.LastSeen.After(latest.LastSeen) {
+			probeFailures[e.Name] = e
+		}
+	}
+
+	output := ServiceHealthOutput{Services: []ServiceHealth{}}
+	for i := range services {
+		svc := &services[i]
+		result := checkService(svc, endpoints[svc.Namespace+"/"+svc.Name], pods)
+		for j := range result.ExcludedPods {
+			p := &result.ExcludedPods[j]
+			if e, ok := probeFailures[p.Name]; ok {
+				p.ProbeFailure = e.Message
+				p.ProbeFailures = probeCounts[p.Name]
+			}
+		}
+
+		switch result.Status {
+		case ServiceHealthy:
+			output.Summary.Healthy++
+		case ServiceDegraded:
+			output.Summary.Degraded++
+		case ServiceUnhealthy:
+			output.Summary.Unhealthy++
+		}
+		output.Summary.Total++
+		if onlyUnhealthy && (result.Status == ServiceHealthy || result.Status == ServiceExternal) {
+			continue
+		}
+		output.Services = append(output.Services, result)
+	}
+
+	rank := map[string]int{ServiceUnhealthy: 0, ServiceDegraded: 1, ServiceHealthy: 2, ServiceExternal: 3}
+	sort.SliceStable(output.Services, func(i, j int) bool {
+		a, b := output.Services[i], output.Services[j]
+		if rank[a.Status] != rank[b.Status] {
+			return rank[a.Status] < rank[b.Status]
+		}
+		return a.Name < b.Name
+	})
+	return output
+}
+
+// checkService evaluates one Service; e may be nil when it has no EndpointSlices
+func checkService(svc *corev1.Service, e *serviceEndpoints, pods []corev1.Pod) ServiceHealth {
+	result := ServiceHealth{
+		Name:      svc.Name,
+		Namespace: svc.Namespace,
+		Type:      string(svc.Spec.Type),
+		Selector:  svc.Spec.Selector,
+		Status:    ServiceHealthy,
+	}
+	if svc.Spec.Type == corev1.ServiceTypeExternalName {
+		result.Status = ServiceExternal
+		return result
+	}
+	if e == nil {
+		e = &serviceEndpoints{readyPods: map[string]bool{}}
+	}
+	result.ReadyEndpoints = e.ready
+	result.NotReadyEndpoints = e.notReady
+
+	if len(svc.Spec.Selector) == 0 {
+		// Endpoints are managed outside Kubernetes; only their readiness can be checked
+		if e.ready == 0 {
+			result.Status = ServiceUnhealthy
+			result.Issues = append(result.Issues, "no selector and no ready endpoints (endpoints must be managed manually)")
+		}
+		return result
+	}
+
+	selector := labels.SelectorFromSet(svc.Spec.Selector)
+	var selected []*corev1.Pod
+	for i := range pods {
+		pod := &pods[i]
+		if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		if selector.Matches(labels.Set(pod.Labels)) {
+			selected = append(selected, pod)
+		}
+	}
+	result.SelectedPods = len(selected)
+	if len(selected) == 0 {
+		result.Issues = append(result.Issues, fmt.Sprintf("selector %s matches no running pods", selector.String()))
+	}
+
+	result.Ports = checkServicePorts(svc, selected)
+	for _, p := range result.Ports {
+		if p.Issue != "" {
+			result.Issues = append(result.Issues, fmt.Sprintf("port %d: %s", p.Port, p.Issue))
+		}
+	}
+
+	for _, pod := range selected {
+		if isPodReady(pod) && (e.readyPods[pod.Name] || len(e.readyPods) == 0 && e.ready > 0) {
+			continue
+		}
+		result.ExcludedPods = append(result.ExcludedPods, excludedPod(pod))
+	}
+	if n := len(result.ExcludedPods); n > 0 {
+		result.Issues = append(result.Issues, fmt.Sprintf("%d of %d selected pods are not serving ready endpoints", n, len(selected)))
+	}
+
+	switch {
+	case e.ready == 0:
+		result.Status = ServiceUnhealthy
+		result.Issues = append([]string{"no ready endpoints"}, result.Issues...)
+	case len(result.Issues) > 0 || e.notReady > 0:
+		result.Status = ServiceDegraded
+	}
+	return result
+}
+
+// checkServicePorts resolves each Service target port against the
+// containers of the selected pods. A named target port that no container
+// declares keeps the pods out of the endpoints; a numeric port may be served
+// without being declared, so it is only reported when the pods declare
+// other ports.
+func checkServicePorts(svc *corev1.Service, pods []*corev1.Pod) []ServicePortCheck {
+	checks := make([]ServicePortCheck, 0, len(svc.Spec.Ports))
+	for _, sp := range svc.Spec.Ports {
+		target := sp.TargetPort
+		if target.Type == intstr.Int && target.IntVal == 0 {
+			target = intstr.FromInt32(sp.Port)
+		}
+		protocol := sp.Protocol
+		if protocol == "" {
+			protocol = corev1.ProtocolTCP
+		}
+		check := ServicePortCheck{
+			Name:       sp.Name,
+			Port:       sp.Port,
+			TargetPort: target.String(),
+			Protocol:   string(protocol),
+		}
+
+		declared := false
+		for _, pod := range pods {
+			for _, c := range pod.Spec.Containers {
+				for _, cp := range c.Ports {
+					cpProtocol := cp.Protocol
+					if cpProtocol == "" {
+						cpProtocol = corev1.ProtocolTCP
+					}
+					if cpProtocol != protocol {
+						continue
+					}
+					if (target.Type == intstr.String && cp.Name == target.StrVal) ||
+						(target.Type == intstr.Int && cp.ContainerPort == target.IntVal) {
+						check.Exposed = true
+					}
+					declared = true
+				}
+			}
+		}
+
+		switch {
+		case len(pods) == 0 || check.Exposed:
+		case target.Type == intstr.String:
+			check.Issue = fmt.Sprintf("no selected container declares a port named %q", target.StrVal)
+		case declared:
+			check.Issue = fmt.Sprintf("target port %d is not among the container ports the selected pods declare", target.IntVal)
+		}
+		checks = append(checks, check)
+	}
+	return checks
+}
+
+// excludedPod explains why a selected pod is not a ready endpoint
+func excludedPod(pod *corev1.Pod) ExcludedPod {
+	reason, message := podBlockingReason(pod)
+	p := ExcludedPod{
+		Name:    pod.Name,
+		Phase:   string(pod.Status.Phase),
+		Node:    pod.Spec.NodeName,
+		Reason:  reason,
+		Message: message,
+	}
+	if pod.DeletionTimestamp != nil {
+		p.Reason = "Terminating"
+		p.Message = fmt.Sprintf("pod is terminating since %s", pod.DeletionTimestamp.Format(time.RFC3339))
+	}
+	for _, gate := range pod.Spec.ReadinessGates {
+		for _, c := range pod.Status.Conditions {
+			if c.Type == gate.ConditionType && c.Status != corev1.ConditionTrue {
+				p.Message = strings.TrimSpace(fmt.Sprintf("%s; readiness gate %s is %s", p.Message, gate.ConditionType, c.Status))
+			}
+		}
+	}
+
+	ready := make(map[string]bool, len(pod.Status.ContainerStatuses))
+	for _, cs := range pod.Status.ContainerStatuses {
+		ready[cs.Name] = cs.Ready
+	}
+	for i := range pod.Spec.Containers {
+		c := &pod.Spec.Containers[i]
+		if ready[c.Name] {
+			continue
+		}
+		p.NotReadyContainers = append(p.NotReadyContainers, c.Name)
+		for _, probe := range probeInfos(c) {
+			if probe.Type == "readiness" {
+				p.ReadinessProbes = append(p.ReadinessProbes, probe)
+			}
+		}
+	}
+	return p
+}
+
+// serviceHealthMessage summarizes the check
+func serviceHealthMessage(output *ServiceHealthOutput) string {
+	s := output.Summary
+	if s.Total == 0 {
+		return "No services found"
+	}
+	msg := fmt.Sprintf("%d services: %d healthy, %d degraded, %d unhealthy", s.Total, s.Healthy, s.Degraded, s.Unhealthy)
+	if len(output.Services) > 0 && output.Services[0].Status == ServiceUnhealthy {
+		worst := output.Services[0]
+		msg += fmt.Sprintf(". %s has no ready endpoints", worst.Name)
+		if len(worst.ExcludedPods) > 0 {
+			p := worst.ExcludedPods[0]
+			msg += fmt.Sprintf(" (pod %s: %s", p.Name, p.Reason)
+			if p.ProbeFailure != "" {
+				msg += ", " + p.ProbeFailure
+			}
+			msg += ")"
+		}
+	}
+	return msg
+}
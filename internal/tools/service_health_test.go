@@ -0,0 +1,134 @@
+package tools
+
+import (
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/KubeHeal/openshift-cluster-health-mcp/pkg/clients"
+)
+
+func servingPod(name string, ready bool, ports ...corev1.ContainerPort) corev1.Pod {
+	pod := workloadPod(name, nil, map[string]string{"app": "api"}, ready, "", time.Now().Add(-time.Hour))
+	pod.Spec.Containers = []corev1.Container{{
+		Name:  "app",
+		Ports: ports,
+		ReadinessProbe: &corev1.Probe{
+			ProbeHandler:  corev1.ProbeHandler{HTTPGet: &corev1.HTTPGetAction{Path: "/ready", Port: intstr.FromString("http")}},
+			PeriodSeconds: 10,
+		},
+	}}
+	pod.Status.ContainerStatuses = []corev1.ContainerStatus{{Name: "app", Ready: ready}}
+	return pod
+}
+
+func podEndpoint(pod string, ready bool) discoveryv1.Endpoint {
+	return discoveryv1.Endpoint{
+		Conditions: discoveryv1.EndpointConditions{Ready: &ready},
+		TargetRef:  &corev1.ObjectReference{Kind: "Pod", Name: pod},
+	}
+}
+
+func apiService(ports ...corev1.ServicePort) corev1.Service {
+	svc := selectedService("app", "api")
+	svc.Spec.Type = corev1.ServiceTypeClusterIP
+	svc.Spec.Ports = ports
+	return svc
+}
+
+func TestServiceHealthTool_Metadata(t *testing.T) {
+	tool := NewServiceHealthTool(nil)
+	if tool.Name() != "check-service-health" {
+		t.Errorf("unexpected name %s", tool.Name())
+	}
+	if required := tool.InputSchema()["required"].([]string); len(required) != 1 || required[0] != "namespace" {
+		t.Errorf("required = %v, want namespace", required)
+	}
+}
+
+func TestAnalyzeServices_NoReadyEndpointsTracesProbeFailures(t *testing.T) {
+	http := corev1.ContainerPort{Name: "http", ContainerPort: 8080}
+	svc := apiService(corev1.ServicePort{Port: 80, TargetPort: intstr.FromString("http")})
+	slice := endpointSlice("app", "api")
+	slice.Endpoints = []discoveryv1.Endpoint{podEndpoint("api-1", false)}
+	events := []clients.Event{
+		{Namespace: "app", Kind: "Pod", Name: "api-1", Reason: "Unhealthy", Message: "Readiness probe failed: HTTP probe failed with statuscode: 503", Count: 7, LastSeen: time.Now()},
+		{Namespace: "app", Kind: "Pod", Name: "api-1", Reason: "Unhealthy", Message: "Liveness probe failed: timeout", Count: 2, LastSeen: time.Now()},
+	}
+
+	output := analyzeServices([]corev1.Service{svc}, []discoveryv1.EndpointSlice{slice}, []corev1.Pod{servingPod("api-1", false, http)}, events, false)
+	if output.Summary.Unhealthy != 1 {
+		t.Fatalf("summary = %+v, want one unhealthy service", output.Summary)
+	}
+	api := output.Services[0]
+	if api.NotReadyEndpoints != 1 || api.SelectedPods != 1 || len(api.ExcludedPods) != 1 {
+		t.Fatalf("api = %+v", api)
+	}
+	pod := api.ExcludedPods[0]
+	if pod.ProbeFailures != 7 || !contains(pod.ProbeFailure, "statuscode: 503") {
+		t.Errorf("excluded pod = %+v, want the readiness probe failure only", pod)
+	}
+	if len(pod.ReadinessProbes) != 1 || pod.ReadinessProbes[0].Handler != "httpGet /ready port http" || pod.NotReadyContainers[0] != "app" {
+		t.Errorf("excluded pod = %+v, want the readiness probe configuration", pod)
+	}
+	if msg := serviceHealthMessage(&output); !contains(msg, "api has no ready endpoints (pod api-1") {
+		t.Errorf("message = %q", msg)
+	}
+}
+
+func TestAnalyzeServices_SelectorAndPorts(t *testing.T) {
+	svc := apiService(
+		corev1.ServicePort{Name: "web", Port: 80, TargetPort: intstr.FromString("http")},
+		corev1.ServicePort{Name: "metrics", Port: 9090},
+		corev1.ServicePort{Name: "admin", Port: 8443, TargetPort: intstr.FromString("admin")},
+	)
+	slice := endpointSlice("app", "api")
+	slice.Endpoints = []discoveryv1.Endpoint{podEndpoint("api-1", true)}
+	pods := []corev1.Pod{servingPod("api-1", true, corev1.ContainerPort{Name: "http", ContainerPort: 8080})}
+
+	output := analyzeServices([]corev1.Service{svc}, []discoveryv1.EndpointSlice{slice}, pods, nil, false)
+	api := output.Services[0]
+	if api.Status != ServiceDegraded || len(api.ExcludedPods) != 0 {
+		t.Fatalf("api = %+v, want degraded by port issues only", api)
+	}
+	ports := map[string]ServicePortCheck{}
+	for _, p := range api.Ports {
+		ports[p.Name] = p
+	}
+	if !ports["web"].Exposed || ports["web"].Issue != "" {
+		t.Errorf("web = %+v, want the named port resolved", ports["web"])
+	}
+	if ports["metrics"].TargetPort != "9090" || ports["metrics"].Issue == "" {
+		t.Errorf("metrics = %+v, want the default target port flagged", ports["metrics"])
+	}
+	if !contains(ports["admin"].Issue, `port named "admin"`) {
+		t.Errorf("admin = %+v", ports["admin"])
+	}
+
+	orphan := selectedService("app", "orphan")
+	external := selectedService("app", "external")
+	external.Spec.Type = corev1.ServiceTypeExternalName
+	output = analyzeServices([]corev1.Service{orphan, external}, nil, pods, nil, true)
+	if len(output.Services) != 1 || output.Services[0].Status != ServiceUnhealthy || !contains(output.Services[0].Issues[1], "matches no running pods") {
+		t.Errorf("services = %+v, want the orphan selector reported and external skipped", output.Services)
+	}
+}
+
+func TestAnalyzeServices_ReadyPodMissingFromEndpoints(t *testing.T) {
+	terminating := servingPod("api-2", true)
+	now := metav1.NewTime(time.Now())
+	terminating.DeletionTimestamp = &now
+	slice := endpointSlice("app", "api")
+	slice.Endpoints = []discoveryv1.Endpoint{podEndpoint("api-1", true)}
+
+	output := analyzeServices([]corev1.Service{apiService()}, []discoveryv1.EndpointSlice{slice},
+		[]corev1.Pod{servingPod("api-1", true), terminating}, nil, false)
+	api := output.Services[0]
+	if api.Status != ServiceDegraded || len(api.ExcludedPods) != 1 || api.ExcludedPods[0].Reason != "Terminating" {
+		t.Errorf("api = %+v, want the terminating pod excluded", api)
+	}
+}
@@ -0,0 +1,559 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+
+	"github.com/KubeHeal/openshift-cluster-health-mcp/pkg/clients"
+)
+
+const (
+	defaultVolumeUsageThreshold = 85.0
+
+	// pvcPendingGracePeriod ignores claims that are only briefly Pending
+	pvcPendingGracePeriod = 5 * time.Minute
+
+	// volumeFullPercent marks a volume as effectively full
+	volumeFullPercent = 95.0
+)
+
+// Default StorageClass annotations; the beta annotation is still honoured
+const (
+	defaultStorageClassAnnotation     = "storageclass.kubernetes.io/is-default-class"
+	defaultStorageClassBetaAnnotation = "storageclass.beta.kubernetes.io/is-default-class"
+	selectedNodeAnnotation            = "volume.kubernetes.io/selected-node"
+)
+
+// Storage health statuses
+const (
+	StorageHealthy   = "healthy"
+	StorageDegraded  = "degraded"
+	StorageUnhealthy = "unhealthy"
+)
+
+// StorageHealthTool reports PVC, PV, attachment, StorageClass and volume usage problems via MCP
+type StorageHealthTool struct {
+	k8sClient  *clients.K8sClient
+	prometheus *clients.PrometheusClient
+}
+
+// NewStorageHealthTool creates a new get-storage-health tool; prometheus may
+// be nil, in which case volume usage is not reported
+func NewStorageHealthTool(k8sClient *clients.K8sClient, prometheus *clients.PrometheusClient) *StorageHealthTool {
+	return &StorageHealthTool{
+		k8sClient:  k8sClient,
+		prometheus: prometheus,
+	}
+}
+
+// Name returns the tool name for MCP registration
+func (t *StorageHealthTool) Name() string {
+	return "get-storage-health"
+}
+
+// Description returns the tool description for MCP
+func (t *StorageHealthTool) Description() string {
+	return "Report storage problems: unbound PVCs with the provisioning failure reason from events, PersistentVolumes in Released/Failed state, VolumeAttachment attach/detach errors, StorageClass misconfiguration (no or multiple default classes, missing classes) and, with Prometheus, volumes above a fullness threshold from kubelet volume stats"
+}
+
+// InputSchema returns the JSON schema for tool inputs
+func (t *StorageHealthTool) InputSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"namespace": map[string]interface{}{
+				"type":        "string",
+				"description": "Only report PVCs and volume usage in this namespace (empty = all namespaces); PVs, attachments and StorageClasses are cluster-scoped",
+				"default":     "",
+			},
+			"usage_threshold_percent": map[string]interface{}{
+				"type":        "number",
+				"description": "Flag volumes whose used bytes or inodes exceed this percentage of capacity",
+				"default":     defaultVolumeUsageThreshold,
+				"minimum":     1,
+				"maximum":     100,
+			},
+		},
+		"required": []string{},
+	}
+}
+
+// StorageHealthInput represents the input parameters
+type StorageHealthInput struct {
+	Namespace             string  `json:"namespace"`
+	UsageThresholdPercent float64 `json:"usage_threshold_percent"`
+}
+
+// UnboundPVC is a PersistentVolumeClaim that is not Bound
+type UnboundPVC struct {
+	Namespace    string `json:"namespace"`
+	Name         string `json:"name"`
+	Phase        string `json:"phase"`
+	StorageClass string `json:"storage_class,omitempty"`
+	Requested    string `json:"requested,omitempty"`
+	Age          string `json:"age"`
+	// WaitingForConsumer is true for WaitForFirstConsumer claims no pod uses yet (expected)
+	WaitingForConsumer bool   `json:"waiting_for_consumer,omitempty"`
+	Reason             string `json:"reason,omitempty"` // Latest event reason, warnings preferred
+	Message            string `json:"message,omitempty"`
+}
+
+// ProblemPV is a PersistentVolume in Released or Failed state
+type ProblemPV struct {
+	Name          string `json:"name"`
+	Phase         string `json:"phase"`
+	ReclaimPolicy string `json:"reclaim_policy"`
+	StorageClass  string `json:"storage_class,omitempty"`
+	Claim         string `json:"claim,omitempty"` // Former claim namespace/name
+	Reason        string `json:"reason,omitempty"`
+	Message       string `json:"message,omitempty"`
+}
+
+// AttachmentError is a VolumeAttachment reporting an attach or detach error
+type AttachmentError struct {
+	Name             string     `json:"name"`
+	Node             string     `json:"node"`
+	PersistentVolume string     `json:"persistent_volume,omitempty"`
+	Attacher         string     `json:"attacher"`
+	Attached         bool       `json:"attached"`
+	Operation        string     `json:"operation"` // attach or detach
+	Error            string     `json:"error"`
+	Time             *time.Time `json:"time,omitempty"`
+}
+
+// StorageClassInfo summarizes a StorageClass
+type StorageClassInfo struct {
+	Name                 string `json:"name"`
+	Provisioner          string `json:"provisioner"`
+	Default              bool   `json:"default"`
+	VolumeBindingMode    string `json:"volume_binding_mode"`
+	ReclaimPolicy        string `json:"reclaim_policy"`
+	AllowVolumeExpansion bool   `json:"allow_volume_expansion"`
+}
+
+// VolumeUsage is the kubelet-reported usage of a mounted PVC
+type VolumeUsage struct {
+	Namespace     string  `json:"namespace"`
+	PVC           string  `json:"pvc"`
+	UsedBytes     float64 `json:"used_bytes"`
+	CapacityBytes float64 `json:"capacity_bytes"`
+	UsedPercent   float64 `json:"used_percent"`
+	InodesPercent float64 `json:"inodes_percent,omitempty"`
+}
+
+// StorageHealthSummary counts storage objects and problems
+type StorageHealthSummary struct {
+	PVCs             int `json:"pvcs"`
+	BoundPVCs        int `json:"bound_pvcs"`
+	UnboundPVCs      int `json:"unbound_pvcs"`
+	PVs              int `json:"pvs"`
+	ReleasedPVs      int `json:"released_pvs"`
+	FailedPVs        int `json:"failed_pvs"`
+	AttachmentErrors int `json:"attachment_errors"`
+	StorageClasses   int `json:"storage_classes"`
+	VolumesOverLimit int `json:"volumes_over_threshold"`
+}
+
+// StorageHealthOutput represents the tool output
+type StorageHealthOutput struct {
+	Status           string               `json:"status"`
+	Summary          StorageHealthSummary `json:"summary"`
+	Issues           []string             `json:"issues,omitempty"` // Most severe first
+	UnboundPVCs      []UnboundPVC         `json:"unbound_pvcs,omitempty"`
+	ProblemPVs       []ProblemPV          `json:"problem_pvs,omitempty"`
+	AttachmentErrors []AttachmentError    `json:"attachment_errors,omitempty"`
+	StorageClasses   []StorageClassInfo   `json:"storage_classes"`
+	VolumeUsage      []VolumeUsage        `json:"volume_usage,omitempty"` // Volumes above the threshold, fullest first
+	Notes            []string             `json:"notes,omitempty"`
+	Message          string               `json:"message"`
+}
+
+// storageInventory holds the objects needed to evaluate storage health;
+// nil usage means it was not collected
+type storageInventory struct {
+	pvcs        []corev1.PersistentVolumeClaim
+	pvs         []corev1.PersistentVolume
+	attachments []storagev1.VolumeAttachment
+	classes     []storagev1.StorageClass
+	events      []clients.Event
+	usage       []VolumeUsage
+	notes       []string
+}
+
+// Execute evaluates storage health
+func (t *StorageHealthTool) Execute(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+	input := StorageHealthInput{UsageThresholdPercent: defaultVolumeUsageThreshold}
+	if argsJSON, err := json.Marshal(args); err == nil {
+		_ = json.Unmarshal(argsJSON, &input) //nolint:errcheck // Intentionally ignore error, use defaults if unmarshal fails
+	}
+	if input.UsageThresholdPercent <= 0 || input.UsageThresholdPercent > 100 {
+		input.UsageThresholdPercent = defaultVolumeUsageThreshold
+	}
+
+	inv, err := t.loadInventory(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+	output := analyzeStorage(inv, input, time.Now())
+	output.Message = storageHealthMessage(&output)
+	return output, nil
+}
+
+// loadInventory lists storage objects; events, attachments and usage are optional
+func (t *StorageHealthTool) loadInventory(ctx context.Context, input StorageHealthInput) (*storageInventory, error) {
+	inv := &storageInventory{}
+
+	pvcs, err := t.k8sClient.ListPersistentVolumeClaims(ctx, input.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	inv.pvcs = pvcs.Items
+
+	pvs, err := t.k8sClient.ListPersistentVolumes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	inv.pvs = pvs.Items
+
+	classes, err := t.k8sClient.ListStorageClasses(ctx)
+	if err != nil {
+		return nil, err
+	}
+	inv.classes = classes.Items
+
+	if attachments, err := t.k8sClient.ListVolumeAttachments(ctx); err != nil {
+		inv.notes = append(inv.notes, fmt.Sprintf("VolumeAttachments not checked: %v", err))
+	} else {
+		inv.attachments = attachments.Items
+	}
+
+	if events, err := t.k8sClient.ListEventTimeline(ctx, clients.EventFilter{Namespace: input.Namespace, Kind: "PersistentVolumeClaim"}); err != nil {
+		inv.notes = append(inv.notes, fmt.Sprintf("PVC events not available, provisioning failure reasons unknown: %v", err))
+	} else {
+		inv.events = events
+	}
+
+	if t.prometheus == nil {
+		inv.notes = append(inv.notes, "Volume usage not checked: Prometheus is not configured")
+		return inv, nil
+	}
+	usage, err := t.volumeUsage(ctx, input.Namespace)
+	if err != nil {
+		inv.notes = append(inv.notes, fmt.Sprintf("Volume usage not checked: %v", err))
+	}
+	inv.usage = usage
+	return inv, nil
+}
+
+// volumeUsage queries kubelet volume stats per PVC
+func (t *StorageHealthTool) volumeUsage(ctx context.Context, namespace string) ([]VolumeUsage, error) {
+	selector := ""
+	if namespace != "" {
+		selector = fmt.Sprintf(`{namespace=%q}`, namespace)
+	}
+	query := func(metric string) ([]clients.PrometheusSample, error) {
+		return t.prometheus.Query(ctx, fmt.Sprintf(`max by (namespace, persistentvolumeclaim) (%s%s)`, metric, selector))
+	}
+	used, err := query("kubelet_volume_stats_used_bytes")
+	if err != nil {
+		return nil, err
+	}
+	capacity, err := query("kubelet_volume_stats_capacity_bytes")
+	if err != nil {
+		return nil, err
+	}
+	// Inode stats are missing for some volume types; usage works without them
+	inodesUsed, _ := query("kubelet_volume_stats_inodes_used")
+	inodes, _ := query("kubelet_volume_stats_inodes")
+	return mergeVolumeStats(used, capacity, inodesUsed, inodes), nil
+}
+
+// mergeVolumeStats joins kubelet volume stats samples per PVC; NaN/Inf
+// values and volumes without a capacity are dropped
+func mergeVolumeStats(used, capacity, inodesUsed, inodes []clients.PrometheusSample) []VolumeUsage {
+	key := func(s clients.PrometheusSample) string {
+		return s.Labels["namespace"] + "/" + s.Labels["persistentvolumeclaim"]
+	}
+	values := func(samples []clients.PrometheusSample) map[string]float64 {
+		m := make(map[string]float64, len(samples))
+		for _, s := range samples {
+			if !math.IsNaN(s.Value) && !math.IsInf(s.Value, 0) {
+				m[key(s)] = s.Value
+			}
+		}
+		return m
+	}
+	usedBytes, capacityBytes := values(used), values(capacity)
+	usedInodes, totalInodes := values(inodesUsed), values(inodes)
+
+	var usage []VolumeUsage
+	for _, s := range capacity {
+		k := key(s)
+		c, ok := capacityBytes[k]
+		if !ok || c <= 0 {
+			continue
+		}
+		v := VolumeUsage{
+			Namespace:     s.Labels["namespace"],
+			PVC:           s.Labels["persistentvolumeclaim"],
+			UsedBytes:     usedBytes[k],
+			CapacityBytes: c,
+			UsedPercent:   math.Round(usedBytes[k]/c*1000) / 10,
+		}
+		if total := totalInodes[k]; total > 0 {
+			v.InodesPercent = math.Round(usedInodes[k]/total*1000) / 10
+		}
+		usage = append(usage, v)
+	}
+	return usage
+}
+
+// analyzeStorage evaluates the inventory. Lost claims, Failed volumes,
+// attachment errors and full volumes make storage unhealthy; stuck claims,
+// Released volumes that should have been deleted, volumes over the
+// threshold and StorageClass misconfiguration degrade it.
+func analyzeStorage(inv *storageInventory, input StorageHealthInput, now time.Time) StorageHealthOutput {
+	output := StorageHealthOutput{
+		Status:         StorageHealthy,
+		Notes:          inv.notes,
+		StorageClasses: []StorageClassInfo{},
+	}
+	var critical, warnings []string
+
+	// StorageClasses
+	classes := make(map[string]*storagev1.StorageClass, len(inv.classes))
+	var defaults []string
+	var defaultClass *storagev1.StorageClass // The one admission assigns to PVCs without a class
+	for i := range inv.classes {
+		sc := &inv.classes[i]
+		classes[sc.Name] = sc
+		info := StorageClassInfo{
+			Name:              sc.Name,
+			Provisioner:       sc.Provisioner,
+			Default:           isDefaultStorageClass(sc),
+			VolumeBindingMode: string(storagev1.VolumeBindingImmediate),
+			ReclaimPolicy:     string(corev1.PersistentVolumeReclaimDelete),
+		}
+		if sc.VolumeBindingMode != nil {
+			info.VolumeBindingMode = string(*sc.VolumeBindingMode)
+		}
+		if sc.ReclaimPolicy != nil {
+			info.ReclaimPolicy = string(*sc.ReclaimPolicy)
+		}
+		if sc.AllowVolumeExpansion != nil {
+			info.AllowVolumeExpansion = *sc.AllowVolumeExpansion
+		}
+		if info.Default {
+			defaults = append(defaults, sc.Name)
+			if defaultClass == nil || newerStorageClass(sc, defaultClass) {
+				defaultClass = sc
+			}
+		}
+		output.StorageClasses = append(output.StorageClasses, info)
+	}
+	sort.Slice(output.StorageClasses, func(i, j int) bool { return output.StorageClasses[i].Name < output.StorageClasses[j].Name })
+	sort.Strings(defaults)
+	output.Summary.StorageClasses = len(inv.classes)
+	switch {
+	case len(inv.classes) == 0:
+		warnings = append(warnings, "No StorageClasses exist: PVCs can only bind to pre-provisioned PersistentVolumes")
+	case len(defaults) == 0:
+		warnings = append(warnings, "No default StorageClass: PVCs without storageClassName are not dynamically provisioned")
+	case len(defaults) > 1:
+		warnings = append(warnings, fmt.Sprintf("Multiple default StorageClasses (%s): %s, the most recently created, is used", strings.Join(defaults, ", "), defaultClass.Name))
+	}
+
+	// PVCs
+	claimEvents := latestClaimEvents(inv.events)
+	for _, pvc := range inv.pvcs {
+		output.Summary.PVCs++
+		if pvc.Status.Phase == corev1.ClaimBound {
+			output.Summary.BoundPVCs++
+			continue
+		}
+		output.Summary.UnboundPVCs++
+		u := UnboundPVC{
+			Namespace: pvc.Namespace,
+			Name:      pvc.Name,
+			Phase:     string(pvc.Status.Phase),
+			Age:       now.Sub(pvc.CreationTimestamp.Time).Round(time.Second).String(),
+		}
+		if pvc.Spec.StorageClassName != nil {
+			u.StorageClass = *pvc.Spec.StorageClassName
+		} else if defaultClass != nil {
+			u.StorageClass = defaultClass.Name
+		}
+		if q, ok := pvc.Spec.Resources.Requests[corev1.ResourceStorage]; ok {
+			u.Requested = q.String()
+		}
+		if e, ok := claimEvents[pvc.Namespace+"/"+pvc.Name]; ok {
+			u.Reason, u.Message = e.Reason, e.Message
+		}
+		resource := fmt.Sprintf("PVC %s/%s", pvc.Namespace, pvc.Name)
+
+		switch {
+		case pvc.Status.Phase == corev1.ClaimLost:
+			critical = append(critical, fmt.Sprintf("%s lost its bound volume %s", resource, pvc.Spec.VolumeName))
+		case u.StorageClass != "" && classes[u.StorageClass] == nil && pvc.Spec.VolumeName == "":
+			warnings = append(warnings, fmt.Sprintf("%s references StorageClass %s, which does not exist", resource, u.StorageClass))
+		case u.StorageClass != "" && isWaitForFirstConsumer(classes[u.StorageClass]) && pvc.Annotations[selectedNodeAnnotation] == "":
+			u.WaitingForConsumer = true
+		case now.Sub(pvc.CreationTimestamp.Time) > pvcPendingGracePeriod:
+			msg := fmt.Sprintf("%s has been Pending for %s", resource, u.Age)
+			if u.Reason != "" {
+				msg += fmt.Sprintf(" (%s: %s)", u.Reason, u.Message)
+			}
+			warnings = append(warnings, msg)
+		}
+		output.UnboundPVCs = append(output.UnboundPVCs, u)
+	}
+
+	// PVs
+	for _, pv := range inv.pvs {
+		output.Summary.PVs++
+		if pv.Status.Phase != corev1.VolumeReleased && pv.Status.Phase != corev1.VolumeFailed {
+			continue
+		}
+		p := ProblemPV{
+			Name:          pv.Name,
+			Phase:         string(pv.Status.Phase),
+			ReclaimPolicy: string(pv.Spec.PersistentVolumeReclaimPolicy),
+			StorageClass:  pv.Spec.StorageClassName,
+			Reason:        pv.Status.Reason,
+			Message:       pv.Status.Message,
+		}
+		if ref := pv.Spec.ClaimRef; ref != nil {
+			p.Claim = ref.Namespace + "/" + ref.Name
+		}
+		if pv.Status.Phase == corev1.VolumeFailed {
+			output.Summary.FailedPVs++
+			critical = append(critical, fmt.Sprintf("PV %s failed reclamation: %s", pv.Name, pv.Status.Message))
+		} else {
+			output.Summary.ReleasedPVs++
+			// Retained volumes wait for an admin by design; Delete should not linger
+			if pv.Spec.PersistentVolumeReclaimPolicy == corev1.PersistentVolumeReclaimDelete {
+				warnings = append(warnings, fmt.Sprintf("PV %s is Released but its Delete reclaim policy has not removed it", pv.Name))
+			}
+		}
+		output.ProblemPVs = append(output.ProblemPVs, p)
+	}
+
+	// VolumeAttachments
+	for _, va := range inv.attachments {
+		a := AttachmentError{
+			Name:     va.Name,
+			Node:     va.Spec.NodeName,
+			Attacher: va.Spec.Attacher,
+			Attached: va.Status.Attached,
+		}
+		if va.Spec.Source.PersistentVolumeName != nil {
+			a.PersistentVolume = *va.Spec.Source.PersistentVolumeName
+		}
+		var volumeErr *storagev1.VolumeError
+		switch {
+		case va.Status.AttachError != nil:
+			a.Operation, volumeErr = "attach", va.Status.AttachError
+		case va.Status.DetachError != nil:
+			a.Operation, volumeErr = "detach", va.Status.DetachError
+		default:
+			continue
+		}
+		a.Error = volumeErr.Message
+		if !volumeErr.Time.IsZero() {
+			t := volumeErr.Time.Time
+			a.Time = &t
+		}
+		output.Summary.AttachmentErrors++
+		critical = append(critical, fmt.Sprintf("Volume %s cannot %s on node %s: %s", a.PersistentVolume, a.Operation, a.Node, a.Error))
+		output.AttachmentErrors = append(output.AttachmentErrors, a)
+	}
+
+	// Volume usage
+	for _, v := range inv.usage {
+		percent := math.Max(v.UsedPercent, v.InodesPercent)
+		if percent < input.UsageThresholdPercent {
+			continue
+		}
+		output.Summary.VolumesOverLimit++
+		msg := fmt.Sprintf("PVC %s/%s is %.1f%% full", v.Namespace, v.PVC, v.UsedPercent)
+		if v.InodesPercent > v.UsedPercent {
+			msg = fmt.Sprintf("PVC %s/%s has used %.1f%% of its inodes", v.Namespace, v.PVC, v.InodesPercent)
+		}
+		if percent >= volumeFullPercent {
+			critical = append(critical, msg)
+		} else {
+			warnings = append(warnings, msg)
+		}
+		output.VolumeUsage = append(output.VolumeUsage, v)
+	}
+	sort.Slice(output.VolumeUsage, func(i, j int) bool {
+		return math.Max(output.VolumeUsage[i].UsedPercent, output.VolumeUsage[i].InodesPercent) >
+			math.Max(output.VolumeUsage[j].UsedPercent, output.VolumeUsage[j].InodesPercent)
+	})
+
+	output.Issues = append(critical, warnings...)
+	switch {
+	case len(critical) > 0:
+		output.Status = StorageUnhealthy
+	case len(warnings) > 0:
+		output.Status = StorageDegraded
+	}
+	return output
+}
+
+// latestClaimEvents returns the latest event per "namespace/claim",
+// preferring Warning events over Normal ones
+func latestClaimEvents(events []clients.Event) map[string]clients.Event {
+	latest := make(map[string]clients.Event)
+	for _, e := range events {
+		key := e.Namespace + "/" + e.Name
+		current, ok := latest[key]
+		warning := strings.EqualFold(e.Type, corev1.EventTypeWarning)
+		currentWarning := ok && strings.EqualFold(current.Type, corev1.EventTypeWarning)
+		if !ok || (warning && !currentWarning) || (warning == currentWarning && e.LastSeen.After(current.LastSeen)) {
+			latest[key] = e
+		}
+	}
+	return latest
+}
+
+// newerStorageClass orders default StorageClasses the way the admission
+// plugin picks one: most recently created first, then by name
+func newerStorageClass(a, b *storagev1.StorageClass) bool {
+	if !a.CreationTimestamp.Equal(&b.CreationTimestamp) {
+		return a.CreationTimestamp.After(b.CreationTimestamp.Time)
+	}
+	return a.Name < b.Name
+}
+
+// isDefaultStorageClass reports whether a StorageClass is annotated as default
+func isDefaultStorageClass(sc *storagev1.StorageClass) bool {
+	return sc.Annotations[defaultStorageClassAnnotation] == "true" || sc.Annotations[defaultStorageClassBetaAnnotation] == "true"
+}
+
+// isWaitForFirstConsumer reports whether a StorageClass delays binding until a pod uses the claim
+func isWaitForFirstConsumer(sc *storagev1.StorageClass) bool {
+	return sc != nil && sc.VolumeBindingMode != nil && *sc.VolumeBindingMode == storagev1.VolumeBindingWaitForFirstConsumer
+}
+
+// storageHealthMessage summarizes the evaluation
+func storageHealthMessage(output *StorageHealthOutput) string {
+	s := output.Summary
+	msg := fmt.Sprintf("Storage is %s: %d/%d PVCs bound, %d PVs (%d released, %d failed), %d attachment errors",
+		output.Status, s.BoundPVCs, s.PVCs, s.PVs, s.ReleasedPVs, s.FailedPVs, s.AttachmentErrors)
+	if s.VolumesOverLimit > 0 {
+		msg += fmt.Sprintf(", %d volumes over the usage threshold", s.VolumesOverLimit)
+	}
+	if len(output.Issues) > 0 {
+		msg += ". Top issue: " + output.Issues[0]
+	}
+	return msg
+}
@@ -0,0 +1,234 @@
+package tools
+
+import (
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/KubeHeal/openshift-cluster-health-mcp/pkg/clients"
+)
+
+func storageClass(name string, isDefault bool, mode storagev1.VolumeBindingMode) storagev1.StorageClass {
+	sc := storagev1.StorageClass{
+		ObjectMeta:        metav1.ObjectMeta{Name: name},
+		Provisioner:       "ebs.csi.aws.com",
+		VolumeBindingMode: &mode,
+	}
+	if isDefault {
+		sc.Annotations = map[string]string{defaultStorageClassAnnotation: "true"}
+	}
+	return sc
+}
+
+func pendingPVC(name string, class *string, age time.Duration, now time.Time) corev1.PersistentVolumeClaim {
+	return corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "app", CreationTimestamp: metav1.NewTime(now.Add(-age))},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			StorageClassName: class,
+			Resources: corev1.VolumeResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("10Gi")},
+			},
+		},
+		Status: corev1.PersistentVolumeClaimStatus{Phase: corev1.ClaimPending},
+	}
+}
+
+func TestStorageHealthTool_Metadata(t *testing.T) {
+	tool := NewStorageHealthTool(nil, nil)
+	if tool.Name() != "get-storage-health" {
+		t.Errorf("unexpected name %s", tool.Name())
+	}
+	props := tool.InputSchema()["properties"].(map[string]interface{})
+	for _, key := range []string{"namespace", "usage_threshold_percent"} {
+		if _, ok := props[key]; !ok {
+			t.Errorf("schema missing %s", key)
+		}
+	}
+}
+
+func TestAnalyzeStorage_PVCs(t *testing.T) {
+	now := time.Now()
+	wffc, missing := "gp3-wffc", "nfs"
+	stuck := pendingPVC("stuck", nil, time.Hour, now)
+	fresh := pendingPVC("fresh", nil, time.Minute, now)
+	waiting := pendingPVC("waiting", &wffc, time.Hour, now)
+	scheduled := pendingPVC("scheduled", &wffc, time.Hour, now)
+	scheduled.Annotations = map[string]string{selectedNodeAnnotation: "worker-1"}
+	orphan := pendingPVC("orphan", &missing, time.Hour, now)
+	bound := pendingPVC("bound", nil, time.Hour, now)
+	bound.Status.Phase = corev1.ClaimBound
+
+	inv := &storageInventory{
+		pvcs: []corev1.PersistentVolumeClaim{stuck, fresh, waiting, scheduled, orphan, bound},
+		classes: []storagev1.StorageClass{
+			storageClass("gp3", true, storagev1.VolumeBindingImmediate),
+			storageClass(wffc, false, storagev1.VolumeBindingWaitForFirstConsumer),
+		},
+		events: []clients.Event{
+			{Namespace: "app", Name: "stuck", Type: "Normal", Reason: "Provisioning", LastSeen: now},
+			{Namespace: "app", Name: "stuck", Type: "Warning", Reason: "ProvisioningFailed", Message: "quota exceeded", LastSeen: now.Add(-time.Minute)},
+		},
+	}
+	output := analyzeStorage(inv, StorageHealthInput{UsageThresholdPercent: 85}, now)
+
+	claims := map[string]UnboundPVC{}
+	for _, u := range output.UnboundPVCs {
+		claims[u.Name] = u
+	}
+	if u := claims["stuck"]; u.StorageClass != "gp3" || u.Reason != "ProvisioningFailed" || u.Requested != "10Gi" {
+		t.Errorf("stuck = %+v, want default class and the warning event", u)
+	}
+	if !claims["waiting"].WaitingForConsumer || claims["scheduled"].WaitingForConsumer {
+		t.Errorf("waiting = %+v, scheduled = %+v", claims["waiting"], claims["scheduled"])
+	}
+	if output.Summary.PVCs != 6 || output.Summary.BoundPVCs != 1 || output.Summary.UnboundPVCs != 5 {
+		t.Errorf("summary = %+v", output.Summary)
+	}
+	// stuck, scheduled and orphan are issues; fresh and waiting are not
+	if output.Status != StorageDegraded || len(output.Issues) != 3 {
+		t.Fatalf("status = %s, issues = %v", output.Status, output.Issues)
+	}
+	if !contains(output.Issues[0], "ProvisioningFailed: quota exceeded") || !contains(output.Issues[2], "StorageClass nfs, which does not exist") {
+		t.Errorf("issues = %v", output.Issues)
+	}
+}
+
+func TestAnalyzeStorage_VolumesAndAttachments(t *testing.T) {
+	pvName := "pv-attach"
+	inv := &storageInventory{
+		pvs: []corev1.PersistentVolume{
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "pv-retained"},
+				Spec:       corev1.PersistentVolumeSpec{PersistentVolumeReclaimPolicy: corev1.PersistentVolumeReclaimRetain},
+				Status:     corev1.PersistentVolumeStatus{Phase: corev1.VolumeReleased},
+			},
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "pv-stuck"},
+				Spec:       corev1.PersistentVolumeSpec{PersistentVolumeReclaimPolicy: corev1.PersistentVolumeReclaimDelete},
+				Status:     corev1.PersistentVolumeStatus{Phase: corev1.VolumeReleased},
+			},
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "pv-failed"},
+				Spec:       corev1.PersistentVolumeSpec{PersistentVolumeReclaimPolicy: corev1.PersistentVolumeReclaimRecycle},
+				Status:     corev1.PersistentVolumeStatus{Phase: corev1.VolumeFailed, Message: "recycler pod failed"},
+			},
+			{ObjectMeta: metav1.ObjectMeta{Name: "pv-bound"}, Status: corev1.PersistentVolumeStatus{Phase: corev1.VolumeBound}},
+		},
+		attachments: []storagev1.VolumeAttachment{
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "csi-1"},
+				Spec: storagev1.VolumeAttachmentSpec{
+					Attacher: "ebs.csi.aws.com",
+					NodeName: "worker-2",
+					Source:   storagev1.VolumeAttachmentSource{PersistentVolumeName: &pvName},
+				},
+				Status: storagev1.VolumeAttachmentStatus{AttachError: &storagev1.VolumeError{Message: "volume is attached to another node"}},
+			},
+			{ObjectMeta: metav1.ObjectMeta{Name: "csi-2"}, Status: storagev1.VolumeAttachmentStatus{Attached: true}},
+		},
+		classes: []storagev1.StorageClass{storageClass("gp3", true, storagev1.VolumeBindingImmediate)},
+	}
+	output := analyzeStorage(inv, StorageHealthInput{UsageThresholdPercent: 85}, time.Now())
+
+	if output.Summary.ReleasedPVs != 2 || output.Summary.FailedPVs != 1 || len(output.ProblemPVs) != 3 {
+		t.Errorf("summary = %+v, problem PVs = %+v", output.Summary, output.ProblemPVs)
+	}
+	if len(output.AttachmentErrors) != 1 || output.AttachmentErrors[0].Operation != "attach" || output.AttachmentErrors[0].PersistentVolume != pvName {
+		t.Errorf("attachment errors = %+v", output.AttachmentErrors)
+	}
+	// Two critical issues first, then the Released Delete volume; the Retain volume is not an issue
+	if output.Status != StorageUnhealthy || len(output.Issues) != 3 || !contains(output.Issues[2], "pv-stuck") {
+		t.Errorf("status = %s, issues = %v", output.Status, output.Issues)
+	}
+}
+
+func TestAnalyzeStorage_StorageClasses(t *testing.T) {
+	tests := []struct {
+		name    string
+		classes []storagev1.StorageClass
+		want    string
+	}{
+		{"none", nil, "No StorageClasses exist"},
+		{"no default", []storagev1.StorageClass{storageClass("gp3", false, storagev1.VolumeBindingImmediate)}, "No default StorageClass"},
+		{"two defaults", []storagev1.StorageClass{
+			storageClass("gp3", true, storagev1.VolumeBindingImmediate),
+			storageClass("gp2", true, storagev1.VolumeBindingImmediate),
+		}, "Multiple default StorageClasses (gp2, gp3)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output := analyzeStorage(&storageInventory{classes: tt.classes}, StorageHealthInput{UsageThresholdPercent: 85}, time.Now())
+			if output.Status != StorageDegraded || len(output.Issues) != 1 || !contains(output.Issues[0], tt.want) {
+				t.Errorf("status = %s, issues = %v", output.Status, output.Issues)
+			}
+		})
+	}
+
+	beta := storageClass("legacy", false, storagev1.VolumeBindingImmediate)
+	beta.Annotations = map[string]string{defaultStorageClassBetaAnnotation: "true"}
+	if output := analyzeStorage(&storageInventory{classes: []storagev1.StorageClass{beta}}, StorageHealthInput{UsageThresholdPercent: 85}, time.Now()); output.Status != StorageHealthy {
+		t.Errorf("beta default annotation not honoured: %v", output.Issues)
+	}
+}
+
+func TestAnalyzeStorage_NewestDefaultClass(t *testing.T) {
+	now := time.Now()
+	older := storageClass("zz-legacy", true, storagev1.VolumeBindingImmediate)
+	older.CreationTimestamp = metav1.NewTime(now.Add(-48 * time.Hour))
+	newer := storageClass("gp3", true, storagev1.VolumeBindingImmediate)
+	newer.CreationTimestamp = metav1.NewTime(now.Add(-time.Hour))
+	tie := storageClass("io2", true, storagev1.VolumeBindingImmediate)
+	tie.CreationTimestamp = newer.CreationTimestamp
+
+	inv := &storageInventory{
+		classes: []storagev1.StorageClass{older, newer},
+		pvcs:    []corev1.PersistentVolumeClaim{pendingPVC("data", nil, time.Minute, now)},
+	}
+	output := analyzeStorage(inv, StorageHealthInput{UsageThresholdPercent: 85}, now)
+	if len(output.UnboundPVCs) != 1 || output.UnboundPVCs[0].StorageClass != "gp3" {
+		t.Errorf("unbound = %+v, want the most recently created default gp3", output.UnboundPVCs)
+	}
+	if !contains(output.Issues[0], "gp3, the most recently created, is used") {
+		t.Errorf("issues = %v", output.Issues)
+	}
+
+	// Equal timestamps fall back to the name
+	inv.classes = []storagev1.StorageClass{older, newer, tie}
+	if output := analyzeStorage(inv, StorageHealthInput{UsageThresholdPercent: 85}, now); output.UnboundPVCs[0].StorageClass != "gp3" {
+		t.Errorf("unbound = %+v, want gp3 before io2", output.UnboundPVCs)
+	}
+}
+
+func TestMergeVolumeStatsAndUsage(t *testing.T) {
+	sample := func(pvc string, v float64) clients.PrometheusSample {
+		return clients.PrometheusSample{Labels: map[string]string{"namespace": "app", "persistentvolumeclaim": pvc}, Value: v}
+	}
+	used := []clients.PrometheusSample{sample("data", 90), sample("logs", 97), sample("cache", 10), sample("files", 20)}
+	capacity := []clients.PrometheusSample{sample("data", 100), sample("logs", 100), sample("cache", 100), sample("files", 100), sample("empty", 0)}
+	inodesUsed := []clients.PrometheusSample{sample("files", 880)}
+	inodes := []clients.PrometheusSample{sample("files", 1000)}
+
+	usage := mergeVolumeStats(used, capacity, inodesUsed, inodes)
+	if len(usage) != 4 {
+		t.Fatalf("usage = %+v, want the zero-capacity volume dropped", usage)
+	}
+
+	inv := &storageInventory{usage: usage, classes: []storagev1.StorageClass{storageClass("gp3", true, storagev1.VolumeBindingImmediate)}}
+	output := analyzeStorage(inv, StorageHealthInput{UsageThresholdPercent: 85}, time.Now())
+	if output.Summary.VolumesOverLimit != 3 || output.VolumeUsage[0].PVC != "logs" {
+		t.Errorf("volume usage = %+v, want logs, data and files fullest first", output.VolumeUsage)
+	}
+	if output.Status != StorageUnhealthy || !contains(output.Issues[0], "logs is 97.0% full") {
+		t.Errorf("issues = %v", output.Issues)
+	}
+	if !contains(output.Issues[2], "files has used 88.0% of its inodes") {
+		t.Errorf("issues = %v, want the inode exhaustion reported", output.Issues)
+	}
+	if msg := storageHealthMessage(&output); !contains(msg, "3 volumes over the usage threshold") {
+		t.Errorf("message = %q", msg)
+	}
+}
@@ -0,0 +1,761 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/KubeHeal/openshift-cluster-health-mcp/pkg/clients"
+)
+
+// Workload kinds
+const (
+	KindDeployment  = "Deployment"
+	KindStatefulSet = "StatefulSet"
+	KindDaemonSet   = "DaemonSet"
+)
+
+// Workload health statuses
+const (
+	WorkloadHealthy     = "healthy"
+	WorkloadProgressing = "progressing"
+	WorkloadStuck       = "stuck"
+	WorkloadDegraded    = "degraded"
+)
+
+const (
+	defaultRolloutStuckMinutes = 10
+	maxBlockingPods            = 10
+
+	// deploymentRevisionAnnotation holds the rollout revision of a ReplicaSet
+	deploymentRevisionAnnotation = "deployment.kubernetes.io/revision"
+)
+
+// blockingPodReasons are pod states that will not resolve without intervention
+var blockingPodReasons = map[string]bool{
+	"CrashLoopBackOff":           true,
+	"ImagePullBackOff":           true,
+	"ErrImagePull":               true,
+	"InvalidImageName":           true,
+	"CreateContainerConfigError": true,
+	"CreateContainerError":       true,
+	"RunContainerError":          true,
+	"Unschedulable":              true,
+}
+
+// WorkloadHealthTool reports rollout health for Deployments, StatefulSets and DaemonSets via MCP
+type WorkloadHealthTool struct {
+	k8sClient *clients.K8sClient
+}
+
+// NewWorkloadHealthTool creates a new get-workload-health tool
+func NewWorkloadHealthTool(k8sClient *clients.K8sClient) *WorkloadHealthTool {
+	return &WorkloadHealthTool{
+		k8sClient: k8sClient,
+	}
+}
+
+// Name returns the tool name for MCP registration
+func (t *WorkloadHealthTool) Name() string {
+	return "get-workload-health"
+}
+
+// Description returns the tool description for MCP
+func (t *WorkloadHealthTool) Description() string {
+	return "Get rollout health of Deployments, StatefulSets and DaemonSets: desired/updated/ready/available counts, conditions, current vs previous revision, strategy, whether a rollout is stuck and which pods block it. Use to verify a workload after remediation."
+}
+
+// InputSchema returns the JSON schema for tool inputs
+func (t *WorkloadHealthTool) InputSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"namespace": map[string]interface{}{
+				"type":        "string",
+				"description": "Namespace to check (empty = all namespaces)",
+				"default":     "",
+			},
+			"kind": map[string]interface{}{
+				"type":        "string",
+				"description": "Workload kind to check (empty = all kinds)",
+				"enum":        []string{"", KindDeployment, KindStatefulSet, KindDaemonSet},
+				"default":     "",
+			},
+			"name": map[string]interface{}{
+				"type":        "string",
+				"description": "Workload name (requires namespace)",
+				"default":     "",
+			},
+			"only_unhealthy": map[string]interface{}{
+				"type":        "boolean",
+				"description": "Only return workloads that are not healthy",
+				"default":     false,
+			},
+			"stuck_threshold_minutes": map[string]interface{}{
+				"type":        "integer",
+				"description": "Minutes a StatefulSet/DaemonSet pod may stay not ready during a rollout before it is considered stuck (Deployments use their progressDeadlineSeconds)",
+				"default":     defaultRolloutStuckMinutes,
+				"minimum":     1,
+			},
+		},
+		"required": []string{},
+	}
+}
+
+// WorkloadHealthInput represents the input parameters
+type WorkloadHealthInput struct {
+	Namespace             string `json:"namespace"`
+	Kind                  string `json:"kind"`
+	Name                  string `json:"name"`
+	OnlyUnhealthy         bool   `json:"only_unhealthy"`
+	StuckThresholdMinutes int    `json:"stuck_threshold_minutes"`
+}
+
+// WorkloadReplicas holds replica counts; for DaemonSets these are scheduled node counts
+type WorkloadReplicas struct {
+	Desired     int32 `json:"desired"`
+	Current     int32 `json:"current"`
+	Updated     int32 `json:"updated"`
+	Ready       int32 `json:"ready"`
+	Available   int32 `json:"available"`
+	Unavailable int32 `json:"unavailable"`
+}
+
+// WorkloadRevision identifies a ReplicaSet or ControllerRevision
+type WorkloadRevision struct {
+	Name     string `json:"name"`
+	Revision int64  `json:"revision"`
+	Replicas *int32 `json:"replicas,omitempty"` // Deployments only
+}
+
+// BlockingPod is a not-ready pod holding up a rollout
+type BlockingPod struct {
+	Name         string `json:"name"`
+	Revision     string `json:"revision,omitempty"`
+	Phase        string `json:"phase"`
+	Reason       string `json:"reason,omitempty"`
+	Message      string `json:"message,omitempty"`
+	Node         string `json:"node,omitempty"`
+	NotReadyFor  string `json:"not_ready_for,omitempty"`
+	RestartCount int32  `json:"restart_count"`
+
+	notReady time.Duration
+}
+
+// WorkloadHealth is the rollout health of one workload
+type WorkloadHealth struct {
+	Kind              string                     `json:"kind"`
+	Name              string                     `json:"name"`
+	Namespace         string                     `json:"namespace"`
+	Status            string                     `json:"status"`
+	Replicas          WorkloadReplicas           `json:"replicas"`
+	Strategy          string                     `json:"strategy"`
+	Conditions        []clients.ClusterCondition `json:"conditions,omitempty"`
+	CurrentRevision   *WorkloadRevision          `json:"current_revision,omitempty"`
+	PreviousRevision  *WorkloadRevision          `json:"previous_revision,omitempty"`
+	RolloutInProgress bool                       `json:"rollout_in_progress"`
+	Stuck             bool                       `json:"stuck"`
+	Issues            []string                   `json:"issues,omitempty"`
+	BlockingPods      []BlockingPod              `json:"blocking_pods,omitempty"`
+}
+
+// WorkloadHealthSummary counts workloads by status
+type WorkloadHealthSummary struct {
+	Total       int `json:"total"`
+	Healthy     int `json:"healthy"`
+	Progressing int `json:"progressing"`
+	Stuck       int `json:"stuck"`
+	Degraded    int `json:"degraded"`
+}
+
+// WorkloadHealthOutput represents the tool output
+type WorkloadHealthOutput struct {
+	Summary   WorkloadHealthSummary `json:"summary"`
+	Workloads []WorkloadHealth      `json:"workloads"`
+	Message   string                `json:"message"`
+}
+
+// workloadInventory holds the objects needed to evaluate workloads
+type workloadInventory struct {
+	deployments  []appsv1.Deployment
+	statefulSets []appsv1.StatefulSet
+	daemonSets   []appsv1.DaemonSet
+	replicaSets  []appsv1.ReplicaSet
+	revisions    []appsv1.ControllerRevision
+	pods         []corev1.Pod
+}
+
+// Execute evaluates workload rollout health
+func (t *WorkloadHealthTool) Execute(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+	input := WorkloadHealthInput{
+		StuckThresholdMinutes: defaultRolloutStuckMinutes,
+	}
+	if argsJSON, err := json.Marshal(args); err == nil {
+		_ = json.Unmarshal(argsJSON, &input) //nolint:errcheck // Intentionally ignore error, use defaults if unmarshal fails
+	}
+
+	switch input.Kind {
+	case "", KindDeployment, KindStatefulSet, KindDaemonSet:
+	default:
+		return nil, fmt.Errorf("invalid kind %q: must be Deployment, StatefulSet or DaemonSet", input.Kind)
+	}
+	if input.Name != "" && input.Namespace == "" {
+		return nil, fmt.Errorf("namespace is required when name is set")
+	}
+	if input.StuckThresholdMinutes < 1 {
+		input.StuckThresholdMinutes = defaultRolloutStuckMinutes
+	}
+
+	inv, err := loadWorkloadInventory(ctx, t.k8sClient, input)
+	if err != nil {
+		return nil, err
+	}
+
+	output := analyzeWorkloads(inv, input, time.Now())
+	if input.Name != "" && output.Summary.Total == 0 {
+		kind := input.Kind
+		if kind == "" {
+			kind = "workload"
+		}
+		return nil, fmt.Errorf("%s %s/%s not found", kind, input.Namespace, input.Name)
+	}
+	return output, nil
+}
+
+// loadWorkloadInventory lists the workloads of the requested kinds and their pods and revisions
+func loadWorkloadInventory(ctx context.Context, k8sClient *clients.K8sClient, input WorkloadHealthInput) (*workloadInventory, error) {
+	inv := &workloadInventory{}
+	ns := input.Namespace
+
+	if input.Kind == "" || input.Kind == KindDeployment {
+		deployments, err := k8sClient.ListDeployments(ctx, ns)
+		if err != nil {
+			return nil, err
+		}
+		inv.deployments = deployments.Items
+
+		replicaSets, err := k8sClient.ListReplicaSets(ctx, ns)
+		if err != nil {
+			return nil, err
+		}
+		inv.replicaSets = replicaSets.Items
+	}
+	if input.Kind == "" || input.Kind == KindStatefulSet {
+		statefulSets, err := k8sClient.ListStatefulSets(ctx, ns)
+		if err != nil {
+			return nil, err
+		}
+		inv.statefulSets = statefulSets.Items
+	}
+	if input.Kind == "" || input.Kind == KindDaemonSet {
+		daemonSets, err := k8sClient.ListDaemonSets(ctx, ns)
+		if err != nil {
+			return nil, err
+		}
+		inv.daemonSets = daemonSets.Items
+	}
+	if len(inv.statefulSets) > 0 || len(inv.daemonSets) > 0 {
+		revisions, err := k8sClient.ListControllerRevisions(ctx, ns)
+		if err != nil {
+			return nil, err
+		}
+		inv.revisions = revisions.Items
+	}
+
+	pods, err := k8sClient.ListPods(ctx, ns)
+	if err != nil {
+		return nil, err
+	}
+	inv.pods = pods.Items
+	return inv, nil
+}
+
+// analyzeWorkloads evaluates every workload in the inventory
+func analyzeWorkloads(inv *workloadInventory, input WorkloadHealthInput, now time.Time) WorkloadHealthOutput {
+	threshold := time.Duration(input.StuckThresholdMinutes) * time.Minute
+	if threshold <= 0 {
+		threshold = defaultRolloutStuckMinutes * time.Minute
+	}
+
+	// Index pods by controlling owner UID
+	podsByOwner := make(map[types.UID][]corev1.Pod)
+	for _, pod := range inv.pods {
+		if owner := metav1.GetControllerOf(&pod); owner != nil {
+			podsByOwner[owner.UID] = append(podsByOwner[owner.UID], pod)
+		}
+	}
+	replicaSetsByOwner := make(map[types.UID][]appsv1.ReplicaSet)
+	for _, rs := range inv.replicaSets {
+		if owner := metav1.GetControllerOf(&rs); owner != nil {
+			replicaSetsByOwner[owner.UID] = append(replicaSetsByOwner[owner.UID], rs)
+		}
+	}
+	revisionsByOwner := make(map[types.UID][]appsv1.ControllerRevision)
+	for _, rev := range inv.revisions {
+		if owner := metav1.GetControllerOf(&rev); owner != nil {
+			revisionsByOwner[owner.UID] = append(revisionsByOwner[owner.UID], rev)
+		}
+	}
+
+	var workloads []WorkloadHealth
+	matches := func(name string) bool {
+		return input.Name == "" || input.Name == name
+	}
+
+	for i := range inv.deployments {
+		d := &inv.deployments[i]
+		if !matches(d.Name) {
+			continue
+		}
+		replicaSets := replicaSetsByOwner[d.UID]
+		var pods []corev1.Pod
+		for _, rs := range replicaSets {
+			pods = append(pods, podsByOwner[rs.UID]...)
+		}
+		workloads = append(workloads, analyzeDeployment(d, replicaSets, pods, now))
+	}
+	for i := range inv.statefulSets {
+		s := &inv.statefulSets[i]
+		if !matches(s.Name) {
+			continue
+		}
+		workloads = append(workloads, analyzeStatefulSet(s, revisionsByOwner[s.UID], podsByOwner[s.UID], now, threshold))
+	}
+	for i := range inv.daemonSets {
+		ds := &inv.daemonSets[i]
+		if !matches(ds.Name) {
+			continue
+		}
+		workloads = append(workloads, analyzeDaemonSet(ds, revisionsByOwner[ds.UID], podsByOwner[ds.UID], now, threshold))
+	}
+
+	output := WorkloadHealthOutput{Workloads: []WorkloadHealth{}}
+	for _, w := range workloads {
+		output.Summary.Total++
+		switch w.Status {
+		case WorkloadHealthy:
+			output.Summary.Healthy++
+		case WorkloadProgressing:
+			output.Summary.Progressing++
+		case WorkloadStuck:
+			output.Summary.Stuck++
+		case WorkloadDegraded:
+			output.Summary.Degraded++
+		}
+		if input.OnlyUnhealthy && w.Status == WorkloadHealthy {
+			continue
+		}
+		output.Workloads = append(output.Workloads, w)
+	}
+
+	// Worst first, then by namespace/name
+	rank := map[string]int{WorkloadStuck: 0, WorkloadDegraded: 1, WorkloadProgressing: 2, WorkloadHealthy: 3}
+	sort.SliceStable(output.Workloads, func(i, j int) bool {
+		a, b := output.Workloads[i], output.Workloads[j]
+		if rank[a.Status] != rank[b.Status] {
+			return rank[a.Status] < rank[b.Status]
+		}
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		return a.Kind+a.Name < b.Kind+b.Name
+	})
+
+	output.Message = workloadHealthMessage(&output)
+	return output
+}
+
+// analyzeDeployment evaluates a Deployment and its ReplicaSets
+func analyzeDeployment(d *appsv1.Deployment, replicaSets []appsv1.ReplicaSet, pods []corev1.Pod, now time.Time) WorkloadHealth {
+	desired := clients.DesiredReplicas(d.Spec.Replicas)
+	w := WorkloadHealth{
+		Kind:      KindDeployment,
+		Name:      d.Name,
+		Namespace: d.Namespace,
+		Replicas: WorkloadReplicas{
+			Desired:     desired,
+			Current:     d.Status.Replicas,
+			Updated:     d.Status.UpdatedReplicas,
+			Ready:       d.Status.ReadyReplicas,
+			Available:   d.Status.AvailableReplicas,
+			Unavailable: d.Status.UnavailableReplicas,
+		},
+		Strategy: deploymentStrategy(d),
+	}
+
+	for _, c := range d.Status.Conditions {
+		w.Conditions = append(w.Conditions, workloadCondition(string(c.Type), string(c.Status), c.Reason, c.Message, c.LastTransitionTime))
+	}
+
+	// Revisions: newest ReplicaSet is current, the next newest with the highest revision is previous
+	revisions := make([]WorkloadRevision, 0, len(replicaSets))
+	revisionOf := make(map[string]string) // pod-template-hash -> revision label
+	for _, rs := range replicaSets {
+		revision, _ := strconv.ParseInt(rs.Annotations[deploymentRevisionAnnotation], 10, 64)
+		replicas := rs.Status.Replicas
+		revisions = append(revisions, WorkloadRevision{Name: rs.Name, Revision: revision, Replicas: &replicas})
+		if hash := rs.Labels[appsv1.DefaultDeploymentUniqueLabelKey]; hash != "" {
+			revisionOf[hash] = rs.Name
+		}
+	}
+	w.CurrentRevision, w.PreviousRevision = latestRevisions(revisions)
+
+	// A rollout is in progress while pods of older revisions remain; availability
+	// gaps after all pods are updated are reported as degraded instead
+	w.RolloutInProgress = d.Status.ObservedGeneration < d.Generation ||
+		d.Status.UpdatedReplicas < desired ||
+		d.Status.Replicas > d.Status.UpdatedReplicas
+	if d.Spec.Paused {
+		w.Issues = append(w.Issues, "rollout is paused")
+	}
+
+	replicaFailure := false
+	for _, c := range d.Status.Conditions {
+		switch {
+		case c.Type == appsv1.DeploymentProgressing && c.Status == corev1.ConditionFalse && c.Reason == "ProgressDeadlineExceeded":
+			w.Stuck = true
+			w.Issues = append(w.Issues, "progress deadline exceeded: "+c.Message)
+		case c.Type == appsv1.DeploymentReplicaFailure && c.Status == corev1.ConditionTrue:
+			replicaFailure = true
+			w.Issues = append(w.Issues, "replica failure: "+c.Message)
+		}
+	}
+
+	w.BlockingPods = blockingPods(pods, func(pod *corev1.Pod) string {
+		return revisionOf[pod.Labels[appsv1.DefaultDeploymentUniqueLabelKey]]
+	}, now)
+
+	w.Status = workloadStatus(&w, d.Status.AvailableReplicas < desired, replicaFailure)
+	return w
+}
+
+// analyzeStatefulSet evaluates a StatefulSet and its controller revisions
+func analyzeStatefulSet(s *appsv1.StatefulSet, revisions []appsv1.ControllerRevision, pods []corev1.Pod, now time.Time, threshold time.Duration) WorkloadHealth {
+	desired := clients.DesiredReplicas(s.Spec.Replicas)
+	w := WorkloadHealth{
+		Kind:      KindStatefulSet,
+		Name:      s.Name,
+		Namespace: s.Namespace,
+		Replicas: WorkloadReplicas{
+			Desired:     desired,
+			Current:     s.Status.Replicas,
+			Updated:     s.Status.UpdatedReplicas,
+			Ready:       s.Status.ReadyReplicas,
+			Available:   s.Status.AvailableReplicas,
+			Unavailable: nonNegative(desired - s.Status.AvailableReplicas),
+		},
+		Strategy: statefulSetStrategy(s),
+	}
+
+	for _, c := range s.Status.Conditions {
+		w.Conditions = append(w.Conditions, workloadCondition(string(c.Type), string(c.Status), c.Reason, c.Message, c.LastTransitionTime))
+	}
+	w.CurrentRevision, w.PreviousRevision = latestRevisions(controllerRevisions(revisions))
+
+	// With a partition only ordinals >= partition are expected to update
+	expectedUpdated := desired
+	if ru := s.Spec.UpdateStrategy.RollingUpdate; ru != nil && ru.Partition != nil {
+		expectedUpdated = nonNegative(desired - *ru.Partition)
+	}
+	onDelete := s.Spec.UpdateStrategy.Type == appsv1.OnDeleteStatefulSetStrategyType
+	w.RolloutInProgress = s.Status.ObservedGeneration < s.Generation ||
+		(!onDelete && s.Status.UpdateRevision != "" && s.Status.UpdatedReplicas < expectedUpdated) ||
+		(s.Status.ReadyReplicas < desired && s.Status.UpdateRevision != s.Status.CurrentRevision)
+	if onDelete && s.Status.UpdateRevision != s.Status.CurrentRevision && s.Status.UpdatedReplicas < desired {
+		w.Issues = append(w.Issues, fmt.Sprintf("OnDelete strategy: %d/%d pods run the latest revision until old pods are deleted", s.Status.UpdatedReplicas, desired))
+	}
+
+	w.BlockingPods = blockingPods(pods, func(pod *corev1.Pod) string {
+		return pod.Labels[appsv1.ControllerRevisionHashLabelKey]
+	}, now)
+	markStuckPods(&w, threshold)
+
+	w.Status = workloadStatus(&w, s.Status.AvailableReplicas < desired, false)
+	return w
+}
+
+// analyzeDaemonSet evaluates a DaemonSet and its controller revisions
+func analyzeDaemonSet(ds *appsv1.DaemonSet, revisions []appsv1.ControllerRevision, pods []corev1.Pod, now time.Time, threshold time.Duration) WorkloadHealth {
+	desired := ds.Status.DesiredNumberScheduled
+	w := WorkloadHealth{
+		Kind:      KindDaemonSet,
+		Name:      ds.Name,
+		Namespace: ds.Namespace,
+		Replicas: WorkloadReplicas{
+			Desired:     desired,
+			Current:     ds.Status.CurrentNumberScheduled,
+			Updated:     ds.Status.UpdatedNumberScheduled,
+			Ready:       ds.Status.NumberReady,
+			Available:   ds.Status.NumberAvailable,
+			Unavailable: ds.Status.NumberUnavailable,
+		},
+		Strategy: daemonSetStrategy(ds),
+	}
+
+	for _, c := range ds.Status.Conditions {
+		w.Conditions = append(w.Conditions, workloadCondition(string(c.Type), string(c.Status), c.Reason, c.Message, c.LastTransitionTime))
+	}
+	w.CurrentRevision, w.PreviousRevision = latestRevisions(controllerRevisions(revisions))
+
+	onDelete := ds.Spec.UpdateStrategy.Type == appsv1.OnDeleteDaemonSetStrategyType
+	w.RolloutInProgress = ds.Status.ObservedGeneration < ds.Generation ||
+		(!onDelete && ds.Status.UpdatedNumberScheduled < desired)
+	if ds.Status.NumberMisscheduled > 0 {
+		w.Issues = append(w.Issues, fmt.Sprintf("%d pods running on nodes they should not run on", ds.Status.NumberMisscheduled))
+	}
+
+	w.BlockingPods = blockingPods(pods, func(pod *corev1.Pod) string {
+		return pod.Labels[appsv1.ControllerRevisionHashLabelKey]
+	}, now)
+	markStuckPods(&w, threshold)
+
+	w.Status = workloadStatus(&w, ds.Status.NumberAvailable < desired, false)
+	return w
+}
+
+// markStuckPods flags a rollout as stuck when a blocking pod cannot recover on
+// its own or has been not ready longer than threshold
+func markStuckPods(w *WorkloadHealth, threshold time.Duration) {
+	if !w.RolloutInProgress {
+		return
+	}
+	for _, p := range w.BlockingPods {
+		if blockingPodReasons[p.Reason] {
+			w.Stuck = true
+			w.Issues = append(w.Issues, fmt.Sprintf("pod %s is %s", p.Name, p.Reason))
+			return
+		}
+		if p.notReady > threshold {
+			w.Stuck = true
+			w.Issues = append(w.Issues, fmt.Sprintf("pod %s not ready for %s", p.Name, p.NotReadyFor))
+			return
+		}
+	}
+}
+
+// workloadStatus derives the overall status from rollout state and availability
+func workloadStatus(w *WorkloadHealth, belowDesired, failing bool) string {
+	switch {
+	case w.Stuck:
+		return WorkloadStuck
+	case w.RolloutInProgress:
+		return WorkloadProgressing
+	case belowDesired:
+		w.Issues = append(w.Issues, fmt.Sprintf("%d/%d available", w.Replicas.Available, w.Replicas.Desired))
+		return WorkloadDegraded
+	case failing || len(w.BlockingPods) > 0:
+		return WorkloadDegraded
+	}
+	return WorkloadHealthy
+}
+
+// blockingPods returns the not-ready pods of a workload with their blocking reason
+func blockingPods(pods []corev1.Pod, revision func(*corev1.Pod) string, now time.Time) []BlockingPod {
+	var blocking []BlockingPod
+	for i := range pods {
+		pod := &pods[i]
+		if pod.DeletionTimestamp != nil || pod.Status.Phase == corev1.PodSucceeded || isPodReady(pod) {
+			continue
+		}
+
+		bp := BlockingPod{
+			Name:     pod.Name,
+			Revision: revision(pod),
+			Phase:    string(pod.Status.Phase),
+			Node:     pod.Spec.NodeName,
+		}
+		for _, cs := range pod.Status.ContainerStatuses {
+			bp.RestartCount += cs.RestartCount
+		}
+		bp.Reason, bp.Message = podBlockingReason(pod)
+
+		since := pod.CreationTimestamp.Time
+		for _, c := range pod.Status.Conditions {
+			if c.Type == corev1.PodReady && c.Status != corev1.ConditionTrue && !c.LastTransitionTime.IsZero() {
+				since = c.LastTransitionTime.Time
+			}
+		}
+		if !since.IsZero() {
+			bp.notReady = now.Sub(since).Round(time.Second)
+			bp.NotReadyFor = bp.notReady.String()
+		}
+
+		blocking = append(blocking, bp)
+		if len(blocking) == maxBlockingPods {
+			break
+		}
+	}
+	return blocking
+}
+
+// podBlockingReason explains why a pod is not ready
+func podBlockingReason(pod *corev1.Pod) (string, string) {
+	for _, c := range pod.Status.Conditions {
+		if c.Type == corev1.PodScheduled && c.Status == corev1.ConditionFalse {
+			return c.Reason, c.Message
+		}
+	}
+	statuses := append(append([]corev1.ContainerStatus{}, pod.Status.InitContainerStatuses...), pod.Status.ContainerStatuses...)
+	for _, cs := range statuses {
+		if cs.State.Waiting != nil && cs.State.Waiting.Reason != "" && cs.State.Waiting.Reason != "PodInitializing" {
+			return cs.State.Waiting.Reason, cs.State.Waiting.Message
+		}
+		if cs.State.Terminated != nil && cs.State.Terminated.ExitCode != 0 {
+			return cs.State.Terminated.Reason, cs.State.Terminated.Message
+		}
+	}
+	for _, cs := range pod.Status.ContainerStatuses {
+		if !cs.Ready {
+			return "ContainersNotReady", fmt.Sprintf("container %s is not ready (readiness probe)", cs.Name)
+		}
+	}
+	return pod.Status.Reason, pod.Status.Message
+}
+
+// isPodReady reports whether the pod Ready condition is true
+func isPodReady(pod *corev1.Pod) bool {
+	for _, c := range pod.Status.Conditions {
+		if c.Type == corev1.PodReady {
+			return c.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// nonNegative clamps a replica difference at zero
+func nonNegative(n int32) int32 {
+	if n < 0 {
+		return 0
+	}
+	return n
+}
+
+// controllerRevisions converts ControllerRevisions to workload revisions
+func controllerRevisions(revisions []appsv1.ControllerRevision) []WorkloadRevision {
+	result := make([]WorkloadRevision, 0, len(revisions))
+	for _, rev := range revisions {
+		result = append(result, WorkloadRevision{Name: rev.Name, Revision: rev.Revision})
+	}
+	return result
+}
+
+// latestRevisions returns the highest and second-highest revisions
+func latestRevisions(revisions []WorkloadRevision) (*WorkloadRevision, *WorkloadRevision) {
+	sort.Slice(revisions, func(i, j int) bool {
+		return revisions[i].Revision > revisions[j].Revision
+	})
+	var current, previous *WorkloadRevision
+	if len(revisions) > 0 {
+		current = &revisions[0]
+	}
+	if len(revisions) > 1 {
+		previous = &revisions[1]
+	}
+	return current, previous
+}
+
+// workloadCondition converts an apps/v1 condition
+func workloadCondition(condType, status, reason, message string, transition metav1.Time) clients.ClusterCondition {
+	c := clients.ClusterCondition{
+		Type:    condType,
+		Status:  status,
+		Reason:  reason,
+		Message: message,
+	}
+	if !transition.IsZero() {
+		t := transition.Time
+		c.LastTransitionTime = &t
+	}
+	return c
+}
+
+// deploymentStrategy describes a Deployment update strategy
+func deploymentStrategy(d *appsv1.Deployment) string {
+	if d.Spec.Strategy.Type == appsv1.RecreateDeploymentStrategyType {
+		return string(appsv1.RecreateDeploymentStrategyType)
+	}
+	strategy := string(appsv1.RollingUpdateDeploymentStrategyType)
+	if ru := d.Spec.Strategy.RollingUpdate; ru != nil {
+		var parts []string
+		if ru.MaxSurge != nil {
+			parts = append(parts, "maxSurge="+ru.MaxSurge.String())
+		}
+		if ru.MaxUnavailable != nil {
+			parts = append(parts, "maxUnavailable="+ru.MaxUnavailable.String())
+		}
+		if len(parts) > 0 {
+			strategy += " (" + strings.Join(parts, ", ") + ")"
+		}
+	}
+	return strategy
+}
+
+// statefulSetStrategy describes a StatefulSet update strategy
+func statefulSetStrategy(s *appsv1.StatefulSet) string {
+	if s.Spec.UpdateStrategy.Type == appsv1.OnDeleteStatefulSetStrategyType {
+		return string(appsv1.OnDeleteStatefulSetStrategyType)
+	}
+	strategy := string(appsv1.RollingUpdateStatefulSetStrategyType)
+	if ru := s.Spec.UpdateStrategy.RollingUpdate; ru != nil {
+		var parts []string
+		if ru.Partition != nil && *ru.Partition > 0 {
+			parts = append(parts, fmt.Sprintf("partition=%d", *ru.Partition))
+		}
+		if ru.MaxUnavailable != nil {
+			parts = append(parts, "maxUnavailable="+ru.MaxUnavailable.String())
+		}
+		if len(parts) > 0 {
+			strategy += " (" + strings.Join(parts, ", ") + ")"
+		}
+	}
+	return strategy
+}
+
+// daemonSetStrategy describes a DaemonSet update strategy
+func daemonSetStrategy(ds *appsv1.DaemonSet) string {
+	if ds.Spec.UpdateStrategy.Type == appsv1.OnDeleteDaemonSetStrategyType {
+		return string(appsv1.OnDeleteDaemonSetStrategyType)
+	}
+	strategy := string(appsv1.RollingUpdateDaemonSetStrategyType)
+	if ru := ds.Spec.UpdateStrategy.RollingUpdate; ru != nil {
+		var parts []string
+		if ru.MaxSurge != nil {
+			parts = append(parts, "maxSurge="+ru.MaxSurge.String())
+		}
+		if ru.MaxUnavailable != nil {
+			parts = append(parts, "maxUnavailable="+ru.MaxUnavailable.String())
+		}
+		if len(parts) > 0 {
+			strategy += " (" + strings.Join(parts, ", ") + ")"
+		}
+	}
+	return strategy
+}
+
+// workloadHealthMessage summarizes workload health
+func workloadHealthMessage(output *WorkloadHealthOutput) string {
+	s := output.Summary
+	if s.Total == 0 {
+		return "No workloads found"
+	}
+	msg := fmt.Sprintf("%d workloads: %d healthy, %d progressing, %d stuck, %d degraded", s.Total, s.Healthy, s.Progressing, s.Stuck, s.Degraded)
+	for _, w := range output.Workloads {
+		if w.Status == WorkloadStuck {
+			msg += fmt.Sprintf("; %s %s/%s is stuck", w.Kind, w.Namespace, w.Name)
+			if len(w.Issues) > 0 {
+				msg += " (" + w.Issues[0] + ")"
+			}
+			break
+		}
+	}
+	return msg
+}
@@ -0,0 +1,219 @@
+package tools
+
+import (
+	"testing"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func int32Ptr(v int32) *int32 { return &v }
+
+func ownedBy(kind, name string, uid types.UID) []metav1.OwnerReference {
+	controller := true
+	return []metav1.OwnerReference{{Kind: kind, Name: name, UID: uid, Controller: &controller}}
+}
+
+func workloadPod(name string, owner []metav1.OwnerReference, labels map[string]string, ready bool, waitingReason string, notReadySince time.Time) corev1.Pod {
+	readyStatus := corev1.ConditionFalse
+	if ready {
+		readyStatus = corev1.ConditionTrue
+	}
+	pod := corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "app", OwnerReferences: owner, Labels: labels, CreationTimestamp: metav1.NewTime(notReadySince)},
+		Status: corev1.PodStatus{
+			Phase:      corev1.PodRunning,
+			Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: readyStatus, LastTransitionTime: metav1.NewTime(notReadySince)}},
+		},
+	}
+	if waitingReason != "" {
+		pod.Status.ContainerStatuses = []corev1.ContainerStatus{{
+			Name:         "app",
+			RestartCount: 4,
+			State:        corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: waitingReason}},
+		}}
+	}
+	return pod
+}
+
+func replicaSet(name, hash string, revision string, replicas int32, owner types.UID) appsv1.ReplicaSet {
+	return appsv1.ReplicaSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            name,
+			Namespace:       "app",
+			UID:             types.UID(name),
+			Labels:          map[string]string{appsv1.DefaultDeploymentUniqueLabelKey: hash},
+			Annotations:     map[string]string{deploymentRevisionAnnotation: revision},
+			OwnerReferences: ownedBy("Deployment", "api", owner),
+		},
+		Status: appsv1.ReplicaSetStatus{Replicas: replicas},
+	}
+}
+
+func TestWorkloadHealthTool_Metadata(t *testing.T) {
+	tool := &WorkloadHealthTool{}
+
+	if tool.Name() != "get-workload-health" {
+		t.Errorf("Expected name 'get-workload-health', got '%s'", tool.Name())
+	}
+
+	schema := tool.InputSchema()
+	properties, ok := schema["properties"].(map[string]interface{})
+	if !ok {
+		t.Fatal("Expected properties to be a map")
+	}
+	for _, prop := range []string{"namespace", "kind", "name", "only_unhealthy", "stuck_threshold_minutes"} {
+		if _, exists := properties[prop]; !exists {
+			t.Errorf("Expected property '%s' in schema", prop)
+		}
+	}
+}
+
+func TestAnalyzeDeployment_StuckRollout(t *testing.T) {
+	now := time.Now()
+	maxSurge := intstr.FromString("25%")
+	d := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "app", UID: "dep", Generation: 3},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: int32Ptr(3),
+			Strategy: appsv1.DeploymentStrategy{
+				Type:          appsv1.RollingUpdateDeploymentStrategyType,
+				RollingUpdate: &appsv1.RollingUpdateDeployment{MaxSurge: &maxSurge},
+			},
+		},
+		Status: appsv1.DeploymentStatus{
+			ObservedGeneration: 3,
+			Replicas:           4,
+			UpdatedReplicas:    1,
+			ReadyReplicas:      3,
+			AvailableReplicas:  3,
+			Conditions: []appsv1.DeploymentCondition{{
+				Type:    appsv1.DeploymentProgressing,
+				Status:  corev1.ConditionFalse,
+				Reason:  "ProgressDeadlineExceeded",
+				Message: `ReplicaSet "api-new" has timed out progressing.`,
+			}},
+		},
+	}
+	replicaSets := []appsv1.ReplicaSet{
+		replicaSet("api-old", "old", "4", 3, "dep"),
+		replicaSet("api-new", "new", "5", 1, "dep"),
+		replicaSet("api-older", "older", "2", 0, "dep"),
+	}
+	pods := []corev1.Pod{
+		workloadPod("api-old-1", ownedBy("ReplicaSet", "api-old", "api-old"), map[string]string{appsv1.DefaultDeploymentUniqueLabelKey: "old"}, true, "", now.Add(-time.Hour)),
+		workloadPod("api-new-1", ownedBy("ReplicaSet", "api-new", "api-new"), map[string]string{appsv1.DefaultDeploymentUniqueLabelKey: "new"}, false, "CrashLoopBackOff", now.Add(-15*time.Minute)),
+	}
+
+	w := analyzeDeployment(d, replicaSets, pods, now)
+
+	if w.Status != WorkloadStuck || !w.Stuck || !w.RolloutInProgress {
+		t.Errorf("Expected stuck rollout, got status=%s stuck=%v rollout=%v", w.Status, w.Stuck, w.RolloutInProgress)
+	}
+	if w.CurrentRevision == nil || w.CurrentRevision.Name != "api-new" || w.PreviousRevision == nil || w.PreviousRevision.Name != "api-old" {
+		t.Errorf("Expected current api-new and previous api-old, got %+v / %+v", w.CurrentRevision, w.PreviousRevision)
+	}
+	if len(w.BlockingPods) != 1 || w.BlockingPods[0].Name != "api-new-1" || w.BlockingPods[0].Reason != "CrashLoopBackOff" || w.BlockingPods[0].Revision != "api-new" {
+		t.Errorf("Expected api-new-1 blocking with CrashLoopBackOff, got %+v", w.BlockingPods)
+	}
+	if w.Strategy != "RollingUpdate (maxSurge=25%)" {
+		t.Errorf("Unexpected strategy: %s", w.Strategy)
+	}
+}
+
+func TestAnalyzeDeployment_NilReplicasAndReplicaFailure(t *testing.T) {
+	d := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "worker", Namespace: "app"},
+		Status: appsv1.DeploymentStatus{
+			Replicas:          1,
+			UpdatedReplicas:   1,
+			ReadyReplicas:     1,
+			AvailableReplicas: 1,
+		},
+	}
+
+	w := analyzeDeployment(d, nil, nil, time.Now())
+	if w.Replicas.Desired != 1 || w.Status != WorkloadHealthy {
+		t.Errorf("Expected healthy deployment with default 1 replica, got %+v", w)
+	}
+
+	d.Status.Conditions = []appsv1.DeploymentCondition{{
+		Type:    appsv1.DeploymentReplicaFailure,
+		Status:  corev1.ConditionTrue,
+		Message: "pods \"worker-x\" is forbidden: exceeded quota",
+	}}
+	w = analyzeDeployment(d, nil, nil, time.Now())
+	if w.Status != WorkloadDegraded || !contains(w.Issues[0], "exceeded quota") {
+		t.Errorf("Expected degraded with quota issue, got %+v", w)
+	}
+}
+
+func TestAnalyzeStatefulSet_Rollout(t *testing.T) {
+	now := time.Now()
+	s := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "app", UID: "sts"},
+		Spec:       appsv1.StatefulSetSpec{Replicas: int32Ptr(3)},
+		Status: appsv1.StatefulSetStatus{
+			Replicas:          3,
+			ReadyReplicas:     2,
+			AvailableReplicas: 2,
+			UpdatedReplicas:   1,
+			CurrentRevision:   "db-a",
+			UpdateRevision:    "db-b",
+		},
+	}
+	revisions := []appsv1.ControllerRevision{
+		{ObjectMeta: metav1.ObjectMeta{Name: "db-a"}, Revision: 1},
+		{ObjectMeta: metav1.ObjectMeta{Name: "db-b"}, Revision: 2},
+	}
+	owner := ownedBy("StatefulSet", "db", "sts")
+	pods := []corev1.Pod{
+		workloadPod("db-2", owner, map[string]string{appsv1.ControllerRevisionHashLabelKey: "db-b"}, false, "", now.Add(-2*time.Minute)),
+	}
+
+	w := analyzeStatefulSet(s, revisions, pods, now, 10*time.Minute)
+	if w.Status != WorkloadProgressing || w.CurrentRevision.Name != "db-b" || w.PreviousRevision.Name != "db-a" {
+		t.Errorf("Expected progressing rollout from db-a to db-b, got %+v", w)
+	}
+
+	// The same pod not ready beyond the threshold makes the rollout stuck
+	w = analyzeStatefulSet(s, revisions, pods, now, time.Minute)
+	if w.Status != WorkloadStuck || len(w.BlockingPods) != 1 || w.BlockingPods[0].Revision != "db-b" {
+		t.Errorf("Expected stuck rollout blocked by db-2, got %+v", w)
+	}
+}
+
+func TestAnalyzeWorkloads_FilterAndSort(t *testing.T) {
+	now := time.Now()
+	healthy := appsv1.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "agent", Namespace: "infra", UID: "ds"},
+		Status: appsv1.DaemonSetStatus{
+			DesiredNumberScheduled: 3, CurrentNumberScheduled: 3, UpdatedNumberScheduled: 3,
+			NumberReady: 3, NumberAvailable: 3,
+		},
+	}
+	degraded := appsv1.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "logger", Namespace: "infra", UID: "ds2"},
+		Status: appsv1.DaemonSetStatus{
+			DesiredNumberScheduled: 3, CurrentNumberScheduled: 3, UpdatedNumberScheduled: 3,
+			NumberReady: 2, NumberAvailable: 2, NumberUnavailable: 1,
+		},
+	}
+	inv := &workloadInventory{daemonSets: []appsv1.DaemonSet{healthy, degraded}}
+
+	output := analyzeWorkloads(inv, WorkloadHealthInput{OnlyUnhealthy: true, StuckThresholdMinutes: 10}, now)
+
+	if output.Summary.Total != 2 || output.Summary.Healthy != 1 || output.Summary.Degraded != 1 {
+		t.Errorf("Unexpected summary: %+v", output.Summary)
+	}
+	if len(output.Workloads) != 1 || output.Workloads[0].Name != "logger" {
+		t.Errorf("Expected only the degraded logger DaemonSet, got %+v", output.Workloads)
+	}
+	if !contains(output.Message, "1 degraded") {
+		t.Errorf("Unexpected message: %q", output.Message)
+	}
+}
@@ -40,6 +40,10 @@ type NamespaceQuota struct {
 	MemoryUsedBytes       int64 `json:"memory_used_bytes"`
 	CurrentPodCount       int   `json:"current_pod_count"`
 	HasQuota              bool  `json:"has_quota"`
+	// Constraints records, per resource, the quota or cluster capacity that
+	// produced the limit
+	Constraints map[string]QuotaConstraint `json:"constraints,omitempty"`
+	Notes       []string                   `json:"notes,omitempty"`
 }
 
 // AvailableCapacity represents the remaining capacity in a namespace
@@ -173,14 +177,7 @@ func (c *Calculator) CalculatePodCapacity(
 	}
 
 	// Determine recommended limit based on requested profile
-	var recommendedResources PodResources
-	if customResources != nil && podProfile == PodProfileCustom {
-		recommendedResources = *customResources
-	} else if resources, ok := DefaultPodProfiles[podProfile]; ok {
-		recommendedResources = resources
-	} else {
-		recommendedResources = DefaultPodProfiles[PodProfileMedium]
-	}
+	recommendedResources := ResolveProfile(podProfile, customResources)
 
 	recommendedEstimate := c.calculateEstimate(recommendedResources, availableCPU, availableMemory, availablePodSlots, safetyMargin)
 
@@ -220,6 +217,18 @@ func (c *Calculator) CalculatePodCapacity(
 	return result, nil
 }
 
+// ResolveProfile returns the resources of a pod profile, falling back to the
+// medium profile when the profile is unknown or custom resources are missing
+func ResolveProfile(podProfile PodProfile, customResources *PodResources) PodResources {
+	if customResources != nil && podProfile == PodProfileCustom {
+		return *customResources
+	}
+	if resources, ok := DefaultPodProfiles[podProfile]; ok {
+		return resources
+	}
+	return DefaultPodProfiles[PodProfileMedium]
+}
+
 // calculateEstimate calculates pod estimates for given resources
 func (c *Calculator) calculateEstimate(
 	resources PodResources,
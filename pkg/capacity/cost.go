@@ -0,0 +1,260 @@
+package capacity
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/yaml"
+
+	"github.com/KubeHeal/openshift-cluster-health-mcp/pkg/scheduling"
+)
+
+// HoursPerMonth is the average number of hours in a month (8760 / 12)
+const HoursPerMonth = 730
+
+// Instance type node labels; the beta label is still set by some providers
+const (
+	InstanceTypeLabel     = "node.kubernetes.io/instance-type"
+	InstanceTypeBetaLabel = "beta.kubernetes.io/instance-type"
+)
+
+const gibibyte = 1024 * mebibyte
+
+// ResourceRates are hourly prices for one vCPU and one GiB of memory
+type ResourceRates struct {
+	CPUCoreHour   float64 `json:"cpu_core_hour"`
+	MemoryGiBHour float64 `json:"memory_gib_hour"`
+}
+
+// PricingModel prices CPU and memory, optionally per node instance type
+//
+//	currency: USD
+//	default: {cpu_core_hour: 0.031, memory_gib_hour: 0.004}
+//	instance_types:
+//	  m5.2xlarge: {cpu_core_hour: 0.024, memory_gib_hour: 0.003}
+type PricingModel struct {
+	Currency string `json:"currency,omitempty"`
+	// Default applies to nodes without an instance type listed below
+	Default       ResourceRates            `json:"default"`
+	InstanceTypes map[string]ResourceRates `json:"instance_types,omitempty"`
+}
+
+// MonthlyCost is a monthly price split by resource
+type MonthlyCost struct {
+	CPU    float64 `json:"cpu"`
+	Memory float64 `json:"memory"`
+	Total  float64 `json:"total"`
+}
+
+// ParsePricingModel parses and validates a YAML or JSON pricing model
+func ParsePricingModel(data []byte) (*PricingModel, error) {
+	var model PricingModel
+	if err := yaml.UnmarshalStrict(data, &model); err != nil {
+		return nil, fmt.Errorf("invalid pricing model: %w", err)
+	}
+	if err := validateRates("default", model.Default); err != nil {
+		return nil, err
+	}
+	for name, rates := range model.InstanceTypes {
+		if err := validateRates("instance type "+name, rates); err != nil {
+			return nil, err
+		}
+	}
+	if model.Default == (ResourceRates{}) && len(model.InstanceTypes) == 0 {
+		return nil, fmt.Errorf("invalid pricing model: default or instance_types rates are required")
+	}
+	if model.Currency == "" {
+		model.Currency = "USD"
+	}
+	return &model, nil
+}
+
+func validateRates(name string, rates ResourceRates) error {
+	if rates.CPUCoreHour < 0 || rates.MemoryGiBHour < 0 {
+		return fmt.Errorf("invalid pricing model: %s rates must not be negative", name)
+	}
+	return nil
+}
+
+// RatesFor returns the rates of an instance type, or the default rates
+func (m *PricingModel) RatesFor(instanceType string) ResourceRates {
+	if rates, ok := m.InstanceTypes[instanceType]; ok && instanceType != "" {
+		return rates
+	}
+	return m.Default
+}
+
+// NodeInstanceType reads a node's instance type label
+func NodeInstanceType(node *corev1.Node) string {
+	if t := node.Labels[InstanceTypeLabel]; t != "" {
+		return t
+	}
+	return node.Labels[InstanceTypeBetaLabel]
+}
+
+// MonthlyCost prices CPU (millicores) and memory (bytes) held for a month
+func (r ResourceRates) MonthlyCost(cpuMillicores, memoryBytes float64) MonthlyCost {
+	cost := MonthlyCost{
+		CPU:    cpuMillicores / 1000 * r.CPUCoreHour * HoursPerMonth,
+		Memory: memoryBytes / gibibyte * r.MemoryGiBHour * HoursPerMonth,
+	}
+	return cost.rounded()
+}
+
+// Add sums two costs
+func (c MonthlyCost) Add(o MonthlyCost) MonthlyCost {
+	return MonthlyCost{CPU: c.CPU + o.CPU, Memory: c.Memory + o.Memory}.rounded()
+}
+
+// Sub subtracts o from c
+func (c MonthlyCost) Sub(o MonthlyCost) MonthlyCost {
+	return MonthlyCost{CPU: c.CPU - o.CPU, Memory: c.Memory - o.Memory}.rounded()
+}
+
+// rounded rounds to cents and recomputes the total
+func (c MonthlyCost) rounded() MonthlyCost {
+	c.CPU = roundCents(c.CPU)
+	c.Memory = roundCents(c.Memory)
+	c.Total = roundCents(c.CPU + c.Memory)
+	return c
+}
+
+func roundCents(v float64) float64 {
+	return math.Round(v*100) / 100
+}
+
+// NodePricer prices resources on specific nodes. Pods on unknown nodes (or
+// not yet scheduled) are priced at the capacity-weighted blend of all nodes.
+type NodePricer struct {
+	model   *PricingModel
+	nodes   map[string]ResourceRates
+	blended ResourceRates
+	cluster MonthlyCost
+}
+
+// NewNodePricer resolves the rates of every node from its instance type
+func NewNodePricer(model *PricingModel, nodes []corev1.Node) *NodePricer {
+	p := &NodePricer{model: model, nodes: make(map[string]ResourceRates, len(nodes))}
+	var cpuWeight, memoryWeight, cpuRate, memoryRate float64
+	for i := range nodes {
+		node := &nodes[i]
+		rates := model.RatesFor(NodeInstanceType(node))
+		p.nodes[node.Name] = rates
+		allocatable := scheduling.NewNodeInfo(node).Allocatable
+		cpuWeight += float64(allocatable.MilliCPU)
+		memoryWeight += float64(allocatable.Memory)
+		cpuRate += float64(allocatable.MilliCPU) * rates.CPUCoreHour
+		memoryRate += float64(allocatable.Memory) * rates.MemoryGiBHour
+		p.cluster = p.cluster.Add(rates.MonthlyCost(float64(allocatable.MilliCPU), float64(allocatable.Memory)))
+	}
+	p.blended = model.Default
+	if cpuWeight > 0 {
+		p.blended.CPUCoreHour = cpuRate / cpuWeight
+	}
+	if memoryWeight > 0 {
+		p.blended.MemoryGiBHour = memoryRate / memoryWeight
+	}
+	return p
+}
+
+// Currency returns the model's currency
+func (p *NodePricer) Currency() string {
+	return p.model.Currency
+}
+
+// Blended returns the capacity-weighted rates across all nodes
+func (p *NodePricer) Blended() ResourceRates {
+	return p.blended
+}
+
+// NodeRates returns the rates of a node, or the blended rates if unknown
+func (p *NodePricer) NodeRates(nodeName string) ResourceRates {
+	if rates, ok := p.nodes[nodeName]; ok {
+		return rates
+	}
+	return p.blended
+}
+
+// ClusterCost is the monthly cost of all nodes' allocatable capacity
+func (p *NodePricer) ClusterCost() MonthlyCost {
+	return p.cluster
+}
+
+// PodUsage is a pod's observed CPU and memory usage
+type PodUsage struct {
+	CPUMillicores float64 `json:"cpu_millicores"`
+	MemoryBytes   float64 `json:"memory_bytes"`
+}
+
+// NamespaceCost is the monthly cost allocated to one namespace
+type NamespaceCost struct {
+	Namespace            string  `json:"namespace"`
+	Pods                 int     `json:"pods"`
+	CPURequestMillicores int64   `json:"cpu_request_millicores"`
+	MemoryRequestBytes   int64   `json:"memory_request_bytes"`
+	CPUUsageMillicores   float64 `json:"cpu_usage_millicores"`
+	MemoryUsageBytes     float64 `json:"memory_usage_bytes"`
+	// RequestsCost allocates by reserved requests, UsageCost by measured
+	// usage (only pods with usage data); IdleCost is requested but unused
+	RequestsCost MonthlyCost `json:"requests_cost"`
+	UsageCost    MonthlyCost `json:"usage_cost"`
+	IdleCost     MonthlyCost `json:"idle_cost"`
+	// Efficiency is the usage share of the requests cost (0-1); 0 without usage data
+	Efficiency float64 `json:"efficiency"`
+	Measured   int     `json:"pods_measured"`
+}
+
+// AllocateNamespaceCosts prices each scheduled, non-terminated pod's
+// requests and usage at the rates of the node it runs on and sums them per
+// namespace. Usage is keyed by "namespace/pod". Namespaces are sorted by
+// requests cost, highest first.
+func AllocateNamespaceCosts(pricer *NodePricer, pods []corev1.Pod, usage map[string]PodUsage) []NamespaceCost {
+	byNamespace := make(map[string]*NamespaceCost)
+	for i := range pods {
+		pod := &pods[i]
+		if pod.Spec.NodeName == "" || scheduling.IsTerminal(pod) {
+			continue
+		}
+		ns, ok := byNamespace[pod.Namespace]
+		if !ok {
+			ns = &NamespaceCost{Namespace: pod.Namespace}
+			byNamespace[pod.Namespace] = ns
+		}
+		rates := pricer.NodeRates(pod.Spec.NodeName)
+		requests := PodResourcesOf(pod).Requests
+		ns.Pods++
+		ns.CPURequestMillicores += requests.MilliCPU
+		ns.MemoryRequestBytes += requests.Memory
+		requestsCost := rates.MonthlyCost(float64(requests.MilliCPU), float64(requests.Memory))
+		ns.RequestsCost = ns.RequestsCost.Add(requestsCost)
+
+		u, ok := usage[pod.Namespace+"/"+pod.Name]
+		if !ok {
+			continue
+		}
+		ns.Measured++
+		ns.CPUUsageMillicores += u.CPUMillicores
+		ns.MemoryUsageBytes += u.MemoryBytes
+		ns.UsageCost = ns.UsageCost.Add(rates.MonthlyCost(u.CPUMillicores, u.MemoryBytes))
+		idleCPU := math.Max(float64(requests.MilliCPU)-u.CPUMillicores, 0)
+		idleMemory := math.Max(float64(requests.Memory)-u.MemoryBytes, 0)
+		ns.IdleCost = ns.IdleCost.Add(rates.MonthlyCost(idleCPU, idleMemory))
+	}
+
+	costs := make([]NamespaceCost, 0, len(byNamespace))
+	for _, ns := range byNamespace {
+		if ns.Measured > 0 && ns.RequestsCost.Total > 0 {
+			ns.Efficiency = math.Round(math.Min(ns.UsageCost.Total/ns.RequestsCost.Total, 1)*100) / 100
+		}
+		costs = append(costs, *ns)
+	}
+	sort.Slice(costs, func(i, j int) bool {
+		if costs[i].RequestsCost.Total != costs[j].RequestsCost.Total {
+			return costs[i].RequestsCost.Total > costs[j].RequestsCost.Total
+		}
+		return costs[i].Namespace < costs[j].Namespace
+	})
+	return costs
+}
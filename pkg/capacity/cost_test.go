@@ -0,0 +1,95 @@
+package capacity
+
+import (
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestParsePricingModel(t *testing.T) {
+	model, err := ParsePricingModel([]byte(`
+default: {cpu_core_hour: 0.04, memory_gib_hour: 0.005}
+instance_types:
+  m5.xlarge: {cpu_core_hour: 0.02, memory_gib_hour: 0.0025}
+`))
+	if err != nil {
+		t.Fatalf("ParsePricingModel: %v", err)
+	}
+	if model.Currency != "USD" {
+		t.Errorf("currency = %q, want USD default", model.Currency)
+	}
+	if r := model.RatesFor("m5.xlarge"); r.CPUCoreHour != 0.02 {
+		t.Errorf("m5.xlarge rates = %+v", r)
+	}
+	if r := model.RatesFor("unknown"); r != model.Default {
+		t.Errorf("unknown instance type rates = %+v, want default", r)
+	}
+
+	for name, data := range map[string]string{
+		"empty":         `currency: EUR`,
+		"negative rate": `default: {cpu_core_hour: -1}`,
+		"unknown field": `default: {cpu_per_hour: 0.04}`,
+	} {
+		if _, err := ParsePricingModel([]byte(data)); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestMonthlyCost(t *testing.T) {
+	rates := ResourceRates{CPUCoreHour: 0.04, MemoryGiBHour: 0.005}
+	cost := rates.MonthlyCost(500, 2*gibibyte)
+	// 0.5 core * 0.04 * 730 = 14.60, 2 GiB * 0.005 * 730 = 7.30
+	if cost.CPU != 14.6 || cost.Memory != 7.3 || cost.Total != 21.9 {
+		t.Errorf("MonthlyCost = %+v", cost)
+	}
+	if delta := cost.Sub(rates.MonthlyCost(1000, 0)); delta.Total != -7.3 {
+		t.Errorf("delta = %+v, want -7.30", delta)
+	}
+}
+
+func TestAllocateNamespaceCosts(t *testing.T) {
+	model := &PricingModel{
+		Currency:      "USD",
+		Default:       ResourceRates{CPUCoreHour: 0.04, MemoryGiBHour: 0.005},
+		InstanceTypes: map[string]ResourceRates{"m5.xlarge": {CPUCoreHour: 0.02, MemoryGiBHour: 0.0025}},
+	}
+	nodes := []corev1.Node{
+		testNode("cheap", "4", "8Gi", map[string]string{InstanceTypeLabel: "m5.xlarge"}),
+		testNode("pricey", "4", "8Gi", nil),
+	}
+	pricer := NewNodePricer(model, nodes)
+	if b := pricer.Blended(); b.CPUCoreHour != 0.03 || b.MemoryGiBHour != 0.00375 {
+		t.Errorf("blended rates = %+v, want capacity-weighted average", b)
+	}
+	if c := pricer.ClusterCost(); c.Total != 219 {
+		t.Errorf("cluster cost = %+v, want 219", c)
+	}
+
+	app := scheduledPod("api", "cheap", "1")
+	web := scheduledPod("frontend", "pricey", "1")
+	web.Namespace = "web"
+	pending := scheduledPod("pending", "", "4")
+	done := scheduledPod("job", "pricey", "4")
+	done.Status.Phase = corev1.PodSucceeded
+	usage := map[string]PodUsage{"app/api": {CPUMillicores: 250}}
+
+	costs := AllocateNamespaceCosts(pricer, []corev1.Pod{app, web, pending, done}, usage)
+	if len(costs) != 2 || costs[0].Namespace != "web" {
+		t.Fatalf("costs = %+v, want web (pricier node) ranked first", costs)
+	}
+	if costs[0].RequestsCost.Total != 29.2 || costs[0].Measured != 0 || costs[0].Efficiency != 0 {
+		t.Errorf("web = %+v", costs[0])
+	}
+	a := costs[1]
+	if a.Pods != 1 || a.RequestsCost.Total != 14.6 || a.UsageCost.Total != 3.65 || a.IdleCost.Total != 10.95 {
+		t.Errorf("app = %+v", a)
+	}
+	if a.Efficiency != 0.25 {
+		t.Errorf("app efficiency = %g, want 0.25", a.Efficiency)
+	}
+	if !strings.HasPrefix(NodeInstanceType(&nodes[0]), "m5") {
+		t.Errorf("instance type not read from the node label")
+	}
+}
@@ -0,0 +1,171 @@
+package capacity
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// WorkloadRef identifies the controller that owns a pod
+type WorkloadRef struct {
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+}
+
+// String renders the reference as namespace/Kind/name, or "" for bare pods
+func (w WorkloadRef) String() string {
+	if w.Kind == "" {
+		return ""
+	}
+	return w.Namespace + "/" + w.Kind + "/" + w.Name
+}
+
+// WorkloadOf resolves the top-level controller of a pod. ReplicaSets created
+// by a Deployment are reported as the Deployment, using the
+// pod-template-hash suffix the Deployment controller appends.
+func WorkloadOf(pod *corev1.Pod) WorkloadRef {
+	owner := metav1.GetControllerOf(pod)
+	if owner == nil {
+		return WorkloadRef{}
+	}
+	ref := WorkloadRef{Kind: owner.Kind, Namespace: pod.Namespace, Name: owner.Name}
+	if owner.Kind == "ReplicaSet" {
+		if hash := pod.Labels["pod-template-hash"]; hash != "" {
+			if name, ok := strings.CutSuffix(owner.Name, "-"+hash); ok {
+				ref.Kind, ref.Name = "Deployment", name
+			}
+		}
+	}
+	return ref
+}
+
+// WorkloadSpread describes where a workload's replicas run
+type WorkloadSpread struct {
+	Workload WorkloadRef `json:"workload"`
+	Replicas int         `json:"replicas"`
+	Nodes    []string    `json:"nodes"`
+	Zones    []string    `json:"zones,omitempty"`
+	Risk     string      `json:"risk"`
+}
+
+// ConcentratedWorkloads finds multi-replica workloads whose running replicas
+// all sit on one node, or in one zone when the nodes span several zones.
+// DaemonSets are excluded since they run one pod per node by design.
+func ConcentratedWorkloads(nodes []corev1.Node, pods []corev1.Pod) []WorkloadSpread {
+	zoneOf := make(map[string]string, len(nodes))
+	for _, node := range nodes {
+		zoneOf[node.Name] = node.Labels[corev1.LabelTopologyZone]
+	}
+	multiZone := len(Zones(nodes)) > 1
+
+	type placement struct {
+		replicas int
+		nodes    map[string]bool
+		zones    map[string]bool
+	}
+	byWorkload := make(map[WorkloadRef]*placement)
+	var order []WorkloadRef
+	for i := range pods {
+		pod := &pods[i]
+		if pod.Spec.NodeName == "" || pod.Status.Phase != corev1.PodRunning || IsDaemonSetPod(pod) {
+			continue
+		}
+		ref := WorkloadOf(pod)
+		if ref.Kind == "" {
+			continue
+		}
+		p, ok := byWorkload[ref]
+		if !ok {
+			p = &placement{nodes: make(map[string]bool), zones: make(map[string]bool)}
+			byWorkload[ref] = p
+			order = append(order, ref)
+		}
+		p.replicas++
+		p.nodes[pod.Spec.NodeName] = true
+		if zone := zoneOf[pod.Spec.NodeName]; zone != "" {
+			p.zones[zone] = true
+		}
+	}
+
+	var spreads []WorkloadSpread
+	for _, ref := range order {
+		p := byWorkload[ref]
+		if p.replicas < 2 {
+			continue
+		}
+		spread := WorkloadSpread{Workload: ref, Replicas: p.replicas, Nodes: sortedKeys(p.nodes), Zones: sortedKeys(p.zones)}
+		switch {
+		case len(p.nodes) == 1:
+			spread.Risk = fmt.Sprintf("all %d replicas run on node %s", p.replicas, spread.Nodes[0])
+		case multiZone && len(p.zones) == 1:
+			spread.Risk = fmt.Sprintf("all %d replicas run in zone %s", p.replicas, spread.Zones[0])
+		default:
+			continue
+		}
+		spreads = append(spreads, spread)
+	}
+	sort.SliceStable(spreads, func(i, j int) bool {
+		return spreads[i].Workload.String() < spreads[j].Workload.String()
+	})
+	return spreads
+}
+
+// PDBBlock is a PodDisruptionBudget that would refuse some evictions
+type PDBBlock struct {
+	Namespace          string   `json:"namespace"`
+	Name               string   `json:"name"`
+	DisruptionsAllowed int32    `json:"disruptions_allowed"`
+	AffectedPods       []string `json:"affected_pods"`
+	Reason             string   `json:"reason"`
+}
+
+// BlockingPDBs returns the budgets that would block evicting the given pods
+// together: more matching pods would be evicted than the budget currently
+// allows. Budgets with an unset selector match no pods (policy/v1 semantics).
+func BlockingPDBs(pdbs []policyv1.PodDisruptionBudget, evicted []*corev1.Pod) []PDBBlock {
+	var blocks []PDBBlock
+	for i := range pdbs {
+		pdb := &pdbs[i]
+		selector, err := metav1.LabelSelectorAsSelector(pdb.Spec.Selector)
+		if err != nil {
+			continue
+		}
+		var affected []string
+		for _, pod := range evicted {
+			if pod.Namespace == pdb.Namespace && selector.Matches(labels.Set(pod.Labels)) {
+				affected = append(affected, pod.Name)
+			}
+		}
+		if len(affected) == 0 || int32(len(affected)) <= pdb.Status.DisruptionsAllowed {
+			continue
+		}
+		sort.Strings(affected)
+		reason := fmt.Sprintf("%d matching pods would be evicted but only %d disruptions are allowed", len(affected), pdb.Status.DisruptionsAllowed)
+		if pdb.Status.DisruptionsAllowed == 0 {
+			reason = fmt.Sprintf("budget allows no disruptions (%d/%d healthy, %d desired)", pdb.Status.CurrentHealthy, pdb.Status.ExpectedPods, pdb.Status.DesiredHealthy)
+		}
+		blocks = append(blocks, PDBBlock{
+			Namespace:          pdb.Namespace,
+			Name:               pdb.Name,
+			DisruptionsAllowed: pdb.Status.DisruptionsAllowed,
+			AffectedPods:       affected,
+			Reason:             reason,
+		})
+	}
+	return blocks
+}
+
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for k := range set {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,241 @@
+package capacity
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/KubeHeal/openshift-cluster-health-mcp/pkg/scheduling"
+)
+
+// Failure scenarios supported by SimulateFailure callers
+const (
+	ScenarioLargestNode = "largest_node"
+	ScenarioNode        = "node"
+	ScenarioZone        = "zone"
+)
+
+// DisplacedPod is a pod evicted by a simulated failure
+type DisplacedPod struct {
+	Namespace     string               `json:"namespace"`
+	Name          string               `json:"name"`
+	Workload      string               `json:"workload,omitempty"`
+	FromNode      string               `json:"from_node"`
+	Requests      scheduling.Resources `json:"requests"`
+	RescheduledTo string               `json:"rescheduled_to,omitempty"`
+	Reasons       []string             `json:"reasons,omitempty"`
+}
+
+// FailureSimulation is the outcome of removing nodes and rescheduling their pods
+type FailureSimulation struct {
+	FailedNodes       []string             `json:"failed_nodes"`
+	RemainingNodes    int                  `json:"remaining_nodes"`
+	LostAllocatable   scheduling.Resources `json:"lost_allocatable"`
+	DisplacedPods     int                  `json:"displaced_pods"`
+	ReschedulablePods int                  `json:"reschedulable_pods"`
+	SkippedPods       int                  `json:"skipped_pods"`
+	Unschedulable     []DisplacedPod       `json:"unschedulable"`
+	PlacedByNode      map[string]int       `json:"placed_by_node"`
+	Survives          bool                 `json:"survives"`
+}
+
+// LargestNode returns the Ready node with the most allocatable CPU (memory
+// breaks ties), i.e. the single node whose loss hurts most
+func LargestNode(nodes []corev1.Node) string {
+	var best *corev1.Node
+	for i := range nodes {
+		node := &nodes[i]
+		if !isNodeReady(node) {
+			continue
+		}
+		if best == nil || largerNode(node, best) {
+			best = node
+		}
+	}
+	if best == nil {
+		return ""
+	}
+	return best.Name
+}
+
+func largerNode(a, b *corev1.Node) bool {
+	if c := a.Status.Allocatable.Cpu().Cmp(*b.Status.Allocatable.Cpu()); c != 0 {
+		return c > 0
+	}
+	if c := a.Status.Allocatable.Memory().Cmp(*b.Status.Allocatable.Memory()); c != 0 {
+		return c > 0
+	}
+	return a.Name < b.Name
+}
+
+// NodesInZone returns the names of nodes labeled with the given topology zone
+func NodesInZone(nodes []corev1.Node, zone string) []string {
+	var names []string
+	for _, node := range nodes {
+		if node.Labels[corev1.LabelTopologyZone] == zone {
+			names = append(names, node.Name)
+		}
+	}
+	return names
+}
+
+// Zones returns the distinct topology zones of the nodes, sorted
+func Zones(nodes []corev1.Node) []string {
+	seen := make(map[string]bool)
+	var zones []string
+	for _, node := range nodes {
+		if zone := node.Labels[corev1.LabelTopologyZone]; zone != "" && !seen[zone] {
+			seen[zone] = true
+			zones = append(zones, zone)
+		}
+	}
+	sort.Strings(zones)
+	return zones
+}
+
+// SimulateFailure removes the failed nodes and reschedules their pods onto
+// the remaining nodes, largest pods first, honoring the same predicates as
+// the scheduling simulator. DaemonSet and static pods are skipped because
+// they do not move. volumeAffinity maps "namespace/claim" to the node
+// affinity of its bound volume, so zonal volumes pin pods to their zone.
+func SimulateFailure(nodes []corev1.Node, pods []corev1.Pod, failed map[string]bool, volumeAffinity map[string]*corev1.VolumeNodeAffinity) *FailureSimulation {
+	result := &FailureSimulation{
+		FailedNodes:   []string{},
+		Unschedulable: []DisplacedPod{},
+		PlacedByNode:  make(map[string]int),
+	}
+
+	var remaining []corev1.Node
+	for i := range nodes {
+		if failed[nodes[i].Name] {
+			result.FailedNodes = append(result.FailedNodes, nodes[i].Name)
+			info := scheduling.NewNodeInfo(&nodes[i])
+			result.LostAllocatable = result.LostAllocatable.Add(info.Allocatable)
+			continue
+		}
+		remaining = append(remaining, nodes[i])
+	}
+	sort.Strings(result.FailedNodes)
+	result.RemainingNodes = len(remaining)
+
+	// Pods bound to failed nodes are not assigned to any remaining NodeInfo
+	infos := scheduling.NewNodeInfos(remaining, pods)
+	sim := scheduling.NewSimulator(infos)
+	for claim, affinity := range volumeAffinity {
+		sim.VolumeNodeAffinity[claim] = affinity
+	}
+
+	displaced, skipped := EvictablePods(pods, failed)
+	result.SkippedPods = skipped
+	sort.SliceStable(displaced, func(i, j int) bool {
+		return requestSize(scheduling.PodRequests(displaced[i])) > requestSize(scheduling.PodRequests(displaced[j]))
+	})
+	result.DisplacedPods = len(displaced)
+
+	for _, pod := range displaced {
+		entry := DisplacedPod{
+			Namespace: pod.Namespace,
+			Name:      pod.Name,
+			Workload:  WorkloadOf(pod).String(),
+			FromNode:  pod.Spec.NodeName,
+			Requests:  scheduling.PodRequests(pod),
+		}
+		// The pod is rescheduled as a new, unbound replica
+		moved := pod.DeepCopy()
+		moved.Spec.NodeName = ""
+		for _, info := range infos {
+			if len(sim.CheckNode(moved, info)) == 0 {
+				moved.Spec.NodeName = info.Node.Name
+				info.AddPod(moved)
+				entry.RescheduledTo = info.Node.Name
+				break
+			}
+		}
+		if entry.RescheduledTo == "" {
+			entry.Reasons = summarizeRejections(sim.Evaluate(moved).ReasonCounts)
+			result.Unschedulable = append(result.Unschedulable, entry)
+		} else {
+			result.ReschedulablePods++
+			result.PlacedByNode[entry.RescheduledTo]++
+		}
+	}
+
+	result.Survives = len(result.Unschedulable) == 0
+	return result
+}
+
+// requestSize orders pods by their dominant request, 1 core against 1Gi
+func requestSize(r scheduling.Resources) float64 {
+	return podSize(PodResources{CPUMillicores: r.MilliCPU, MemoryMB: r.Memory / (1024 * 1024)})
+}
+
+// summarizeRejections renders predicate counts as "N node(s) Predicate"
+func summarizeRejections(counts map[string]int) []string {
+	reasons := make([]string, 0, len(counts))
+	for predicate, n := range counts {
+		reasons = append(reasons, fmt.Sprintf("%d node(s) %s", n, predicate))
+	}
+	sort.Strings(reasons)
+	if len(reasons) == 0 {
+		reasons = append(reasons, "no remaining nodes")
+	}
+	return reasons
+}
+
+// EvictablePods returns the non-terminal pods on the given nodes that would
+// be recreated elsewhere, and the number of DaemonSet and static pods skipped
+// because they stay with their node
+func EvictablePods(pods []corev1.Pod, nodes map[string]bool) ([]*corev1.Pod, int) {
+	var evictable []*corev1.Pod
+	skipped := 0
+	for i := range pods {
+		pod := &pods[i]
+		if !nodes[pod.Spec.NodeName] || scheduling.IsTerminal(pod) {
+			continue
+		}
+		if IsDaemonSetPod(pod) || isMirrorPod(pod) {
+			skipped++
+			continue
+		}
+		evictable = append(evictable, pod)
+	}
+	return evictable, skipped
+}
+
+// IsDaemonSetPod reports whether a pod is controlled by a DaemonSet
+func IsDaemonSetPod(pod *corev1.Pod) bool {
+	for _, ref := range pod.OwnerReferences {
+		if ref.Controller != nil && *ref.Controller && ref.Kind == "DaemonSet" {
+			return true
+		}
+	}
+	return false
+}
+
+// isMirrorPod reports whether a pod is the API mirror of a static pod
+func isMirrorPod(pod *corev1.Pod) bool {
+	_, ok := pod.Annotations[corev1.MirrorPodAnnotationKey]
+	return ok
+}
+
+func isNodeReady(node *corev1.Node) bool {
+	for _, cond := range node.Status.Conditions {
+		if cond.Type == corev1.NodeReady {
+			return cond.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// Summary describes the outcome of the simulation in one sentence
+func (s *FailureSimulation) Summary() string {
+	if s.DisplacedPods == 0 {
+		return fmt.Sprintf("Losing %s displaces no reschedulable pods.", strings.Join(s.FailedNodes, ", "))
+	}
+	if s.Survives {
+		return fmt.Sprintf("All %d displaced pods fit on the remaining %d nodes.", s.DisplacedPods, s.RemainingNodes)
+	}
+	return fmt.Sprintf("%d of %d displaced pods would not fit on the remaining %d nodes.", len(s.Unschedulable), s.DisplacedPods, s.RemainingNodes)
+}
@@ -0,0 +1,128 @@
+package capacity
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func zonedNode(name, zone, cpu string) corev1.Node {
+	return testNode(name, cpu, "16Gi", map[string]string{corev1.LabelTopologyZone: zone})
+}
+
+func ownedPod(name, node, cpu, kind, owner string, labels map[string]string) corev1.Pod {
+	pod := scheduledPod(name, node, cpu)
+	controller := true
+	pod.Labels = labels
+	pod.OwnerReferences = []metav1.OwnerReference{{Kind: kind, Name: owner, Controller: &controller}}
+	return pod
+}
+
+func TestLargestNodeAndZones(t *testing.T) {
+	nodes := []corev1.Node{zonedNode("a", "z1", "4"), zonedNode("b", "z2", "8"), zonedNode("c", "z1", "2")}
+	if got := LargestNode(nodes); got != "b" {
+		t.Errorf("expected largest node b, got %s", got)
+	}
+	if zones := Zones(nodes); len(zones) != 2 || zones[0] != "z1" {
+		t.Errorf("expected zones [z1 z2], got %v", zones)
+	}
+	if names := NodesInZone(nodes, "z1"); len(names) != 2 {
+		t.Errorf("expected 2 nodes in z1, got %v", names)
+	}
+}
+
+func TestSimulateFailure(t *testing.T) {
+	nodes := []corev1.Node{zonedNode("a", "z1", "2"), zonedNode("b", "z2", "2")}
+	pods := []corev1.Pod{
+		scheduledPod("fits", "a", "1"),
+		scheduledPod("too-big", "a", "1500m"),
+		ownedPod("agent", "a", "100m", "DaemonSet", "agent", nil),
+		scheduledPod("resident", "b", "500m"),
+	}
+
+	sim := SimulateFailure(nodes, pods, map[string]bool{"a": true}, nil)
+	if sim.RemainingNodes != 1 || sim.FailedNodes[0] != "a" {
+		t.Fatalf("unexpected failed/remaining nodes: %v / %d", sim.FailedNodes, sim.RemainingNodes)
+	}
+	if sim.DisplacedPods != 2 || sim.SkippedPods != 1 {
+		t.Errorf("expected 2 displaced and 1 skipped DaemonSet pod, got %d and %d", sim.DisplacedPods, sim.SkippedPods)
+	}
+	// Largest first: too-big (1500m) takes node b's 1500m free, leaving none for fits
+	if sim.ReschedulablePods != 1 || len(sim.Unschedulable) != 1 || sim.Unschedulable[0].Name != "fits" {
+		t.Errorf("expected too-big rescheduled and fits unschedulable, got %+v", sim.Unschedulable)
+	}
+	if sim.Survives {
+		t.Error("expected simulation not to survive")
+	}
+	if sim.LostAllocatable.MilliCPU != 2000 {
+		t.Errorf("expected 2000m lost, got %d", sim.LostAllocatable.MilliCPU)
+	}
+	if len(sim.Unschedulable[0].Reasons) == 0 {
+		t.Error("expected rejection reasons for unschedulable pod")
+	}
+}
+
+func TestWorkloadOf(t *testing.T) {
+	pod := ownedPod("web-7d9f-abcde", "a", "100m", "ReplicaSet", "web-7d9f", map[string]string{"pod-template-hash": "7d9f"})
+	if ref := WorkloadOf(&pod); ref.Kind != "Deployment" || ref.Name != "web" {
+		t.Errorf("expected Deployment web, got %+v", ref)
+	}
+	bare := scheduledPod("bare", "a", "100m")
+	if ref := WorkloadOf(&bare); ref.String() != "" {
+		t.Errorf("expected no workload for bare pod, got %s", ref)
+	}
+}
+
+func TestConcentratedWorkloads(t *testing.T) {
+	nodes := []corev1.Node{zonedNode("a", "z1", "4"), zonedNode("b", "z1", "4"), zonedNode("c", "z2", "4")}
+	pods := []corev1.Pod{
+		ownedPod("db-0", "a", "100m", "StatefulSet", "db", nil),
+		ownedPod("db-1", "a", "100m", "StatefulSet", "db", nil),
+		ownedPod("api-0", "a", "100m", "StatefulSet", "api", nil),
+		ownedPod("api-1", "b", "100m", "StatefulSet", "api", nil),
+		ownedPod("web-0", "a", "100m", "StatefulSet", "web", nil),
+		ownedPod("web-1", "c", "100m", "StatefulSet", "web", nil),
+		ownedPod("agent-a", "a", "100m", "DaemonSet", "agent", nil),
+		ownedPod("agent-b", "a", "100m", "DaemonSet", "agent", nil),
+	}
+
+	spreads := ConcentratedWorkloads(nodes, pods)
+	if len(spreads) != 2 {
+		t.Fatalf("expected api (one zone) and db (one node), got %+v", spreads)
+	}
+	if spreads[0].Workload.Name != "api" || !containsRole(spreads[0].Zones, "z1") {
+		t.Errorf("expected api concentrated in z1, got %+v", spreads[0])
+	}
+	if spreads[1].Workload.Name != "db" || len(spreads[1].Nodes) != 1 {
+		t.Errorf("expected db concentrated on one node, got %+v", spreads[1])
+	}
+}
+
+func TestBlockingPDBs(t *testing.T) {
+	labels := map[string]string{"app": "web"}
+	web0 := ownedPod("web-0", "a", "100m", "StatefulSet", "web", labels)
+	web1 := ownedPod("web-1", "a", "100m", "StatefulSet", "web", labels)
+	other := scheduledPod("other", "a", "100m")
+
+	pdbs := []policyv1.PodDisruptionBudget{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "app"},
+			Spec:       policyv1.PodDisruptionBudgetSpec{Selector: &metav1.LabelSelector{MatchLabels: labels}},
+			Status:     policyv1.PodDisruptionBudgetStatus{DisruptionsAllowed: 1, CurrentHealthy: 3, DesiredHealthy: 2, ExpectedPods: 3},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "nil-selector", Namespace: "app"},
+			Status:     policyv1.PodDisruptionBudgetStatus{DisruptionsAllowed: 0},
+		},
+	}
+
+	blocks := BlockingPDBs(pdbs, []*corev1.Pod{&web0, &web1, &other})
+	if len(blocks) != 1 || blocks[0].Name != "web" || len(blocks[0].AffectedPods) != 2 {
+		t.Fatalf("expected web PDB to block 2 pods, got %+v", blocks)
+	}
+	if blocks := BlockingPDBs(pdbs, []*corev1.Pod{&web0}); len(blocks) != 0 {
+		t.Errorf("expected a single eviction within budget, got %+v", blocks)
+	}
+}
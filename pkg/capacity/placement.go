@@ -0,0 +1,284 @@
+package capacity
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/KubeHeal/openshift-cluster-health-mcp/pkg/scheduling"
+)
+
+// nodeRoleLabelPrefix is the label prefix carrying node roles
+const nodeRoleLabelPrefix = "node-role.kubernetes.io/"
+
+// PlacementOptions restricts which nodes a simulated pod may land on
+type PlacementOptions struct {
+	NodeSelector map[string]string   `json:"node_selector,omitempty"`
+	NodeRoles    []string            `json:"node_roles,omitempty"`
+	Tolerations  []corev1.Toleration `json:"tolerations,omitempty"`
+}
+
+// NodeHeadroom describes the free capacity of one node for a pod profile
+type NodeHeadroom struct {
+	Node              string   `json:"node"`
+	Roles             []string `json:"roles"`
+	Eligible          bool     `json:"eligible"`
+	ExcludedReason    string   `json:"excluded_reason,omitempty"`
+	FreeCPUMillicores int64    `json:"free_cpu_millicores"`
+	FreeMemoryBytes   int64    `json:"free_memory_bytes"`
+	FreePodSlots      int      `json:"free_pod_slots"`
+	FittingPods       int      `json:"fitting_pods"`
+	LimitingFactor    string   `json:"limiting_factor,omitempty"`
+}
+
+// PlacementResult compares aggregate capacity with what actually fits on
+// individual nodes after first-fit-decreasing placement
+type PlacementResult struct {
+	Profile             PodResources   `json:"profile"`
+	EligibleNodes       int            `json:"eligible_nodes"`
+	ExcludedNodes       int            `json:"excluded_nodes"`
+	AggregatePods       int            `json:"aggregate_pods"`
+	SchedulablePods     int            `json:"schedulable_pods"`
+	SafeSchedulablePods int            `json:"safe_schedulable_pods"`
+	FragmentedPods      int            `json:"fragmented_pods"`
+	Nodes               []NodeHeadroom `json:"nodes"`
+	Explanation         string         `json:"explanation"`
+}
+
+// PackResult is the outcome of placing a set of pods onto nodes
+type PackResult struct {
+	// Assignments maps each input pod index to a node name, or "" if it did not fit
+	Assignments []string `json:"assignments"`
+	Placed      int      `json:"placed"`
+	Unplaced    int      `json:"unplaced"`
+}
+
+// CalculateNodePlacement simulates placing pods of the given profile onto
+// nodes. Each node's free capacity is allocatable minus the requests of the
+// pods scheduled on it; nodes the profile cannot land on (cordoned, not
+// ready, untolerated taints, role or selector mismatch) are excluded.
+func (c *Calculator) CalculateNodePlacement(nodes []*scheduling.NodeInfo, resources PodResources, opts PlacementOptions) *PlacementResult {
+	result := &PlacementResult{
+		Profile: resources,
+		Nodes:   make([]NodeHeadroom, 0, len(nodes)),
+	}
+	probe := profilePod(opts)
+	sim := scheduling.NewSimulator(nodes)
+
+	var totalCPU, totalMemory int64
+	totalSlots := 0
+	for _, info := range nodes {
+		headroom := NodeHeadroom{
+			Node:              info.Node.Name,
+			Roles:             nodeRoles(info.Node),
+			FreeCPUMillicores: nonNegative(info.Free().MilliCPU),
+			FreeMemoryBytes:   nonNegative(info.Free().Memory),
+			FreePodSlots:      int(nonNegative(info.AllowedPods - int64(len(info.Pods)))),
+		}
+		if reason := excludedReason(sim, probe, info, headroom.Roles, opts.NodeRoles); reason != "" {
+			headroom.ExcludedReason = reason
+			result.ExcludedNodes++
+			result.Nodes = append(result.Nodes, headroom)
+			continue
+		}
+		headroom.Eligible = true
+		result.EligibleNodes++
+		totalCPU += headroom.FreeCPUMillicores
+		totalMemory += headroom.FreeMemoryBytes
+		totalSlots += headroom.FreePodSlots
+		result.Nodes = append(result.Nodes, headroom)
+	}
+
+	result.AggregatePods = c.calculateEstimate(resources, totalCPU, totalMemory, totalSlots, 0).MaxPods
+
+	// With identical pods first-fit-decreasing fills each node in turn until
+	// its limiting resource runs out, so the per-node fit is the placement
+	for i := range result.Nodes {
+		node := &result.Nodes[i]
+		if !node.Eligible {
+			continue
+		}
+		estimate := c.calculateEstimate(resources, node.FreeCPUMillicores, node.FreeMemoryBytes, node.FreePodSlots, 0)
+		node.FittingPods = estimate.MaxPods
+		node.LimitingFactor = estimate.LimitingFactor
+		result.SchedulablePods += estimate.MaxPods
+	}
+
+	result.SafeSchedulablePods = int(float64(result.SchedulablePods) * (1 - c.safetyMargin))
+	result.FragmentedPods = result.AggregatePods - result.SchedulablePods
+	if result.FragmentedPods < 0 {
+		result.FragmentedPods = 0
+	}
+	sort.SliceStable(result.Nodes, func(i, j int) bool {
+		if result.Nodes[i].Eligible != result.Nodes[j].Eligible {
+			return result.Nodes[i].Eligible
+		}
+		return result.Nodes[i].FittingPods > result.Nodes[j].FittingPods
+	})
+	result.Explanation = placementExplanation(result)
+	return result
+}
+
+// PackPods places pods onto nodes with first-fit-decreasing: pods are taken
+// largest first and each goes to the first eligible node with room. Nodes are
+// tried in the given order.
+func (c *Calculator) PackPods(nodes []*scheduling.NodeInfo, pods []PodResources, opts PlacementOptions) *PackResult {
+	bins := make([]*bin, 0, len(nodes))
+	sim := scheduling.NewSimulator(nodes)
+	probe := profilePod(opts)
+	for _, info := range nodes {
+		if excludedReason(sim, probe, info, nodeRoles(info.Node), opts.NodeRoles) != "" {
+			continue
+		}
+		bins = append(bins, &bin{
+			name:   info.Node.Name,
+			cpu:    info.Free().MilliCPU,
+			memory: info.Free().Memory,
+			slots:  int(info.AllowedPods - int64(len(info.Pods))),
+		})
+	}
+
+	order := make([]int, len(pods))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return podSize(pods[order[a]]) > podSize(pods[order[b]])
+	})
+
+	result := &PackResult{Assignments: make([]string, len(pods))}
+	for _, idx := range order {
+		pod := pods[idx]
+		for _, b := range bins {
+			if b.fits(pod) {
+				b.place(pod)
+				result.Assignments[idx] = b.name
+				result.Placed++
+				break
+			}
+		}
+	}
+	result.Unplaced = len(pods) - result.Placed
+	return result
+}
+
+// bin is a node's remaining capacity during placement
+type bin struct {
+	name   string
+	cpu    int64
+	memory int64
+	slots  int
+}
+
+func (b *bin) fits(pod PodResources) bool {
+	return b.slots > 0 && b.cpu >= pod.CPUMillicores && b.memory >= pod.MemoryMB*1024*1024
+}
+
+func (b *bin) place(pod PodResources) {
+	b.slots--
+	b.cpu -= pod.CPUMillicores
+	b.memory -= pod.MemoryMB * 1024 * 1024
+}
+
+// podSize orders pods for first-fit-decreasing by their dominant request,
+// normalizing 1 core against 1Gi of memory
+func podSize(pod PodResources) float64 {
+	cpu := float64(pod.CPUMillicores) / 1000
+	memory := float64(pod.MemoryMB) / 1024
+	if cpu > memory {
+		return cpu
+	}
+	return memory
+}
+
+// profilePod builds a pod carrying the placement constraints; requests are
+// left empty because resources are accounted for by the placement itself
+func profilePod(opts PlacementOptions) *corev1.Pod {
+	return &corev1.Pod{
+		Spec: corev1.PodSpec{
+			NodeSelector: opts.NodeSelector,
+			Tolerations:  opts.Tolerations,
+		},
+	}
+}
+
+// excludedReason explains why a node cannot host the profile regardless of
+// free resources, or returns "" when it can
+func excludedReason(sim *scheduling.Simulator, probe *corev1.Pod, info *scheduling.NodeInfo, roles, wantRoles []string) string {
+	if len(wantRoles) > 0 && !hasAnyRole(roles, wantRoles) {
+		return fmt.Sprintf("node roles %s do not include %s", strings.Join(roles, ","), strings.Join(wantRoles, " or "))
+	}
+	var reasons []string
+	for _, r := range sim.CheckNode(probe, info) {
+		switch r.Predicate {
+		case scheduling.PredicateCPU, scheduling.PredicateMemory, scheduling.PredicatePodCount:
+			// Resource shortfalls show up as zero fitting pods, not exclusion
+			continue
+		}
+		reasons = append(reasons, r.Reason)
+	}
+	return strings.Join(reasons, "; ")
+}
+
+// nodeRoles returns the node-role.kubernetes.io roles of a node; nodes
+// without a role label are workers
+func nodeRoles(node *corev1.Node) []string {
+	var roles []string
+	for key := range node.Labels {
+		if role, ok := strings.CutPrefix(key, nodeRoleLabelPrefix); ok && role != "" {
+			if role == "master" {
+				role = "control-plane"
+			}
+			if !containsRole(roles, role) {
+				roles = append(roles, role)
+			}
+		}
+	}
+	if len(roles) == 0 {
+		roles = append(roles, "worker")
+	}
+	sort.Strings(roles)
+	return roles
+}
+
+func hasAnyRole(roles, want []string) bool {
+	for _, w := range want {
+		if w == "master" {
+			w = "control-plane"
+		}
+		if containsRole(roles, w) {
+			return true
+		}
+	}
+	return false
+}
+
+func containsRole(roles []string, role string) bool {
+	for _, r := range roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+func nonNegative(v int64) int64 {
+	if v < 0 {
+		return 0
+	}
+	return v
+}
+
+// placementExplanation summarizes aggregate versus schedulable capacity
+func placementExplanation(result *PlacementResult) string {
+	if result.EligibleNodes == 0 {
+		return "No eligible nodes: every node is excluded by readiness, cordon, taints, roles or the node selector."
+	}
+	if result.FragmentedPods == 0 {
+		return fmt.Sprintf("%d pods fit across %d eligible nodes; free capacity is not fragmented.", result.SchedulablePods, result.EligibleNodes)
+	}
+	return fmt.Sprintf("Aggregate free capacity suggests %d pods, but only %d fit on individual nodes; %d pods' worth of capacity is fragmented across nodes.",
+		result.AggregatePods, result.SchedulablePods, result.FragmentedPods)
+}
@@ -0,0 +1,149 @@
+package capacity
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/KubeHeal/openshift-cluster-health-mcp/pkg/scheduling"
+)
+
+func testNode(name, cpu, memory string, labels map[string]string) corev1.Node {
+	return corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels},
+		Status: corev1.NodeStatus{
+			Allocatable: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse(cpu),
+				corev1.ResourceMemory: resource.MustParse(memory),
+				corev1.ResourcePods:   resource.MustParse("110"),
+			},
+			Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: corev1.ConditionTrue}},
+		},
+	}
+}
+
+func scheduledPod(name, node, cpu string) corev1.Pod {
+	return corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "app"},
+		Spec: corev1.PodSpec{
+			NodeName: node,
+			Containers: []corev1.Container{{
+				Name: "app",
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse(cpu)},
+				},
+			}},
+		},
+		Status: corev1.PodStatus{Phase: corev1.PodRunning},
+	}
+}
+
+func TestCalculateNodePlacementFragmentation(t *testing.T) {
+	// 10 nodes with 90m free each: 900m in aggregate, but no single 500m pod fits
+	var nodes []corev1.Node
+	var pods []corev1.Pod
+	for i := 0; i < 10; i++ {
+		name := string(rune('a' + i))
+		nodes = append(nodes, testNode(name, "1", "8Gi", nil))
+		pods = append(pods, scheduledPod("busy-"+name, name, "910m"))
+	}
+
+	calc := NewCalculator(0.15)
+	result := calc.CalculateNodePlacement(scheduling.NewNodeInfos(nodes, pods), PodResources{CPUMillicores: 500, MemoryMB: 64}, PlacementOptions{})
+
+	if result.AggregatePods != 1 {
+		t.Errorf("expected aggregate capacity of 1 pod, got %d", result.AggregatePods)
+	}
+	if result.SchedulablePods != 0 {
+		t.Errorf("expected no schedulable pods, got %d", result.SchedulablePods)
+	}
+	if result.FragmentedPods != 1 {
+		t.Errorf("expected 1 fragmented pod, got %d", result.FragmentedPods)
+	}
+	if result.EligibleNodes != 10 || len(result.Nodes) != 10 {
+		t.Errorf("expected 10 eligible nodes, got %d", result.EligibleNodes)
+	}
+	if result.Nodes[0].FreeCPUMillicores != 90 {
+		t.Errorf("expected 90m free per node, got %d", result.Nodes[0].FreeCPUMillicores)
+	}
+}
+
+func TestCalculateNodePlacementEligibility(t *testing.T) {
+	master := testNode("master-0", "4", "16Gi", map[string]string{"node-role.kubernetes.io/master": ""})
+	master.Spec.Taints = []corev1.Taint{{Key: "node-role.kubernetes.io/master", Effect: corev1.TaintEffectNoSchedule}}
+	cordoned := testNode("worker-cordoned", "4", "16Gi", map[string]string{"node-role.kubernetes.io/worker": ""})
+	cordoned.Spec.Unschedulable = true
+	infra := testNode("infra-0", "4", "16Gi", map[string]string{"node-role.kubernetes.io/infra": "", "zone": "a"})
+	worker := testNode("worker-0", "2", "16Gi", map[string]string{"node-role.kubernetes.io/worker": "", "zone": "b"})
+	infos := scheduling.NewNodeInfos([]corev1.Node{master, cordoned, infra, worker}, nil)
+	profile := PodResources{CPUMillicores: 1000, MemoryMB: 1024}
+	calc := NewCalculator(0)
+
+	result := calc.CalculateNodePlacement(infos, profile, PlacementOptions{})
+	if result.EligibleNodes != 2 || result.ExcludedNodes != 2 {
+		t.Fatalf("expected 2 eligible and 2 excluded nodes, got %d and %d", result.EligibleNodes, result.ExcludedNodes)
+	}
+	if result.SchedulablePods != 6 {
+		t.Errorf("expected 6 schedulable pods (4 on infra, 2 on worker), got %d", result.SchedulablePods)
+	}
+	if result.Nodes[0].Node != "infra-0" || result.Nodes[0].FittingPods != 4 {
+		t.Errorf("expected infra-0 first with 4 pods, got %+v", result.Nodes[0])
+	}
+
+	result = calc.CalculateNodePlacement(infos, profile, PlacementOptions{NodeRoles: []string{"worker"}})
+	if result.SchedulablePods != 2 {
+		t.Errorf("expected 2 pods on worker nodes, got %d", result.SchedulablePods)
+	}
+
+	result = calc.CalculateNodePlacement(infos, profile, PlacementOptions{NodeSelector: map[string]string{"zone": "a"}})
+	if result.SchedulablePods != 4 {
+		t.Errorf("expected 4 pods in zone a, got %d", result.SchedulablePods)
+	}
+
+	tolerations := []corev1.Toleration{{Key: "node-role.kubernetes.io/master", Operator: corev1.TolerationOpExists}}
+	result = calc.CalculateNodePlacement(infos, profile, PlacementOptions{Tolerations: tolerations})
+	if result.SchedulablePods != 10 {
+		t.Errorf("expected tolerated master to add 4 pods, got %d", result.SchedulablePods)
+	}
+}
+
+func TestPackPods(t *testing.T) {
+	nodes := []corev1.Node{testNode("a", "1", "8Gi", nil), testNode("b", "1", "8Gi", nil)}
+	calc := NewCalculator(0)
+	pods := []PodResources{
+		{CPUMillicores: 300, MemoryMB: 64},
+		{CPUMillicores: 700, MemoryMB: 64},
+		{CPUMillicores: 600, MemoryMB: 64},
+		{CPUMillicores: 400, MemoryMB: 64},
+		{CPUMillicores: 500, MemoryMB: 64},
+	}
+
+	result := calc.PackPods(scheduling.NewNodeInfos(nodes, nil), pods, PlacementOptions{})
+	// Decreasing order: 700->a, 600->b, 500 unplaced, 400->b, 300->a
+	if result.Placed != 4 || result.Unplaced != 1 {
+		t.Fatalf("expected 4 placed and 1 unplaced, got %d and %d", result.Placed, result.Unplaced)
+	}
+	want := []string{"a", "a", "b", "b", ""}
+	for i, node := range want {
+		if result.Assignments[i] != node {
+			t.Errorf("pod %d: expected node %q, got %q", i, node, result.Assignments[i])
+		}
+	}
+}
+
+func TestNodeRoles(t *testing.T) {
+	node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{
+		"node-role.kubernetes.io/master":        "",
+		"node-role.kubernetes.io/control-plane": "",
+		"node-role.kubernetes.io/worker":        "",
+	}}}
+	roles := nodeRoles(node)
+	if len(roles) != 2 || roles[0] != "control-plane" || roles[1] != "worker" {
+		t.Errorf("expected [control-plane worker], got %v", roles)
+	}
+	if roles := nodeRoles(&corev1.Node{}); len(roles) != 1 || roles[0] != "worker" {
+		t.Errorf("expected unlabeled node to default to worker, got %v", roles)
+	}
+}
@@ -0,0 +1,81 @@
+package capacity
+
+import (
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/KubeHeal/openshift-cluster-health-mcp/pkg/scheduling"
+)
+
+// PodResourceTotals is the effective resource footprint of a pod
+type PodResourceTotals struct {
+	Requests scheduling.Resources `json:"requests"`
+	Limits   scheduling.Resources `json:"limits"`
+	Overhead scheduling.Resources `json:"overhead,omitempty"`
+	// Containers counts the app and sidecar containers that run together
+	Containers int `json:"containers"`
+}
+
+// PodSpecResources computes the effective requests and limits of a pod spec
+// the way Kubernetes does: app and restartable sidecar init containers are
+// summed, ordinary init containers count as their maximum, and the larger of
+// the two is used; spec.overhead is added on top. Requests fall back to
+// limits as the API server defaults them. A limit is 0 (unbounded) unless
+// every container sets it; overhead is added to a limit only when one is set.
+func PodSpecResources(spec *corev1.PodSpec) PodResourceTotals {
+	totals := PodResourceTotals{
+		Requests: scheduling.SumContainers(spec, scheduling.ContainerRequests),
+		Limits:   scheduling.SumContainers(spec, containerLimits),
+		Overhead: scheduling.OverheadOf(spec),
+	}
+	if !allContainersLimit(spec, corev1.ResourceCPU) {
+		totals.Limits.MilliCPU = 0
+	}
+	if !allContainersLimit(spec, corev1.ResourceMemory) {
+		totals.Limits.Memory = 0
+	}
+	totals.Requests = totals.Requests.Add(totals.Overhead)
+	if totals.Limits.MilliCPU > 0 {
+		totals.Limits.MilliCPU += totals.Overhead.MilliCPU
+	}
+	if totals.Limits.Memory > 0 {
+		totals.Limits.Memory += totals.Overhead.Memory
+	}
+
+	totals.Containers = len(spec.Containers)
+	for i := range spec.InitContainers {
+		if scheduling.IsSidecar(&spec.InitContainers[i]) {
+			totals.Containers++
+		}
+	}
+	return totals
+}
+
+// PodResourcesOf computes the effective requests and limits of a pod
+func PodResourcesOf(pod *corev1.Pod) PodResourceTotals {
+	return PodSpecResources(&pod.Spec)
+}
+
+// containerLimits returns the limits set on a container
+func containerLimits(c *corev1.Container) scheduling.Resources {
+	var r scheduling.Resources
+	if q, ok := c.Resources.Limits[corev1.ResourceCPU]; ok {
+		r.MilliCPU = q.MilliValue()
+	}
+	if q, ok := c.Resources.Limits[corev1.ResourceMemory]; ok {
+		r.Memory = q.Value()
+	}
+	return r
+}
+
+// allContainersLimit reports whether every app and init container sets a
+// limit on the resource; a single unlimited container leaves the pod unbounded
+func allContainersLimit(spec *corev1.PodSpec, name corev1.ResourceName) bool {
+	for _, containers := range [][]corev1.Container{spec.Containers, spec.InitContainers} {
+		for _, c := range containers {
+			if _, ok := c.Resources.Limits[name]; !ok {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,110 @@
+package capacity
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/KubeHeal/openshift-cluster-health-mcp/pkg/scheduling"
+)
+
+func sizedContainer(name, cpuReq, memReq, cpuLim, memLim string) corev1.Container {
+	c := corev1.Container{Name: name, Resources: corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{},
+		Limits:   corev1.ResourceList{},
+	}}
+	set := func(list corev1.ResourceList, name corev1.ResourceName, v string) {
+		if v != "" {
+			list[name] = resource.MustParse(v)
+		}
+	}
+	set(c.Resources.Requests, corev1.ResourceCPU, cpuReq)
+	set(c.Resources.Requests, corev1.ResourceMemory, memReq)
+	set(c.Resources.Limits, corev1.ResourceCPU, cpuLim)
+	set(c.Resources.Limits, corev1.ResourceMemory, memLim)
+	return c
+}
+
+func TestPodSpecResources(t *testing.T) {
+	always := corev1.ContainerRestartPolicyAlways
+	sidecar := sizedContainer("istio-proxy", "100m", "128Mi", "", "1Gi")
+	sidecar.RestartPolicy = &always
+
+	tests := []struct {
+		name       string
+		spec       corev1.PodSpec
+		requests   scheduling.Resources
+		limits     scheduling.Resources
+		containers int
+	}{
+		{
+			name: "app containers and sidecars are summed",
+			spec: corev1.PodSpec{Containers: []corev1.Container{
+				sizedContainer("app", "500m", "512Mi", "1", "1Gi"),
+				sizedContainer("fluent-bit", "50m", "64Mi", "", ""),
+			}},
+			// fluent-bit sets no limits, so the pod is unbounded
+			requests:   scheduling.Resources{MilliCPU: 550, Memory: 576 << 20},
+			containers: 2,
+		},
+		{
+			name: "limits are summed when every container sets them",
+			spec: corev1.PodSpec{Containers: []corev1.Container{
+				sizedContainer("app", "500m", "512Mi", "1", "1Gi"),
+				sizedContainer("fluent-bit", "50m", "64Mi", "", "128Mi"),
+			}},
+			requests:   scheduling.Resources{MilliCPU: 550, Memory: 576 << 20},
+			limits:     scheduling.Resources{Memory: 1152 << 20},
+			containers: 2,
+		},
+		{
+			name: "largest init container wins when it exceeds the app sum",
+			spec: corev1.PodSpec{
+				InitContainers: []corev1.Container{
+					sizedContainer("migrate", "2", "256Mi", "", ""),
+					sizedContainer("warmup", "100m", "1Gi", "", ""),
+				},
+				Containers: []corev1.Container{sizedContainer("app", "500m", "512Mi", "", "")},
+			},
+			requests:   scheduling.Resources{MilliCPU: 2000, Memory: 1 << 30},
+			containers: 1,
+		},
+		{
+			name: "restartable init containers run with the app",
+			spec: corev1.PodSpec{
+				InitContainers: []corev1.Container{sidecar},
+				Containers:     []corev1.Container{sizedContainer("app", "500m", "512Mi", "", "512Mi")},
+			},
+			requests:   scheduling.Resources{MilliCPU: 600, Memory: 640 << 20},
+			limits:     scheduling.Resources{Memory: 1536 << 20},
+			containers: 2,
+		},
+		{
+			name: "overhead is added to requests and to set limits",
+			spec: corev1.PodSpec{
+				Containers: []corev1.Container{sizedContainer("app", "", "", "", "256Mi")},
+				Overhead:   corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("250m"), corev1.ResourceMemory: resource.MustParse("120Mi")},
+			},
+			// Memory request defaults to the limit
+			requests:   scheduling.Resources{MilliCPU: 250, Memory: 376 << 20},
+			limits:     scheduling.Resources{Memory: 376 << 20},
+			containers: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := PodSpecResources(&tt.spec)
+			if got.Requests != tt.requests {
+				t.Errorf("requests = %+v, want %+v", got.Requests, tt.requests)
+			}
+			if got.Limits != tt.limits {
+				t.Errorf("limits = %+v, want %+v", got.Limits, tt.limits)
+			}
+			if got.Containers != tt.containers {
+				t.Errorf("containers = %d, want %d", got.Containers, tt.containers)
+			}
+		})
+	}
+}
@@ -0,0 +1,175 @@
+package capacity
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	"sigs.k8s.io/yaml"
+)
+
+// Profile sources
+const (
+	ProfileSourceBuiltin    = "builtin"
+	ProfileSourceConfig     = "config"
+	ProfileSourceDeployment = "deployment"
+	ProfileSourceCustom     = "custom"
+)
+
+// ProfileDefinition is an operator-defined pod profile, e.g. "java-service"
+// or "kafka-broker". A profile either sets cpu/memory directly or names a
+// Deployment ("namespace/name") whose pod template supplies them.
+type ProfileDefinition struct {
+	Description    string `json:"description,omitempty"`
+	CPU            string `json:"cpu,omitempty"`
+	Memory         string `json:"memory,omitempty"`
+	FromDeployment string `json:"from_deployment,omitempty"`
+	// ReplicasPerUnit groups pods into deployable units (e.g. 3 brokers per
+	// Kafka cluster); capacity is also reported in units. Default: 1
+	ReplicasPerUnit *int              `json:"replicas_per_unit,omitempty"`
+	NodeSelector    map[string]string `json:"node_selector,omitempty"`
+}
+
+// profileFile is the layout of a profile file or ConfigMap key
+type profileFile struct {
+	Profiles map[string]ProfileDefinition `json:"profiles"`
+}
+
+// ResolvedProfile is a pod profile with concrete resources
+type ResolvedProfile struct {
+	Name            string            `json:"name"`
+	Source          string            `json:"source"`
+	Description     string            `json:"description,omitempty"`
+	Deployment      string            `json:"deployment,omitempty"`
+	Resources       PodResources      `json:"resources"`
+	ReplicasPerUnit int               `json:"replicas_per_unit"`
+	NodeSelector    map[string]string `json:"node_selector,omitempty"`
+}
+
+// ParseProfileDefinitions parses YAML or JSON of the form
+//
+//	profiles:
+//	  java-service: {cpu: 500m, memory: 1Gi, replicas_per_unit: 2}
+//	  kafka-broker: {from_deployment: kafka/broker, replicas_per_unit: 3}
+//
+// and validates every definition
+func ParseProfileDefinitions(data []byte) (map[string]ProfileDefinition, error) {
+	var file profileFile
+	if err := yaml.UnmarshalStrict(data, &file); err != nil {
+		return nil, fmt.Errorf("invalid pod profiles: %w", err)
+	}
+	for name, def := range file.Profiles {
+		if err := validateProfile(name, def); err != nil {
+			return nil, err
+		}
+	}
+	return file.Profiles, nil
+}
+
+func validateProfile(name string, def ProfileDefinition) error {
+	if name == "" || name == string(PodProfileCustom) {
+		return fmt.Errorf("invalid pod profile name %q", name)
+	}
+	if _, builtin := DefaultPodProfiles[PodProfile(name)]; builtin {
+		return fmt.Errorf("pod profile %s: name is reserved for a builtin profile", name)
+	}
+	if def.ReplicasPerUnit != nil && *def.ReplicasPerUnit < 1 {
+		return fmt.Errorf("pod profile %s: replicas_per_unit must be at least 1", name)
+	}
+	if def.FromDeployment != "" {
+		if def.CPU != "" || def.Memory != "" {
+			return fmt.Errorf("pod profile %s: set either from_deployment or cpu/memory, not both", name)
+		}
+		if _, _, err := SplitNamespacedName(def.FromDeployment); err != nil {
+			return fmt.Errorf("pod profile %s: %w", name, err)
+		}
+		return nil
+	}
+	if def.CPU == "" && def.Memory == "" {
+		return fmt.Errorf("pod profile %s: cpu or memory is required", name)
+	}
+	for field, value := range map[string]string{"cpu": def.CPU, "memory": def.Memory} {
+		if value == "" {
+			continue
+		}
+		if _, err := resource.ParseQuantity(value); err != nil {
+			return fmt.Errorf("pod profile %s: invalid %s %q: %w", name, field, value, err)
+		}
+	}
+	return nil
+}
+
+// Resolve converts a definition with cpu/memory into a profile. Definitions
+// using from_deployment are resolved by the caller from the Deployment.
+func (d ProfileDefinition) Resolve(name string) ResolvedProfile {
+	p := ResolvedProfile{
+		Name:            name,
+		Source:          ProfileSourceConfig,
+		Description:     d.Description,
+		ReplicasPerUnit: d.Units(),
+		NodeSelector:    d.NodeSelector,
+	}
+	if q, err := resource.ParseQuantity(d.CPU); err == nil {
+		p.Resources.CPUMillicores = q.MilliValue()
+	}
+	if q, err := resource.ParseQuantity(d.Memory); err == nil {
+		p.Resources.MemoryMB = q.Value() / (1024 * 1024)
+	}
+	return p
+}
+
+// Units returns the replicas per unit, defaulting to 1 when unset
+func (d ProfileDefinition) Units() int {
+	if d.ReplicasPerUnit == nil {
+		return 1
+	}
+	return *d.ReplicasPerUnit
+}
+
+// BuiltinProfile returns one of the small/medium/large profiles
+func BuiltinProfile(name string) (ResolvedProfile, bool) {
+	resources, ok := DefaultPodProfiles[PodProfile(name)]
+	if !ok {
+		return ResolvedProfile{}, false
+	}
+	return ResolvedProfile{Name: name, Source: ProfileSourceBuiltin, Resources: resources, ReplicasPerUnit: 1}, true
+}
+
+// DeploymentProfile builds a profile from a Deployment's effective pod
+// requests and node selector
+func DeploymentProfile(name, deployment string, requests PodResources, nodeSelector map[string]string, replicasPerUnit int) ResolvedProfile {
+	if replicasPerUnit < 1 {
+		replicasPerUnit = 1
+	}
+	return ResolvedProfile{
+		Name:            name,
+		Source:          ProfileSourceDeployment,
+		Deployment:      deployment,
+		Resources:       requests,
+		ReplicasPerUnit: replicasPerUnit,
+		NodeSelector:    nodeSelector,
+	}
+}
+
+// ProfileNames lists the builtin profiles followed by the configured ones
+func ProfileNames(defs map[string]ProfileDefinition) []string {
+	names := []string{string(PodProfileSmall), string(PodProfileMedium), string(PodProfileLarge)}
+	configured := make([]string, 0, len(defs))
+	for name := range defs {
+		if _, builtin := DefaultPodProfiles[PodProfile(name)]; !builtin {
+			configured = append(configured, name)
+		}
+	}
+	sort.Strings(configured)
+	return append(names, configured...)
+}
+
+// SplitNamespacedName splits "namespace/name"
+func SplitNamespacedName(ref string) (string, string, error) {
+	namespace, name, ok := strings.Cut(ref, "/")
+	if !ok || namespace == "" || name == "" || strings.Contains(name, "/") {
+		return "", "", fmt.Errorf("invalid reference %q (expected namespace/name)", ref)
+	}
+	return namespace, name, nil
+}
@@ -0,0 +1,74 @@
+package capacity
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseProfileDefinitions(t *testing.T) {
+	data := []byte(`
+profiles:
+  java-service:
+    cpu: 500m
+    memory: 1Gi
+    replicas_per_unit: 2
+    node_selector:
+      node-role.kubernetes.io/worker: ""
+  kafka-broker:
+    from_deployment: kafka/broker
+    replicas_per_unit: 3
+`)
+	defs, err := ParseProfileDefinitions(data)
+	if err != nil {
+		t.Fatalf("ParseProfileDefinitions: %v", err)
+	}
+
+	java := defs["java-service"].Resolve("java-service")
+	want := PodResources{CPUMillicores: 500, MemoryMB: 1024}
+	if java.Resources != want || java.ReplicasPerUnit != 2 || java.Source != ProfileSourceConfig {
+		t.Errorf("java-service = %+v", java)
+	}
+	if _, ok := java.NodeSelector["node-role.kubernetes.io/worker"]; !ok {
+		t.Errorf("java-service node selector = %v", java.NodeSelector)
+	}
+	if defs["kafka-broker"].FromDeployment != "kafka/broker" {
+		t.Errorf("kafka-broker = %+v", defs["kafka-broker"])
+	}
+
+	names := ProfileNames(defs)
+	if !reflect.DeepEqual(names, []string{"small", "medium", "large", "java-service", "kafka-broker"}) {
+		t.Errorf("ProfileNames = %v", names)
+	}
+}
+
+func TestParseProfileDefinitionsInvalid(t *testing.T) {
+	tests := map[string]string{
+		"unknown field":        "profiles:\n  a: {cpus: 1}",
+		"bad quantity":         "profiles:\n  a: {cpu: lots}",
+		"no resources":         "profiles:\n  a: {replicas_per_unit: 2}",
+		"both sources":         "profiles:\n  a: {cpu: 1, from_deployment: ns/app}",
+		"bad deployment ref":   "profiles:\n  a: {from_deployment: app}",
+		"reserved custom name": "profiles:\n  custom: {cpu: 1}",
+		"negative replicas":    "profiles:\n  a: {cpu: 1, replicas_per_unit: -1}",
+		"zero replicas":        "profiles:\n  a: {cpu: 1, replicas_per_unit: 0}",
+		"builtin name":         "profiles:\n  medium: {cpu: 1}",
+	}
+	for name, data := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := ParseProfileDefinitions([]byte(data)); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
+
+func TestResolveDefaultsReplicasPerUnit(t *testing.T) {
+	p := ProfileDefinition{Memory: "256Mi"}.Resolve("cache")
+	if p.ReplicasPerUnit != 1 || p.Resources.MemoryMB != 256 || p.Resources.CPUMillicores != 0 {
+		t.Errorf("Resolve = %+v", p)
+	}
+	if _, _, err := SplitNamespacedName("a/b/c"); err == nil || !strings.Contains(err.Error(), "namespace/name") {
+		t.Errorf("SplitNamespacedName(a/b/c) error = %v", err)
+	}
+}
@@ -0,0 +1,392 @@
+package capacity
+
+import (
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/KubeHeal/openshift-cluster-health-mcp/pkg/scheduling"
+)
+
+// Constraint sources for namespace capacity numbers
+const (
+	ConstraintResourceQuota = "resource_quota"
+	ConstraintClusterFree   = "cluster_free_capacity"
+)
+
+// Quota resources tracked for namespace capacity
+const (
+	QuotaResourceCPU    = "cpu"
+	QuotaResourceMemory = "memory"
+	QuotaResourcePods   = "pods"
+)
+
+// QuotaResources are the resources namespace capacity is computed for
+var QuotaResources = []string{QuotaResourceCPU, QuotaResourceMemory, QuotaResourcePods}
+
+// quotaKeys lists the ResourceQuota keys that bound each tracked resource.
+// Plain "cpu" and "memory" are aliases of requests.cpu and requests.memory.
+var quotaKeys = map[string][]corev1.ResourceName{
+	QuotaResourceCPU:    {corev1.ResourceRequestsCPU, corev1.ResourceCPU, corev1.ResourceLimitsCPU},
+	QuotaResourceMemory: {corev1.ResourceRequestsMemory, corev1.ResourceMemory, corev1.ResourceLimitsMemory},
+	QuotaResourcePods:   {corev1.ResourcePods, "count/pods"},
+}
+
+// QuotaTarget describes the pods being sized; it decides which scoped
+// ResourceQuotas apply to them
+type QuotaTarget struct {
+	BestEffort    bool   `json:"best_effort"`
+	Terminating   bool   `json:"terminating"`
+	PriorityClass string `json:"priority_class,omitempty"`
+}
+
+// LimitRangeDefaults are the container defaults a namespace's LimitRanges
+// inject into containers that do not set requests or limits
+type LimitRangeDefaults struct {
+	CPURequestMillicores int64    `json:"cpu_request_millicores,omitempty"`
+	MemoryRequestBytes   int64    `json:"memory_request_bytes,omitempty"`
+	CPULimitMillicores   int64    `json:"cpu_limit_millicores,omitempty"`
+	MemoryLimitBytes     int64    `json:"memory_limit_bytes,omitempty"`
+	Sources              []string `json:"sources,omitempty"`
+}
+
+// QuotaConstraint is the constraint that produced one namespace capacity
+// number. Values are in request units (millicores, bytes, pods); limits.*
+// quotas are converted with the LimitRange limit-to-request ratio.
+type QuotaConstraint struct {
+	Resource  string   `json:"resource"`
+	Source    string   `json:"source"`
+	Name      string   `json:"name"`
+	Key       string   `json:"key,omitempty"`
+	Scopes    []string `json:"scopes,omitempty"`
+	Hard      int64    `json:"hard"`
+	Used      int64    `json:"used"`
+	Remaining int64    `json:"remaining"`
+	Detail    string   `json:"detail"`
+}
+
+// NamespaceUsage is what a namespace's non-terminal pods request
+type NamespaceUsage struct {
+	CPUMillicores int64 `json:"cpu_millicores"`
+	MemoryBytes   int64 `json:"memory_bytes"`
+	Pods          int   `json:"pods"`
+}
+
+// ClusterFreeCapacity is the unrequested capacity of nodes that accept
+// ordinary workloads
+type ClusterFreeCapacity struct {
+	CPUMillicores int64 `json:"cpu_millicores"`
+	MemoryBytes   int64 `json:"memory_bytes"`
+	PodSlots      int64 `json:"pod_slots"`
+	Nodes         int   `json:"nodes"`
+}
+
+// LimitRangeDefaultsFrom collects container defaults from LimitRanges. The
+// first LimitRange to set a value wins, as in the LimitRanger admission
+// plugin; an unset defaultRequest falls back to the default limit.
+func LimitRangeDefaultsFrom(ranges []corev1.LimitRange) LimitRangeDefaults {
+	var d LimitRangeDefaults
+	for _, lr := range ranges {
+		contributed := false
+		for _, item := range lr.Spec.Limits {
+			if item.Type != corev1.LimitTypeContainer {
+				continue
+			}
+			set := func(target *int64, list corev1.ResourceList, name corev1.ResourceName, milli bool) {
+				if *target != 0 {
+					return
+				}
+				if q, ok := list[name]; ok {
+					*target = quantityValue(q, milli)
+					contributed = true
+				}
+			}
+			set(&d.CPURequestMillicores, item.DefaultRequest, corev1.ResourceCPU, true)
+			set(&d.MemoryRequestBytes, item.DefaultRequest, corev1.ResourceMemory, false)
+			set(&d.CPULimitMillicores, item.Default, corev1.ResourceCPU, true)
+			set(&d.MemoryLimitBytes, item.Default, corev1.ResourceMemory, false)
+			set(&d.CPURequestMillicores, item.Default, corev1.ResourceCPU, true)
+			set(&d.MemoryRequestBytes, item.Default, corev1.ResourceMemory, false)
+		}
+		if contributed {
+			d.Sources = append(d.Sources, lr.Name)
+		}
+	}
+	return d
+}
+
+// PodRequestsWithDefaults returns the scheduling requests of a pod after
+// LimitRange defaults are applied to containers that set neither a request
+// nor a limit (pods created before the LimitRange existed)
+func PodRequestsWithDefaults(pod *corev1.Pod, d LimitRangeDefaults) scheduling.Resources {
+	if d.CPURequestMillicores == 0 && d.MemoryRequestBytes == 0 {
+		return PodResourcesOf(pod).Requests
+	}
+	defaulted := pod.DeepCopy()
+	for i := range defaulted.Spec.Containers {
+		applyContainerDefaults(&defaulted.Spec.Containers[i], d)
+	}
+	for i := range defaulted.Spec.InitContainers {
+		applyContainerDefaults(&defaulted.Spec.InitContainers[i], d)
+	}
+	return PodResourcesOf(defaulted).Requests
+}
+
+func applyContainerDefaults(c *corev1.Container, d LimitRangeDefaults) {
+	fill := func(name corev1.ResourceName, q *resource.Quantity) {
+		if q == nil {
+			return
+		}
+		if _, ok := c.Resources.Requests[name]; ok {
+			return
+		}
+		if _, ok := c.Resources.Limits[name]; ok {
+			return
+		}
+		if c.Resources.Requests == nil {
+			c.Resources.Requests = corev1.ResourceList{}
+		}
+		c.Resources.Requests[name] = *q
+	}
+	if d.CPURequestMillicores > 0 {
+		fill(corev1.ResourceCPU, resource.NewMilliQuantity(d.CPURequestMillicores, resource.DecimalSI))
+	}
+	if d.MemoryRequestBytes > 0 {
+		fill(corev1.ResourceMemory, resource.NewQuantity(d.MemoryRequestBytes, resource.BinarySI))
+	}
+}
+
+// limitRatio is the limit-to-request ratio of defaulted containers, used to
+// express limits.* quota headroom in request units; 1 assumes limits equal
+// requests
+func (d LimitRangeDefaults) limitRatio(res string) float64 {
+	switch {
+	case res == QuotaResourceCPU && d.CPULimitMillicores > 0 && d.CPURequestMillicores > 0:
+		return float64(d.CPULimitMillicores) / float64(d.CPURequestMillicores)
+	case res == QuotaResourceMemory && d.MemoryLimitBytes > 0 && d.MemoryRequestBytes > 0:
+		return float64(d.MemoryLimitBytes) / float64(d.MemoryRequestBytes)
+	}
+	return 1
+}
+
+// QuotaAppliesTo reports whether a ResourceQuota's scopes match the target
+// pods; when they do not, it returns the reason
+func QuotaAppliesTo(quota *corev1.ResourceQuota, target QuotaTarget) (bool, string) {
+	for _, scope := range quota.Spec.Scopes {
+		if ok, reason := scopeMatches(scope, corev1.ScopeSelectorOpExists, nil, target); !ok {
+			return false, reason
+		}
+	}
+	if quota.Spec.ScopeSelector != nil {
+		for _, expr := range quota.Spec.ScopeSelector.MatchExpressions {
+			if ok, reason := scopeMatches(expr.ScopeName, expr.Operator, expr.Values, target); !ok {
+				return false, reason
+			}
+		}
+	}
+	return true, ""
+}
+
+func scopeMatches(scope corev1.ResourceQuotaScope, op corev1.ScopeSelectorOperator, values []string, target QuotaTarget) (bool, string) {
+	switch scope {
+	case corev1.ResourceQuotaScopeBestEffort:
+		return target.BestEffort, "applies only to BestEffort pods (no requests or limits)"
+	case corev1.ResourceQuotaScopeNotBestEffort:
+		return !target.BestEffort, "applies only to pods with requests or limits"
+	case corev1.ResourceQuotaScopeTerminating:
+		return target.Terminating, "applies only to pods with activeDeadlineSeconds"
+	case corev1.ResourceQuotaScopeNotTerminating:
+		return !target.Terminating, "applies only to pods without activeDeadlineSeconds"
+	case corev1.ResourceQuotaScopePriorityClass:
+		return priorityClassMatches(op, values, target.PriorityClass)
+	}
+	return false, fmt.Sprintf("scope %s does not apply to plain pods", scope)
+}
+
+func priorityClassMatches(op corev1.ScopeSelectorOperator, values []string, class string) (bool, string) {
+	listed := false
+	for _, v := range values {
+		if v == class {
+			listed = true
+		}
+	}
+	switch op {
+	case corev1.ScopeSelectorOpIn:
+		return class != "" && listed, fmt.Sprintf("applies only to priority classes %s", strings.Join(values, ","))
+	case corev1.ScopeSelectorOpNotIn:
+		return class == "" || !listed, fmt.Sprintf("does not apply to priority classes %s", strings.Join(values, ","))
+	case corev1.ScopeSelectorOpDoesNotExist:
+		return class == "", "applies only to pods without a priority class"
+	}
+	return class != "", "applies only to pods with a priority class"
+}
+
+// AggregateResourceQuotas returns the tightest constraint per resource
+// across every ResourceQuota that applies to the target pods, plus notes on
+// quotas whose scopes exclude them. Resources no quota bounds are absent.
+func AggregateResourceQuotas(quotas []corev1.ResourceQuota, target QuotaTarget, defaults LimitRangeDefaults) (map[string]QuotaConstraint, []string) {
+	constraints := make(map[string]QuotaConstraint)
+	var notes []string
+	for i := range quotas {
+		quota := &quotas[i]
+		scopes := quotaScopes(quota)
+		if ok, reason := QuotaAppliesTo(quota, target); !ok {
+			notes = append(notes, fmt.Sprintf("ResourceQuota %s ignored: %s", quota.Name, reason))
+			continue
+		}
+		for _, res := range QuotaResources {
+			for _, key := range quotaKeys[res] {
+				hardQ, ok := quota.Status.Hard[key]
+				if !ok {
+					if hardQ, ok = quota.Spec.Hard[key]; !ok {
+						continue
+					}
+				}
+				usedQ := quota.Status.Used[key]
+				c := quotaConstraint(res, key, quota.Name, scopes, hardQ, usedQ, defaults)
+				if current, ok := constraints[res]; !ok || c.Remaining < current.Remaining {
+					constraints[res] = c
+				}
+			}
+		}
+	}
+	return constraints, notes
+}
+
+func quotaConstraint(res string, key corev1.ResourceName, name string, scopes []string, hardQ, usedQ resource.Quantity, defaults LimitRangeDefaults) QuotaConstraint {
+	milli := res == QuotaResourceCPU
+	hard, used := quantityValue(hardQ, milli), quantityValue(usedQ, milli)
+	c := QuotaConstraint{
+		Resource: res,
+		Source:   ConstraintResourceQuota,
+		Name:     name,
+		Key:      string(key),
+		Scopes:   scopes,
+		Hard:     hard,
+		Used:     used,
+	}
+	c.Detail = fmt.Sprintf("ResourceQuota %s %s: %s of %s used", name, key, formatQuotaValue(res, used), formatQuotaValue(res, hard))
+	if strings.HasPrefix(string(key), "limits.") {
+		if ratio := defaults.limitRatio(res); ratio != 1 {
+			c.Hard = int64(float64(hard) / ratio)
+			c.Used = int64(float64(used) / ratio)
+			c.Detail += fmt.Sprintf(" (converted to requests at the LimitRange limit/request ratio %.2f)", ratio)
+		}
+	}
+	if len(scopes) > 0 {
+		c.Detail += fmt.Sprintf(" [scopes %s]", strings.Join(scopes, ","))
+	}
+	c.Remaining = nonNegative(c.Hard - c.Used)
+	return c
+}
+
+// quotaScopes renders a quota's scopes and scope selector for reporting
+func quotaScopes(quota *corev1.ResourceQuota) []string {
+	var scopes []string
+	for _, s := range quota.Spec.Scopes {
+		scopes = append(scopes, string(s))
+	}
+	if quota.Spec.ScopeSelector != nil {
+		for _, expr := range quota.Spec.ScopeSelector.MatchExpressions {
+			scope := fmt.Sprintf("%s %s", expr.ScopeName, expr.Operator)
+			if len(expr.Values) > 0 {
+				scope += " " + strings.Join(expr.Values, ",")
+			}
+			scopes = append(scopes, scope)
+		}
+	}
+	return scopes
+}
+
+// FreeClusterCapacity sums the unrequested capacity of ready, schedulable
+// nodes without untolerated taints that match the placement options
+func FreeClusterCapacity(nodes []*scheduling.NodeInfo, opts PlacementOptions) ClusterFreeCapacity {
+	var free ClusterFreeCapacity
+	probe := profilePod(opts)
+	sim := scheduling.NewSimulator(nodes)
+	for _, info := range nodes {
+		if excludedReason(sim, probe, info, nodeRoles(info.Node), opts.NodeRoles) != "" {
+			continue
+		}
+		free.Nodes++
+		free.CPUMillicores += nonNegative(info.Free().MilliCPU)
+		free.MemoryBytes += nonNegative(info.Free().Memory)
+		free.PodSlots += nonNegative(info.AllowedPods - int64(len(info.Pods)))
+	}
+	return free
+}
+
+// BuildNamespaceQuota combines quota constraints with the namespace's usage.
+// Resources no ResourceQuota bounds fall back to the cluster's free capacity,
+// so their limit is what the namespace already uses plus what is free; free
+// is nil when the cluster could not be inspected.
+func BuildNamespaceQuota(constraints map[string]QuotaConstraint, notes []string, usage NamespaceUsage, free *ClusterFreeCapacity) *NamespaceQuota {
+	quota := &NamespaceQuota{Constraints: make(map[string]QuotaConstraint), Notes: notes}
+	for _, res := range QuotaResources {
+		c, ok := constraints[res]
+		if ok {
+			quota.HasQuota = true
+		} else {
+			c = clusterConstraint(res, usage, free)
+		}
+		quota.Constraints[res] = c
+		switch res {
+		case QuotaResourceCPU:
+			quota.CPULimitMillicores, quota.CPUUsedMillicores = c.Hard, c.Used
+		case QuotaResourceMemory:
+			quota.MemoryLimitBytes, quota.MemoryUsedBytes = c.Hard, c.Used
+		case QuotaResourcePods:
+			quota.PodCountLimit, quota.CurrentPodCount = int(c.Hard), int(c.Used)
+		}
+	}
+	return quota
+}
+
+// clusterConstraint bounds a resource by the cluster's free capacity
+func clusterConstraint(res string, usage NamespaceUsage, free *ClusterFreeCapacity) QuotaConstraint {
+	c := QuotaConstraint{Resource: res, Source: ConstraintClusterFree, Name: "cluster"}
+	switch res {
+	case QuotaResourceCPU:
+		c.Used = usage.CPUMillicores
+	case QuotaResourceMemory:
+		c.Used = usage.MemoryBytes
+	case QuotaResourcePods:
+		c.Used = int64(usage.Pods)
+	}
+	if free == nil {
+		c.Hard = c.Used
+		c.Detail = fmt.Sprintf("no ResourceQuota limits %s and cluster free capacity is unavailable", res)
+		return c
+	}
+	switch res {
+	case QuotaResourceCPU:
+		c.Remaining = free.CPUMillicores
+	case QuotaResourceMemory:
+		c.Remaining = free.MemoryBytes
+	case QuotaResourcePods:
+		c.Remaining = free.PodSlots
+	}
+	c.Hard = c.Used + c.Remaining
+	c.Detail = fmt.Sprintf("no ResourceQuota limits %s; namespace uses %s and %d schedulable nodes have %s free",
+		res, formatQuotaValue(res, c.Used), free.Nodes, formatQuotaValue(res, c.Remaining))
+	return c
+}
+
+func quantityValue(q resource.Quantity, milli bool) int64 {
+	if milli {
+		return q.MilliValue()
+	}
+	return q.Value()
+}
+
+func formatQuotaValue(res string, v int64) string {
+	switch res {
+	case QuotaResourceCPU:
+		return formatCPU(v)
+	case QuotaResourceMemory:
+		return formatMemory(v)
+	}
+	return fmt.Sprintf("%d pods", v)
+}
@@ -0,0 +1,158 @@
+package capacity
+
+import (
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/KubeHeal/openshift-cluster-health-mcp/pkg/scheduling"
+)
+
+func testQuota(name string, hard, used map[corev1.ResourceName]string, scopes ...corev1.ResourceQuotaScope) corev1.ResourceQuota {
+	q := corev1.ResourceQuota{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "app"},
+		Spec:       corev1.ResourceQuotaSpec{Hard: corev1.ResourceList{}, Scopes: scopes},
+		Status:     corev1.ResourceQuotaStatus{Used: corev1.ResourceList{}},
+	}
+	for k, v := range hard {
+		q.Spec.Hard[k] = resource.MustParse(v)
+	}
+	for k, v := range used {
+		q.Status.Used[k] = resource.MustParse(v)
+	}
+	return q
+}
+
+func TestAggregateResourceQuotas(t *testing.T) {
+	quotas := []corev1.ResourceQuota{
+		testQuota("compute", map[corev1.ResourceName]string{
+			corev1.ResourceRequestsCPU: "4", corev1.ResourceRequestsMemory: "8Gi",
+		}, map[corev1.ResourceName]string{
+			corev1.ResourceRequestsCPU: "1", corev1.ResourceRequestsMemory: "7Gi",
+		}),
+		// Tighter on CPU than "compute" and read from requests used, not limits
+		testQuota("team-cap", map[corev1.ResourceName]string{
+			corev1.ResourceCPU: "2", corev1.ResourcePods: "20",
+		}, map[corev1.ResourceName]string{
+			corev1.ResourceCPU: "1500m", corev1.ResourcePods: "12",
+		}, corev1.ResourceQuotaScopeNotBestEffort),
+		// Scoped to BestEffort pods: must not constrain pods with requests
+		testQuota("besteffort", map[corev1.ResourceName]string{corev1.ResourcePods: "1"},
+			map[corev1.ResourceName]string{corev1.ResourcePods: "1"}, corev1.ResourceQuotaScopeBestEffort),
+	}
+
+	constraints, notes := AggregateResourceQuotas(quotas, QuotaTarget{}, LimitRangeDefaults{})
+
+	cpu := constraints[QuotaResourceCPU]
+	if cpu.Name != "team-cap" || cpu.Key != "cpu" || cpu.Remaining != 500 {
+		t.Errorf("cpu constraint = %+v, want team-cap cpu with 500m remaining", cpu)
+	}
+	mem := constraints[QuotaResourceMemory]
+	if mem.Name != "compute" || mem.Key != "requests.memory" || mem.Remaining != 1<<30 {
+		t.Errorf("memory constraint = %+v, want compute requests.memory with 1Gi remaining", mem)
+	}
+	pods := constraints[QuotaResourcePods]
+	if pods.Name != "team-cap" || pods.Remaining != 8 {
+		t.Errorf("pods constraint = %+v, want team-cap with 8 remaining", pods)
+	}
+	if len(notes) != 1 || !strings.Contains(notes[0], "besteffort") {
+		t.Errorf("notes = %v, want the BestEffort quota reported as ignored", notes)
+	}
+
+	// The same quotas bind a BestEffort pod by pod count only
+	constraints, _ = AggregateResourceQuotas(quotas, QuotaTarget{BestEffort: true}, LimitRangeDefaults{})
+	if c := constraints[QuotaResourcePods]; c.Name != "besteffort" || c.Remaining != 0 {
+		t.Errorf("BestEffort pods constraint = %+v, want besteffort with 0 remaining", c)
+	}
+}
+
+func TestAggregateResourceQuotasPriorityClass(t *testing.T) {
+	quota := testQuota("critical", map[corev1.ResourceName]string{corev1.ResourceRequestsCPU: "1"}, nil)
+	quota.Spec.ScopeSelector = &corev1.ScopeSelector{MatchExpressions: []corev1.ScopedResourceSelectorRequirement{{
+		ScopeName: corev1.ResourceQuotaScopePriorityClass,
+		Operator:  corev1.ScopeSelectorOpIn,
+		Values:    []string{"high"},
+	}}}
+
+	tests := []struct {
+		class   string
+		applies bool
+	}{
+		{"high", true},
+		{"low", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		constraints, _ := AggregateResourceQuotas([]corev1.ResourceQuota{quota}, QuotaTarget{PriorityClass: tt.class}, LimitRangeDefaults{})
+		if _, ok := constraints[QuotaResourceCPU]; ok != tt.applies {
+			t.Errorf("priority class %q: quota applied = %v, want %v", tt.class, ok, tt.applies)
+		}
+	}
+}
+
+func TestAggregateResourceQuotasLimitsRatio(t *testing.T) {
+	// limits.cpu 4 with 2 used and containers defaulting to 2x their request:
+	// 1 core of requests fits in the remaining limit headroom
+	quota := testQuota("limits", map[corev1.ResourceName]string{corev1.ResourceLimitsCPU: "4"},
+		map[corev1.ResourceName]string{corev1.ResourceLimitsCPU: "2"})
+	defaults := LimitRangeDefaults{CPURequestMillicores: 250, CPULimitMillicores: 500}
+
+	constraints, _ := AggregateResourceQuotas([]corev1.ResourceQuota{quota}, QuotaTarget{}, defaults)
+	if c := constraints[QuotaResourceCPU]; c.Remaining != 1000 || !strings.Contains(c.Detail, "ratio 2.00") {
+		t.Errorf("cpu constraint = %+v, want 1000m remaining converted at ratio 2", c)
+	}
+}
+
+func TestLimitRangeDefaults(t *testing.T) {
+	ranges := []corev1.LimitRange{{
+		ObjectMeta: metav1.ObjectMeta{Name: "defaults"},
+		Spec: corev1.LimitRangeSpec{Limits: []corev1.LimitRangeItem{{
+			Type:           corev1.LimitTypeContainer,
+			Default:        corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("500m"), corev1.ResourceMemory: resource.MustParse("512Mi")},
+			DefaultRequest: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("100m")},
+		}}},
+	}}
+	d := LimitRangeDefaultsFrom(ranges)
+	// Memory has no defaultRequest, so it defaults to the default limit
+	if d.CPURequestMillicores != 100 || d.MemoryRequestBytes != 512<<20 || d.CPULimitMillicores != 500 {
+		t.Errorf("defaults = %+v", d)
+	}
+
+	pod := scheduledPod("p", "n1", "200m")
+	pod.Spec.Containers = append(pod.Spec.Containers, corev1.Container{Name: "sidecar"})
+	got := PodRequestsWithDefaults(&pod, d)
+	// app keeps its 200m and gets default memory; sidecar gets both defaults
+	want := scheduling.Resources{MilliCPU: 300, Memory: 2 * 512 << 20}
+	if got != want {
+		t.Errorf("PodRequestsWithDefaults = %+v, want %+v", got, want)
+	}
+}
+
+func TestBuildNamespaceQuotaFallsBackToClusterFree(t *testing.T) {
+	nodes := []corev1.Node{testNode("n1", "4", "8Gi", nil), testNode("n2", "4", "8Gi", nil)}
+	nodes[1].Spec.Unschedulable = true
+	pods := []corev1.Pod{scheduledPod("p1", "n1", "1")}
+	free := FreeClusterCapacity(scheduling.NewNodeInfos(nodes, pods), PlacementOptions{})
+	if free.Nodes != 1 || free.CPUMillicores != 3000 || free.PodSlots != 109 {
+		t.Fatalf("free = %+v, want only the schedulable node", free)
+	}
+
+	constraints := map[string]QuotaConstraint{
+		QuotaResourcePods: {Resource: QuotaResourcePods, Source: ConstraintResourceQuota, Name: "pods", Hard: 10, Used: 4, Remaining: 6},
+	}
+	usage := NamespaceUsage{CPUMillicores: 1000, MemoryBytes: 1 << 30, Pods: 4}
+	quota := BuildNamespaceQuota(constraints, nil, usage, &free)
+
+	if !quota.HasQuota || quota.PodCountLimit != 10 || quota.CurrentPodCount != 4 {
+		t.Errorf("pods should come from the quota: %+v", quota)
+	}
+	if quota.CPULimitMillicores != 4000 || quota.CPUUsedMillicores != 1000 {
+		t.Errorf("cpu limit = %d used = %d, want namespace usage plus 3 free cores", quota.CPULimitMillicores, quota.CPUUsedMillicores)
+	}
+	if c := quota.Constraints[QuotaResourceMemory]; c.Source != ConstraintClusterFree || c.Remaining != 8<<30 {
+		t.Errorf("memory constraint = %+v, want cluster free capacity", c)
+	}
+}
@@ -0,0 +1,173 @@
+package capacity
+
+import (
+	"math"
+	"sort"
+)
+
+// Right-sizing risk flags
+const (
+	RiskOOM            = "oom_risk"
+	RiskCPUThrottling  = "cpu_throttling"
+	RiskUnderRequested = "under_requested"
+	RiskNoRequests     = "no_requests"
+)
+
+const mebibyte = 1024 * 1024
+
+// RightsizingOptions tunes request recommendations
+type RightsizingOptions struct {
+	Percentile       float64 `json:"percentile"`
+	CPUHeadroom      float64 `json:"cpu_headroom"`
+	MemoryHeadroom   float64 `json:"memory_headroom"`
+	MinCPUMillicores int64   `json:"min_cpu_millicores"`
+	MinMemoryBytes   int64   `json:"min_memory_bytes"`
+	// ThrottlingThreshold is the fraction of throttled CFS periods flagged as risky
+	ThrottlingThreshold float64 `json:"throttling_threshold"`
+}
+
+// DefaultRightsizingOptions recommends p95 usage plus 15% CPU and 20% memory headroom
+func DefaultRightsizingOptions() RightsizingOptions {
+	return RightsizingOptions{
+		Percentile:          95,
+		CPUHeadroom:         0.15,
+		MemoryHeadroom:      0.20,
+		MinCPUMillicores:    10,
+		MinMemoryBytes:      32 * mebibyte,
+		ThrottlingThreshold: 0.25,
+	}
+}
+
+// ContainerResources are a container's requests and limits; 0 means unset
+type ContainerResources struct {
+	CPURequestMillicores int64 `json:"cpu_request_millicores"`
+	CPULimitMillicores   int64 `json:"cpu_limit_millicores"`
+	MemoryRequestBytes   int64 `json:"memory_request_bytes"`
+	MemoryLimitBytes     int64 `json:"memory_limit_bytes"`
+}
+
+// ContainerUsage is observed usage over the analysis window
+type ContainerUsage struct {
+	CPUMillicores   float64 `json:"cpu_millicores"`
+	MemoryBytes     float64 `json:"memory_bytes"`
+	PeakMemoryBytes float64 `json:"peak_memory_bytes"`
+	// ThrottledRatio is the fraction of CFS periods throttled; negative when unknown
+	ThrottledRatio float64 `json:"throttled_ratio"`
+	Samples        int     `json:"samples"`
+	OOMKilled      bool    `json:"oom_killed"`
+}
+
+// ResourceRecommendation is the suggested requests/limits for one container
+type ResourceRecommendation struct {
+	Recommended ContainerResources `json:"recommended"`
+	// Reclaimable is current minus recommended request per replica; negative
+	// values mean the container needs more than it requests
+	CPUReclaimableMillicores int64    `json:"cpu_reclaimable_millicores"`
+	MemoryReclaimableBytes   int64    `json:"memory_reclaimable_bytes"`
+	Risks                    []string `json:"risks"`
+}
+
+// RecommendResources sizes requests at the usage percentile plus headroom.
+// Limits are only recommended where the container already has one: CPU
+// limits keep their current ratio to the request and memory limits stay
+// above peak usage. Memory of an OOMKilled container is never lowered below
+// its current request or limit, since sampled usage misses the spike that
+// killed it.
+func RecommendResources(current ContainerResources, usage ContainerUsage, opts RightsizingOptions) ResourceRecommendation {
+	rec := ResourceRecommendation{Risks: []string{}}
+
+	cpu := roundUp(int64(math.Ceil(usage.CPUMillicores*(1+opts.CPUHeadroom))), 5)
+	if cpu < opts.MinCPUMillicores {
+		cpu = opts.MinCPUMillicores
+	}
+	memory := roundUp(int64(math.Ceil(usage.MemoryBytes*(1+opts.MemoryHeadroom))), mebibyte)
+	if memory < opts.MinMemoryBytes {
+		memory = opts.MinMemoryBytes
+	}
+	if usage.OOMKilled {
+		memory = max(memory, current.MemoryRequestBytes)
+	}
+	rec.Recommended.CPURequestMillicores = cpu
+	rec.Recommended.MemoryRequestBytes = memory
+
+	throttled := usage.ThrottledRatio >= opts.ThrottlingThreshold ||
+		(current.CPULimitMillicores > 0 && usage.CPUMillicores >= 0.9*float64(current.CPULimitMillicores))
+	if current.CPULimitMillicores > 0 {
+		limit := current.CPULimitMillicores
+		if current.CPURequestMillicores > 0 {
+			ratio := float64(current.CPULimitMillicores) / float64(current.CPURequestMillicores)
+			limit = roundUp(int64(math.Ceil(float64(cpu)*ratio)), 5)
+		}
+		if throttled {
+			// Throttled containers need room above their typical usage
+			limit = max(limit, roundUp(int64(math.Ceil(usage.CPUMillicores*2)), 5))
+		}
+		rec.Recommended.CPULimitMillicores = max(limit, cpu)
+	}
+
+	oomRisk := usage.OOMKilled ||
+		(current.MemoryLimitBytes > 0 && usage.PeakMemoryBytes >= 0.9*float64(current.MemoryLimitBytes))
+	if current.MemoryLimitBytes > 0 {
+		peak := roundUp(int64(math.Ceil(usage.PeakMemoryBytes*(1+opts.MemoryHeadroom))), mebibyte)
+		rec.Recommended.MemoryLimitBytes = max(peak, memory)
+		if usage.OOMKilled {
+			rec.Recommended.MemoryLimitBytes = max(rec.Recommended.MemoryLimitBytes, current.MemoryLimitBytes)
+		}
+	}
+
+	if current.CPURequestMillicores > 0 {
+		rec.CPUReclaimableMillicores = current.CPURequestMillicores - cpu
+	}
+	if current.MemoryRequestBytes > 0 {
+		rec.MemoryReclaimableBytes = current.MemoryRequestBytes - memory
+	}
+
+	if oomRisk {
+		rec.Risks = append(rec.Risks, RiskOOM)
+	}
+	if throttled {
+		rec.Risks = append(rec.Risks, RiskCPUThrottling)
+	}
+	if (current.CPURequestMillicores > 0 && usage.CPUMillicores > float64(current.CPURequestMillicores)) ||
+		(current.MemoryRequestBytes > 0 && usage.MemoryBytes > float64(current.MemoryRequestBytes)) {
+		rec.Risks = append(rec.Risks, RiskUnderRequested)
+	}
+	if current.CPURequestMillicores == 0 || current.MemoryRequestBytes == 0 {
+		rec.Risks = append(rec.Risks, RiskNoRequests)
+	}
+	return rec
+}
+
+// ReclaimableScore ranks recommendations by reclaimable resources,
+// normalizing 1 core against 1Gi of memory
+func ReclaimableScore(cpuMillicores, memoryBytes int64) float64 {
+	return float64(cpuMillicores)/1000 + float64(memoryBytes)/(1024*mebibyte)
+}
+
+// Percentile returns the p-th percentile (0-100) of values using linear
+// interpolation between closest ranks; it returns 0 for no values
+func Percentile(values []float64, p float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	if p <= 0 {
+		return sorted[0]
+	}
+	if p >= 100 {
+		return sorted[len(sorted)-1]
+	}
+	rank := p / 100 * float64(len(sorted)-1)
+	lower := int(math.Floor(rank))
+	upper := int(math.Ceil(rank))
+	return sorted[lower] + (sorted[upper]-sorted[lower])*(rank-float64(lower))
+}
+
+// roundUp rounds v up to a multiple of step
+func roundUp(v, step int64) int64 {
+	if v%step == 0 {
+		return v
+	}
+	return (v/step + 1) * step
+}
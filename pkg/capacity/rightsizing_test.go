@@ -0,0 +1,127 @@
+package capacity
+
+import (
+	"math"
+	"testing"
+)
+
+func TestRecommendResources_OverProvisioned(t *testing.T) {
+	current := ContainerResources{
+		CPURequestMillicores: 1000,
+		CPULimitMillicores:   2000,
+		MemoryRequestBytes:   2048 * mebibyte,
+		MemoryLimitBytes:     4096 * mebibyte,
+	}
+	usage := ContainerUsage{CPUMillicores: 200, MemoryBytes: 500 * mebibyte, PeakMemoryBytes: 600 * mebibyte, ThrottledRatio: 0.01}
+
+	rec := RecommendResources(current, usage, DefaultRightsizingOptions())
+
+	if rec.Recommended.CPURequestMillicores != 230 {
+		t.Errorf("cpu request = %d, want 230 (200m + 15%%)", rec.Recommended.CPURequestMillicores)
+	}
+	if rec.Recommended.CPULimitMillicores != 460 {
+		t.Errorf("cpu limit = %d, want 460 (2x request ratio kept)", rec.Recommended.CPULimitMillicores)
+	}
+	if rec.Recommended.MemoryRequestBytes != 600*mebibyte {
+		t.Errorf("memory request = %d, want 600Mi", rec.Recommended.MemoryRequestBytes)
+	}
+	if rec.Recommended.MemoryLimitBytes != 720*mebibyte {
+		t.Errorf("memory limit = %d, want 720Mi (peak + 20%%)", rec.Recommended.MemoryLimitBytes)
+	}
+	if rec.CPUReclaimableMillicores != 770 || rec.MemoryReclaimableBytes != 1448*mebibyte {
+		t.Errorf("reclaimable = %dm/%d, want 770m/1448Mi", rec.CPUReclaimableMillicores, rec.MemoryReclaimableBytes)
+	}
+	if len(rec.Risks) != 0 {
+		t.Errorf("risks = %v, want none", rec.Risks)
+	}
+}
+
+func TestRecommendResources_Risks(t *testing.T) {
+	current := ContainerResources{
+		CPURequestMillicores: 100,
+		CPULimitMillicores:   200,
+		MemoryRequestBytes:   256 * mebibyte,
+		MemoryLimitBytes:     512 * mebibyte,
+	}
+	usage := ContainerUsage{CPUMillicores: 190, MemoryBytes: 400 * mebibyte, PeakMemoryBytes: 500 * mebibyte, ThrottledRatio: 0.4}
+
+	rec := RecommendResources(current, usage, DefaultRightsizingOptions())
+
+	want := []string{RiskOOM, RiskCPUThrottling, RiskUnderRequested}
+	if len(rec.Risks) != len(want) {
+		t.Fatalf("risks = %v, want %v", rec.Risks, want)
+	}
+	for i, risk := range want {
+		if rec.Risks[i] != risk {
+			t.Errorf("risk[%d] = %s, want %s", i, rec.Risks[i], risk)
+		}
+	}
+	if rec.CPUReclaimableMillicores >= 0 || rec.MemoryReclaimableBytes >= 0 {
+		t.Errorf("under-requested container should have negative reclaimable, got %dm/%d",
+			rec.CPUReclaimableMillicores, rec.MemoryReclaimableBytes)
+	}
+	if rec.Recommended.CPULimitMillicores < 380 {
+		t.Errorf("throttled cpu limit = %d, want at least 2x usage", rec.Recommended.CPULimitMillicores)
+	}
+}
+
+func TestRecommendResources_NoRequestsOrLimits(t *testing.T) {
+	rec := RecommendResources(ContainerResources{}, ContainerUsage{CPUMillicores: 1, MemoryBytes: 1, ThrottledRatio: -1}, DefaultRightsizingOptions())
+
+	if rec.Recommended.CPURequestMillicores != 10 || rec.Recommended.MemoryRequestBytes != 32*mebibyte {
+		t.Errorf("minimums not applied: %+v", rec.Recommended)
+	}
+	if rec.Recommended.CPULimitMillicores != 0 || rec.Recommended.MemoryLimitBytes != 0 {
+		t.Errorf("limits should not be introduced: %+v", rec.Recommended)
+	}
+	if rec.CPUReclaimableMillicores != 0 || rec.MemoryReclaimableBytes != 0 {
+		t.Errorf("nothing is reclaimable without requests")
+	}
+	if len(rec.Risks) != 1 || rec.Risks[0] != RiskNoRequests {
+		t.Errorf("risks = %v, want [%s]", rec.Risks, RiskNoRequests)
+	}
+}
+
+func TestPercentile(t *testing.T) {
+	values := []float64{40, 10, 30, 20, 50}
+	tests := []struct {
+		p    float64
+		want float64
+	}{
+		{0, 10},
+		{50, 30},
+		{95, 48},
+		{100, 50},
+	}
+	for _, tt := range tests {
+		if got := Percentile(values, tt.p); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("Percentile(%g) = %g, want %g", tt.p, got, tt.want)
+		}
+	}
+	if got := Percentile(nil, 95); got != 0 {
+		t.Errorf("Percentile(nil) = %g, want 0", got)
+	}
+	if values[0] != 40 {
+		t.Error("Percentile must not reorder its input")
+	}
+}
+
+func TestRecommendResources_OOMKilledKeepsMemory(t *testing.T) {
+	current := ContainerResources{
+		CPURequestMillicores: 500,
+		MemoryRequestBytes:   1024 * mebibyte,
+		MemoryLimitBytes:     2048 * mebibyte,
+	}
+	// Sampled usage is far below the request: the spike that killed it was missed
+	usage := ContainerUsage{CPUMillicores: 100, MemoryBytes: 200 * mebibyte, PeakMemoryBytes: 300 * mebibyte, ThrottledRatio: -1, OOMKilled: true}
+
+	rec := RecommendResources(current, usage, DefaultRightsizingOptions())
+
+	if rec.Recommended.MemoryRequestBytes != current.MemoryRequestBytes || rec.Recommended.MemoryLimitBytes != current.MemoryLimitBytes {
+		t.Errorf("recommended memory = %d/%d, want current %d/%d", rec.Recommended.MemoryRequestBytes,
+			rec.Recommended.MemoryLimitBytes, current.MemoryRequestBytes, current.MemoryLimitBytes)
+	}
+	if rec.MemoryReclaimableBytes != 0 || rec.CPUReclaimableMillicores <= 0 {
+		t.Errorf("reclaimable = %dm/%d, want cpu only", rec.CPUReclaimableMillicores, rec.MemoryReclaimableBytes)
+	}
+}
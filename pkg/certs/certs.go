@@ -0,0 +1,95 @@
+// Package certs parses PEM-encoded X.509 certificates and reports their
+// validity window. Only certificate metadata is kept; private key blocks
+// in the input are skipped and never returned.
+package certs
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"time"
+)
+
+// Certificate expiry statuses
+const (
+	StatusValid       = "valid"
+	StatusExpiring    = "expiring"
+	StatusExpired     = "expired"
+	StatusNotYetValid = "not_yet_valid"
+)
+
+// Info is the metadata of one certificate
+type Info struct {
+	Subject   string    `json:"subject"`
+	Issuer    string    `json:"issuer"`
+	DNSNames  []string  `json:"dns_names,omitempty"`
+	IsCA      bool      `json:"is_ca,omitempty"`
+	NotBefore time.Time `json:"not_before"`
+	NotAfter  time.Time `json:"not_after"`
+}
+
+// ParsePEM parses every CERTIFICATE block in data, leaf first as in a
+// served chain. Other block types (keys, parameters) are ignored.
+func ParsePEM(data []byte) ([]Info, error) {
+	var infos []Info
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("invalid certificate: %w", err)
+		}
+		infos = append(infos, Info{
+			Subject:   cert.Subject.String(),
+			Issuer:    cert.Issuer.String(),
+			DNSNames:  cert.DNSNames,
+			IsCA:      cert.IsCA,
+			NotBefore: cert.NotBefore,
+			NotAfter:  cert.NotAfter,
+		})
+	}
+	if len(infos) == 0 {
+		return nil, fmt.Errorf("no PEM certificate found")
+	}
+	return infos, nil
+}
+
+// DaysRemaining returns the whole days until expiry, negative once expired
+func (i Info) DaysRemaining(now time.Time) int {
+	remaining := i.NotAfter.Sub(now)
+	days := int(remaining / (24 * time.Hour))
+	if remaining < 0 && remaining%(24*time.Hour) != 0 {
+		days--
+	}
+	return days
+}
+
+// Status classifies the certificate; it is expiring within warning of NotAfter
+func (i Info) Status(now time.Time, warning time.Duration) string {
+	switch {
+	case now.After(i.NotAfter):
+		return StatusExpired
+	case now.Before(i.NotBefore):
+		return StatusNotYetValid
+	case i.NotAfter.Sub(now) <= warning:
+		return StatusExpiring
+	}
+	return StatusValid
+}
+
+// Earliest returns the certificate of a chain that expires first
+func Earliest(infos []Info) *Info {
+	var earliest *Info
+	for i := range infos {
+		if earliest == nil || infos[i].NotAfter.Before(earliest.NotAfter) {
+			earliest = &infos[i]
+		}
+	}
+	return earliest
+}
@@ -0,0 +1,78 @@
+package certs
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+)
+
+func testCertificate(t *testing.T, cn string, notBefore, notAfter time.Time) []byte {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: cn},
+		DNSNames:     []string{cn},
+		NotBefore:    notBefore,
+		NotAfter:     notAfter,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
+func TestParsePEM(t *testing.T) {
+	now := time.Now()
+	leaf := testCertificate(t, "app.example.com", now.Add(-time.Hour), now.Add(10*24*time.Hour+time.Hour))
+	ca := testCertificate(t, "ca", now.Add(-time.Hour), now.Add(365*24*time.Hour))
+	key := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: []byte("not parsed")})
+
+	infos, err := ParsePEM(append(append(leaf, key...), ca...))
+	if err != nil {
+		t.Fatalf("ParsePEM: %v", err)
+	}
+	if len(infos) != 2 || infos[0].Subject != "CN=app.example.com" || infos[0].DNSNames[0] != "app.example.com" {
+		t.Fatalf("infos = %+v, want leaf then CA with the key skipped", infos)
+	}
+	if d := infos[0].DaysRemaining(now); d != 10 {
+		t.Errorf("DaysRemaining = %d, want 10", d)
+	}
+	if s := infos[0].Status(now, 30*24*time.Hour); s != StatusExpiring {
+		t.Errorf("Status = %s, want expiring", s)
+	}
+	if s := infos[1].Status(now, 30*24*time.Hour); s != StatusValid {
+		t.Errorf("CA status = %s, want valid", s)
+	}
+	if e := Earliest(infos); e.Subject != infos[0].Subject {
+		t.Errorf("Earliest = %s, want the leaf", e.Subject)
+	}
+
+	if _, err := ParsePEM(key); err == nil {
+		t.Error("expected an error without certificates")
+	}
+}
+
+func TestStatusExpired(t *testing.T) {
+	now := time.Now()
+	infos, err := ParsePEM(testCertificate(t, "old", now.Add(-48*time.Hour), now.Add(-36*time.Hour)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s := infos[0].Status(now, 0); s != StatusExpired {
+		t.Errorf("Status = %s, want expired", s)
+	}
+	if d := infos[0].DaysRemaining(now); d != -2 {
+		t.Errorf("DaysRemaining = %d, want -2", d)
+	}
+}
@@ -0,0 +1,245 @@
+package certs
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Severities of a scanned certificate
+const (
+	SeverityOK       = "ok"
+	SeverityWarning  = "warning"
+	SeverityCritical = "critical"
+)
+
+// Thresholds are the days before expiry at which a certificate becomes a
+// warning or critical finding
+type Thresholds struct {
+	WarningDays  int `json:"warning_days"`
+	CriticalDays int `json:"critical_days"`
+}
+
+// DefaultThresholds warns 30 days and escalates 7 days before expiry
+var DefaultThresholds = Thresholds{WarningDays: 30, CriticalDays: 7}
+
+// Source is a PEM bundle held by a cluster object, e.g. the tls.crt key of
+// a Secret or the certificate of a Route. PEM must only contain public
+// certificate data; it is never serialized.
+type Source struct {
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	Key       string `json:"key"`             // Data key or field holding the bundle
+	Owner     string `json:"owner,omitempty"` // Kind/name of the managing object, if known
+	PEM       []byte `json:"-"`
+}
+
+// Finding is one certificate of a scanned bundle
+type Finding struct {
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	Key       string `json:"key"`
+	Info
+	DaysRemaining int    `json:"days_remaining"`
+	Status        string `json:"status"` // valid, expiring, expired or not_yet_valid
+	Severity      string `json:"severity"`
+	Copies        int    `json:"copies,omitempty"` // Identical ConfigMap bundles collapsed into this finding
+}
+
+// OwnerGroup holds the findings of the objects sharing an owner
+type OwnerGroup struct {
+	Owner    string    `json:"owner"` // Empty when the objects have no known owner
+	Severity string    `json:"severity"`
+	Findings []Finding `json:"findings"` // Earliest expiry first
+}
+
+// NamespaceGroup holds the findings of one namespace grouped by owner
+type NamespaceGroup struct {
+	Namespace string       `json:"namespace"`
+	Severity  string       `json:"severity"`
+	Critical  int          `json:"critical"`
+	Warning   int          `json:"warning"`
+	Owners    []OwnerGroup `json:"owners"`
+}
+
+// Summary counts the scanned certificates
+type Summary struct {
+	Sources      int `json:"sources"`
+	Certificates int `json:"certificates"`
+	Expired      int `json:"expired"`
+	Critical     int `json:"critical"` // Includes expired certificates
+	Warning      int `json:"warning"`
+	OK           int `json:"ok"`
+	ParseErrors  int `json:"parse_errors"`
+}
+
+// Report is the result of a certificate scan
+type Report struct {
+	Summary     Summary          `json:"summary"`
+	Namespaces  []NamespaceGroup `json:"namespaces"` // Most severe first
+	ParseErrors []string         `json:"parse_errors,omitempty"`
+}
+
+// Severity classifies a certificate against the thresholds. Expired
+// certificates are critical; not yet valid ones are a warning.
+func (t Thresholds) Severity(info Info, now time.Time) string {
+	switch days := info.DaysRemaining(now); {
+	case now.After(info.NotAfter) || days < t.CriticalDays:
+		return SeverityCritical
+	case now.Before(info.NotBefore) || days < t.WarningDays:
+		return SeverityWarning
+	}
+	return SeverityOK
+}
+
+// Scan parses every source and groups the certificates by namespace and
+// owner. Identical ConfigMap bundles (e.g. the service CA injected into
+// every namespace) are reported once with a copy count. With onlyProblems,
+// certificates of severity ok are counted but not listed.
+func Scan(sources []Source, now time.Time, thresholds Thresholds, onlyProblems bool) Report {
+	report := Report{Namespaces: []NamespaceGroup{}}
+	warning := time.Duration(thresholds.WarningDays) * 24 * time.Hour
+
+	type ownerKey struct{ namespace, owner string }
+	type bundleRef struct {
+		group   *OwnerGroup
+		indexes []int
+	}
+	groups := make(map[ownerKey]*OwnerGroup)
+	bundles := make(map[string]bundleRef) // ConfigMap key+PEM -> findings already reported
+	var order []ownerKey
+
+	for _, src := range sources {
+		report.Summary.Sources++
+		if src.Kind == "ConfigMap" {
+			if seen, ok := bundles[src.Key+"\x00"+string(src.PEM)]; ok {
+				for _, i := range seen.indexes {
+					seen.group.Findings[i].Copies++
+				}
+				continue
+			}
+		}
+		infos, err := ParsePEM(src.PEM)
+		if err != nil {
+			report.Summary.ParseErrors++
+			report.ParseErrors = append(report.ParseErrors, fmt.Sprintf("%s %s/%s %s: %v", src.Kind, src.Namespace, src.Name, src.Key, err))
+			continue
+		}
+
+		key := ownerKey{src.Namespace, src.Owner}
+		group, ok := groups[key]
+		if !ok {
+			group = &OwnerGroup{Owner: src.Owner, Severity: SeverityOK}
+			groups[key] = group
+			order = append(order, key)
+		}
+		var reported []int
+		for _, info := range infos {
+			f := Finding{
+				Kind:          src.Kind,
+				Namespace:     src.Namespace,
+				Name:          src.Name,
+				Key:           src.Key,
+				Info:          info,
+				DaysRemaining: info.DaysRemaining(now),
+				Status:        info.Status(now, warning),
+				Severity:      thresholds.Severity(info, now),
+			}
+			report.Summary.Certificates++
+			switch f.Severity {
+			case SeverityCritical:
+				report.Summary.Critical++
+			case SeverityWarning:
+				report.Summary.Warning++
+			default:
+				report.Summary.OK++
+			}
+			if f.Status == StatusExpired {
+				report.Summary.Expired++
+			}
+			if onlyProblems && f.Severity == SeverityOK {
+				continue
+			}
+			group.Findings = append(group.Findings, f)
+			reported = append(reported, len(group.Findings)-1)
+		}
+		if src.Kind == "ConfigMap" {
+			bundles[src.Key+"\x00"+string(src.PEM)] = bundleRef{group: group, indexes: reported}
+		}
+	}
+	for _, key := range order {
+		group := groups[key]
+		if len(group.Findings) == 0 {
+			continue
+		}
+		sort.SliceStable(group.Findings, func(i, j int) bool {
+			return group.Findings[i].NotAfter.Before(group.Findings[j].NotAfter)
+		})
+		for _, f := range group.Findings {
+			group.Severity = worseSeverity(group.Severity, f.Severity)
+		}
+
+		var ns *NamespaceGroup
+		for i := range report.Namespaces {
+			if report.Namespaces[i].Namespace == key.namespace {
+				ns = &report.Namespaces[i]
+			}
+		}
+		if ns == nil {
+			report.Namespaces = append(report.Namespaces, NamespaceGroup{Namespace: key.namespace, Severity: SeverityOK})
+			ns = &report.Namespaces[len(report.Namespaces)-1]
+		}
+		ns.Owners = append(ns.Owners, *group)
+		ns.Severity = worseSeverity(ns.Severity, group.Severity)
+		for _, f := range group.Findings {
+			switch f.Severity {
+			case SeverityCritical:
+				ns.Critical++
+			case SeverityWarning:
+				ns.Warning++
+			}
+		}
+	}
+
+	for i := range report.Namespaces {
+		owners := report.Namespaces[i].Owners
+		sort.SliceStable(owners, func(a, b int) bool {
+			if ra, rb := severityRank(owners[a].Severity), severityRank(owners[b].Severity); ra != rb {
+				return ra > rb
+			}
+			return owners[a].Owner < owners[b].Owner
+		})
+	}
+	sort.SliceStable(report.Namespaces, func(i, j int) bool {
+		a, b := report.Namespaces[i], report.Namespaces[j]
+		if ra, rb := severityRank(a.Severity), severityRank(b.Severity); ra != rb {
+			return ra > rb
+		}
+		if a.Critical+a.Warning != b.Critical+b.Warning {
+			return a.Critical+a.Warning > b.Critical+b.Warning
+		}
+		return a.Namespace < b.Namespace
+	})
+	return report
+}
+
+// severityRank orders severities from ok (0) to critical (2)
+func severityRank(severity string) int {
+	switch severity {
+	case SeverityCritical:
+		return 2
+	case SeverityWarning:
+		return 1
+	}
+	return 0
+}
+
+// worseSeverity returns the more severe of a and b
+func worseSeverity(a, b string) string {
+	if severityRank(b) > severityRank(a) {
+		return b
+	}
+	return a
+}
@@ -0,0 +1,80 @@
+package certs
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestThresholdsSeverity(t *testing.T) {
+	now := time.Now()
+	day := 24 * time.Hour
+	tests := []struct {
+		name      string
+		notBefore time.Time
+		notAfter  time.Time
+		want      string
+	}{
+		{"valid", now.Add(-day), now.Add(90*day + time.Hour), SeverityOK},
+		{"warning", now.Add(-day), now.Add(20*day + time.Hour), SeverityWarning},
+		{"critical", now.Add(-day), now.Add(3*day + time.Hour), SeverityCritical},
+		{"expired", now.Add(-90 * day), now.Add(-time.Hour), SeverityCritical},
+		{"not yet valid", now.Add(day), now.Add(90 * day), SeverityWarning},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info := Info{NotBefore: tt.notBefore, NotAfter: tt.notAfter}
+			if got := DefaultThresholds.Severity(info, now); got != tt.want {
+				t.Errorf("Severity = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScan(t *testing.T) {
+	now := time.Now()
+	day := 24 * time.Hour
+	expired := testCertificate(t, "expired.example.com", now.Add(-90*day), now.Add(-day))
+	soon := testCertificate(t, "soon.example.com", now.Add(-day), now.Add(20*day+time.Hour))
+	fine := testCertificate(t, "fine.example.com", now.Add(-day), now.Add(300*day))
+	serviceCA := testCertificate(t, "openshift-service-serving-signer", now.Add(-day), now.Add(5*day+time.Hour))
+
+	sources := []Source{
+		{Kind: "Secret", Namespace: "shop", Name: "web-tls", Key: "tls.crt", Owner: "Certificate/web", PEM: append(soon, fine...)},
+		{Kind: "Secret", Namespace: "shop", Name: "api-tls", Key: "tls.crt", Owner: "Service/api", PEM: expired},
+		{Kind: "Route", Namespace: "blog", Name: "blog", Key: "certificate", Owner: "Route/blog", PEM: fine},
+		{Kind: "ConfigMap", Namespace: "a", Name: "openshift-service-ca.crt", Key: "service-ca.crt", PEM: serviceCA},
+		{Kind: "ConfigMap", Namespace: "b", Name: "openshift-service-ca.crt", Key: "service-ca.crt", PEM: serviceCA},
+		{Kind: "ConfigMap", Namespace: "c", Name: "openshift-service-ca.crt", Key: "service-ca.crt", PEM: serviceCA},
+		{Kind: "Secret", Namespace: "shop", Name: "broken", Key: "tls.crt", PEM: []byte("garbage")},
+	}
+
+	report := Scan(sources, now, DefaultThresholds, true)
+	s := report.Summary
+	if s.Sources != 7 || s.Certificates != 5 || s.Critical != 2 || s.Expired != 1 || s.Warning != 1 || s.OK != 2 || s.ParseErrors != 1 {
+		t.Errorf("summary = %+v", s)
+	}
+	if len(report.ParseErrors) != 1 || !strings.HasPrefix(report.ParseErrors[0], "Secret shop/broken tls.crt") {
+		t.Errorf("parse errors = %v", report.ParseErrors)
+	}
+
+	// blog only has an ok certificate and is omitted; shop has the most findings
+	if len(report.Namespaces) != 2 || report.Namespaces[0].Namespace != "shop" || report.Namespaces[1].Namespace != "a" {
+		t.Fatalf("namespaces = %+v", report.Namespaces)
+	}
+	shop := report.Namespaces[0]
+	if shop.Severity != SeverityCritical || shop.Critical != 1 || shop.Warning != 1 || len(shop.Owners) != 2 {
+		t.Errorf("shop = %+v", shop)
+	}
+	if shop.Owners[0].Owner != "Service/api" || shop.Owners[1].Owner != "Certificate/web" || len(shop.Owners[1].Findings) != 1 {
+		t.Errorf("owners = %+v, want critical owner first and the ok certificate omitted", shop.Owners)
+	}
+	if ca := report.Namespaces[1].Owners[0].Findings[0]; ca.Copies != 2 || ca.Severity != SeverityCritical {
+		t.Errorf("service CA = %+v, want one finding with two copies", ca)
+	}
+
+	all := Scan(sources, now, DefaultThresholds, false)
+	if len(all.Namespaces) != 3 || len(all.Namespaces[0].Owners[1].Findings) != 2 {
+		t.Errorf("namespaces = %+v, want ok certificates listed", all.Namespaces)
+	}
+}
@@ -0,0 +1,178 @@
+package clients
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+
+	"github.com/KubeHeal/openshift-cluster-health-mcp/pkg/certs"
+)
+
+// ServiceCABundleKey is the ConfigMap key the OpenShift service CA operator
+// injects its CA bundle into
+const ServiceCABundleKey = "service-ca.crt"
+
+// Annotations identifying the object that manages a TLS Secret
+const (
+	certManagerCertificateAnnotation = "cert-manager.io/certificate-name"
+	servingCertServiceAnnotation     = "service.beta.openshift.io/originating-service-name"
+)
+
+// tlsSecretCertificateKeys are the only Secret keys read for certificates;
+// tls.key is never read
+var tlsSecretCertificateKeys = []string{corev1.TLSCertKey, corev1.ServiceAccountRootCAKey}
+
+// ListServiceCABundles returns the service CA bundles held by ConfigMaps in
+// a namespace (all namespaces if empty)
+func (c *K8sClient) ListServiceCABundles(ctx context.Context, namespace string) ([]certs.Source, error) {
+	configMaps, err := c.clientset.CoreV1().ConfigMaps(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list configmaps in namespace %s: %w", namespace, err)
+	}
+
+	var sources []certs.Source
+	for i := range configMaps.Items {
+		sources = append(sources, configMapCertificateSources(&configMaps.Items[i])...)
+	}
+	return sources, nil
+}
+
+// ListTLSSecretCertificates returns the certificates of kubernetes.io/tls
+// Secrets in a namespace (all namespaces if empty). Private keys are
+// dropped as soon as the list is received.
+func (c *K8sClient) ListTLSSecretCertificates(ctx context.Context, namespace string) ([]certs.Source, error) {
+	secrets, err := c.clientset.CoreV1().Secrets(namespace).List(ctx, metav1.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector("type", string(corev1.SecretTypeTLS)).String(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list TLS secrets in namespace %s: %w", namespace, err)
+	}
+
+	var sources []certs.Source
+	for i := range secrets.Items {
+		sources = append(sources, secretCertificateSources(&secrets.Items[i])...)
+	}
+	return sources, nil
+}
+
+// CollectCertificateSources gathers the certificates of service CA bundle
+// ConfigMaps, Routes and, when includeSecrets is set, kubernetes.io/tls
+// Secrets. ConfigMaps are required; unavailable Routes or Secrets are
+// reported as notes.
+func (c *K8sClient) CollectCertificateSources(ctx context.Context, namespace string, includeSecrets bool) ([]certs.Source, []string, error) {
+	sources, err := c.ListServiceCABundles(ctx, namespace)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var notes []string
+	routes, err := c.ListRoutes(ctx, namespace)
+	switch {
+	case apierrors.IsNotFound(err):
+		notes = append(notes, "Routes not scanned: this does not appear to be an OpenShift cluster")
+	case err != nil:
+		notes = append(notes, fmt.Sprintf("Routes not scanned: %v", err))
+	default:
+		sources = append(sources, RouteCertificateSources(routes)...)
+	}
+
+	if !includeSecrets {
+		notes = append(notes, "TLS Secrets not scanned: secret access is disabled (set ENABLE_SECRET_CERT_SCAN=true to enable)")
+		return sources, notes, nil
+	}
+	secretSources, err := c.ListTLSSecretCertificates(ctx, namespace)
+	if err != nil {
+		notes = append(notes, fmt.Sprintf("TLS Secrets not scanned: %v", err))
+	} else {
+		sources = append(sources, secretSources...)
+	}
+	return sources, notes, nil
+}
+
+// RouteCertificateSources returns the inline certificates of Routes
+func RouteCertificateSources(routes []Route) []certs.Source {
+	var sources []certs.Source
+	for _, route := range routes {
+		if route.TLS == nil {
+			continue
+		}
+		for _, field := range []struct{ key, pem string }{
+			{"certificate", route.TLS.Certificate},
+			{"caCertificate", route.TLS.CACertificate},
+			{"destinationCACertificate", route.TLS.DestinationCACertificate},
+		} {
+			if field.pem == "" {
+				continue
+			}
+			sources = append(sources, certs.Source{
+				Kind:      "Route",
+				Namespace: route.Namespace,
+				Name:      route.Name,
+				Key:       field.key,
+				Owner:     "Route/" + route.Name,
+				PEM:       []byte(field.pem),
+			})
+		}
+	}
+	return sources
+}
+
+// configMapCertificateSources returns the service CA bundle of a ConfigMap, if any
+func configMapCertificateSources(cm *corev1.ConfigMap) []certs.Source {
+	bundle, ok := cm.Data[ServiceCABundleKey]
+	if !ok || bundle == "" {
+		return nil
+	}
+	return []certs.Source{{
+		Kind:      "ConfigMap",
+		Namespace: cm.Namespace,
+		Name:      cm.Name,
+		Key:       ServiceCABundleKey,
+		Owner:     certificateOwner(cm),
+		PEM:       []byte(bundle),
+	}}
+}
+
+// secretCertificateSources returns the certificate keys of a TLS Secret
+func secretCertificateSources(secret *corev1.Secret) []certs.Source {
+	var sources []certs.Source
+	for _, key := range tlsSecretCertificateKeys {
+		data, ok := secret.Data[key]
+		if !ok || len(data) == 0 {
+			continue
+		}
+		sources = append(sources, certs.Source{
+			Kind:      "Secret",
+			Namespace: secret.Namespace,
+			Name:      secret.Name,
+			Key:       key,
+			Owner:     certificateOwner(secret),
+			PEM:       data,
+		})
+	}
+	return sources
+}
+
+// certificateOwner returns the Kind/name of the object managing a
+// certificate holder: its controller owner reference, else the cert-manager
+// Certificate or the Service a serving certificate was issued for
+func certificateOwner(obj metav1.Object) string {
+	if ref := metav1.GetControllerOf(obj); ref != nil {
+		return ref.Kind + "/" + ref.Name
+	}
+	if refs := obj.GetOwnerReferences(); len(refs) > 0 {
+		return refs[0].Kind + "/" + refs[0].Name
+	}
+	annotations := obj.GetAnnotations()
+	if name := annotations[certManagerCertificateAnnotation]; name != "" {
+		return "Certificate/" + name
+	}
+	if name := annotations[servingCertServiceAnnotation]; name != "" {
+		return "Service/" + name
+	}
+	return ""
+}
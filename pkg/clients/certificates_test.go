@@ -0,0 +1,76 @@
+package clients
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestSecretCertificateSources(t *testing.T) {
+	controller := true
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "web-tls",
+			Namespace: "shop",
+			OwnerReferences: []metav1.OwnerReference{
+				{Kind: "Certificate", Name: "web", Controller: &controller},
+			},
+		},
+		Type: corev1.SecretTypeTLS,
+		Data: map[string][]byte{
+			corev1.TLSCertKey:       []byte("cert"),
+			corev1.TLSPrivateKeyKey: []byte("key"),
+		},
+	}
+
+	sources := secretCertificateSources(secret)
+	if len(sources) != 1 || sources[0].Key != corev1.TLSCertKey || sources[0].Owner != "Certificate/web" {
+		t.Fatalf("sources = %+v, want only tls.crt owned by the Certificate", sources)
+	}
+	if string(sources[0].PEM) != "cert" {
+		t.Errorf("PEM = %q", sources[0].PEM)
+	}
+}
+
+func TestCertificateOwner(t *testing.T) {
+	tests := []struct {
+		name string
+		meta metav1.ObjectMeta
+		want string
+	}{
+		{"owner reference", metav1.ObjectMeta{OwnerReferences: []metav1.OwnerReference{{Kind: "Deployment", Name: "api"}}}, "Deployment/api"},
+		{"cert-manager", metav1.ObjectMeta{Annotations: map[string]string{certManagerCertificateAnnotation: "web"}}, "Certificate/web"},
+		{"serving cert", metav1.ObjectMeta{Annotations: map[string]string{servingCertServiceAnnotation: "api"}}, "Service/api"},
+		{"none", metav1.ObjectMeta{}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := certificateOwner(&corev1.ConfigMap{ObjectMeta: tt.meta}); got != tt.want {
+				t.Errorf("certificateOwner = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConfigMapAndRouteCertificateSources(t *testing.T) {
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "openshift-service-ca.crt", Namespace: "app"},
+		Data:       map[string]string{ServiceCABundleKey: "ca"},
+	}
+	if sources := configMapCertificateSources(cm); len(sources) != 1 || sources[0].Kind != "ConfigMap" {
+		t.Errorf("configmap sources = %+v", sources)
+	}
+	if sources := configMapCertificateSources(&corev1.ConfigMap{Data: map[string]string{"other": "x"}}); sources != nil {
+		t.Errorf("sources = %+v, want nothing without a service CA bundle", sources)
+	}
+
+	routes := []Route{
+		{Name: "plain", Namespace: "app"},
+		{Name: "reencrypt", Namespace: "app", TLS: &RouteTLS{Termination: "reencrypt", Certificate: "leaf", DestinationCACertificate: "ca"}},
+	}
+	sources := RouteCertificateSources(routes)
+	if len(sources) != 2 || sources[0].Key != "certificate" || sources[1].Key != "destinationCACertificate" || sources[1].Owner != "Route/reencrypt" {
+		t.Errorf("route sources = %+v", sources)
+	}
+}
@@ -0,0 +1,333 @@
+package clients
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	eventsv1 "k8s.io/api/events/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+)
+
+// Event API sources
+const (
+	EventSourceCore   = "core/v1"
+	EventSourceEvents = "events.k8s.io/v1"
+)
+
+// Event is a normalized Kubernetes event from either events API. Repeated
+// occurrences of the same event are collapsed into one entry with a Count.
+type Event struct {
+	Namespace string    `json:"namespace"`
+	Kind      string    `json:"kind"`
+	Name      string    `json:"name"`
+	Type      string    `json:"type"`
+	Reason    string    `json:"reason"`
+	Message   string    `json:"message"`
+	Reporter  string    `json:"reporter,omitempty"`
+	Count     int32     `json:"count"`
+	FirstSeen time.Time `json:"first_seen"`
+	LastSeen  time.Time `json:"last_seen"`
+	Source    string    `json:"source"`
+
+	uid string
+}
+
+// EventFilter selects events; empty fields match everything
+type EventFilter struct {
+	Namespace string
+	Kind      string
+	Name      string
+	Type      string
+	Reason    string
+	Since     time.Time
+}
+
+// Matches reports whether an event passes the filter
+func (f EventFilter) Matches(e *Event) bool {
+	if f.Namespace != "" && e.Namespace != f.Namespace {
+		return false
+	}
+	if f.Kind != "" && !strings.EqualFold(e.Kind, f.Kind) {
+		return false
+	}
+	if f.Name != "" && e.Name != f.Name {
+		return false
+	}
+	if f.Type != "" && !strings.EqualFold(e.Type, f.Type) {
+		return false
+	}
+	if f.Reason != "" && !strings.EqualFold(e.Reason, f.Reason) {
+		return false
+	}
+	if !f.Since.IsZero() && e.LastSeen.Before(f.Since) {
+		return false
+	}
+	return true
+}
+
+// EventReasonSummary aggregates events sharing a reason
+type EventReasonSummary struct {
+	Reason        string    `json:"reason"`
+	Count         int32     `json:"count"`
+	Objects       int       `json:"objects"`
+	Namespaces    []string  `json:"namespaces"`
+	LatestMessage string    `json:"latest_message"`
+	LastSeen      time.Time `json:"last_seen"`
+}
+
+// ListEventTimeline lists events from core/v1 and events.k8s.io/v1, merges
+// and collapses them, applies the filter and returns them oldest first.
+// If namespace is empty, events from all namespaces are returned.
+func (c *K8sClient) ListEventTimeline(ctx context.Context, filter EventFilter) ([]Event, error) {
+	opts := metav1.ListOptions{}
+	if selector := involvedObjectSelector(filter); selector != "" {
+		opts.FieldSelector = selector
+	}
+
+	coreEvents, err := c.clientset.CoreV1().Events(filter.Namespace).List(ctx, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list events in namespace %s: %w", filter.Namespace, err)
+	}
+
+	// events.k8s.io/v1 is optional: older clusters or restricted RBAC fall back to core/v1 only
+	var newEvents []eventsv1.Event
+	v1Opts := metav1.ListOptions{}
+	if selector := regardingSelector(filter); selector != "" {
+		v1Opts.FieldSelector = selector
+	}
+	if list, err := c.clientset.EventsV1().Events(filter.Namespace).List(ctx, v1Opts); err == nil {
+		newEvents = list.Items
+	}
+
+	return MergeEvents(coreEvents.Items, newEvents, filter), nil
+}
+
+// involvedObjectSelector pushes name and type filtering to the API server for
+// core/v1; kind is matched client-side so it can be case-insensitive
+func involvedObjectSelector(filter EventFilter) string {
+	var selectors []fields.Selector
+	if filter.Name != "" {
+		selectors = append(selectors, fields.OneTermEqualSelector("involvedObject.name", filter.Name))
+	}
+	if eventType := canonicalEventType(filter.Type); eventType != "" {
+		selectors = append(selectors, fields.OneTermEqualSelector("type", eventType))
+	}
+	return fields.AndSelectors(selectors...).String()
+}
+
+// regardingSelector pushes name and type filtering to the API server for
+// events.k8s.io/v1
+func regardingSelector(filter EventFilter) string {
+	var selectors []fields.Selector
+	if filter.Name != "" {
+		selectors = append(selectors, fields.OneTermEqualSelector("regarding.name", filter.Name))
+	}
+	if eventType := canonicalEventType(filter.Type); eventType != "" {
+		selectors = append(selectors, fields.OneTermEqualSelector("type", eventType))
+	}
+	return fields.AndSelectors(selectors...).String()
+}
+
+// canonicalEventType returns the API spelling of a case-insensitive event
+// type. Unknown types return "" and are only matched client-side, since
+// field selectors are case-sensitive.
+func canonicalEventType(eventType string) string {
+	for _, t := range []string{corev1.EventTypeNormal, corev1.EventTypeWarning} {
+		if strings.EqualFold(eventType, t) {
+			return t
+		}
+	}
+	return ""
+}
+
+// MergeEvents normalizes events from both APIs, drops duplicates (both APIs
+// serve the same stored objects), collapses repeated occurrences of the same
+// event, applies the filter and sorts the result oldest first
+func MergeEvents(core []corev1.Event, v1 []eventsv1.Event, filter EventFilter) []Event {
+	seen := make(map[string]bool)
+	var all []Event
+	for i := range core {
+		e := convertCoreEvent(&core[i])
+		if e.uid != "" {
+			seen[e.uid] = true
+		}
+		all = append(all, e)
+	}
+	for i := range v1 {
+		e := convertEventsV1Event(&v1[i])
+		if e.uid != "" && seen[e.uid] {
+			continue
+		}
+		all = append(all, e)
+	}
+
+	collapsed := CollapseEvents(all)
+	result := make([]Event, 0, len(collapsed))
+	for i := range collapsed {
+		if filter.Matches(&collapsed[i]) {
+			result = append(result, collapsed[i])
+		}
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].LastSeen.Before(result[j].LastSeen)
+	})
+	return result
+}
+
+// CollapseEvents merges events with the same object, type, reason and message,
+// summing counts and widening the first/last seen window
+func CollapseEvents(events []Event) []Event {
+	index := make(map[string]int)
+	var result []Event
+	for _, e := range events {
+		key := strings.Join([]string{e.Namespace, e.Kind, e.Name, e.Type, e.Reason, e.Message}, "\x00")
+		i, exists := index[key]
+		if !exists {
+			index[key] = len(result)
+			result = append(result, e)
+			continue
+		}
+
+		existing := &result[i]
+		existing.Count += e.Count
+		if e.FirstSeen.Before(existing.FirstSeen) {
+			existing.FirstSeen = e.FirstSeen
+		}
+		if e.LastSeen.After(existing.LastSeen) {
+			existing.LastSeen = e.LastSeen
+			if e.Reporter != "" {
+				existing.Reporter = e.Reporter
+			}
+		}
+	}
+	return result
+}
+
+// SummarizeEventReasons groups events by reason, ordered by total count
+func SummarizeEventReasons(events []Event) []EventReasonSummary {
+	type aggregate struct {
+		summary    EventReasonSummary
+		objects    map[string]bool
+		namespaces map[string]bool
+	}
+
+	byReason := make(map[string]*aggregate)
+	for _, e := range events {
+		agg, exists := byReason[e.Reason]
+		if !exists {
+			agg = &aggregate{
+				summary:    EventReasonSummary{Reason: e.Reason},
+				objects:    make(map[string]bool),
+				namespaces: make(map[string]bool),
+			}
+			byReason[e.Reason] = agg
+		}
+		agg.summary.Count += e.Count
+		agg.objects[e.Namespace+"/"+e.Kind+"/"+e.Name] = true
+		if e.Namespace != "" {
+			agg.namespaces[e.Namespace] = true
+		}
+		if !e.LastSeen.Before(agg.summary.LastSeen) {
+			agg.summary.LastSeen = e.LastSeen
+			agg.summary.LatestMessage = e.Message
+		}
+	}
+
+	summaries := make([]EventReasonSummary, 0, len(byReason))
+	for _, agg := range byReason {
+		agg.summary.Objects = len(agg.objects)
+		for ns := range agg.namespaces {
+			agg.summary.Namespaces = append(agg.summary.Namespaces, ns)
+		}
+		sort.Strings(agg.summary.Namespaces)
+		summaries = append(summaries, agg.summary)
+	}
+
+	sort.Slice(summaries, func(i, j int) bool {
+		if summaries[i].Count != summaries[j].Count {
+			return summaries[i].Count > summaries[j].Count
+		}
+		return summaries[i].Reason < summaries[j].Reason
+	})
+	return summaries
+}
+
+// convertCoreEvent normalizes a core/v1 event, preferring series data when present
+func convertCoreEvent(ev *corev1.Event) Event {
+	e := Event{
+		Namespace: ev.Namespace,
+		Kind:      ev.InvolvedObject.Kind,
+		Name:      ev.InvolvedObject.Name,
+		Type:      ev.Type,
+		Reason:    ev.Reason,
+		Message:   ev.Message,
+		Reporter:  ev.ReportingController,
+		Count:     ev.Count,
+		Source:    EventSourceCore,
+		uid:       string(ev.UID),
+	}
+	if e.Reporter == "" {
+		e.Reporter = ev.Source.Component
+	}
+
+	e.FirstSeen = firstTime(ev.FirstTimestamp.Time, ev.EventTime.Time, ev.CreationTimestamp.Time)
+	e.LastSeen = firstTime(ev.LastTimestamp.Time, ev.EventTime.Time, e.FirstSeen)
+	if ev.Series != nil {
+		e.Count = ev.Series.Count
+		if !ev.Series.LastObservedTime.IsZero() {
+			e.LastSeen = ev.Series.LastObservedTime.Time
+		}
+	}
+	if e.Count < 1 {
+		e.Count = 1
+	}
+	return e
+}
+
+// convertEventsV1Event normalizes an events.k8s.io/v1 event
+func convertEventsV1Event(ev *eventsv1.Event) Event {
+	e := Event{
+		Namespace: ev.Namespace,
+		Kind:      ev.Regarding.Kind,
+		Name:      ev.Regarding.Name,
+		Type:      ev.Type,
+		Reason:    ev.Reason,
+		Message:   ev.Note,
+		Reporter:  ev.ReportingController,
+		Count:     ev.DeprecatedCount,
+		Source:    EventSourceEvents,
+		uid:       string(ev.UID),
+	}
+	if e.Reporter == "" {
+		e.Reporter = ev.DeprecatedSource.Component
+	}
+
+	e.FirstSeen = firstTime(ev.EventTime.Time, ev.DeprecatedFirstTimestamp.Time, ev.CreationTimestamp.Time)
+	e.LastSeen = firstTime(ev.DeprecatedLastTimestamp.Time, e.FirstSeen)
+	if ev.Series != nil {
+		e.Count = ev.Series.Count
+		if !ev.Series.LastObservedTime.IsZero() {
+			e.LastSeen = ev.Series.LastObservedTime.Time
+		}
+	}
+	if e.Count < 1 {
+		e.Count = 1
+	}
+	return e
+}
+
+// firstTime returns the first non-zero time
+func firstTime(times ...time.Time) time.Time {
+	for _, t := range times {
+		if !t.IsZero() {
+			return t
+		}
+	}
+	return time.Time{}
+}
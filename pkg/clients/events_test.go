@@ -0,0 +1,132 @@
+package clients
+
+import (
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	eventsv1 "k8s.io/api/events/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func coreEvent(uid, namespace, kind, name, eventType, reason, message string, count int32, first, last time.Time) corev1.Event {
+	return corev1.Event{
+		ObjectMeta:     metav1.ObjectMeta{UID: types.UID(uid), Namespace: namespace},
+		InvolvedObject: corev1.ObjectReference{Kind: kind, Name: name, Namespace: namespace},
+		Type:           eventType,
+		Reason:         reason,
+		Message:        message,
+		Count:          count,
+		FirstTimestamp: metav1.NewTime(first),
+		LastTimestamp:  metav1.NewTime(last),
+		Source:         corev1.EventSource{Component: "kubelet"},
+	}
+}
+
+func TestMergeEvents_DedupesAndCollapses(t *testing.T) {
+	base := time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+	core := []corev1.Event{
+		coreEvent("a", "app", "Pod", "api-1", "Warning", "BackOff", "Back-off restarting failed container", 5, base, base.Add(10*time.Minute)),
+		coreEvent("b", "app", "Pod", "api-1", "Warning", "BackOff", "Back-off restarting failed container", 3, base.Add(-time.Hour), base.Add(20*time.Minute)),
+		coreEvent("c", "app", "Pod", "api-1", "Normal", "Pulled", "Image pulled", 1, base, base),
+	}
+	v1 := []eventsv1.Event{
+		// Same stored object as "a", served by the new API: must be dropped
+		{
+			ObjectMeta: metav1.ObjectMeta{UID: "a", Namespace: "app"},
+			Regarding:  corev1.ObjectReference{Kind: "Pod", Name: "api-1"},
+			Type:       "Warning",
+			Reason:     "BackOff",
+			Note:       "Back-off restarting failed container",
+			EventTime:  metav1.NewMicroTime(base),
+		},
+		// Series-based event only visible through events.k8s.io/v1
+		{
+			ObjectMeta:          metav1.ObjectMeta{UID: "d", Namespace: "app"},
+			Regarding:           corev1.ObjectReference{Kind: "Pod", Name: "api-2"},
+			Type:                "Warning",
+			Reason:              "FailedScheduling",
+			Note:                "0/3 nodes are available",
+			ReportingController: "default-scheduler",
+			EventTime:           metav1.NewMicroTime(base.Add(5 * time.Minute)),
+			Series: &eventsv1.EventSeries{
+				Count:            7,
+				LastObservedTime: metav1.NewMicroTime(base.Add(15 * time.Minute)),
+			},
+		},
+	}
+
+	events := MergeEvents(core, v1, EventFilter{})
+	if len(events) != 3 {
+		t.Fatalf("Expected 3 events after dedupe and collapse, got %d: %+v", len(events), events)
+	}
+
+	// Sorted oldest first by last seen
+	if events[0].Reason != "Pulled" || events[1].Reason != "FailedScheduling" || events[2].Reason != "BackOff" {
+		t.Errorf("Unexpected timeline order: %s, %s, %s", events[0].Reason, events[1].Reason, events[2].Reason)
+	}
+
+	backoff := events[2]
+	if backoff.Count != 8 || !backoff.FirstSeen.Equal(base.Add(-time.Hour)) || !backoff.LastSeen.Equal(base.Add(20*time.Minute)) {
+		t.Errorf("Expected collapsed BackOff with count 8 and widened window, got %+v", backoff)
+	}
+
+	scheduling := events[1]
+	if scheduling.Count != 7 || scheduling.Source != EventSourceEvents || scheduling.Reporter != "default-scheduler" {
+		t.Errorf("Expected series count from events.k8s.io/v1, got %+v", scheduling)
+	}
+}
+
+func TestMergeEvents_Filter(t *testing.T) {
+	base := time.Now()
+	core := []corev1.Event{
+		coreEvent("a", "app", "Pod", "api-1", "Warning", "BackOff", "restarting", 1, base.Add(-3*time.Hour), base.Add(-2*time.Hour)),
+		coreEvent("b", "app", "Pod", "api-1", "Warning", "Unhealthy", "probe failed", 1, base, base),
+		coreEvent("c", "app", "Deployment", "api", "Normal", "ScalingReplicaSet", "scaled", 1, base, base),
+	}
+
+	filter := EventFilter{Kind: "pod", Type: "warning", Since: base.Add(-time.Hour)}
+	events := MergeEvents(core, nil, filter)
+	if len(events) != 1 || events[0].Reason != "Unhealthy" {
+		t.Errorf("Expected only the recent Unhealthy pod warning, got %+v", events)
+	}
+}
+
+func TestSummarizeEventReasons(t *testing.T) {
+	base := time.Now()
+	events := []Event{
+		{Namespace: "a", Kind: "Pod", Name: "p1", Reason: "BackOff", Message: "old", Count: 2, LastSeen: base.Add(-time.Minute)},
+		{Namespace: "b", Kind: "Pod", Name: "p2", Reason: "BackOff", Message: "new", Count: 4, LastSeen: base},
+		{Namespace: "a", Kind: "Pod", Name: "p1", Reason: "Unhealthy", Message: "probe", Count: 1, LastSeen: base},
+	}
+
+	summaries := SummarizeEventReasons(events)
+	if len(summaries) != 2 || summaries[0].Reason != "BackOff" {
+		t.Fatalf("Expected BackOff first, got %+v", summaries)
+	}
+	top := summaries[0]
+	if top.Count != 6 || top.Objects != 2 || len(top.Namespaces) != 2 || top.LatestMessage != "new" {
+		t.Errorf("Unexpected BackOff summary: %+v", top)
+	}
+}
+
+func TestEventFieldSelectors(t *testing.T) {
+	tests := []struct {
+		filter       EventFilter
+		core, events string
+	}{
+		{EventFilter{}, "", ""},
+		{EventFilter{Type: "warning"}, "type=Warning", "type=Warning"},
+		{EventFilter{Name: "web-0", Type: "Warning"}, "involvedObject.name=web-0,type=Warning", "regarding.name=web-0,type=Warning"},
+		{EventFilter{Name: "web-0", Type: "Custom"}, "involvedObject.name=web-0", "regarding.name=web-0"},
+	}
+	for _, tt := range tests {
+		if got := involvedObjectSelector(tt.filter); got != tt.core {
+			t.Errorf("involvedObjectSelector(%+v) = %q, want %q", tt.filter, got, tt.core)
+		}
+		if got := regardingSelector(tt.filter); got != tt.events {
+			t.Errorf("regardingSelector(%+v) = %q, want %q", tt.filter, got, tt.events)
+		}
+	}
+}
@@ -3,22 +3,35 @@ package clients
 import (
 	"context"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"sync"
 	"time"
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
+
+	"github.com/KubeHeal/openshift-cluster-health-mcp/pkg/capacity"
+	"github.com/KubeHeal/openshift-cluster-health-mcp/pkg/health"
 )
 
 // K8sClient wraps the Kubernetes clientset with additional functionality
 type K8sClient struct {
-	clientset *kubernetes.Clientset
-	config    *rest.Config
+	clientset    *kubernetes.Clientset
+	config       *rest.Config
+	healthEngine *health.Engine
+
+	// Dynamic client for OpenShift CRDs, created lazily
+	dynamicOnce   sync.Once
+	dynamicClient dynamic.Interface
+	dynamicErr    error
 }
 
 // K8sClientConfig holds configuration for the Kubernetes client
@@ -75,8 +88,9 @@ func NewK8sClient(cfg *K8sClientConfig) (*K8sClient, error) {
 	}
 
 	client := &K8sClient{
-		clientset: clientset,
-		config:    config,
+		clientset:    clientset,
+		config:       config,
+		healthEngine: health.NewEngine(health.DefaultConfig()),
 	}
 
 	return client, nil
@@ -177,6 +191,15 @@ func (c *K8sClient) GetPod(ctx context.Context, namespace, name string) (*corev1
 	return pod, nil
 }
 
+// StreamPodLogs opens a log stream for a pod container; the caller must close it
+func (c *K8sClient) StreamPodLogs(ctx context.Context, namespace, name string, opts *corev1.PodLogOptions) (io.ReadCloser, error) {
+	stream, err := c.clientset.CoreV1().Pods(namespace).GetLogs(name, opts).Stream(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get logs for pod %s/%s: %w", namespace, name, err)
+	}
+	return stream, nil
+}
+
 // ListNamespaces returns all namespaces
 func (c *K8sClient) ListNamespaces(ctx context.Context) (*corev1.NamespaceList, error) {
 	namespaces, err := c.clientset.CoreV1().Namespaces().List(ctx, metav1.ListOptions{})
@@ -186,6 +209,15 @@ func (c *K8sClient) ListNamespaces(ctx context.Context) (*corev1.NamespaceList,
 	return namespaces, nil
 }
 
+// GetNamespace returns a namespace by name
+func (c *K8sClient) GetNamespace(ctx context.Context, name string) (*corev1.Namespace, error) {
+	namespace, err := c.clientset.CoreV1().Namespaces().Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get namespace %s: %w", name, err)
+	}
+	return namespace, nil
+}
+
 // ListEvents returns events in the specified namespace
 func (c *K8sClient) ListEvents(ctx context.Context, namespace string) (*corev1.EventList, error) {
 	events, err := c.clientset.CoreV1().Events(namespace).List(ctx, metav1.ListOptions{})
@@ -195,26 +227,112 @@ func (c *K8sClient) ListEvents(ctx context.Context, namespace string) (*corev1.E
 	return events, nil
 }
 
+// ListPersistentVolumeClaims returns PVCs in the specified namespace
+// If namespace is empty, returns PVCs from all namespaces
+func (c *K8sClient) ListPersistentVolumeClaims(ctx context.Context, namespace string) (*corev1.PersistentVolumeClaimList, error) {
+	pvcs, err := c.clientset.CoreV1().PersistentVolumeClaims(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list persistent volume claims in namespace %s: %w", namespace, err)
+	}
+	return pvcs, nil
+}
+
+// ListPersistentVolumes returns all cluster-scoped persistent volumes
+func (c *K8sClient) ListPersistentVolumes(ctx context.Context) (*corev1.PersistentVolumeList, error) {
+	pvs, err := c.clientset.CoreV1().PersistentVolumes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list persistent volumes: %w", err)
+	}
+	return pvs, nil
+}
+
+// ListStorageClasses returns all storage classes
+func (c *K8sClient) ListStorageClasses(ctx context.Context) (*storagev1.StorageClassList, error) {
+	classes, err := c.clientset.StorageV1().StorageClasses().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list storage classes: %w", err)
+	}
+	return classes, nil
+}
+
+// ListVolumeAttachments returns all CSI volume attachments
+func (c *K8sClient) ListVolumeAttachments(ctx context.Context) (*storagev1.VolumeAttachmentList, error) {
+	attachments, err := c.clientset.StorageV1().VolumeAttachments().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list volume attachments: %w", err)
+	}
+	return attachments, nil
+}
+
+// SetHealthEngine replaces the scoring engine used by GetClusterHealth
+func (c *K8sClient) SetHealthEngine(engine *health.Engine) {
+	c.healthEngine = engine
+}
+
+// HealthConfig returns the scoring configuration of the health engine
+func (c *K8sClient) HealthConfig() health.Config {
+	if c.healthEngine == nil {
+		return health.DefaultConfig()
+	}
+	return c.healthEngine.Config()
+}
+
 // GetClusterHealth returns a summary of cluster health
 func (c *K8sClient) GetClusterHealth(ctx context.Context) (*ClusterHealth, error) {
-	// Get nodes
+	snapshot, err := c.GetHealthSnapshot(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return c.SummarizeClusterHealth(snapshot), nil
+}
+
+// GetHealthSnapshot lists the objects the health scoring engine needs.
+// PVCs and ClusterOperators are optional: if they cannot be listed (RBAC,
+// non-OpenShift cluster) their dimension is reported as not observable
+// rather than failing the whole request.
+func (c *K8sClient) GetHealthSnapshot(ctx context.Context) (*health.Snapshot, error) {
 	nodes, err := c.ListNodes(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	// Get all pods
 	pods, err := c.ListPods(ctx, "")
 	if err != nil {
 		return nil, err
 	}
 
+	snapshot := &health.Snapshot{
+		Nodes: nodes.Items,
+		Pods:  pods.Items,
+	}
+	if pvcs, err := c.ListPersistentVolumeClaims(ctx, ""); err == nil {
+		snapshot.PVCs = pvcs.Items
+		snapshot.PVCsObserved = true
+	}
+	if operators, err := c.ListClusterOperators(ctx); err == nil {
+		for _, op := range operators {
+			snapshot.Operators = append(snapshot.Operators, health.OperatorStatus{
+				Name:      op.Name,
+				Available: op.Available,
+				Degraded:  op.Degraded,
+				Message:   op.Message,
+			})
+		}
+		snapshot.OperatorsObserved = true
+	}
+	return snapshot, nil
+}
+
+// SummarizeClusterHealth computes a ClusterHealth summary from an
+// already-listed snapshot, so callers that need the raw objects too only
+// list them once. Status and score come from the health scoring engine.
+func (c *K8sClient) SummarizeClusterHealth(snapshot *health.Snapshot) *ClusterHealth {
 	// Calculate node health
-	totalNodes := len(nodes.Items)
+	totalNodes := len(snapshot.Nodes)
 	readyNodes := 0
 	notReadyNodes := 0
 
-	for _, node := range nodes.Items {
+	for _, node := range snapshot.Nodes {
 		for _, condition := range node.Status.Conditions {
 			if condition.Type == corev1.NodeReady {
 				if condition.Status == corev1.ConditionTrue {
@@ -228,14 +346,14 @@ func (c *K8sClient) GetClusterHealth(ctx context.Context) (*ClusterHealth, error
 	}
 
 	// Calculate pod health
-	totalPods := len(pods.Items)
+	totalPods := len(snapshot.Pods)
 	runningPods := 0
 	pendingPods := 0
 	failedPods := 0
 	succeededPods := 0
 	unknownPods := 0
 
-	for _, pod := range pods.Items {
+	for _, pod := range snapshot.Pods {
 		switch pod.Status.Phase {
 		case corev1.PodRunning:
 			runningPods++
@@ -250,17 +368,15 @@ func (c *K8sClient) GetClusterHealth(ctx context.Context) (*ClusterHealth, error
 		}
 	}
 
-	// Determine overall health status
-	status := "healthy"
-	if notReadyNodes > 0 || failedPods > 0 {
-		status = "degraded"
-	}
-	if readyNodes == 0 || totalNodes == 0 {
-		status = "unhealthy"
+	engine := c.healthEngine
+	if engine == nil {
+		engine = health.NewEngine(health.DefaultConfig())
 	}
+	report := engine.Evaluate(snapshot)
 
 	return &ClusterHealth{
-		Status: status,
+		Status: report.Status,
+		Score:  report.Score,
 		Nodes: NodeHealth{
 			Total:    totalNodes,
 			Ready:    readyNodes,
@@ -274,14 +390,17 @@ func (c *K8sClient) GetClusterHealth(ctx context.Context) (*ClusterHealth, error
 			Succeeded: succeededPods,
 			Unknown:   unknownPods,
 		},
-	}, nil
+		Scoring: report,
+	}
 }
 
 // ClusterHealth represents the overall health of the cluster
 type ClusterHealth struct {
-	Status string     `json:"status"` // healthy, degraded, unhealthy
-	Nodes  NodeHealth `json:"nodes"`
-	Pods   PodHealth  `json:"pods"`
+	Status  string         `json:"status"` // healthy, degraded, unhealthy
+	Score   float64        `json:"score"`  // 0-100 weighted health score
+	Nodes   NodeHealth     `json:"nodes"`
+	Pods    PodHealth      `json:"pods"`
+	Scoring *health.Report `json:"scoring,omitempty"` // Per-dimension scores and findings
 }
 
 // NodeHealth represents node health metrics
@@ -327,10 +446,15 @@ type DeploymentInfo struct {
 	Namespace         string `json:"namespace"`
 	Replicas          int    `json:"replicas"`
 	AvailableReplicas int    `json:"available_replicas"`
-	CPURequest        int64  `json:"cpu_request_millicores"`
-	MemoryRequest     int64  `json:"memory_request_bytes"`
-	CPULimit          int64  `json:"cpu_limit_millicores"`
-	MemoryLimit       int64  `json:"memory_limit_bytes"`
+	// Requests and limits are per pod, not per container; a limit of 0 is
+	// unbounded because some container sets none
+	CPURequest        int64             `json:"cpu_request_millicores"`
+	MemoryRequest     int64             `json:"memory_request_bytes"`
+	CPULimit          int64             `json:"cpu_limit_millicores"`
+	MemoryLimit       int64             `json:"memory_limit_bytes"`
+	PriorityClassName string            `json:"priority_class_name,omitempty"`
+	Containers        int               `json:"containers"`
+	NodeSelector      map[string]string `json:"node_selector,omitempty"`
 }
 
 // GetDeployment returns deployment information
@@ -343,26 +467,20 @@ func (c *K8sClient) GetDeployment(ctx context.Context, namespace, name string) (
 	info := &DeploymentInfo{
 		Name:              deployment.Name,
 		Namespace:         deployment.Namespace,
-		Replicas:          int(*deployment.Spec.Replicas),
+		Replicas:          int(DesiredReplicas(deployment.Spec.Replicas)),
 		AvailableReplicas: int(deployment.Status.AvailableReplicas),
+		PriorityClassName: deployment.Spec.Template.Spec.PriorityClassName,
+		NodeSelector:      deployment.Spec.Template.Spec.NodeSelector,
 	}
 
-	// Extract resource requests/limits from the first container
-	if len(deployment.Spec.Template.Spec.Containers) > 0 {
-		container := deployment.Spec.Template.Spec.Containers[0]
-		if cpu := container.Resources.Requests.Cpu(); cpu != nil {
-			info.CPURequest = cpu.MilliValue()
-		}
-		if mem := container.Resources.Requests.Memory(); mem != nil {
-			info.MemoryRequest = mem.Value()
-		}
-		if cpu := container.Resources.Limits.Cpu(); cpu != nil {
-			info.CPULimit = cpu.MilliValue()
-		}
-		if mem := container.Resources.Limits.Memory(); mem != nil {
-			info.MemoryLimit = mem.Value()
-		}
-	}
+	// Effective pod requests/limits across app, sidecar and init containers
+	// plus overhead, as the scheduler and quota admission count them
+	resources := capacity.PodSpecResources(&deployment.Spec.Template.Spec)
+	info.CPURequest = resources.Requests.MilliCPU
+	info.MemoryRequest = resources.Requests.Memory
+	info.CPULimit = resources.Limits.MilliCPU
+	info.MemoryLimit = resources.Limits.Memory
+	info.Containers = resources.Containers
 
 	return info, nil
 }
@@ -376,80 +494,29 @@ func (c *K8sClient) ListDeployments(ctx context.Context, namespace string) (*app
 	return deployments, nil
 }
 
-// ResourceQuotaInfo represents resource quota information for a namespace
-type ResourceQuotaInfo struct {
-	Name                 string `json:"name"`
-	Namespace            string `json:"namespace"`
-	CPULimitMillicores   int64  `json:"cpu_limit_millicores"`
-	MemoryLimitBytes     int64  `json:"memory_limit_bytes"`
-	CPUUsedMillicores    int64  `json:"cpu_used_millicores"`
-	MemoryUsedBytes      int64  `json:"memory_used_bytes"`
-	CPURequestMillicores int64  `json:"cpu_request_millicores"`
-	MemoryRequestBytes   int64  `json:"memory_request_bytes"`
-	PodCountLimit        int    `json:"pod_count_limit"`
-	PodCountUsed         int    `json:"pod_count_used"`
-}
-
-// GetResourceQuota returns resource quota information for a namespace
-func (c *K8sClient) GetResourceQuota(ctx context.Context, namespace string) (*ResourceQuotaInfo, error) {
-	quotaList, err := c.clientset.CoreV1().ResourceQuotas(namespace).List(ctx, metav1.ListOptions{})
+// ListResourceQuotas returns all resource quotas in a namespace
+func (c *K8sClient) ListResourceQuotas(ctx context.Context, namespace string) (*corev1.ResourceQuotaList, error) {
+	quotas, err := c.clientset.CoreV1().ResourceQuotas(namespace).List(ctx, metav1.ListOptions{})
 	if err != nil {
 		return nil, fmt.Errorf("failed to list resource quotas in namespace %s: %w", namespace, err)
 	}
+	return quotas, nil
+}
 
-	if len(quotaList.Items) == 0 {
-		return nil, fmt.Errorf("no resource quota found in namespace %s", namespace)
-	}
-
-	// Use the first quota (typically there's only one)
-	quota := quotaList.Items[0]
-
-	info := &ResourceQuotaInfo{
-		Name:      quota.Name,
-		Namespace: quota.Namespace,
-	}
-
-	// Extract hard limits
-	if cpu, ok := quota.Spec.Hard[corev1.ResourceLimitsCPU]; ok {
-		info.CPULimitMillicores = cpu.MilliValue()
-	}
-	if mem, ok := quota.Spec.Hard[corev1.ResourceLimitsMemory]; ok {
-		info.MemoryLimitBytes = mem.Value()
-	}
-	if cpu, ok := quota.Spec.Hard[corev1.ResourceRequestsCPU]; ok {
-		info.CPURequestMillicores = cpu.MilliValue()
-	}
-	if mem, ok := quota.Spec.Hard[corev1.ResourceRequestsMemory]; ok {
-		info.MemoryRequestBytes = mem.Value()
-	}
-	// Extract pod count limit
-	if pods, ok := quota.Spec.Hard[corev1.ResourcePods]; ok {
-		info.PodCountLimit = int(pods.Value())
-	}
-
-	// Extract used values
-	if cpu, ok := quota.Status.Used[corev1.ResourceLimitsCPU]; ok {
-		info.CPUUsedMillicores = cpu.MilliValue()
-	}
-	if mem, ok := quota.Status.Used[corev1.ResourceLimitsMemory]; ok {
-		info.MemoryUsedBytes = mem.Value()
-	}
-	// Extract used pod count
-	if pods, ok := quota.Status.Used[corev1.ResourcePods]; ok {
-		info.PodCountUsed = int(pods.Value())
+// GetConfigMap returns a ConfigMap
+func (c *K8sClient) GetConfigMap(ctx context.Context, namespace, name string) (*corev1.ConfigMap, error) {
+	configMap, err := c.clientset.CoreV1().ConfigMaps(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get configmap %s/%s: %w", namespace, name, err)
 	}
+	return configMap, nil
+}
 
-	// If limits not set, use requests as fallback
-	if info.CPULimitMillicores == 0 && info.CPURequestMillicores > 0 {
-		info.CPULimitMillicores = info.CPURequestMillicores
-	}
-	if info.MemoryLimitBytes == 0 && info.MemoryRequestBytes > 0 {
-		info.MemoryLimitBytes = info.MemoryRequestBytes
-	}
-	// Default pod limit if not set
-	if info.PodCountLimit == 0 {
-		info.PodCountLimit = 100 // Default to 100 pods
+// ListLimitRanges returns all limit ranges in a namespace
+func (c *K8sClient) ListLimitRanges(ctx context.Context, namespace string) (*corev1.LimitRangeList, error) {
+	limitRanges, err := c.clientset.CoreV1().LimitRanges(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list limit ranges in namespace %s: %w", namespace, err)
 	}
-
-	return info, nil
+	return limitRanges, nil
 }
@@ -0,0 +1,120 @@
+package clients
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// Node annotations maintained by the machine-config-daemon
+const (
+	MCOCurrentConfigAnnotation = "machineconfiguration.openshift.io/currentConfig"
+	MCODesiredConfigAnnotation = "machineconfiguration.openshift.io/desiredConfig"
+	MCOStateAnnotation         = "machineconfiguration.openshift.io/state"
+	MCOReasonAnnotation        = "machineconfiguration.openshift.io/reason"
+)
+
+var machineConfigPoolGVR = schema.GroupVersionResource{
+	Group:    "machineconfiguration.openshift.io",
+	Version:  "v1",
+	Resource: "machineconfigpools",
+}
+
+// MachineConfigPool summarizes a machineconfiguration.openshift.io/v1 MachineConfigPool
+type MachineConfigPool struct {
+	Name                    string             `json:"name"`
+	Paused                  bool               `json:"paused"`
+	CurrentConfig           string             `json:"current_config,omitempty"` // status.configuration.name
+	DesiredConfig           string             `json:"desired_config,omitempty"` // spec.configuration.name
+	MachineCount            int64              `json:"machine_count"`
+	UpdatedMachineCount     int64              `json:"updated_machine_count"`
+	ReadyMachineCount       int64              `json:"ready_machine_count"`
+	UnavailableMachineCount int64              `json:"unavailable_machine_count"`
+	DegradedMachineCount    int64              `json:"degraded_machine_count"`
+	Updated                 bool               `json:"updated"`
+	Updating                bool               `json:"updating"`
+	Degraded                bool               `json:"degraded"`
+	Message                 string             `json:"message,omitempty"` // Message of the Degraded condition
+	NodeSelector            labels.Selector    `json:"-"`
+	Conditions              []ClusterCondition `json:"conditions,omitempty"`
+}
+
+// ListMachineConfigPools returns all MachineConfigPools sorted by name.
+// On non-OpenShift clusters the returned error satisfies apierrors.IsNotFound.
+func (c *K8sClient) ListMachineConfigPools(ctx context.Context) ([]MachineConfigPool, error) {
+	dyn, err := c.getDynamicClient()
+	if err != nil {
+		return nil, err
+	}
+
+	list, err := dyn.Resource(machineConfigPoolGVR).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list machineconfigpools: %w", err)
+	}
+
+	pools := make([]MachineConfigPool, 0, len(list.Items))
+	for i := range list.Items {
+		pools = append(pools, convertMachineConfigPool(&list.Items[i]))
+	}
+	sort.Slice(pools, func(i, j int) bool {
+		return pools[i].Name < pools[j].Name
+	})
+	return pools, nil
+}
+
+// convertMachineConfigPool converts an unstructured MachineConfigPool
+func convertMachineConfigPool(obj *unstructured.Unstructured) MachineConfigPool {
+	pool := MachineConfigPool{
+		Name:       obj.GetName(),
+		Conditions: extractConditions(obj.Object, "status", "conditions"),
+	}
+
+	pool.Paused, _, _ = unstructured.NestedBool(obj.Object, "spec", "paused")
+	pool.DesiredConfig, _, _ = unstructured.NestedString(obj.Object, "spec", "configuration", "name")
+	pool.CurrentConfig, _, _ = unstructured.NestedString(obj.Object, "status", "configuration", "name")
+	pool.MachineCount, _, _ = unstructured.NestedInt64(obj.Object, "status", "machineCount")
+	pool.UpdatedMachineCount, _, _ = unstructured.NestedInt64(obj.Object, "status", "updatedMachineCount")
+	pool.ReadyMachineCount, _, _ = unstructured.NestedInt64(obj.Object, "status", "readyMachineCount")
+	pool.UnavailableMachineCount, _, _ = unstructured.NestedInt64(obj.Object, "status", "unavailableMachineCount")
+	pool.DegradedMachineCount, _, _ = unstructured.NestedInt64(obj.Object, "status", "degradedMachineCount")
+
+	if raw, found, err := unstructured.NestedMap(obj.Object, "spec", "nodeSelector"); found && err == nil {
+		var selector metav1.LabelSelector
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(raw, &selector); err == nil {
+			if s, err := metav1.LabelSelectorAsSelector(&selector); err == nil {
+				pool.NodeSelector = s
+			}
+		}
+	}
+
+	for _, cond := range pool.Conditions {
+		isTrue := cond.Status == "True"
+		switch cond.Type {
+		case "Updated":
+			pool.Updated = isTrue
+		case "Updating":
+			pool.Updating = isTrue
+		case "Degraded":
+			pool.Degraded = isTrue
+			if isTrue {
+				pool.Message = cond.Message
+			}
+		}
+	}
+
+	return pool
+}
+
+// MatchesNode reports whether the pool's node selector selects the given labels
+func (p *MachineConfigPool) MatchesNode(nodeLabels map[string]string) bool {
+	if p.NodeSelector == nil || p.NodeSelector.Empty() {
+		return false
+	}
+	return p.NodeSelector.Matches(labels.Set(nodeLabels))
+}
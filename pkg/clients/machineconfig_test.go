@@ -0,0 +1,51 @@
+package clients
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestConvertMachineConfigPool(t *testing.T) {
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{
+		"metadata": map[string]interface{}{"name": "worker"},
+		"spec": map[string]interface{}{
+			"paused":        true,
+			"configuration": map[string]interface{}{"name": "rendered-worker-b"},
+			"nodeSelector": map[string]interface{}{
+				"matchLabels": map[string]interface{}{"node-role.kubernetes.io/worker": ""},
+			},
+		},
+		"status": map[string]interface{}{
+			"configuration":           map[string]interface{}{"name": "rendered-worker-a"},
+			"machineCount":            int64(3),
+			"updatedMachineCount":     int64(1),
+			"readyMachineCount":       int64(1),
+			"unavailableMachineCount": int64(1),
+			"degradedMachineCount":    int64(1),
+			"conditions": []interface{}{
+				condition("Updated", "False", ""),
+				condition("Updating", "True", ""),
+				condition("Degraded", "True", "Node worker-2 is reporting: failed to drain"),
+			},
+		},
+	}}
+
+	pool := convertMachineConfigPool(obj)
+
+	if !pool.Paused || pool.DesiredConfig != "rendered-worker-b" || pool.CurrentConfig != "rendered-worker-a" {
+		t.Errorf("Unexpected spec/config fields: %+v", pool)
+	}
+	if pool.MachineCount != 3 || pool.UpdatedMachineCount != 1 || pool.DegradedMachineCount != 1 {
+		t.Errorf("Unexpected machine counts: %+v", pool)
+	}
+	if pool.Updated || !pool.Updating || !pool.Degraded || pool.Message == "" {
+		t.Errorf("Unexpected condition flags: %+v", pool)
+	}
+	if !pool.MatchesNode(map[string]string{"node-role.kubernetes.io/worker": ""}) {
+		t.Error("Expected pool to match worker nodes")
+	}
+	if pool.MatchesNode(map[string]string{"node-role.kubernetes.io/master": ""}) {
+		t.Error("Expected pool not to match master nodes")
+	}
+}
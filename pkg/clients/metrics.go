@@ -0,0 +1,79 @@
+package clients
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// ContainerMetrics is a point-in-time usage sample of one container from the
+// metrics.k8s.io API
+type ContainerMetrics struct {
+	Namespace     string    `json:"namespace"`
+	Pod           string    `json:"pod"`
+	Container     string    `json:"container"`
+	CPUMillicores int64     `json:"cpu_millicores"`
+	MemoryBytes   int64     `json:"memory_bytes"`
+	Timestamp     time.Time `json:"timestamp"`
+}
+
+// podMetricsList mirrors metrics.k8s.io/v1beta1 PodMetricsList; the metrics
+// client module is not a dependency, so the response is decoded directly
+type podMetricsList struct {
+	Items []struct {
+		Metadata struct {
+			Name      string `json:"name"`
+			Namespace string `json:"namespace"`
+		} `json:"metadata"`
+		Timestamp  time.Time `json:"timestamp"`
+		Containers []struct {
+			Name  string            `json:"name"`
+			Usage map[string]string `json:"usage"`
+		} `json:"containers"`
+	} `json:"items"`
+}
+
+// ListContainerMetrics returns current container usage from the metrics API
+// If namespace is empty, returns metrics from all namespaces
+func (c *K8sClient) ListContainerMetrics(ctx context.Context, namespace string) ([]ContainerMetrics, error) {
+	path := "/apis/metrics.k8s.io/v1beta1/pods"
+	if namespace != "" {
+		path = "/apis/metrics.k8s.io/v1beta1/namespaces/" + namespace + "/pods"
+	}
+	raw, err := c.clientset.Discovery().RESTClient().Get().AbsPath(path).DoRaw(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pod metrics (is metrics-server available?): %w", err)
+	}
+	return parsePodMetrics(raw)
+}
+
+// parsePodMetrics flattens a PodMetricsList into per-container samples
+func parsePodMetrics(raw []byte) ([]ContainerMetrics, error) {
+	var list podMetricsList
+	if err := json.Unmarshal(raw, &list); err != nil {
+		return nil, fmt.Errorf("failed to decode pod metrics: %w", err)
+	}
+
+	var metrics []ContainerMetrics
+	for _, item := range list.Items {
+		for _, container := range item.Containers {
+			sample := ContainerMetrics{
+				Namespace: item.Metadata.Namespace,
+				Pod:       item.Metadata.Name,
+				Container: container.Name,
+				Timestamp: item.Timestamp,
+			}
+			if q, err := resource.ParseQuantity(container.Usage["cpu"]); err == nil {
+				sample.CPUMillicores = q.MilliValue()
+			}
+			if q, err := resource.ParseQuantity(container.Usage["memory"]); err == nil {
+				sample.MemoryBytes = q.Value()
+			}
+			metrics = append(metrics, sample)
+		}
+	}
+	return metrics, nil
+}
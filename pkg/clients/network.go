@@ -0,0 +1,30 @@
+package clients
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ListServices returns all services in a namespace
+// If namespace is empty, returns services from all namespaces
+func (c *K8sClient) ListServices(ctx context.Context, namespace string) (*corev1.ServiceList, error) {
+	services, err := c.clientset.CoreV1().Services(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list services in namespace %s: %w", namespace, err)
+	}
+	return services, nil
+}
+
+// ListEndpointSlices returns all endpoint slices in a namespace
+// If namespace is empty, returns endpoint slices from all namespaces
+func (c *K8sClient) ListEndpointSlices(ctx context.Context, namespace string) (*discoveryv1.EndpointSliceList, error) {
+	slices, err := c.clientset.DiscoveryV1().EndpointSlices(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list endpointslices in namespace %s: %w", namespace, err)
+	}
+	return slices, nil
+}
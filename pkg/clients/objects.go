@@ -0,0 +1,56 @@
+package clients
+
+import (
+	"context"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// ObjectCountResources are the resource types counted to estimate control
+// plane (etcd/API server) load when Prometheus is not available
+var ObjectCountResources = []schema.GroupVersionResource{
+	{Version: "v1", Resource: "pods"},
+	{Version: "v1", Resource: "events"},
+	{Version: "v1", Resource: "services"},
+	{Version: "v1", Resource: "configmaps"},
+	{Version: "v1", Resource: "namespaces"},
+	{Version: "v1", Resource: "nodes"},
+	{Version: "v1", Resource: "persistentvolumeclaims"},
+	{Group: "apps", Version: "v1", Resource: "deployments"},
+	{Group: "apps", Version: "v1", Resource: "replicasets"},
+	{Group: "apps", Version: "v1", Resource: "statefulsets"},
+	{Group: "apps", Version: "v1", Resource: "daemonsets"},
+}
+
+// CountObjects returns the number of objects of a resource type across all
+// namespaces. It lists a single item and uses the server's remaining item
+// count, so the cost does not grow with the number of objects.
+func (c *K8sClient) CountObjects(ctx context.Context, gvr schema.GroupVersionResource) (int64, error) {
+	dyn, err := c.getDynamicClient()
+	if err != nil {
+		return 0, err
+	}
+
+	list, err := dyn.Resource(gvr).List(ctx, metav1.ListOptions{Limit: 1})
+	if err != nil {
+		return 0, fmt.Errorf("failed to count %s: %w", gvr.Resource, err)
+	}
+	count := int64(len(list.Items))
+	if remaining := list.GetRemainingItemCount(); remaining != nil {
+		count += *remaining
+	} else if list.GetContinue() != "" {
+		return 0, fmt.Errorf("server did not report a remaining item count for %s", gvr.Resource)
+	}
+	return count, nil
+}
+
+// ResourceKey returns the name Prometheus' apiserver_storage_objects uses for
+// a resource type, e.g. "pods" or "deployments.apps"
+func ResourceKey(gvr schema.GroupVersionResource) string {
+	if gvr.Group == "" {
+		return gvr.Resource
+	}
+	return gvr.Resource + "." + gvr.Group
+}
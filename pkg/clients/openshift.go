@@ -0,0 +1,349 @@
+package clients
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+)
+
+// GVRs for OpenShift config.openshift.io resources
+var (
+	clusterOperatorGVR = schema.GroupVersionResource{
+		Group:    "config.openshift.io",
+		Version:  "v1",
+		Resource: "clusteroperators",
+	}
+	clusterVersionGVR = schema.GroupVersionResource{
+		Group:    "config.openshift.io",
+		Version:  "v1",
+		Resource: "clusterversions",
+	}
+)
+
+// ClusterCondition is a status condition on an OpenShift config resource
+type ClusterCondition struct {
+	Type               string     `json:"type"`
+	Status             string     `json:"status"`
+	Reason             string     `json:"reason,omitempty"`
+	Message            string     `json:"message,omitempty"`
+	LastTransitionTime *time.Time `json:"last_transition_time,omitempty"`
+}
+
+// ClusterOperator summarizes a config.openshift.io/v1 ClusterOperator
+type ClusterOperator struct {
+	Name        string             `json:"name"`
+	Version     string             `json:"version,omitempty"`
+	Available   bool               `json:"available"`
+	Progressing bool               `json:"progressing"`
+	Degraded    bool               `json:"degraded"`
+	Upgradeable *bool              `json:"upgradeable,omitempty"`
+	Message     string             `json:"message,omitempty"` // Message of the most relevant failing condition
+	Since       *time.Time         `json:"since,omitempty"`   // Last transition of the most relevant condition
+	Conditions  []ClusterCondition `json:"conditions,omitempty"`
+}
+
+// Healthy reports whether the operator is Available and not Degraded
+func (o *ClusterOperator) Healthy() bool {
+	return o.Available && !o.Degraded
+}
+
+// ClusterVersion summarizes the config.openshift.io/v1 ClusterVersion "version"
+type ClusterVersion struct {
+	Version             string               `json:"version"`
+	DesiredVersion      string               `json:"desired_version,omitempty"`
+	Channel             string               `json:"channel,omitempty"`
+	ClusterID           string               `json:"cluster_id,omitempty"`
+	Progressing         bool                 `json:"progressing"`
+	History             []UpdateHistoryEntry `json:"history,omitempty"`
+	FailingConditions   []ClusterCondition   `json:"failing_conditions,omitempty"`
+	AvailableUpdates    []string             `json:"available_updates,omitempty"`
+	UpdatesNotRetrieved string               `json:"updates_not_retrieved,omitempty"` // RetrievedUpdates=False message; informational, normal when disconnected or without a channel
+	Conditions          []ClusterCondition   `json:"conditions,omitempty"`
+}
+
+// UpdateHistoryEntry is one entry of the ClusterVersion update history
+type UpdateHistoryEntry struct {
+	Version        string     `json:"version"`
+	State          string     `json:"state"` // Completed or Partial
+	StartedTime    *time.Time `json:"started_time,omitempty"`
+	CompletionTime *time.Time `json:"completion_time,omitempty"`
+}
+
+// OperatorSummary counts ClusterOperators by state
+type OperatorSummary struct {
+	Total       int      `json:"total"`
+	Available   int      `json:"available"`
+	Unavailable int      `json:"unavailable"`
+	Degraded    int      `json:"degraded"`
+	Progressing int      `json:"progressing"`
+	Unhealthy   []string `json:"unhealthy,omitempty"` // Names of unavailable or degraded operators
+}
+
+// OperatorStatusReport combines ClusterVersion and ClusterOperator state
+type OperatorStatusReport struct {
+	Status         string            `json:"status"` // healthy, degraded, unhealthy
+	Updating       bool              `json:"updating"`
+	ClusterVersion *ClusterVersion   `json:"cluster_version,omitempty"`
+	Summary        OperatorSummary   `json:"summary"`
+	Operators      []ClusterOperator `json:"operators"`
+}
+
+// getDynamicClient returns the dynamic client for CRDs, creating it on first use
+func (c *K8sClient) getDynamicClient() (dynamic.Interface, error) {
+	c.dynamicOnce.Do(func() {
+		c.dynamicClient, c.dynamicErr = dynamic.NewForConfig(c.config)
+	})
+	if c.dynamicErr != nil {
+		return nil, fmt.Errorf("failed to create dynamic client: %w", c.dynamicErr)
+	}
+	return c.dynamicClient, nil
+}
+
+// ListClusterOperators returns all OpenShift ClusterOperators sorted by name.
+// On non-OpenShift clusters the returned error satisfies apierrors.IsNotFound.
+func (c *K8sClient) ListClusterOperators(ctx context.Context) ([]ClusterOperator, error) {
+	dyn, err := c.getDynamicClient()
+	if err != nil {
+		return nil, err
+	}
+
+	list, err := dyn.Resource(clusterOperatorGVR).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list clusteroperators: %w", err)
+	}
+
+	operators := make([]ClusterOperator, 0, len(list.Items))
+	for i := range list.Items {
+		operators = append(operators, convertClusterOperator(&list.Items[i]))
+	}
+	sort.Slice(operators, func(i, j int) bool {
+		return operators[i].Name < operators[j].Name
+	})
+	return operators, nil
+}
+
+// GetClusterVersion returns the OpenShift ClusterVersion "version" singleton.
+// On non-OpenShift clusters the returned error satisfies apierrors.IsNotFound.
+func (c *K8sClient) GetClusterVersion(ctx context.Context) (*ClusterVersion, error) {
+	dyn, err := c.getDynamicClient()
+	if err != nil {
+		return nil, err
+	}
+
+	obj, err := dyn.Resource(clusterVersionGVR).Get(ctx, "version", metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get clusterversion: %w", err)
+	}
+	return convertClusterVersion(obj), nil
+}
+
+// GetOperatorStatus reads ClusterOperators and ClusterVersion. The
+// ClusterVersion is optional (e.g. restricted RBAC); ClusterOperators are not.
+func (c *K8sClient) GetOperatorStatus(ctx context.Context) (*OperatorStatusReport, error) {
+	operators, err := c.ListClusterOperators(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &OperatorStatusReport{
+		Summary:   SummarizeClusterOperators(operators),
+		Operators: operators,
+	}
+	if cv, err := c.GetClusterVersion(ctx); err == nil {
+		report.ClusterVersion = cv
+		report.Updating = cv.Progressing
+	}
+
+	report.Status = "healthy"
+	if report.Summary.Degraded > 0 || (report.ClusterVersion != nil && len(report.ClusterVersion.FailingConditions) > 0) {
+		report.Status = "degraded"
+	}
+	if report.Summary.Unavailable > 0 {
+		report.Status = "unhealthy"
+	}
+	return report, nil
+}
+
+// SummarizeClusterOperators counts operators by state
+func SummarizeClusterOperators(operators []ClusterOperator) OperatorSummary {
+	summary := OperatorSummary{Total: len(operators)}
+	for _, op := range operators {
+		if op.Available {
+			summary.Available++
+		} else {
+			summary.Unavailable++
+		}
+		if op.Degraded {
+			summary.Degraded++
+		}
+		if op.Progressing {
+			summary.Progressing++
+		}
+		if !op.Healthy() {
+			summary.Unhealthy = append(summary.Unhealthy, op.Name)
+		}
+	}
+	return summary
+}
+
+// convertClusterOperator converts an unstructured ClusterOperator
+func convertClusterOperator(obj *unstructured.Unstructured) ClusterOperator {
+	op := ClusterOperator{
+		Name:       obj.GetName(),
+		Conditions: extractConditions(obj.Object, "status", "conditions"),
+	}
+
+	// The "operator" entry carries the operator's own version
+	versions, _, _ := unstructured.NestedSlice(obj.Object, "status", "versions")
+	for _, v := range versions {
+		if vm, ok := v.(map[string]interface{}); ok && getString(vm, "name") == "operator" {
+			op.Version = getString(vm, "version")
+		}
+	}
+
+	var available, degraded *ClusterCondition
+	for i := range op.Conditions {
+		cond := &op.Conditions[i]
+		isTrue := cond.Status == "True"
+		switch cond.Type {
+		case "Available":
+			op.Available = isTrue
+			available = cond
+		case "Progressing":
+			op.Progressing = isTrue
+		case "Degraded":
+			op.Degraded = isTrue
+			degraded = cond
+		case "Upgradeable":
+			upgradeable := isTrue
+			op.Upgradeable = &upgradeable
+		}
+	}
+
+	// Explain the worst state: unavailable first, then degraded
+	switch {
+	case !op.Available && available != nil:
+		op.Message, op.Since = conditionMessage(available), available.LastTransitionTime
+	case op.Degraded && degraded != nil:
+		op.Message, op.Since = conditionMessage(degraded), degraded.LastTransitionTime
+	case !op.Available:
+		op.Message = "Available condition not reported"
+	}
+
+	return op
+}
+
+// convertClusterVersion converts an unstructured ClusterVersion
+func convertClusterVersion(obj *unstructured.Unstructured) *ClusterVersion {
+	cv := &ClusterVersion{
+		Conditions: extractConditions(obj.Object, "status", "conditions"),
+	}
+	cv.Channel, _, _ = unstructured.NestedString(obj.Object, "spec", "channel")
+	cv.ClusterID, _, _ = unstructured.NestedString(obj.Object, "spec", "clusterID")
+	cv.DesiredVersion, _, _ = unstructured.NestedString(obj.Object, "status", "desired", "version")
+
+	history, _, _ := unstructured.NestedSlice(obj.Object, "status", "history")
+	for _, h := range history {
+		hm, ok := h.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		cv.History = append(cv.History, UpdateHistoryEntry{
+			Version:        getString(hm, "version"),
+			State:          getString(hm, "state"),
+			StartedTime:    parseTime(getString(hm, "startedTime")),
+			CompletionTime: parseTime(getString(hm, "completionTime")),
+		})
+	}
+
+	// The current version is the most recent completed update; history is newest first
+	for _, h := range cv.History {
+		if h.State == "Completed" {
+			cv.Version = h.Version
+			break
+		}
+	}
+	if cv.Version == "" {
+		cv.Version = cv.DesiredVersion
+	}
+
+	updates, _, _ := unstructured.NestedSlice(obj.Object, "status", "availableUpdates")
+	for _, u := range updates {
+		if um, ok := u.(map[string]interface{}); ok {
+			if version := getString(um, "version"); version != "" {
+				cv.AvailableUpdates = append(cv.AvailableUpdates, version)
+			}
+		}
+	}
+
+	for _, cond := range cv.Conditions {
+		isTrue := cond.Status == "True"
+		switch cond.Type {
+		case "Progressing":
+			cv.Progressing = isTrue
+		case "Failing", "Degraded":
+			if isTrue {
+				cv.FailingConditions = append(cv.FailingConditions, cond)
+			}
+		case "Available", "ReleaseAccepted":
+			if cond.Status == "False" {
+				cv.FailingConditions = append(cv.FailingConditions, cond)
+			}
+		case "RetrievedUpdates":
+			if cond.Status == "False" {
+				cv.UpdatesNotRetrieved = conditionMessage(&cond)
+			}
+		}
+	}
+
+	return cv
+}
+
+// extractConditions reads a standard conditions list from an unstructured object
+func extractConditions(obj map[string]interface{}, fields ...string) []ClusterCondition {
+	raw, found, err := unstructured.NestedSlice(obj, fields...)
+	if !found || err != nil {
+		return nil
+	}
+
+	conditions := make([]ClusterCondition, 0, len(raw))
+	for _, c := range raw {
+		cm, ok := c.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		conditions = append(conditions, ClusterCondition{
+			Type:               getString(cm, "type"),
+			Status:             getString(cm, "status"),
+			Reason:             getString(cm, "reason"),
+			Message:            getString(cm, "message"),
+			LastTransitionTime: parseTime(getString(cm, "lastTransitionTime")),
+		})
+	}
+	return conditions
+}
+
+func conditionMessage(cond *ClusterCondition) string {
+	if cond.Message != "" {
+		return cond.Message
+	}
+	return cond.Reason
+}
+
+// parseTime parses an RFC3339 timestamp, returning nil if empty or invalid
+func parseTime(value string) *time.Time {
+	if value == "" {
+		return nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil
+	}
+	return &t
+}
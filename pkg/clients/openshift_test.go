@@ -0,0 +1,129 @@
+package clients
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func condition(condType, status, message string) interface{} {
+	return map[string]interface{}{
+		"type":               condType,
+		"status":             status,
+		"message":            message,
+		"lastTransitionTime": "2026-01-01T10:00:00Z",
+	}
+}
+
+func TestConvertClusterOperator(t *testing.T) {
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{
+		"metadata": map[string]interface{}{"name": "ingress"},
+		"status": map[string]interface{}{
+			"conditions": []interface{}{
+				condition("Available", "True", ""),
+				condition("Progressing", "False", ""),
+				condition("Degraded", "True", "router pods crashing"),
+				condition("Upgradeable", "False", ""),
+			},
+			"versions": []interface{}{
+				map[string]interface{}{"name": "operator", "version": "4.16.3"},
+				map[string]interface{}{"name": "router", "version": "4.16.3-router"},
+			},
+		},
+	}}
+
+	op := convertClusterOperator(obj)
+
+	if op.Name != "ingress" || op.Version != "4.16.3" {
+		t.Errorf("Unexpected name/version: %s %s", op.Name, op.Version)
+	}
+	if !op.Available || op.Progressing || !op.Degraded {
+		t.Errorf("Unexpected condition flags: %+v", op)
+	}
+	if op.Upgradeable == nil || *op.Upgradeable {
+		t.Error("Expected Upgradeable=false")
+	}
+	if op.Message != "router pods crashing" || op.Since == nil {
+		t.Errorf("Expected degraded message and since, got %q %v", op.Message, op.Since)
+	}
+	if op.Healthy() {
+		t.Error("Expected degraded operator to be unhealthy")
+	}
+}
+
+func TestConvertClusterOperator_NoConditions(t *testing.T) {
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{
+		"metadata": map[string]interface{}{"name": "new-operator"},
+	}}
+
+	op := convertClusterOperator(obj)
+	if op.Available || op.Message == "" {
+		t.Errorf("Expected operator without conditions to be unavailable with an explanation, got %+v", op)
+	}
+}
+
+func TestConvertClusterVersion(t *testing.T) {
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{
+		"metadata": map[string]interface{}{"name": "version"},
+		"spec": map[string]interface{}{
+			"channel":   "stable-4.16",
+			"clusterID": "abc-123",
+		},
+		"status": map[string]interface{}{
+			"desired": map[string]interface{}{"version": "4.16.4"},
+			"history": []interface{}{
+				map[string]interface{}{"version": "4.16.4", "state": "Partial", "startedTime": "2026-01-02T00:00:00Z"},
+				map[string]interface{}{"version": "4.16.3", "state": "Completed", "startedTime": "2026-01-01T00:00:00Z", "completionTime": "2026-01-01T01:00:00Z"},
+			},
+			"availableUpdates": []interface{}{
+				map[string]interface{}{"version": "4.16.5", "image": "quay.io/x"},
+			},
+			"conditions": []interface{}{
+				condition("Available", "True", ""),
+				condition("Progressing", "True", "Working towards 4.16.4"),
+				condition("Failing", "True", "Cluster operator ingress is degraded"),
+				condition("RetrievedUpdates", "False", "The update channel has not been configured."),
+			},
+		},
+	}}
+
+	cv := convertClusterVersion(obj)
+
+	if cv.Version != "4.16.3" || cv.DesiredVersion != "4.16.4" {
+		t.Errorf("Expected current 4.16.3 and desired 4.16.4, got %s %s", cv.Version, cv.DesiredVersion)
+	}
+	if cv.Channel != "stable-4.16" || cv.ClusterID != "abc-123" {
+		t.Errorf("Unexpected spec fields: %+v", cv)
+	}
+	if !cv.Progressing {
+		t.Error("Expected Progressing=true")
+	}
+	if len(cv.History) != 2 || cv.History[1].CompletionTime == nil {
+		t.Errorf("Unexpected history: %+v", cv.History)
+	}
+	if len(cv.AvailableUpdates) != 1 || cv.AvailableUpdates[0] != "4.16.5" {
+		t.Errorf("Unexpected available updates: %v", cv.AvailableUpdates)
+	}
+	if len(cv.FailingConditions) != 1 || cv.FailingConditions[0].Type != "Failing" {
+		t.Errorf("Expected one failing condition, got %+v", cv.FailingConditions)
+	}
+	if cv.UpdatesNotRetrieved != "The update channel has not been configured." {
+		t.Errorf("Expected RetrievedUpdates=False reported as informational, got %q", cv.UpdatesNotRetrieved)
+	}
+}
+
+func TestSummarizeClusterOperators(t *testing.T) {
+	summary := SummarizeClusterOperators([]ClusterOperator{
+		{Name: "authentication", Available: true},
+		{Name: "ingress", Available: true, Degraded: true},
+		{Name: "monitoring", Available: false, Progressing: true},
+	})
+
+	if summary.Total != 3 || summary.Available != 2 || summary.Unavailable != 1 ||
+		summary.Degraded != 1 || summary.Progressing != 1 {
+		t.Errorf("Unexpected summary counts: %+v", summary)
+	}
+	if len(summary.Unhealthy) != 2 {
+		t.Errorf("Expected 2 unhealthy operators, got %v", summary.Unhealthy)
+	}
+}
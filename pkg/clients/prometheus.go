@@ -0,0 +1,168 @@
+package clients
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultServiceCAFile is the OpenShift service CA bundle mounted into every pod;
+// it signs the certificates of in-cluster monitoring endpoints
+const DefaultServiceCAFile = "/var/run/secrets/kubernetes.io/serviceaccount/service-ca.crt"
+
+// PrometheusClient runs instant PromQL queries against the Prometheus HTTP API
+type PrometheusClient struct {
+	baseURL         string
+	httpClient      *http.Client
+	bearerToken     string
+	bearerTokenFile string
+}
+
+// PrometheusConfig holds configuration for the Prometheus client
+type PrometheusConfig struct {
+	URL     string
+	Timeout time.Duration
+	// BearerToken or BearerTokenFile authenticate the request; the file is
+	// re-read on every query so rotated service account tokens keep working
+	BearerToken     string
+	BearerTokenFile string
+	// CAFile is an extra CA bundle trusted in addition to the system roots
+	CAFile string
+}
+
+// PrometheusSample is one series of an instant vector result
+type PrometheusSample struct {
+	Labels map[string]string `json:"labels"`
+	Value  float64           `json:"value"`
+}
+
+// NewPrometheusClient creates a new Prometheus client
+func NewPrometheusClient(config PrometheusConfig) (*PrometheusClient, error) {
+	if config.URL == "" {
+		return nil, fmt.Errorf("prometheus URL is required")
+	}
+	timeout := config.Timeout
+	if timeout == 0 {
+		timeout = 30 * time.Second
+	}
+
+	roots, err := x509.SystemCertPool()
+	if err != nil || roots == nil {
+		roots = x509.NewCertPool()
+	}
+	if config.CAFile != "" {
+		pem, err := os.ReadFile(config.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read Prometheus CA file %s: %w", config.CAFile, err)
+		}
+		if !roots.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in Prometheus CA file %s", config.CAFile)
+		}
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = &tls.Config{RootCAs: roots, MinVersion: tls.VersionTLS12}
+
+	return &PrometheusClient{
+		baseURL:         strings.TrimSuffix(config.URL, "/"),
+		httpClient:      &http.Client{Timeout: timeout, Transport: transport},
+		bearerToken:     config.BearerToken,
+		bearerTokenFile: config.BearerTokenFile,
+	}, nil
+}
+
+// prometheusResponse is the envelope of /api/v1/query responses
+type prometheusResponse struct {
+	Status    string `json:"status"`
+	ErrorType string `json:"errorType"`
+	Error     string `json:"error"`
+	Data      struct {
+		ResultType string `json:"resultType"`
+		Result     []struct {
+			Metric map[string]string `json:"metric"`
+			Value  []interface{}     `json:"value"`
+		} `json:"result"`
+	} `json:"data"`
+}
+
+// Query evaluates an instant PromQL query and returns the vector samples
+func (c *PrometheusClient) Query(ctx context.Context, query string) ([]PrometheusSample, error) {
+	form := url.Values{"query": {query}}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+"/api/v1/query", strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	if token := c.token(); token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query Prometheus: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }() //nolint:errcheck // Best effort cleanup
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read Prometheus response: %w", err)
+	}
+	return parsePrometheusResponse(resp.StatusCode, body)
+}
+
+// parsePrometheusResponse decodes an instant vector response
+func parsePrometheusResponse(statusCode int, body []byte) ([]PrometheusSample, error) {
+	var parsed prometheusResponse
+	if err := json.Unmarshal(body, &parsed); err != nil {
+		return nil, fmt.Errorf("prometheus returned status %d: %s", statusCode, truncate(string(body), 200))
+	}
+	if parsed.Status != "success" {
+		return nil, fmt.Errorf("prometheus query failed (%s): %s", parsed.ErrorType, parsed.Error)
+	}
+	if parsed.Data.ResultType != "vector" {
+		return nil, fmt.Errorf("unexpected Prometheus result type %q, expected vector", parsed.Data.ResultType)
+	}
+
+	samples := make([]PrometheusSample, 0, len(parsed.Data.Result))
+	for _, r := range parsed.Data.Result {
+		if len(r.Value) != 2 {
+			continue
+		}
+		raw, ok := r.Value[1].(string)
+		if !ok {
+			continue
+		}
+		value, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			continue
+		}
+		samples = append(samples, PrometheusSample{Labels: r.Metric, Value: value})
+	}
+	return samples, nil
+}
+
+// token returns the bearer token, preferring the (rotating) token file
+func (c *PrometheusClient) token() string {
+	if c.bearerTokenFile != "" {
+		if data, err := os.ReadFile(c.bearerTokenFile); err == nil {
+			return strings.TrimSpace(string(data))
+		}
+	}
+	return c.bearerToken
+}
+
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[:n] + "..."
+}
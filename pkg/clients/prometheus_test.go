@@ -0,0 +1,78 @@
+package clients
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParsePrometheusResponse_Vector(t *testing.T) {
+	body := []byte(`{"status":"success","data":{"resultType":"vector","result":[
+		{"metric":{"namespace":"app","pod":"api-1","container":"api"},"value":[1700000000.1,"0.25"]},
+		{"metric":{"namespace":"app","pod":"api-2","container":"api"},"value":[1700000000.1,"NaN"]},
+		{"metric":{"namespace":"app","pod":"api-3","container":"api"},"value":[1700000000.1]}
+	]}}`)
+
+	samples, err := parsePrometheusResponse(200, body)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// NaN parses as a float; malformed values are skipped
+	if len(samples) != 2 {
+		t.Fatalf("expected 2 samples, got %d", len(samples))
+	}
+	if samples[0].Labels["pod"] != "api-1" || samples[0].Value != 0.25 {
+		t.Errorf("unexpected first sample: %+v", samples[0])
+	}
+}
+
+func TestParsePrometheusResponse_Errors(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		body   string
+		want   string
+	}{
+		{"query error", 400, `{"status":"error","errorType":"bad_data","error":"parse error"}`, "bad_data"},
+		{"matrix result", 200, `{"status":"success","data":{"resultType":"matrix","result":[]}}`, "expected vector"},
+		{"not json", 403, `Forbidden`, "status 403"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parsePrometheusResponse(tt.status, []byte(tt.body))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("error = %v, want it to contain %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewPrometheusClient_RequiresURL(t *testing.T) {
+	if _, err := NewPrometheusClient(PrometheusConfig{}); err == nil {
+		t.Error("expected error for empty URL")
+	}
+	if _, err := NewPrometheusClient(PrometheusConfig{URL: "https://prometheus:9091", CAFile: "/nonexistent/ca.crt"}); err == nil {
+		t.Error("expected error for missing CA file")
+	}
+}
+
+func TestParsePodMetrics(t *testing.T) {
+	raw := []byte(`{"items":[{"metadata":{"name":"api-1","namespace":"app"},"timestamp":"2026-01-01T10:00:00Z",
+		"containers":[{"name":"api","usage":{"cpu":"250m","memory":"128Mi"}},{"name":"sidecar","usage":{"cpu":"1500000n","memory":"2048Ki"}}]}]}`)
+
+	metrics, err := parsePodMetrics(raw)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(metrics) != 2 {
+		t.Fatalf("expected 2 container samples, got %d", len(metrics))
+	}
+	if metrics[0].CPUMillicores != 250 || metrics[0].MemoryBytes != 128*1024*1024 {
+		t.Errorf("unexpected api usage: %+v", metrics[0])
+	}
+	if metrics[1].CPUMillicores != 2 || metrics[1].MemoryBytes != 2048*1024 {
+		t.Errorf("unexpected sidecar usage (nanocores round up): %+v", metrics[1])
+	}
+	if _, err := parsePodMetrics([]byte("not json")); err == nil {
+		t.Error("expected decode error")
+	}
+}
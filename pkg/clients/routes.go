@@ -0,0 +1,230 @@
+package clients
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// IngressControllerNamespace holds the OpenShift IngressController resources
+const IngressControllerNamespace = "openshift-ingress-operator"
+
+// GVRs for OpenShift ingress resources
+var (
+	routeGVR = schema.GroupVersionResource{
+		Group:    "route.openshift.io",
+		Version:  "v1",
+		Resource: "routes",
+	}
+	ingressControllerGVR = schema.GroupVersionResource{
+		Group:    "operator.openshift.io",
+		Version:  "v1",
+		Resource: "ingresscontrollers",
+	}
+)
+
+// Route summarizes a route.openshift.io/v1 Route
+type Route struct {
+	Name       string         `json:"name"`
+	Namespace  string         `json:"namespace"`
+	Host       string         `json:"host"`
+	Path       string         `json:"path,omitempty"`
+	TargetPort string         `json:"target_port,omitempty"`
+	Backends   []RouteBackend `json:"backends"` // spec.to first, then alternateBackends
+	TLS        *RouteTLS      `json:"tls,omitempty"`
+	Ingress    []RouteIngress `json:"ingress,omitempty"`
+}
+
+// RouteBackend is a Service receiving a weighted share of a Route's traffic
+type RouteBackend struct {
+	Service string `json:"service"`
+	Weight  *int64 `json:"weight,omitempty"`
+}
+
+// RouteTLS is a Route's TLS configuration. The inline PEM certificates are
+// kept for expiry checks but never serialized; the private key is not read.
+type RouteTLS struct {
+	Termination                   string `json:"termination"` // edge, passthrough or reencrypt
+	InsecureEdgeTerminationPolicy string `json:"insecure_edge_termination_policy,omitempty"`
+	Certificate                   string `json:"-"`
+	CACertificate                 string `json:"-"`
+	DestinationCACertificate      string `json:"-"`
+}
+
+// RouteIngress is the admission status of a Route on one router shard
+type RouteIngress struct {
+	RouterName         string     `json:"router_name"`
+	Host               string     `json:"host,omitempty"`
+	Admitted           bool       `json:"admitted"`
+	Reason             string     `json:"reason,omitempty"`
+	Message            string     `json:"message,omitempty"`
+	LastTransitionTime *time.Time `json:"last_transition_time,omitempty"`
+}
+
+// IngressController summarizes an operator.openshift.io/v1 IngressController
+type IngressController struct {
+	Name              string             `json:"name"`
+	Domain            string             `json:"domain,omitempty"`
+	Replicas          int64              `json:"replicas"`
+	AvailableReplicas int64              `json:"available_replicas"`
+	Available         bool               `json:"available"`
+	Progressing       bool               `json:"progressing"`
+	Degraded          bool               `json:"degraded"`
+	Message           string             `json:"message,omitempty"` // Message of the most relevant failing condition
+	Conditions        []ClusterCondition `json:"conditions,omitempty"`
+}
+
+// Healthy reports whether the IngressController is Available and not Degraded
+func (ic *IngressController) Healthy() bool {
+	return ic.Available && !ic.Degraded
+}
+
+// ListRoutes returns the Routes in a namespace (all namespaces if empty)
+// sorted by namespace and name. On non-OpenShift clusters the returned
+// error satisfies apierrors.IsNotFound.
+func (c *K8sClient) ListRoutes(ctx context.Context, namespace string) ([]Route, error) {
+	dyn, err := c.getDynamicClient()
+	if err != nil {
+		return nil, err
+	}
+
+	list, err := dyn.Resource(routeGVR).Namespace(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list routes in namespace %s: %w", namespace, err)
+	}
+
+	routes := make([]Route, 0, len(list.Items))
+	for i := range list.Items {
+		routes = append(routes, convertRoute(&list.Items[i]))
+	}
+	sort.Slice(routes, func(i, j int) bool {
+		if routes[i].Namespace != routes[j].Namespace {
+			return routes[i].Namespace < routes[j].Namespace
+		}
+		return routes[i].Name < routes[j].Name
+	})
+	return routes, nil
+}
+
+// ListIngressControllers returns the OpenShift IngressControllers sorted by name.
+// On non-OpenShift clusters the returned error satisfies apierrors.IsNotFound.
+func (c *K8sClient) ListIngressControllers(ctx context.Context) ([]IngressController, error) {
+	dyn, err := c.getDynamicClient()
+	if err != nil {
+		return nil, err
+	}
+
+	list, err := dyn.Resource(ingressControllerGVR).Namespace(IngressControllerNamespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list ingresscontrollers: %w", err)
+	}
+
+	controllers := make([]IngressController, 0, len(list.Items))
+	for i := range list.Items {
+		controllers = append(controllers, convertIngressController(&list.Items[i]))
+	}
+	sort.Slice(controllers, func(i, j int) bool {
+		return controllers[i].Name < controllers[j].Name
+	})
+	return controllers, nil
+}
+
+// convertRoute converts an unstructured Route
+func convertRoute(obj *unstructured.Unstructured) Route {
+	route := Route{
+		Name:      obj.GetName(),
+		Namespace: obj.GetNamespace(),
+	}
+	route.Host, _, _ = unstructured.NestedString(obj.Object, "spec", "host")
+	route.Path, _, _ = unstructured.NestedString(obj.Object, "spec", "path")
+
+	if port, found, _ := unstructured.NestedFieldNoCopy(obj.Object, "spec", "port", "targetPort"); found && port != nil {
+		route.TargetPort = fmt.Sprint(port)
+	}
+
+	backend := func(m map[string]interface{}) RouteBackend {
+		b := RouteBackend{Service: getString(m, "name")}
+		if w, found, err := unstructured.NestedInt64(m, "weight"); found && err == nil {
+			b.Weight = &w
+		}
+		return b
+	}
+	if to, found, _ := unstructured.NestedMap(obj.Object, "spec", "to"); found {
+		route.Backends = append(route.Backends, backend(to))
+	}
+	if alternates, found, _ := unstructured.NestedSlice(obj.Object, "spec", "alternateBackends"); found {
+		for _, a := range alternates {
+			if m, ok := a.(map[string]interface{}); ok {
+				route.Backends = append(route.Backends, backend(m))
+			}
+		}
+	}
+
+	if tls, found, _ := unstructured.NestedMap(obj.Object, "spec", "tls"); found {
+		route.TLS = &RouteTLS{
+			Termination:                   getString(tls, "termination"),
+			InsecureEdgeTerminationPolicy: getString(tls, "insecureEdgeTerminationPolicy"),
+			Certificate:                   getString(tls, "certificate"),
+			CACertificate:                 getString(tls, "caCertificate"),
+			DestinationCACertificate:      getString(tls, "destinationCACertificate"),
+		}
+	}
+
+	ingresses, _, _ := unstructured.NestedSlice(obj.Object, "status", "ingress")
+	for _, raw := range ingresses {
+		m, ok := raw.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		ingress := RouteIngress{
+			RouterName: getString(m, "routerName"),
+			Host:       getString(m, "host"),
+		}
+		for _, cond := range extractConditions(m, "conditions") {
+			if cond.Type == "Admitted" {
+				ingress.Admitted = cond.Status == "True"
+				ingress.Reason = cond.Reason
+				ingress.Message = cond.Message
+				ingress.LastTransitionTime = cond.LastTransitionTime
+			}
+		}
+		route.Ingress = append(route.Ingress, ingress)
+	}
+	return route
+}
+
+// convertIngressController converts an unstructured IngressController
+func convertIngressController(obj *unstructured.Unstructured) IngressController {
+	ic := IngressController{
+		Name:       obj.GetName(),
+		Conditions: extractConditions(obj.Object, "status", "conditions"),
+	}
+	ic.Domain, _, _ = unstructured.NestedString(obj.Object, "status", "domain")
+	ic.Replicas, _, _ = unstructured.NestedInt64(obj.Object, "spec", "replicas")
+	ic.AvailableReplicas, _, _ = unstructured.NestedInt64(obj.Object, "status", "availableReplicas")
+
+	for i := range ic.Conditions {
+		cond := &ic.Conditions[i]
+		isTrue := cond.Status == "True"
+		switch cond.Type {
+		case "Available":
+			ic.Available = isTrue
+			if !isTrue && ic.Message == "" {
+				ic.Message = conditionMessage(cond)
+			}
+		case "Progressing":
+			ic.Progressing = isTrue
+		case "Degraded":
+			ic.Degraded = isTrue
+			if isTrue {
+				ic.Message = conditionMessage(cond)
+			}
+		}
+	}
+	return ic
+}
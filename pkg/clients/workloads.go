@@ -0,0 +1,80 @@
+package clients
+
+import (
+	"context"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DesiredReplicas returns the replica count of a workload spec; Kubernetes
+// defaults an unset replicas field to 1
+func DesiredReplicas(replicas *int32) int32 {
+	if replicas == nil {
+		return 1
+	}
+	return *replicas
+}
+
+// ListStatefulSets returns all statefulsets in a namespace
+// If namespace is empty, returns statefulsets from all namespaces
+func (c *K8sClient) ListStatefulSets(ctx context.Context, namespace string) (*appsv1.StatefulSetList, error) {
+	statefulSets, err := c.clientset.AppsV1().StatefulSets(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list statefulsets in namespace %s: %w", namespace, err)
+	}
+	return statefulSets, nil
+}
+
+// ListDaemonSets returns all daemonsets in a namespace
+// If namespace is empty, returns daemonsets from all namespaces
+func (c *K8sClient) ListDaemonSets(ctx context.Context, namespace string) (*appsv1.DaemonSetList, error) {
+	daemonSets, err := c.clientset.AppsV1().DaemonSets(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list daemonsets in namespace %s: %w", namespace, err)
+	}
+	return daemonSets, nil
+}
+
+// ListReplicaSets returns all replicasets in a namespace
+// If namespace is empty, returns replicasets from all namespaces
+func (c *K8sClient) ListReplicaSets(ctx context.Context, namespace string) (*appsv1.ReplicaSetList, error) {
+	replicaSets, err := c.clientset.AppsV1().ReplicaSets(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list replicasets in namespace %s: %w", namespace, err)
+	}
+	return replicaSets, nil
+}
+
+// ListControllerRevisions returns all controller revisions in a namespace
+// If namespace is empty, returns controller revisions from all namespaces
+func (c *K8sClient) ListControllerRevisions(ctx context.Context, namespace string) (*appsv1.ControllerRevisionList, error) {
+	revisions, err := c.clientset.AppsV1().ControllerRevisions(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list controller revisions in namespace %s: %w", namespace, err)
+	}
+	return revisions, nil
+}
+
+// ListPodDisruptionBudgets returns all pod disruption budgets in a namespace
+// If namespace is empty, returns pod disruption budgets from all namespaces
+func (c *K8sClient) ListPodDisruptionBudgets(ctx context.Context, namespace string) (*policyv1.PodDisruptionBudgetList, error) {
+	pdbs, err := c.clientset.PolicyV1().PodDisruptionBudgets(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pod disruption budgets in namespace %s: %w", namespace, err)
+	}
+	return pdbs, nil
+}
+
+// ListHorizontalPodAutoscalers returns all autoscaling/v2 HorizontalPodAutoscalers in a namespace
+// If namespace is empty, returns HPAs from all namespaces
+func (c *K8sClient) ListHorizontalPodAutoscalers(ctx context.Context, namespace string) (*autoscalingv2.HorizontalPodAutoscalerList, error) {
+	hpas, err := c.clientset.AutoscalingV2().HorizontalPodAutoscalers(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list horizontalpodautoscalers in namespace %s: %w", namespace, err)
+	}
+	return hpas, nil
+}
@@ -0,0 +1,13 @@
+package clients
+
+import "testing"
+
+func TestDesiredReplicas(t *testing.T) {
+	if got := DesiredReplicas(nil); got != 1 {
+		t.Errorf("Expected unset replicas to default to 1, got %d", got)
+	}
+	zero := int32(0)
+	if got := DesiredReplicas(&zero); got != 0 {
+		t.Errorf("Expected explicit 0 replicas, got %d", got)
+	}
+}
@@ -0,0 +1,70 @@
+package health
+
+// Config holds the tunable parameters of the scoring model
+type Config struct {
+	// Weights per dimension; dimensions without a weight use 1.0.
+	// A weight of 0 excludes the dimension from the overall score.
+	Weights map[string]float64
+
+	// DegradedThreshold: overall scores below this are "degraded".
+	// Nil uses the default; 0 is a valid threshold.
+	DegradedThreshold *float64 // Default: 90
+
+	// UnhealthyThreshold: overall scores below this are "unhealthy".
+	// Nil uses the default; 0 disables the unhealthy status.
+	UnhealthyThreshold *float64 // Default: 60
+
+	// IgnoredNamespaces are excluded from workload and storage scoring
+	// (e.g. CI namespaces full of intentionally failing jobs).
+	// Entries ending in '*' match as prefixes.
+	IgnoredNamespaces []string
+}
+
+// DefaultWeights emphasises the dimensions most likely to affect users
+var DefaultWeights = map[string]float64{
+	DimensionNodes:        0.20,
+	DimensionWorkloads:    0.20,
+	DimensionControlPlane: 0.20,
+	DimensionOperators:    0.15,
+	DimensionStorage:      0.10,
+	DimensionNetworking:   0.15,
+}
+
+// DefaultConfig returns the default scoring configuration
+func DefaultConfig() Config {
+	cfg := Config{}
+	cfg.applyDefaults()
+	return cfg
+}
+
+// applyDefaults fills unset fields with defaults
+func (c *Config) applyDefaults() {
+	c.DegradedThreshold = thresholdOrDefault(c.DegradedThreshold, 90)
+	c.UnhealthyThreshold = thresholdOrDefault(c.UnhealthyThreshold, 60)
+
+	weights := make(map[string]float64, len(DefaultWeights))
+	for dim, w := range DefaultWeights {
+		weights[dim] = w
+	}
+	for dim, w := range c.Weights {
+		weights[dim] = w
+	}
+	c.Weights = weights
+}
+
+// thresholdOrDefault returns a copy of an explicitly set threshold, or the default
+func thresholdOrDefault(threshold *float64, defaultValue float64) *float64 {
+	v := defaultValue
+	if threshold != nil {
+		v = *threshold
+	}
+	return &v
+}
+
+// weight returns the weight for a dimension
+func (c *Config) weight(dimension string) float64 {
+	if w, ok := c.Weights[dimension]; ok {
+		return w
+	}
+	return 1.0
+}
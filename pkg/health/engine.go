@@ -0,0 +1,248 @@
+// Package health provides a weighted, explainable cluster health scoring
+// model. Each dimension (nodes, workloads, control plane, cluster operators,
+// storage, networking) is scored 0-100 by a pluggable Scorer that also reports the
+// findings that cost it points; the overall score is the weighted average.
+package health
+
+import (
+	"math"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Dimension names used by the built-in scorers
+const (
+	DimensionNodes        = "nodes"
+	DimensionWorkloads    = "workloads"
+	DimensionControlPlane = "control_plane"
+	DimensionOperators    = "operators"
+	DimensionStorage      = "storage"
+	DimensionNetworking   = "networking"
+)
+
+// Overall health statuses
+const (
+	StatusHealthy   = "healthy"
+	StatusDegraded  = "degraded"
+	StatusUnhealthy = "unhealthy"
+)
+
+// Finding severities
+const (
+	SeverityInfo     = "info"
+	SeverityWarning  = "warning"
+	SeverityCritical = "critical"
+)
+
+// Snapshot holds the cluster objects a scoring pass operates on
+type Snapshot struct {
+	Nodes []corev1.Node
+	Pods  []corev1.Pod
+	PVCs  []corev1.PersistentVolumeClaim
+
+	// PVCsObserved is false when PVCs could not be listed (e.g. RBAC),
+	// so the storage dimension reports "not observable" instead of 100
+	PVCsObserved bool
+
+	// Operators holds OpenShift ClusterOperator states; OperatorsObserved
+	// is false on non-OpenShift clusters or when they cannot be listed
+	Operators         []OperatorStatus
+	OperatorsObserved bool
+}
+
+// OperatorStatus is the scoring-relevant state of an OpenShift ClusterOperator
+type OperatorStatus struct {
+	Name      string
+	Available bool
+	Degraded  bool
+	Message   string
+}
+
+// Finding explains why a dimension lost points
+type Finding struct {
+	Dimension string  `json:"dimension"`
+	Severity  string  `json:"severity"`
+	Message   string  `json:"message"`
+	Resource  string  `json:"resource,omitempty"`
+	Penalty   float64 `json:"penalty"`
+}
+
+// DimensionScore is the result of one Scorer
+type DimensionScore struct {
+	Name     string    `json:"name"`
+	Score    float64   `json:"score"`
+	Weight   float64   `json:"weight"`
+	Findings []Finding `json:"findings,omitempty"`
+}
+
+// Report is the result of a full scoring pass
+type Report struct {
+	Score      float64          `json:"score"`
+	Status     string           `json:"status"`
+	Dimensions []DimensionScore `json:"dimensions"`
+	Findings   []Finding        `json:"findings,omitempty"` // Warning/critical findings across dimensions, worst first
+}
+
+// Scorer scores one health dimension
+type Scorer interface {
+	// Dimension returns the dimension name used to look up its weight
+	Dimension() string
+
+	// Score returns a 0-100 score and the findings that explain it
+	Score(snapshot *Snapshot, cfg *Config) (float64, []Finding)
+}
+
+// ObservableScorer is implemented by scorers whose dimension may not be
+// observable on a cluster (e.g. ClusterOperators outside OpenShift). An
+// unobserved dimension is reported with weight 0 so it does not add a free
+// 100 to the overall score.
+type ObservableScorer interface {
+	Observed(snapshot *Snapshot) bool
+}
+
+// Engine combines registered scorers into a weighted report
+type Engine struct {
+	config  Config
+	scorers []Scorer
+}
+
+// NewEngine creates an engine with the built-in scorers registered
+func NewEngine(cfg Config) *Engine {
+	cfg.applyDefaults()
+	e := &Engine{config: cfg}
+	e.Register(&NodeScorer{})
+	e.Register(&WorkloadScorer{})
+	e.Register(&ControlPlaneScorer{})
+	e.Register(&OperatorScorer{})
+	e.Register(&StorageScorer{})
+	e.Register(&NetworkingScorer{})
+	return e
+}
+
+// Register adds a scorer, replacing any existing scorer for the same dimension
+func (e *Engine) Register(scorer Scorer) {
+	for i, existing := range e.scorers {
+		if existing.Dimension() == scorer.Dimension() {
+			e.scorers[i] = scorer
+			return
+		}
+	}
+	e.scorers = append(e.scorers, scorer)
+}
+
+// Config returns the engine configuration
+func (e *Engine) Config() Config {
+	return e.config
+}
+
+// Evaluate scores the snapshot across all registered dimensions
+func (e *Engine) Evaluate(snapshot *Snapshot) *Report {
+	report := &Report{
+		Dimensions: make([]DimensionScore, 0, len(e.scorers)),
+	}
+
+	var weightedSum, totalWeight float64
+	hasCritical := false
+
+	for _, scorer := range e.scorers {
+		score, findings := scorer.Score(snapshot, &e.config)
+		score = roundScore(clampScore(score))
+		weight := e.config.weight(scorer.Dimension())
+		if o, ok := scorer.(ObservableScorer); ok && !o.Observed(snapshot) {
+			weight = 0
+		}
+
+		report.Dimensions = append(report.Dimensions, DimensionScore{
+			Name:     scorer.Dimension(),
+			Score:    score,
+			Weight:   weight,
+			Findings: findings,
+		})
+
+		weightedSum += score * weight
+		totalWeight += weight
+
+		for _, f := range findings {
+			if f.Severity == SeverityCritical {
+				hasCritical = true
+			}
+			if f.Severity != SeverityInfo {
+				report.Findings = append(report.Findings, f)
+			}
+		}
+	}
+
+	if totalWeight > 0 {
+		report.Score = roundScore(weightedSum / totalWeight)
+	}
+
+	sort.SliceStable(report.Findings, func(i, j int) bool {
+		si, sj := severityRank(report.Findings[i].Severity), severityRank(report.Findings[j].Severity)
+		if si != sj {
+			return si > sj
+		}
+		return report.Findings[i].Penalty > report.Findings[j].Penalty
+	})
+
+	report.Status = e.statusFor(report.Score, hasCritical, snapshot)
+	return report
+}
+
+// statusFor maps a score to a status; a critical finding caps the status at
+// degraded and a cluster without any ready node is always unhealthy
+func (e *Engine) statusFor(score float64, hasCritical bool, snapshot *Snapshot) string {
+	if countReadyNodes(snapshot.Nodes) == 0 {
+		return StatusUnhealthy
+	}
+
+	status := StatusHealthy
+	switch {
+	case score < *e.config.UnhealthyThreshold:
+		status = StatusUnhealthy
+	case score < *e.config.DegradedThreshold:
+		status = StatusDegraded
+	}
+	if hasCritical && status == StatusHealthy {
+		status = StatusDegraded
+	}
+	return status
+}
+
+// IsNamespaceIgnored reports whether a namespace matches an ignore pattern.
+// Patterns are exact names or prefixes ending in '*' (e.g. "ci-*").
+func (c *Config) IsNamespaceIgnored(namespace string) bool {
+	for _, pattern := range c.IgnoredNamespaces {
+		if strings.HasSuffix(pattern, "*") {
+			if strings.HasPrefix(namespace, strings.TrimSuffix(pattern, "*")) {
+				return true
+			}
+		} else if namespace == pattern {
+			return true
+		}
+	}
+	return false
+}
+
+func clampScore(score float64) float64 {
+	if math.IsNaN(score) {
+		return 0
+	}
+	return math.Max(0, math.Min(100, score))
+}
+
+func roundScore(score float64) float64 {
+	return math.Round(score*10) / 10
+}
+
+func severityRank(severity string) int {
+	switch severity {
+	case SeverityCritical:
+		return 2
+	case SeverityWarning:
+		return 1
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,276 @@
+package health
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func node(name string, ready bool, labels map[string]string, extra ...corev1.NodeCondition) corev1.Node {
+	status := corev1.ConditionFalse
+	if ready {
+		status = corev1.ConditionTrue
+	}
+	n := corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels}}
+	n.Status.Conditions = append([]corev1.NodeCondition{{Type: corev1.NodeReady, Status: status}}, extra...)
+	return n
+}
+
+func pod(namespace, name string, phase corev1.PodPhase, ready bool) corev1.Pod {
+	status := corev1.ConditionFalse
+	if ready {
+		status = corev1.ConditionTrue
+	}
+	return corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:         namespace,
+			Name:              name,
+			CreationTimestamp: metav1.NewTime(time.Now().Add(-time.Hour)),
+		},
+		Status: corev1.PodStatus{
+			Phase:      phase,
+			Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: status}},
+		},
+	}
+}
+
+func healthySnapshot(workloadPods int) *Snapshot {
+	snapshot := &Snapshot{
+		PVCsObserved:      true,
+		OperatorsObserved: true,
+		Operators: []OperatorStatus{
+			{Name: "kube-apiserver", Available: true},
+			{Name: "ingress", Available: true},
+		},
+	}
+	for i := 0; i < 3; i++ {
+		snapshot.Nodes = append(snapshot.Nodes, node(fmt.Sprintf("master-%d", i), true, map[string]string{"node-role.kubernetes.io/master": ""}))
+		snapshot.Pods = append(snapshot.Pods, pod("openshift-etcd", fmt.Sprintf("etcd-%d", i), corev1.PodRunning, true))
+		snapshot.Pods = append(snapshot.Pods, pod("openshift-dns", fmt.Sprintf("dns-%d", i), corev1.PodRunning, true))
+	}
+	for i := 0; i < workloadPods; i++ {
+		snapshot.Pods = append(snapshot.Pods, pod("app", fmt.Sprintf("app-%d", i), corev1.PodRunning, true))
+	}
+	return snapshot
+}
+
+func dimension(report *Report, name string) DimensionScore {
+	for _, d := range report.Dimensions {
+		if d.Name == name {
+			return d
+		}
+	}
+	return DimensionScore{}
+}
+
+func TestEngine_HealthyCluster(t *testing.T) {
+	report := NewEngine(DefaultConfig()).Evaluate(healthySnapshot(50))
+
+	if report.Status != StatusHealthy {
+		t.Errorf("Expected healthy, got %s", report.Status)
+	}
+	if report.Score != 100 {
+		t.Errorf("Expected score 100, got %v", report.Score)
+	}
+	if len(report.Dimensions) != 6 {
+		t.Errorf("Expected 6 dimensions, got %d", len(report.Dimensions))
+	}
+	if len(report.Findings) != 0 {
+		t.Errorf("Expected no warning findings, got %+v", report.Findings)
+	}
+}
+
+func TestEngine_SingleFailedJobPodStaysHealthy(t *testing.T) {
+	snapshot := healthySnapshot(200)
+	failed := pod("batch", "report-abc", corev1.PodFailed, false)
+	failed.OwnerReferences = []metav1.OwnerReference{{Kind: "Job", Name: "report"}}
+	snapshot.Pods = append(snapshot.Pods, failed)
+
+	report := NewEngine(DefaultConfig()).Evaluate(snapshot)
+
+	if report.Status != StatusHealthy {
+		t.Errorf("Expected one failed Job pod to leave the cluster healthy, got %s (score %v)", report.Status, report.Score)
+	}
+	workloads := dimension(report, DimensionWorkloads)
+	if workloads.Score >= 100 || len(workloads.Findings) != 1 {
+		t.Errorf("Expected the failure to be explained in the workloads dimension, got %+v", workloads)
+	}
+}
+
+func TestEngine_IgnoredNamespaces(t *testing.T) {
+	snapshot := healthySnapshot(10)
+	for i := 0; i < 10; i++ {
+		snapshot.Pods = append(snapshot.Pods, pod("ci-build-42", fmt.Sprintf("test-%d", i), corev1.PodFailed, false))
+	}
+
+	report := NewEngine(DefaultConfig()).Evaluate(snapshot)
+	if report.Status == StatusHealthy {
+		t.Error("Expected failing CI pods to degrade the cluster when not ignored")
+	}
+
+	report = NewEngine(Config{IgnoredNamespaces: []string{"ci-*"}}).Evaluate(snapshot)
+	if report.Status != StatusHealthy {
+		t.Errorf("Expected ignored CI namespace to leave the cluster healthy, got %s", report.Status)
+	}
+}
+
+func TestEngine_NotReadyControlPlaneIsCritical(t *testing.T) {
+	snapshot := healthySnapshot(10)
+	snapshot.Nodes[0] = node("master-0", false, map[string]string{"node-role.kubernetes.io/master": ""})
+
+	report := NewEngine(DefaultConfig()).Evaluate(snapshot)
+
+	if report.Status == StatusHealthy {
+		t.Errorf("Expected a NotReady control plane node to degrade the cluster, got %s", report.Status)
+	}
+	if len(report.Findings) == 0 || report.Findings[0].Severity != SeverityCritical {
+		t.Errorf("Expected a critical finding first, got %+v", report.Findings)
+	}
+}
+
+func TestEngine_DegradedOperatorDegradesCluster(t *testing.T) {
+	snapshot := healthySnapshot(10)
+	snapshot.Operators[1] = OperatorStatus{Name: "ingress", Available: true, Degraded: true, Message: "router pods crashing"}
+
+	report := NewEngine(DefaultConfig()).Evaluate(snapshot)
+
+	if report.Status != StatusDegraded {
+		t.Errorf("Expected a degraded ClusterOperator to degrade the cluster, got %s (score %v)", report.Status, report.Score)
+	}
+	operators := dimension(report, DimensionOperators)
+	if operators.Score != 100-operatorDegradedPenalty || len(operators.Findings) != 1 {
+		t.Errorf("Expected one degraded-operator finding, got %+v", operators)
+	}
+}
+
+func TestEngine_NoReadyNodesIsUnhealthy(t *testing.T) {
+	snapshot := healthySnapshot(0)
+	for i := range snapshot.Nodes {
+		snapshot.Nodes[i] = node(snapshot.Nodes[i].Name, false, nil)
+	}
+
+	report := NewEngine(DefaultConfig()).Evaluate(snapshot)
+	if report.Status != StatusUnhealthy {
+		t.Errorf("Expected unhealthy, got %s", report.Status)
+	}
+}
+
+func TestEngine_WeightsAndThresholds(t *testing.T) {
+	snapshot := healthySnapshot(0)
+	snapshot.PVCs = []corev1.PersistentVolumeClaim{{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "app", Name: "data", CreationTimestamp: metav1.NewTime(time.Now().Add(-time.Hour))},
+		Status:     corev1.PersistentVolumeClaimStatus{Phase: corev1.ClaimLost},
+	}}
+
+	report := NewEngine(DefaultConfig()).Evaluate(snapshot)
+	if dimension(report, DimensionStorage).Score != 0 {
+		t.Errorf("Expected storage score 0 with a lost PVC, got %v", dimension(report, DimensionStorage).Score)
+	}
+
+	// Excluding storage makes the lost PVC cost no points
+	report = NewEngine(Config{Weights: map[string]float64{DimensionStorage: 0}}).Evaluate(snapshot)
+	if report.Score != 100 {
+		t.Errorf("Expected score 100 with storage weight 0, got %v", report.Score)
+	}
+
+	// Thresholds map the score to a status
+	snapshot.PVCs = nil
+	snapshot.Pods = append(snapshot.Pods, pod("app", "broken", corev1.PodFailed, false), pod("app", "ok", corev1.PodRunning, true))
+	degraded, unhealthy := 99.9, 99.0
+	strict := NewEngine(Config{DegradedThreshold: &degraded, UnhealthyThreshold: &unhealthy}).Evaluate(snapshot)
+	if strict.Status != StatusUnhealthy {
+		t.Errorf("Expected strict thresholds to report unhealthy at score %v, got %s", strict.Score, strict.Status)
+	}
+
+	// An explicit 0 is kept rather than replaced by the default
+	never := 0.0
+	lenient := NewEngine(Config{DegradedThreshold: &degraded, UnhealthyThreshold: &never}).Evaluate(snapshot)
+	if lenient.Status != StatusDegraded {
+		t.Errorf("Expected unhealthy threshold 0 to report degraded at score %v, got %s", lenient.Score, lenient.Status)
+	}
+}
+
+func TestEngine_UnobservedDimensionHasNoWeight(t *testing.T) {
+	withoutOperators := healthySnapshot(10)
+	withoutOperators.Pods = append(withoutOperators.Pods, pod("app", "broken", corev1.PodFailed, false))
+	withoutOperators.Operators, withoutOperators.OperatorsObserved = nil, false
+
+	report := NewEngine(DefaultConfig()).Evaluate(withoutOperators)
+	zeroWeight := NewEngine(Config{Weights: map[string]float64{DimensionOperators: 0}}).Evaluate(withoutOperators)
+
+	if report.Score == 100 || report.Score != zeroWeight.Score {
+		t.Errorf("Expected unobserved operators to score like operator weight 0, got %v vs %v", report.Score, zeroWeight.Score)
+	}
+	if operators := dimension(report, DimensionOperators); operators.Weight != 0 || len(operators.Findings) != 1 {
+		t.Errorf("Expected unobserved operators with weight 0 and an info finding, got %+v", operators)
+	}
+}
+
+type fixedScorer struct{ score float64 }
+
+func (s *fixedScorer) Dimension() string { return DimensionNetworking }
+func (s *fixedScorer) Score(*Snapshot, *Config) (float64, []Finding) {
+	return s.score, nil
+}
+
+func TestEngine_RegisterReplacesScorer(t *testing.T) {
+	engine := NewEngine(Config{Weights: map[string]float64{
+		DimensionNodes: 0, DimensionWorkloads: 0, DimensionControlPlane: 0, DimensionOperators: 0, DimensionStorage: 0,
+	}})
+	engine.Register(&fixedScorer{score: 150})
+
+	report := engine.Evaluate(healthySnapshot(0))
+	if len(report.Dimensions) != 6 {
+		t.Errorf("Expected Register to replace the networking scorer, got %d dimensions", len(report.Dimensions))
+	}
+	if report.Score != 100 {
+		t.Errorf("Expected scores to be clamped to 100, got %v", report.Score)
+	}
+}
+
+func TestWorkloadScorer_CrashLoopAndLimitFindings(t *testing.T) {
+	snapshot := &Snapshot{}
+	for i := 0; i < 15; i++ {
+		p := pod("app", fmt.Sprintf("crash-%d", i), corev1.PodRunning, false)
+		p.Status.ContainerStatuses = []corev1.ContainerStatus{{
+			State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}},
+		}}
+		snapshot.Pods = append(snapshot.Pods, p)
+	}
+
+	score, findings := (&WorkloadScorer{}).Score(snapshot, &Config{})
+	if score > 0 {
+		t.Errorf("Expected score <= 0 when every pod crash-loops, got %v", score)
+	}
+	// 1 summary + 9 pods (capped) + 1 "more findings omitted"
+	if len(findings) != maxFindingsPerDimension+1 {
+		t.Errorf("Expected %d findings, got %d", maxFindingsPerDimension+1, len(findings))
+	}
+	if findings[0].Severity != SeverityCritical {
+		t.Errorf("Expected critical summary finding first, got %+v", findings[0])
+	}
+}
+
+func TestScorers_NotObservable(t *testing.T) {
+	snapshot := &Snapshot{Nodes: []corev1.Node{node("worker-0", true, nil)}}
+
+	if score, findings := (&StorageScorer{}).Score(snapshot, &Config{}); score != 100 || len(findings) != 1 {
+		t.Errorf("Expected unobservable storage to score 100 with an info finding, got %v %+v", score, findings)
+	}
+	if score, findings := (&ControlPlaneScorer{}).Score(snapshot, &Config{}); score != 100 || findings[0].Severity != SeverityInfo {
+		t.Errorf("Expected hosted control plane to score 100 with an info finding, got %v %+v", score, findings)
+	}
+}
+
+func TestConfig_IsNamespaceIgnored(t *testing.T) {
+	cfg := Config{IgnoredNamespaces: []string{"ci-*", "sandbox"}}
+
+	for ns, expected := range map[string]bool{"ci-123": true, "sandbox": true, "sandbox-2": false, "default": false} {
+		if cfg.IsNamespaceIgnored(ns) != expected {
+			t.Errorf("IsNamespaceIgnored(%q) = %v, expected %v", ns, !expected, expected)
+		}
+	}
+}
@@ -0,0 +1,400 @@
+package health
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Namespace dimension names; namespaces reuse DimensionStorage for PVCs
+const (
+	DimensionRollouts  = "rollouts"
+	DimensionPods      = "pods"
+	DimensionStability = "stability"
+	DimensionServices  = "services"
+	DimensionQuota     = "quota"
+)
+
+// Workload rollout statuses as reported by the workload health analysis
+const (
+	WorkloadStuck    = "stuck"
+	WorkloadDegraded = "degraded"
+)
+
+// RestartHotspotThreshold is the container restart count from which a
+// container is reported as a restart hotspot
+const RestartHotspotThreshold = 5
+
+const (
+	// restartHotspotPenalty is deducted per restart hotspot
+	restartHotspotPenalty = 10.0
+
+	// Warning event reasons cost a fixed amount each, capped so a noisy
+	// namespace cannot lose more than maxEventPenalty to events alone
+	warningReasonPenalty = 5.0
+	maxEventPenalty      = 30.0
+
+	// Quota resources at or above quotaWarningRatio of their hard limit
+	// are warnings; exhausted resources block new pods and are critical
+	quotaWarningRatio     = 0.9
+	quotaWarningPenalty   = 25.0
+	quotaExhaustedPenalty = 50.0
+)
+
+// NamespaceWeights weighs the namespace dimensions; dimensions without
+// anything to score (e.g. no Services) are left out of the average
+var NamespaceWeights = map[string]float64{
+	DimensionRollouts:  0.25,
+	DimensionPods:      0.25,
+	DimensionStability: 0.15,
+	DimensionServices:  0.15,
+	DimensionStorage:   0.10,
+	DimensionQuota:     0.10,
+}
+
+// WorkloadState is the rollout state of a Deployment, StatefulSet or DaemonSet
+type WorkloadState struct {
+	Kind      string
+	Name      string
+	Status    string // healthy, progressing, stuck or degraded
+	Available int32
+	Issue     string
+}
+
+// ServiceState counts the ready and not-ready endpoints of a Service with a selector
+type ServiceState struct {
+	Name     string
+	Ready    int
+	NotReady int
+}
+
+// QuotaUsage is the usage of one ResourceQuota resource
+type QuotaUsage struct {
+	Quota    string  `json:"quota"`
+	Resource string  `json:"resource"`
+	Used     float64 `json:"used"`
+	Hard     float64 `json:"hard"`
+}
+
+// Ratio returns used/hard, or 0 for a zero hard limit
+func (q QuotaUsage) Ratio() float64 {
+	if q.Hard <= 0 {
+		return 0
+	}
+	return q.Used / q.Hard
+}
+
+// EventReason summarizes recent Warning events sharing a reason
+type EventReason struct {
+	Reason  string
+	Count   int32
+	Objects int
+	Message string
+}
+
+// RestartHotspot is a container that restarted at least RestartHotspotThreshold times
+type RestartHotspot struct {
+	Pod        string `json:"pod"`
+	Container  string `json:"container"`
+	Restarts   int32  `json:"restarts"`
+	LastReason string `json:"last_reason,omitempty"`
+}
+
+// NamespaceSnapshot holds the objects of one namespace a scoring pass operates on
+type NamespaceSnapshot struct {
+	Namespace string
+	Pods      []corev1.Pod
+	PVCs      []corev1.PersistentVolumeClaim
+	Workloads []WorkloadState
+	Services  []ServiceState
+	Quotas    []QuotaUsage
+
+	// WarningEvents holds recent Warning event reasons, most frequent first
+	WarningEvents []EventReason
+
+	// The *Observed flags are false when the objects could not be listed,
+	// so the dimension is reported as not observable instead of 100
+	PVCsObserved     bool
+	ServicesObserved bool
+	QuotasObserved   bool
+	EventsObserved   bool
+}
+
+// NamespaceReport is the result of scoring one namespace
+type NamespaceReport struct {
+	Namespace  string           `json:"namespace"`
+	Score      float64          `json:"score"`
+	Status     string           `json:"status"`
+	Dimensions []DimensionScore `json:"dimensions"`
+	Findings   []Finding        `json:"findings,omitempty"` // Warning/critical findings across dimensions, worst first
+}
+
+// EvaluateNamespace scores a namespace across rollouts, pods, stability,
+// services, storage and quota. Only dimensions with something to score are
+// weighted; status uses the config thresholds and a critical finding caps
+// it at degraded.
+func EvaluateNamespace(snapshot *NamespaceSnapshot, cfg Config) *NamespaceReport {
+	cfg.applyDefaults()
+	report := &NamespaceReport{Namespace: snapshot.Namespace, Dimensions: []DimensionScore{}}
+
+	// The cluster scorers skip ignored namespaces; a namespace asked for
+	// explicitly is always scored
+	scoped := &Config{}
+	sub := &Snapshot{Pods: snapshot.Pods, PVCs: snapshot.PVCs, PVCsObserved: snapshot.PVCsObserved}
+
+	var weightedSum, totalWeight float64
+	hasCritical := false
+	add := func(dimension string, applies bool, score float64, findings []Finding) {
+		weight := 0.0
+		if applies {
+			weight = NamespaceWeights[dimension]
+		}
+		score = roundScore(clampScore(score))
+		report.Dimensions = append(report.Dimensions, DimensionScore{
+			Name:     dimension,
+			Score:    score,
+			Weight:   weight,
+			Findings: findings,
+		})
+		weightedSum += score * weight
+		totalWeight += weight
+		for _, f := range findings {
+			if f.Severity == SeverityCritical {
+				hasCritical = true
+			}
+			if f.Severity != SeverityInfo {
+				report.Findings = append(report.Findings, f)
+			}
+		}
+	}
+
+	score, findings := scoreRollouts(snapshot.Workloads)
+	add(DimensionRollouts, len(snapshot.Workloads) > 0, score, findings)
+
+	score, findings = (&WorkloadScorer{}).Score(sub, scoped)
+	for i := range findings {
+		findings[i].Dimension = DimensionPods
+	}
+	add(DimensionPods, len(snapshot.Pods) > 0, score, findings)
+
+	score, findings = scoreStability(snapshot)
+	add(DimensionStability, len(snapshot.Pods) > 0 || len(snapshot.WarningEvents) > 0, score, findings)
+
+	score, findings = scoreServices(snapshot)
+	add(DimensionServices, snapshot.ServicesObserved && len(snapshot.Services) > 0, score, findings)
+
+	score, findings = (&StorageScorer{}).Score(sub, scoped)
+	add(DimensionStorage, snapshot.PVCsObserved && len(snapshot.PVCs) > 0, score, findings)
+
+	score, findings = scoreQuotas(snapshot)
+	add(DimensionQuota, snapshot.QuotasObserved && len(snapshot.Quotas) > 0, score, findings)
+
+	report.Score = 100
+	if totalWeight > 0 {
+		report.Score = roundScore(weightedSum / totalWeight)
+	}
+
+	sort.SliceStable(report.Findings, func(i, j int) bool {
+		si, sj := severityRank(report.Findings[i].Severity), severityRank(report.Findings[j].Severity)
+		if si != sj {
+			return si > sj
+		}
+		return report.Findings[i].Penalty > report.Findings[j].Penalty
+	})
+
+	report.Status = StatusHealthy
+	switch {
+	case report.Score < *cfg.UnhealthyThreshold:
+		report.Status = StatusUnhealthy
+	case report.Score < *cfg.DegradedThreshold:
+		report.Status = StatusDegraded
+	}
+	if hasCritical && report.Status == StatusHealthy {
+		report.Status = StatusDegraded
+	}
+	return report
+}
+
+// scoreRollouts deducts each workload's share for stuck or degraded rollouts;
+// a degraded workload with nothing available is critical
+func scoreRollouts(workloads []WorkloadState) (float64, []Finding) {
+	if len(workloads) == 0 {
+		return 100, nil
+	}
+	share := 100.0 / float64(len(workloads))
+	score := 100.0
+	var findings []Finding
+	for _, w := range workloads {
+		if w.Status != WorkloadStuck && w.Status != WorkloadDegraded {
+			continue
+		}
+		severity := SeverityWarning
+		message := fmt.Sprintf("%s %s is %s", w.Kind, w.Name, w.Status)
+		if w.Status == WorkloadDegraded && w.Available == 0 {
+			severity = SeverityCritical
+			message += " with no available replicas"
+		}
+		if w.Issue != "" {
+			message += " (" + w.Issue + ")"
+		}
+		score -= share
+		findings = append(findings, Finding{
+			Dimension: DimensionRollouts,
+			Severity:  severity,
+			Message:   message,
+			Resource:  fmt.Sprintf("%s/%s", w.Kind, w.Name),
+			Penalty:   roundScore(share),
+		})
+	}
+	return score, limitFindings(DimensionRollouts, findings)
+}
+
+// scoreStability deducts points for restart hotspots and recent Warning event reasons
+func scoreStability(snapshot *NamespaceSnapshot) (float64, []Finding) {
+	score := 100.0
+	var findings []Finding
+	for _, h := range RestartHotspots(snapshot.Pods) {
+		score -= restartHotspotPenalty
+		message := fmt.Sprintf("Container %s of pod %s restarted %d times", h.Container, h.Pod, h.Restarts)
+		if h.LastReason != "" {
+			message += " (last: " + h.LastReason + ")"
+		}
+		findings = append(findings, Finding{
+			Dimension: DimensionStability,
+			Severity:  SeverityWarning,
+			Message:   message,
+			Resource:  fmt.Sprintf("pod/%s/%s", snapshot.Namespace, h.Pod),
+			Penalty:   restartHotspotPenalty,
+		})
+	}
+
+	if !snapshot.EventsObserved {
+		findings = append(findings, Finding{
+			Dimension: DimensionStability,
+			Severity:  SeverityInfo,
+			Message:   "Events not observable (missing RBAC?)",
+		})
+		return score, limitFindings(DimensionStability, findings)
+	}
+
+	eventPenalty := 0.0
+	for _, e := range snapshot.WarningEvents {
+		penalty := math.Min(warningReasonPenalty, maxEventPenalty-eventPenalty)
+		eventPenalty += penalty
+		score -= penalty
+		findings = append(findings, Finding{
+			Dimension: DimensionStability,
+			Severity:  SeverityWarning,
+			Message:   fmt.Sprintf("%d %s warning events on %d objects: %s", e.Count, e.Reason, e.Objects, e.Message),
+			Penalty:   penalty,
+		})
+	}
+	return score, limitFindings(DimensionStability, findings)
+}
+
+// scoreServices deducts each Service's share when it has no ready endpoints
+// (critical: traffic fails) and half a share when some endpoints are not ready
+func scoreServices(snapshot *NamespaceSnapshot) (float64, []Finding) {
+	if !snapshot.ServicesObserved {
+		return 100, []Finding{{
+			Dimension: DimensionServices,
+			Severity:  SeverityInfo,
+			Message:   "Services or EndpointSlices not observable (missing RBAC?)",
+		}}
+	}
+	if len(snapshot.Services) == 0 {
+		return 100, nil
+	}
+
+	share := 100.0 / float64(len(snapshot.Services))
+	score := 100.0
+	var findings []Finding
+	for _, svc := range snapshot.Services {
+		resource := fmt.Sprintf("service/%s/%s", snapshot.Namespace, svc.Name)
+		switch {
+		case svc.Ready == 0:
+			score -= share
+			findings = append(findings, Finding{
+				Dimension: DimensionServices,
+				Severity:  SeverityCritical,
+				Message:   fmt.Sprintf("Service %s has no ready endpoints (%d not ready)", svc.Name, svc.NotReady),
+				Resource:  resource,
+				Penalty:   roundScore(share),
+			})
+		case svc.NotReady > 0:
+			score -= share / 2
+			findings = append(findings, Finding{
+				Dimension: DimensionServices,
+				Severity:  SeverityWarning,
+				Message:   fmt.Sprintf("Service %s has %d of %d endpoints not ready", svc.Name, svc.NotReady, svc.Ready+svc.NotReady),
+				Resource:  resource,
+				Penalty:   roundScore(share / 2),
+			})
+		}
+	}
+	return score, limitFindings(DimensionServices, findings)
+}
+
+// scoreQuotas deducts fixed penalties for quota resources near or at their hard limit
+func scoreQuotas(snapshot *NamespaceSnapshot) (float64, []Finding) {
+	if !snapshot.QuotasObserved {
+		return 100, []Finding{{
+			Dimension: DimensionQuota,
+			Severity:  SeverityInfo,
+			Message:   "ResourceQuotas not observable (missing RBAC?)",
+		}}
+	}
+
+	score := 100.0
+	var findings []Finding
+	for _, q := range snapshot.Quotas {
+		ratio := q.Ratio()
+		if q.Hard <= 0 || ratio < quotaWarningRatio {
+			continue
+		}
+		severity, penalty := SeverityWarning, quotaWarningPenalty
+		if ratio >= 1 {
+			severity, penalty = SeverityCritical, quotaExhaustedPenalty
+		}
+		score -= penalty
+		findings = append(findings, Finding{
+			Dimension: DimensionQuota,
+			Severity:  severity,
+			Message:   fmt.Sprintf("ResourceQuota %s %s at %.0f%% (%g of %g)", q.Quota, q.Resource, ratio*100, q.Used, q.Hard),
+			Resource:  fmt.Sprintf("resourcequota/%s/%s", snapshot.Namespace, q.Quota),
+			Penalty:   penalty,
+		})
+	}
+	return score, limitFindings(DimensionQuota, findings)
+}
+
+// RestartHotspots returns the containers of non-terminated pods that
+// restarted at least RestartHotspotThreshold times, most restarts first
+func RestartHotspots(pods []corev1.Pod) []RestartHotspot {
+	var hotspots []RestartHotspot
+	for i := range pods {
+		pod := &pods[i]
+		if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		for _, cs := range pod.Status.ContainerStatuses {
+			if cs.RestartCount < RestartHotspotThreshold {
+				continue
+			}
+			h := RestartHotspot{Pod: pod.Name, Container: cs.Name, Restarts: cs.RestartCount}
+			if t := cs.LastTerminationState.Terminated; t != nil {
+				h.LastReason = t.Reason
+			}
+			hotspots = append(hotspots, h)
+		}
+	}
+	sort.SliceStable(hotspots, func(i, j int) bool {
+		if hotspots[i].Restarts != hotspots[j].Restarts {
+			return hotspots[i].Restarts > hotspots[j].Restarts
+		}
+		return hotspots[i].Pod+hotspots[i].Container < hotspots[j].Pod+hotspots[j].Container
+	})
+	return hotspots
+}
@@ -0,0 +1,122 @@
+package health
+
+import (
+	"fmt"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+func healthyNamespace() *NamespaceSnapshot {
+	snapshot := &NamespaceSnapshot{
+		Namespace:        "app",
+		PVCsObserved:     true,
+		ServicesObserved: true,
+		QuotasObserved:   true,
+		EventsObserved:   true,
+		Workloads:        []WorkloadState{{Kind: "Deployment", Name: "api", Status: "healthy", Available: 3}},
+		Services:         []ServiceState{{Name: "api", Ready: 3}},
+		Quotas:           []QuotaUsage{{Quota: "compute", Resource: "pods", Used: 3, Hard: 10}},
+	}
+	for i := 0; i < 3; i++ {
+		snapshot.Pods = append(snapshot.Pods, pod("app", fmt.Sprintf("api-%d", i), corev1.PodRunning, true))
+	}
+	return snapshot
+}
+
+func namespaceDimension(report *NamespaceReport, name string) DimensionScore {
+	for _, d := range report.Dimensions {
+		if d.Name == name {
+			return d
+		}
+	}
+	return DimensionScore{}
+}
+
+func TestEvaluateNamespace_Healthy(t *testing.T) {
+	report := EvaluateNamespace(healthyNamespace(), DefaultConfig())
+	if report.Score != 100 || report.Status != StatusHealthy || len(report.Findings) != 0 {
+		t.Errorf("report = %+v, want healthy with no findings", report)
+	}
+	if d := namespaceDimension(report, DimensionStorage); d.Weight != 0 {
+		t.Errorf("storage weight = %v, want 0 without PVCs", d.Weight)
+	}
+
+	empty := EvaluateNamespace(&NamespaceSnapshot{Namespace: "empty"}, DefaultConfig())
+	if empty.Score != 100 || empty.Status != StatusHealthy {
+		t.Errorf("empty namespace = %+v, want healthy", empty)
+	}
+}
+
+func TestEvaluateNamespace_IgnoredNamespaceIsStillScored(t *testing.T) {
+	snapshot := healthyNamespace()
+	snapshot.Pods[0].Status.Phase = corev1.PodFailed
+	report := EvaluateNamespace(snapshot, Config{IgnoredNamespaces: []string{"app"}})
+	if d := namespaceDimension(report, DimensionPods); d.Score == 100 || d.Findings[0].Dimension != DimensionPods {
+		t.Errorf("pods dimension = %+v, want the failed pod counted", d)
+	}
+}
+
+func TestEvaluateNamespace_CriticalFindings(t *testing.T) {
+	snapshot := healthyNamespace()
+	snapshot.Services = append(snapshot.Services, ServiceState{Name: "worker", NotReady: 2})
+	snapshot.Quotas = append(snapshot.Quotas, QuotaUsage{Quota: "compute", Resource: "requests.cpu", Used: 4, Hard: 4})
+
+	report := EvaluateNamespace(snapshot, DefaultConfig())
+	if report.Status == StatusHealthy {
+		t.Errorf("status = %s, want a critical finding to cap it", report.Status)
+	}
+	if d := namespaceDimension(report, DimensionServices); d.Score != 50 {
+		t.Errorf("services score = %v, want 50", d.Score)
+	}
+	if d := namespaceDimension(report, DimensionQuota); d.Score != 50 {
+		t.Errorf("quota score = %v, want 50", d.Score)
+	}
+	if len(report.Findings) != 2 || report.Findings[0].Severity != SeverityCritical {
+		t.Errorf("findings = %+v", report.Findings)
+	}
+}
+
+func TestEvaluateNamespace_RolloutsAndStability(t *testing.T) {
+	snapshot := healthyNamespace()
+	snapshot.Workloads = append(snapshot.Workloads,
+		WorkloadState{Kind: "StatefulSet", Name: "db", Status: WorkloadStuck, Available: 1, Issue: "pod db-1 is CrashLoopBackOff"},
+		WorkloadState{Kind: "Deployment", Name: "web", Status: WorkloadDegraded},
+	)
+	snapshot.Pods[0].Status.ContainerStatuses = []corev1.ContainerStatus{{
+		Name:                 "app",
+		RestartCount:         12,
+		LastTerminationState: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{Reason: "OOMKilled"}},
+	}}
+	for i := 0; i < 8; i++ {
+		snapshot.WarningEvents = append(snapshot.WarningEvents, EventReason{Reason: fmt.Sprintf("Reason%d", i), Count: 1, Objects: 1})
+	}
+
+	report := EvaluateNamespace(snapshot, DefaultConfig())
+	rollouts := namespaceDimension(report, DimensionRollouts)
+	if rollouts.Score != 33.3 || len(rollouts.Findings) != 2 || rollouts.Findings[1].Severity != SeverityCritical {
+		t.Errorf("rollouts = %+v", rollouts)
+	}
+	// One hotspot (10) plus events capped at 30
+	if d := namespaceDimension(report, DimensionStability); d.Score != 60 {
+		t.Errorf("stability score = %v, want 60", d.Score)
+	}
+
+	hotspots := RestartHotspots(snapshot.Pods)
+	if len(hotspots) != 1 || hotspots[0].Restarts != 12 || hotspots[0].LastReason != "OOMKilled" {
+		t.Errorf("hotspots = %+v", hotspots)
+	}
+}
+
+func TestEvaluateNamespace_NotObservable(t *testing.T) {
+	snapshot := healthyNamespace()
+	snapshot.ServicesObserved = false
+	snapshot.QuotasObserved = false
+	report := EvaluateNamespace(snapshot, DefaultConfig())
+	for _, name := range []string{DimensionServices, DimensionQuota} {
+		d := namespaceDimension(report, name)
+		if d.Weight != 0 || len(d.Findings) != 1 || d.Findings[0].Severity != SeverityInfo {
+			t.Errorf("%s = %+v, want unweighted with an info finding", name, d)
+		}
+	}
+}
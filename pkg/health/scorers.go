@@ -0,0 +1,611 @@
+package health
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// maxFindingsPerDimension bounds per-resource findings so a large
+	// outage does not produce thousands of lines for the LLM
+	maxFindingsPerDimension = 10
+
+	// workloadAmplification converts the fraction of unhealthy pods into
+	// score points: 1% unhealthy costs 5 points, 20% or more costs all 100
+	workloadAmplification = 5.0
+
+	// jobFailureWeight discounts failed Job pods, which are usually retried
+	// or superseded and should not degrade a large cluster on their own
+	jobFailureWeight = 0.25
+
+	// pendingGracePeriod ignores pods and PVCs that are only briefly pending
+	pendingGracePeriod = 5 * time.Minute
+
+	// Fixed penalties per unhealthy ClusterOperator; both are critical
+	// findings so any degraded operator caps the overall status at degraded
+	operatorUnavailablePenalty = 25.0
+	operatorDegradedPenalty    = 10.0
+)
+
+// controlPlaneNamespaces host the OpenShift control plane static pods
+var controlPlaneNamespaces = map[string]bool{
+	"openshift-kube-apiserver":          true,
+	"openshift-kube-controller-manager": true,
+	"openshift-kube-scheduler":          true,
+	"openshift-etcd":                    true,
+}
+
+// controlPlaneComponents identifies upstream control plane pods in kube-system
+var controlPlaneComponents = map[string]bool{
+	"kube-apiserver":          true,
+	"kube-controller-manager": true,
+	"kube-scheduler":          true,
+	"etcd":                    true,
+}
+
+// networkingNamespaces host cluster networking components
+var networkingNamespaces = map[string]bool{
+	"openshift-dns":            true,
+	"openshift-ingress":        true,
+	"openshift-sdn":            true,
+	"openshift-ovn-kubernetes": true,
+	"openshift-multus":         true,
+}
+
+// networkingApps identifies upstream networking pods in kube-system
+var networkingApps = map[string]bool{
+	"kube-dns":   true,
+	"coredns":    true,
+	"kube-proxy": true,
+}
+
+// waitingFailureReasons are container waiting reasons that indicate a
+// broken workload rather than normal startup
+var waitingFailureReasons = map[string]bool{
+	"CrashLoopBackOff":           true,
+	"ImagePullBackOff":           true,
+	"ErrImagePull":               true,
+	"CreateContainerConfigError": true,
+	"CreateContainerError":       true,
+	"InvalidImageName":           true,
+}
+
+// NodeScorer scores node readiness and resource pressure
+type NodeScorer struct{}
+
+// Dimension returns the dimension name
+func (s *NodeScorer) Dimension() string { return DimensionNodes }
+
+// Score deducts a node's share for NotReady nodes and half a share per
+// pressure condition
+func (s *NodeScorer) Score(snapshot *Snapshot, cfg *Config) (float64, []Finding) {
+	total := len(snapshot.Nodes)
+	if total == 0 {
+		return 0, []Finding{{
+			Dimension: DimensionNodes,
+			Severity:  SeverityCritical,
+			Message:   "No nodes found in the cluster",
+			Penalty:   100,
+		}}
+	}
+
+	share := 100.0 / float64(total)
+	score := 100.0
+	var findings []Finding
+
+	for _, node := range snapshot.Nodes {
+		if !isNodeReady(&node) {
+			score -= share
+			findings = append(findings, Finding{
+				Dimension: DimensionNodes,
+				Severity:  SeverityWarning,
+				Message:   fmt.Sprintf("Node %s is NotReady%s", node.Name, conditionReason(&node, corev1.NodeReady)),
+				Resource:  "node/" + node.Name,
+				Penalty:   roundScore(share),
+			})
+			continue
+		}
+
+		for _, condType := range []corev1.NodeConditionType{corev1.NodeMemoryPressure, corev1.NodeDiskPressure, corev1.NodePIDPressure} {
+			if hasNodeCondition(&node, condType) {
+				score -= share / 2
+				findings = append(findings, Finding{
+					Dimension: DimensionNodes,
+					Severity:  SeverityWarning,
+					Message:   fmt.Sprintf("Node %s reports %s", node.Name, condType),
+					Resource:  "node/" + node.Name,
+					Penalty:   roundScore(share / 2),
+				})
+			}
+		}
+
+		if node.Spec.Unschedulable {
+			findings = append(findings, Finding{
+				Dimension: DimensionNodes,
+				Severity:  SeverityInfo,
+				Message:   fmt.Sprintf("Node %s is cordoned (SchedulingDisabled)", node.Name),
+				Resource:  "node/" + node.Name,
+			})
+		}
+	}
+
+	return score, limitFindings(DimensionNodes, findings)
+}
+
+// WorkloadScorer scores the fraction of unhealthy pods outside ignored namespaces
+type WorkloadScorer struct{}
+
+// Dimension returns the dimension name
+func (s *WorkloadScorer) Dimension() string { return DimensionWorkloads }
+
+// Score weighs failed, stuck-pending and crash-looping pods against all
+// considered pods, discounting failed Job pods
+func (s *WorkloadScorer) Score(snapshot *Snapshot, cfg *Config) (float64, []Finding) {
+	considered := 0
+	var unhealthyWeight float64
+	var findings []Finding
+	now := time.Now()
+
+	for i := range snapshot.Pods {
+		pod := &snapshot.Pods[i]
+		if cfg.IsNamespaceIgnored(pod.Namespace) || pod.Status.Phase == corev1.PodSucceeded {
+			continue
+		}
+		considered++
+
+		weight, severity, reason := classifyPod(pod, now)
+		if weight == 0 {
+			continue
+		}
+		unhealthyWeight += weight
+		findings = append(findings, Finding{
+			Dimension: DimensionWorkloads,
+			Severity:  severity,
+			Message:   fmt.Sprintf("Pod %s/%s %s", pod.Namespace, pod.Name, reason),
+			Resource:  fmt.Sprintf("pod/%s/%s", pod.Namespace, pod.Name),
+			Penalty:   weight,
+		})
+	}
+
+	if considered == 0 {
+		return 100, nil
+	}
+
+	// Convert per-pod weights into score points now that the total is known
+	pointsPerPod := 100.0 * workloadAmplification / float64(considered)
+	for i := range findings {
+		findings[i].Penalty = roundScore(findings[i].Penalty * pointsPerPod)
+	}
+
+	ratio := unhealthyWeight / float64(considered)
+	if ratio >= 0.2 {
+		findings = append([]Finding{{
+			Dimension: DimensionWorkloads,
+			Severity:  SeverityCritical,
+			Message:   fmt.Sprintf("%.0f%% of considered pods are unhealthy", ratio*100),
+		}}, findings...)
+	}
+
+	return 100 - ratio*100*workloadAmplification, limitFindings(DimensionWorkloads, findings)
+}
+
+// classifyPod returns the unhealthy weight, severity and reason for a pod
+// (weight 0 means the pod is healthy)
+func classifyPod(pod *corev1.Pod, now time.Time) (float64, string, string) {
+	switch pod.Status.Phase {
+	case corev1.PodFailed:
+		if isOwnedBy(pod, "Job") {
+			return jobFailureWeight, SeverityInfo, "failed (Job pod)"
+		}
+		return 1, SeverityWarning, "failed" + reasonSuffix(pod.Status.Reason)
+	case corev1.PodUnknown:
+		return 1, SeverityWarning, "is in Unknown phase (node unreachable?)"
+	case corev1.PodPending:
+		if reason := waitingFailureReason(pod); reason != "" {
+			return 1, SeverityWarning, "is Pending with " + reason
+		}
+		if now.Sub(pod.CreationTimestamp.Time) > pendingGracePeriod {
+			return 1, SeverityWarning, "has been Pending for over " + pendingGracePeriod.String()
+		}
+	case corev1.PodRunning:
+		if reason := waitingFailureReason(pod); reason != "" {
+			return 1, SeverityWarning, "has a container in " + reason
+		}
+	}
+	return 0, "", ""
+}
+
+// ControlPlaneScorer scores control plane node and pod readiness
+type ControlPlaneScorer struct{}
+
+// Dimension returns the dimension name
+func (s *ControlPlaneScorer) Dimension() string { return DimensionControlPlane }
+
+// Observed reports whether any control plane node or pod is visible; managed
+// and hosted control planes are not
+func (s *ControlPlaneScorer) Observed(snapshot *Snapshot) bool {
+	cpNodes, cpPods := controlPlaneObjects(snapshot)
+	return len(cpNodes) > 0 || len(cpPods) > 0
+}
+
+// Score splits points evenly between control plane nodes and pods;
+// any unready control plane node or pod is a critical finding
+func (s *ControlPlaneScorer) Score(snapshot *Snapshot, cfg *Config) (float64, []Finding) {
+	cpNodes, cpPods := controlPlaneObjects(snapshot)
+
+	if len(cpNodes) == 0 && len(cpPods) == 0 {
+		return 100, []Finding{{
+			Dimension: DimensionControlPlane,
+			Severity:  SeverityInfo,
+			Message:   "Control plane not observable (managed or hosted control plane)",
+		}}
+	}
+
+	// Each half is worth 50 points; if only one half is observable it is worth 100
+	nodeBudget, podBudget := 50.0, 50.0
+	if len(cpNodes) == 0 {
+		nodeBudget, podBudget = 0, 100
+	} else if len(cpPods) == 0 {
+		nodeBudget, podBudget = 100, 0
+	}
+
+	score := 100.0
+	var findings []Finding
+
+	for _, node := range cpNodes {
+		if !isNodeReady(node) {
+			penalty := nodeBudget / float64(len(cpNodes))
+			score -= penalty
+			findings = append(findings, Finding{
+				Dimension: DimensionControlPlane,
+				Severity:  SeverityCritical,
+				Message:   fmt.Sprintf("Control plane node %s is NotReady", node.Name),
+				Resource:  "node/" + node.Name,
+				Penalty:   roundScore(penalty),
+			})
+		}
+	}
+
+	for _, pod := range cpPods {
+		if !isPodReady(pod) {
+			penalty := podBudget / float64(len(cpPods))
+			score -= penalty
+			findings = append(findings, Finding{
+				Dimension: DimensionControlPlane,
+				Severity:  SeverityCritical,
+				Message:   fmt.Sprintf("Control plane pod %s/%s is not ready (%s)", pod.Namespace, pod.Name, pod.Status.Phase),
+				Resource:  fmt.Sprintf("pod/%s/%s", pod.Namespace, pod.Name),
+				Penalty:   roundScore(penalty),
+			})
+		}
+	}
+
+	return score, limitFindings(DimensionControlPlane, findings)
+}
+
+// OperatorScorer scores OpenShift ClusterOperator availability
+type OperatorScorer struct{}
+
+// Dimension returns the dimension name
+func (s *OperatorScorer) Dimension() string { return DimensionOperators }
+
+// Observed reports whether ClusterOperators could be listed
+func (s *OperatorScorer) Observed(snapshot *Snapshot) bool { return snapshot.OperatorsObserved }
+
+// Score deducts a fixed penalty per unavailable or degraded operator
+func (s *OperatorScorer) Score(snapshot *Snapshot, cfg *Config) (float64, []Finding) {
+	if !snapshot.OperatorsObserved {
+		return 100, []Finding{{
+			Dimension: DimensionOperators,
+			Severity:  SeverityInfo,
+			Message:   "ClusterOperators not observable (not an OpenShift cluster or missing RBAC)",
+		}}
+	}
+
+	score := 100.0
+	var findings []Finding
+	for _, op := range snapshot.Operators {
+		var penalty float64
+		var state string
+		switch {
+		case !op.Available:
+			penalty, state = operatorUnavailablePenalty, "is not Available"
+		case op.Degraded:
+			penalty, state = operatorDegradedPenalty, "is Degraded"
+		default:
+			continue
+		}
+		score -= penalty
+		message := fmt.Sprintf("ClusterOperator %s %s", op.Name, state)
+		if op.Message != "" {
+			message += ": " + op.Message
+		}
+		findings = append(findings, Finding{
+			Dimension: DimensionOperators,
+			Severity:  SeverityCritical,
+			Message:   message,
+			Resource:  "clusteroperator/" + op.Name,
+			Penalty:   penalty,
+		})
+	}
+
+	return score, limitFindings(DimensionOperators, findings)
+}
+
+// StorageScorer scores PersistentVolumeClaim binding
+type StorageScorer struct{}
+
+// Dimension returns the dimension name
+func (s *StorageScorer) Dimension() string { return DimensionStorage }
+
+// Observed reports whether PVCs could be listed
+func (s *StorageScorer) Observed(snapshot *Snapshot) bool { return snapshot.PVCsObserved }
+
+// Score deducts each claim's share for claims stuck Pending or Lost
+func (s *StorageScorer) Score(snapshot *Snapshot, cfg *Config) (float64, []Finding) {
+	if !snapshot.PVCsObserved {
+		return 100, []Finding{{
+			Dimension: DimensionStorage,
+			Severity:  SeverityInfo,
+			Message:   "PersistentVolumeClaims not observable (missing RBAC?)",
+		}}
+	}
+
+	var pvcs []*corev1.PersistentVolumeClaim
+	for i := range snapshot.PVCs {
+		if !cfg.IsNamespaceIgnored(snapshot.PVCs[i].Namespace) {
+			pvcs = append(pvcs, &snapshot.PVCs[i])
+		}
+	}
+	if len(pvcs) == 0 {
+		return 100, nil
+	}
+
+	share := 100.0 / float64(len(pvcs))
+	score := 100.0
+	var findings []Finding
+	now := time.Now()
+
+	for _, pvc := range pvcs {
+		resource := fmt.Sprintf("pvc/%s/%s", pvc.Namespace, pvc.Name)
+		switch pvc.Status.Phase {
+		case corev1.ClaimLost:
+			score -= share
+			findings = append(findings, Finding{
+				Dimension: DimensionStorage,
+				Severity:  SeverityCritical,
+				Message:   fmt.Sprintf("PVC %s/%s lost its bound volume", pvc.Namespace, pvc.Name),
+				Resource:  resource,
+				Penalty:   roundScore(share),
+			})
+		case corev1.ClaimPending:
+			if now.Sub(pvc.CreationTimestamp.Time) <= pendingGracePeriod {
+				continue
+			}
+			score -= share
+			findings = append(findings, Finding{
+				Dimension: DimensionStorage,
+				Severity:  SeverityWarning,
+				Message:   fmt.Sprintf("PVC %s/%s has been Pending for over %s", pvc.Namespace, pvc.Name, pendingGracePeriod),
+				Resource:  resource,
+				Penalty:   roundScore(share),
+			})
+		}
+	}
+
+	return score, limitFindings(DimensionStorage, findings)
+}
+
+// NetworkingScorer scores node network availability and networking pods
+type NetworkingScorer struct{}
+
+// Dimension returns the dimension name
+func (s *NetworkingScorer) Dimension() string { return DimensionNetworking }
+
+// Score deducts a node's share for NetworkUnavailable nodes and half a
+// pod's share for each unready networking pod
+func (s *NetworkingScorer) Score(snapshot *Snapshot, cfg *Config) (float64, []Finding) {
+	var netPods []*corev1.Pod
+	for i := range snapshot.Pods {
+		pod := &snapshot.Pods[i]
+		if isNetworkingPod(pod) && pod.Status.Phase != corev1.PodSucceeded {
+			netPods = append(netPods, pod)
+		}
+	}
+
+	score := 100.0
+	var findings []Finding
+
+	if n := len(snapshot.Nodes); n > 0 {
+		share := 100.0 / float64(n)
+		for i := range snapshot.Nodes {
+			node := &snapshot.Nodes[i]
+			if hasNodeCondition(node, corev1.NodeNetworkUnavailable) {
+				score -= share
+				findings = append(findings, Finding{
+					Dimension: DimensionNetworking,
+					Severity:  SeverityWarning,
+					Message:   fmt.Sprintf("Node %s reports NetworkUnavailable", node.Name),
+					Resource:  "node/" + node.Name,
+					Penalty:   roundScore(share),
+				})
+			}
+		}
+	}
+
+	if len(netPods) == 0 {
+		findings = append(findings, Finding{
+			Dimension: DimensionNetworking,
+			Severity:  SeverityInfo,
+			Message:   "No cluster networking pods observed",
+		})
+		return score, limitFindings(DimensionNetworking, findings)
+	}
+
+	share := 100.0 / float64(len(netPods))
+	for _, pod := range netPods {
+		if !isPodReady(pod) {
+			score -= share / 2
+			findings = append(findings, Finding{
+				Dimension: DimensionNetworking,
+				Severity:  SeverityWarning,
+				Message:   fmt.Sprintf("Networking pod %s/%s is not ready (%s)", pod.Namespace, pod.Name, pod.Status.Phase),
+				Resource:  fmt.Sprintf("pod/%s/%s", pod.Namespace, pod.Name),
+				Penalty:   roundScore(share / 2),
+			})
+		}
+	}
+
+	return score, limitFindings(DimensionNetworking, findings)
+}
+
+// limitFindings keeps the first maxFindingsPerDimension findings and
+// summarizes the rest
+func limitFindings(dimension string, findings []Finding) []Finding {
+	if len(findings) <= maxFindingsPerDimension {
+		return findings
+	}
+	var rest float64
+	for _, f := range findings[maxFindingsPerDimension:] {
+		rest += f.Penalty
+	}
+	limited := append([]Finding{}, findings[:maxFindingsPerDimension]...)
+	return append(limited, Finding{
+		Dimension: dimension,
+		Severity:  SeverityInfo,
+		Message:   fmt.Sprintf("%d more findings omitted", len(findings)-maxFindingsPerDimension),
+		Penalty:   roundScore(rest),
+	})
+}
+
+func isNodeReady(node *corev1.Node) bool {
+	for _, c := range node.Status.Conditions {
+		if c.Type == corev1.NodeReady {
+			return c.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+func countReadyNodes(nodes []corev1.Node) int {
+	ready := 0
+	for i := range nodes {
+		if isNodeReady(&nodes[i]) {
+			ready++
+		}
+	}
+	return ready
+}
+
+func hasNodeCondition(node *corev1.Node, condType corev1.NodeConditionType) bool {
+	for _, c := range node.Status.Conditions {
+		if c.Type == condType {
+			return c.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+func conditionReason(node *corev1.Node, condType corev1.NodeConditionType) string {
+	for _, c := range node.Status.Conditions {
+		if c.Type == condType && c.Reason != "" {
+			return " (" + c.Reason + ")"
+		}
+	}
+	return ""
+}
+
+// controlPlaneObjects returns the control plane nodes and long-running pods
+func controlPlaneObjects(snapshot *Snapshot) ([]*corev1.Node, []*corev1.Pod) {
+	var cpNodes []*corev1.Node
+	for i := range snapshot.Nodes {
+		if isControlPlaneNode(&snapshot.Nodes[i]) {
+			cpNodes = append(cpNodes, &snapshot.Nodes[i])
+		}
+	}
+	var cpPods []*corev1.Pod
+	for i := range snapshot.Pods {
+		pod := &snapshot.Pods[i]
+		if isControlPlanePod(pod) && !isOneShotPod(pod) {
+			cpPods = append(cpPods, pod)
+		}
+	}
+	return cpNodes, cpPods
+}
+
+func isControlPlaneNode(node *corev1.Node) bool {
+	_, master := node.Labels["node-role.kubernetes.io/master"]
+	_, cp := node.Labels["node-role.kubernetes.io/control-plane"]
+	return master || cp
+}
+
+func isControlPlanePod(pod *corev1.Pod) bool {
+	if controlPlaneNamespaces[pod.Namespace] {
+		return true
+	}
+	if pod.Namespace == "kube-system" {
+		return pod.Labels["tier"] == "control-plane" || controlPlaneComponents[pod.Labels["component"]]
+	}
+	return false
+}
+
+// isOneShotPod identifies completed or run-to-completion helper pods that
+// OpenShift operators create in control plane namespaces
+func isOneShotPod(pod *corev1.Pod) bool {
+	if pod.Status.Phase == corev1.PodSucceeded {
+		return true
+	}
+	for _, prefix := range []string{"installer-", "revision-pruner-"} {
+		if strings.HasPrefix(pod.Name, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func isNetworkingPod(pod *corev1.Pod) bool {
+	if networkingNamespaces[pod.Namespace] {
+		return true
+	}
+	if pod.Namespace == "kube-system" {
+		return networkingApps[pod.Labels["k8s-app"]]
+	}
+	return false
+}
+
+func isPodReady(pod *corev1.Pod) bool {
+	for _, c := range pod.Status.Conditions {
+		if c.Type == corev1.PodReady {
+			return c.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+func isOwnedBy(pod *corev1.Pod, kind string) bool {
+	for _, ref := range pod.OwnerReferences {
+		if ref.Kind == kind {
+			return true
+		}
+	}
+	return false
+}
+
+func waitingFailureReason(pod *corev1.Pod) string {
+	for _, cs := range pod.Status.ContainerStatuses {
+		if cs.State.Waiting != nil && waitingFailureReasons[cs.State.Waiting.Reason] {
+			return cs.State.Waiting.Reason
+		}
+	}
+	return ""
+}
+
+func reasonSuffix(reason string) string {
+	if reason == "" {
+		return ""
+	}
+	return " (" + reason + ")"
+}
@@ -0,0 +1,247 @@
+package history
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/KubeHeal/openshift-cluster-health-mcp/pkg/clients"
+)
+
+func sampleAt(base time.Time, minutes int, status string, notReady, failed int) Sample {
+	return Sample{
+		Timestamp: base.Add(time.Duration(minutes) * time.Minute),
+		Status:    status,
+		Nodes:     clients.NodeHealth{Total: 3, Ready: 3 - notReady, NotReady: notReady},
+		Pods:      clients.PodHealth{Total: 10, Running: 10 - failed, Failed: failed},
+	}
+}
+
+func TestStore_RingBufferWraps(t *testing.T) {
+	store := NewStore(3)
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	for i := 0; i < 5; i++ {
+		store.Add(sampleAt(base, i, "healthy", 0, 0))
+	}
+
+	if store.Len() != 3 {
+		t.Fatalf("Expected 3 samples, got %d", store.Len())
+	}
+
+	samples := store.Snapshot()
+	for i, sample := range samples {
+		expected := base.Add(time.Duration(i+2) * time.Minute)
+		if !sample.Timestamp.Equal(expected) {
+			t.Errorf("Sample %d: expected timestamp %v, got %v", i, expected, sample.Timestamp)
+		}
+	}
+
+	latest, ok := store.Latest()
+	if !ok || !latest.Timestamp.Equal(base.Add(4*time.Minute)) {
+		t.Errorf("Expected latest sample at minute 4, got %v", latest.Timestamp)
+	}
+}
+
+func TestStore_Range(t *testing.T) {
+	store := NewStore(10)
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < 10; i++ {
+		store.Add(sampleAt(base, i, "healthy", 0, 0))
+	}
+
+	samples := store.Range(base.Add(3*time.Minute), base.Add(5*time.Minute))
+	if len(samples) != 3 {
+		t.Errorf("Expected 3 samples in range, got %d", len(samples))
+	}
+}
+
+func TestDetectTransitions(t *testing.T) {
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	samples := []Sample{
+		sampleAt(base, 0, "healthy", 0, 0),
+		sampleAt(base, 1, "healthy", 0, 0),
+		sampleAt(base, 2, "degraded", 1, 0),
+		sampleAt(base, 3, "degraded", 1, 2),
+		sampleAt(base, 4, "healthy", 0, 0),
+	}
+
+	transitions := DetectTransitions(samples)
+	if len(transitions) != 2 {
+		t.Fatalf("Expected 2 transitions, got %d", len(transitions))
+	}
+
+	first := transitions[0]
+	if first.From != "healthy" || first.To != "degraded" {
+		t.Errorf("Expected healthy→degraded, got %s→%s", first.From, first.To)
+	}
+	if !first.Timestamp.Equal(base.Add(2 * time.Minute)) {
+		t.Errorf("Expected transition at minute 2, got %v", first.Timestamp)
+	}
+	if len(first.Changes) == 0 {
+		t.Error("Expected transition to describe changed counters")
+	}
+}
+
+func TestBuildTimeline_DownsamplesToWorstStatus(t *testing.T) {
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	var samples []Sample
+	for i := 0; i < 10; i++ {
+		status := "healthy"
+		if i == 3 {
+			status = "degraded" // Short blip inside the first 5m bucket
+		}
+		samples = append(samples, sampleAt(base, i, status, 0, 0))
+	}
+
+	timeline := BuildTimeline(samples, base, base.Add(10*time.Minute), 5*time.Minute)
+
+	if len(timeline.Points) != 2 {
+		t.Fatalf("Expected 2 points, got %d", len(timeline.Points))
+	}
+	if timeline.Points[0].Status != "degraded" {
+		t.Errorf("Expected first bucket to keep worst status 'degraded', got %s", timeline.Points[0].Status)
+	}
+	if timeline.Points[0].Samples != 5 {
+		t.Errorf("Expected 5 samples in first bucket, got %d", timeline.Points[0].Samples)
+	}
+	if len(timeline.Transitions) != 2 {
+		t.Errorf("Expected blip to produce 2 transitions, got %d", len(timeline.Transitions))
+	}
+	if timeline.CurrentStatus != "healthy" {
+		t.Errorf("Expected current status healthy, got %s", timeline.CurrentStatus)
+	}
+	if timeline.StatusSince == nil || !timeline.StatusSince.Equal(base.Add(4*time.Minute)) {
+		t.Errorf("Expected status_since at minute 4, got %v", timeline.StatusSince)
+	}
+}
+
+func TestBuildTimeline_Empty(t *testing.T) {
+	now := time.Now()
+	timeline := BuildTimeline(nil, now.Add(-time.Hour), now, time.Minute)
+
+	if timeline.SampleCount != 0 {
+		t.Errorf("Expected 0 samples, got %d", timeline.SampleCount)
+	}
+	if timeline.Points == nil || timeline.Transitions == nil {
+		t.Error("Expected empty (non-nil) points and transitions for JSON output")
+	}
+	if timeline.StatusSince != nil {
+		t.Error("Expected no status_since without samples")
+	}
+}
+
+func TestSampler_SampleOnceAndPersist(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history.json")
+	calls := 0
+	collect := func(ctx context.Context) (*Sample, error) {
+		calls++
+		return &Sample{Status: "healthy"}, nil
+	}
+
+	sampler := NewSampler(collect, SamplerConfig{Capacity: 5, PersistPath: path})
+	for i := 0; i < 3; i++ {
+		if err := sampler.SampleOnce(context.Background()); err != nil {
+			t.Fatalf("SampleOnce failed: %v", err)
+		}
+	}
+	if sampler.Store().Len() != 3 {
+		t.Fatalf("Expected 3 samples, got %d", sampler.Store().Len())
+	}
+
+	// A new sampler should restore persisted samples
+	restored := NewSampler(collect, SamplerConfig{Capacity: 5, PersistPath: path})
+	if restored.Store().Len() != 3 {
+		t.Errorf("Expected 3 restored samples, got %d", restored.Store().Len())
+	}
+}
+
+func TestSampler_CollectError(t *testing.T) {
+	collect := func(ctx context.Context) (*Sample, error) {
+		return nil, fmt.Errorf("api unavailable")
+	}
+
+	sampler := NewSampler(collect, SamplerConfig{Capacity: 5})
+	if err := sampler.SampleOnce(context.Background()); err == nil {
+		t.Error("Expected error from failing collector")
+	}
+	if sampler.Store().Len() != 0 {
+		t.Error("Expected no sample recorded on error")
+	}
+}
+
+func TestSampler_StartStop(t *testing.T) {
+	collected := make(chan struct{}, 1)
+	collect := func(ctx context.Context) (*Sample, error) {
+		select {
+		case collected <- struct{}{}:
+		default:
+		}
+		return &Sample{Status: "healthy"}, nil
+	}
+
+	sampler := NewSampler(collect, SamplerConfig{Interval: time.Hour, Capacity: 5})
+	sampler.Start()
+
+	select {
+	case <-collected:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Expected an immediate sample after Start")
+	}
+
+	sampler.Stop()
+	sampler.Stop() // Stop must be idempotent
+}
+
+func TestSampler_StopWithoutStart(t *testing.T) {
+	collect := func(ctx context.Context) (*Sample, error) {
+		t.Error("Unexpected collection from a sampler that was never started")
+		return &Sample{}, nil
+	}
+	sampler := NewSampler(collect, SamplerConfig{Interval: time.Hour, Capacity: 5})
+
+	stopped := make(chan struct{})
+	go func() {
+		sampler.Stop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Stop without Start deadlocked")
+	}
+	sampler.Start() // Start after Stop is a no-op
+}
+
+func TestTopRestarters(t *testing.T) {
+	pod := func(name string, restarts ...int32) corev1.Pod {
+		p := corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"}}
+		for _, r := range restarts {
+			p.Status.ContainerStatuses = append(p.Status.ContainerStatuses, corev1.ContainerStatus{RestartCount: r})
+		}
+		return p
+	}
+
+	pods := []corev1.Pod{
+		pod("stable", 0),
+		pod("flaky", 2, 1),
+		pod("crashing", 40),
+		pod("sidecar-restarts", 0, 5),
+	}
+
+	result := topRestarters(pods, 2)
+	if len(result) != 2 {
+		t.Fatalf("Expected 2 restarters, got %d", len(result))
+	}
+	if result[0].Pod != "crashing" || result[0].Restarts != 40 {
+		t.Errorf("Expected crashing pod first, got %+v", result[0])
+	}
+	if result[1].Pod != "sidecar-restarts" {
+		t.Errorf("Expected sidecar-restarts second, got %+v", result[1])
+	}
+}
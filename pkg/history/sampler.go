@@ -0,0 +1,252 @@
+package history
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/KubeHeal/openshift-cluster-health-mcp/pkg/clients"
+)
+
+// CollectFunc produces a single health sample
+type CollectFunc func(ctx context.Context) (*Sample, error)
+
+// SamplerConfig holds configuration for the background sampler
+type SamplerConfig struct {
+	// Interval between samples
+	Interval time.Duration // Default: 60s
+
+	// Capacity is the maximum number of samples kept in memory
+	Capacity int // Default: 1440 (24h at 1 minute resolution)
+
+	// PersistPath optionally spills the buffer to a file (e.g. on a PVC)
+	// so history survives pod restarts. Empty disables persistence.
+	PersistPath string
+
+	// Timeout bounds a single collection
+	Timeout time.Duration // Default: 10s
+}
+
+// Sampler periodically collects health samples into a Store
+type Sampler struct {
+	store       *Store
+	collect     CollectFunc
+	interval    time.Duration
+	timeout     time.Duration
+	persistPath string
+	stop        chan struct{}
+	done        chan struct{}
+	startOnce   sync.Once
+	stopOnce    sync.Once
+	persistMu   sync.Mutex
+}
+
+// NewSampler creates a sampler; call Start to begin collecting
+func NewSampler(collect CollectFunc, cfg SamplerConfig) *Sampler {
+	if cfg.Interval <= 0 {
+		cfg.Interval = 60 * time.Second
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = 10 * time.Second
+	}
+
+	s := &Sampler{
+		store:       NewStore(cfg.Capacity),
+		collect:     collect,
+		interval:    cfg.Interval,
+		timeout:     cfg.Timeout,
+		persistPath: cfg.PersistPath,
+		stop:        make(chan struct{}),
+		done:        make(chan struct{}),
+	}
+
+	if s.persistPath != "" {
+		if err := s.load(); err != nil {
+			log.Printf("WARNING: failed to load health history from %s: %v", s.persistPath, err)
+		}
+	}
+
+	return s
+}
+
+// Start launches the background sampling loop; it is a no-op once the
+// sampler has been started or stopped
+func (s *Sampler) Start() {
+	s.startOnce.Do(func() {
+		go s.loop()
+	})
+}
+
+// Stop terminates the sampling loop and waits for it to exit. Stopping a
+// sampler that was never started returns immediately.
+func (s *Sampler) Stop() {
+	s.stopOnce.Do(func() {
+		close(s.stop)
+	})
+	// Never started: there is no loop to close done
+	s.startOnce.Do(func() {
+		close(s.done)
+	})
+	<-s.done
+}
+
+// Store returns the underlying sample store
+func (s *Sampler) Store() *Store {
+	return s.store
+}
+
+// Interval returns the configured sampling interval
+func (s *Sampler) Interval() time.Duration {
+	return s.interval
+}
+
+// loop samples immediately and then on every tick until stopped
+func (s *Sampler) loop() {
+	defer close(s.done)
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	s.sampleAndLog()
+	for {
+		select {
+		case <-ticker.C:
+			s.sampleAndLog()
+		case <-s.stop:
+			return
+		}
+	}
+}
+
+// sampleAndLog runs one collection and logs failures instead of stopping
+func (s *Sampler) sampleAndLog() {
+	ctx, cancel := context.WithTimeout(context.Background(), s.timeout)
+	defer cancel()
+
+	if err := s.SampleOnce(ctx); err != nil {
+		log.Printf("Health history sample failed: %v", err)
+	}
+}
+
+// SampleOnce collects a single sample and records it
+func (s *Sampler) SampleOnce(ctx context.Context) error {
+	sample, err := s.collect(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to collect health sample: %w", err)
+	}
+	if sample.Timestamp.IsZero() {
+		sample.Timestamp = time.Now().UTC()
+	}
+
+	s.store.Add(*sample)
+
+	if s.persistPath != "" {
+		if err := s.persist(); err != nil {
+			return fmt.Errorf("failed to persist health history: %w", err)
+		}
+	}
+	return nil
+}
+
+// persist writes the whole buffer atomically (temp file + rename)
+func (s *Sampler) persist() error {
+	s.persistMu.Lock()
+	defer s.persistMu.Unlock()
+
+	data, err := json.Marshal(s.store.Snapshot())
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(s.persistPath), ".health-history-*")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()           //nolint:errcheck // Best effort cleanup
+		_ = os.Remove(tmp.Name()) //nolint:errcheck // Best effort cleanup
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmp.Name()) //nolint:errcheck // Best effort cleanup
+		return err
+	}
+	return os.Rename(tmp.Name(), s.persistPath)
+}
+
+// load restores samples previously written by persist
+func (s *Sampler) load() error {
+	data, err := os.ReadFile(s.persistPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+
+	var samples []Sample
+	if err := json.Unmarshal(data, &samples); err != nil {
+		return err
+	}
+	for _, sample := range samples {
+		s.store.Add(sample)
+	}
+	log.Printf("Restored %d health history samples from %s", s.store.Len(), s.persistPath)
+	return nil
+}
+
+// NewK8sCollector returns a CollectFunc that samples cluster health from the
+// Kubernetes API, recording the topN pods with the most container restarts
+func NewK8sCollector(k8sClient *clients.K8sClient, topN int) CollectFunc {
+	return func(ctx context.Context) (*Sample, error) {
+		snapshot, err := k8sClient.GetHealthSnapshot(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		health := k8sClient.SummarizeClusterHealth(snapshot)
+		return &Sample{
+			Timestamp:     time.Now().UTC(),
+			Status:        health.Status,
+			Score:         health.Score,
+			Nodes:         health.Nodes,
+			Pods:          health.Pods,
+			TopRestarters: topRestarters(snapshot.Pods, topN),
+		}, nil
+	}
+}
+
+// topRestarters returns the n pods with the highest total restart counts
+func topRestarters(pods []corev1.Pod, n int) []Restarter {
+	restarters := make([]Restarter, 0)
+	for _, pod := range pods {
+		var restarts int32
+		for _, cs := range pod.Status.ContainerStatuses {
+			restarts += cs.RestartCount
+		}
+		if restarts == 0 {
+			continue
+		}
+		restarters = append(restarters, Restarter{
+			Namespace: pod.Namespace,
+			Pod:       pod.Name,
+			Restarts:  restarts,
+		})
+	}
+
+	sort.Slice(restarters, func(i, j int) bool {
+		return restarters[i].Restarts > restarters[j].Restarts
+	})
+	if len(restarters) > n {
+		restarters = restarters[:n]
+	}
+	return restarters
+}
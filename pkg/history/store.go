@@ -0,0 +1,112 @@
+// Package history records periodic cluster health samples in a bounded
+// in-memory ring buffer so the server can answer "when did this change?"
+// questions without an external time-series database.
+package history
+
+import (
+	"sync"
+	"time"
+
+	"github.com/KubeHeal/openshift-cluster-health-mcp/pkg/clients"
+)
+
+// Sample is a single point-in-time health observation
+type Sample struct {
+	Timestamp     time.Time          `json:"timestamp"`
+	Status        string             `json:"status"`
+	Score         float64            `json:"score,omitempty"`
+	Nodes         clients.NodeHealth `json:"nodes"`
+	Pods          clients.PodHealth  `json:"pods"`
+	TopRestarters []Restarter        `json:"top_restarters,omitempty"`
+}
+
+// Restarter identifies a pod with a high container restart count
+type Restarter struct {
+	Namespace string `json:"namespace"`
+	Pod       string `json:"pod"`
+	Restarts  int32  `json:"restarts"`
+}
+
+// Store is a thread-safe, fixed-capacity ring buffer of samples
+type Store struct {
+	mu       sync.RWMutex
+	samples  []Sample
+	start    int // Index of the oldest sample
+	count    int // Number of samples currently held
+	capacity int
+}
+
+// NewStore creates a ring buffer holding at most capacity samples
+func NewStore(capacity int) *Store {
+	if capacity < 1 {
+		capacity = 1440 // Default: 24h at 1 minute resolution
+	}
+	return &Store{
+		samples:  make([]Sample, capacity),
+		capacity: capacity,
+	}
+}
+
+// Add appends a sample, overwriting the oldest one when the buffer is full
+func (s *Store) Add(sample Sample) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.count < s.capacity {
+		s.samples[(s.start+s.count)%s.capacity] = sample
+		s.count++
+		return
+	}
+
+	// Buffer full: overwrite oldest and advance start
+	s.samples[s.start] = sample
+	s.start = (s.start + 1) % s.capacity
+}
+
+// Len returns the number of samples currently held
+func (s *Store) Len() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.count
+}
+
+// Capacity returns the maximum number of samples the store can hold
+func (s *Store) Capacity() int {
+	return s.capacity
+}
+
+// Snapshot returns all samples ordered from oldest to newest
+func (s *Store) Snapshot() []Sample {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	result := make([]Sample, 0, s.count)
+	for i := 0; i < s.count; i++ {
+		result = append(result, s.samples[(s.start+i)%s.capacity])
+	}
+	return result
+}
+
+// Range returns samples with from <= timestamp <= to, oldest first
+func (s *Store) Range(from, to time.Time) []Sample {
+	all := s.Snapshot()
+	result := make([]Sample, 0, len(all))
+	for _, sample := range all {
+		if sample.Timestamp.Before(from) || sample.Timestamp.After(to) {
+			continue
+		}
+		result = append(result, sample)
+	}
+	return result
+}
+
+// Latest returns the newest sample, if any
+func (s *Store) Latest() (Sample, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.count == 0 {
+		return Sample{}, false
+	}
+	return s.samples[(s.start+s.count-1)%s.capacity], true
+}
@@ -0,0 +1,151 @@
+package history
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/KubeHeal/openshift-cluster-health-mcp/pkg/clients"
+)
+
+// Point is a downsampled view of all samples that fall into one step
+type Point struct {
+	Timestamp time.Time          `json:"timestamp"` // Start of the step bucket
+	Status    string             `json:"status"`    // Worst status observed in the bucket
+	MinScore  float64            `json:"min_score"` // Lowest health score observed in the bucket
+	Nodes     clients.NodeHealth `json:"nodes"`     // Counts from the last sample in the bucket
+	Pods      clients.PodHealth  `json:"pods"`      // Counts from the last sample in the bucket
+	Samples   int                `json:"samples"`
+}
+
+// Transition records a change of overall status between two consecutive samples
+type Transition struct {
+	Timestamp time.Time `json:"timestamp"`
+	From      string    `json:"from"`
+	To        string    `json:"to"`
+	Changes   []string  `json:"changes,omitempty"`
+}
+
+// Timeline is the result of downsampling a range of samples
+type Timeline struct {
+	From          time.Time    `json:"from"`
+	To            time.Time    `json:"to"`
+	Step          string       `json:"step"`
+	SampleCount   int          `json:"sample_count"`
+	CurrentStatus string       `json:"current_status,omitempty"`
+	StatusSince   *time.Time   `json:"status_since,omitempty"`
+	Points        []Point      `json:"points"`
+	Transitions   []Transition `json:"transitions"`
+}
+
+// BuildTimeline downsamples samples in [from, to] into step-sized buckets and
+// detects status transitions on the raw (non-downsampled) samples so short
+// degradations are never hidden by the bucket size
+func BuildTimeline(samples []Sample, from, to time.Time, step time.Duration) *Timeline {
+	if step <= 0 {
+		step = time.Minute
+	}
+
+	inRange := make([]Sample, 0, len(samples))
+	for _, sample := range samples {
+		if sample.Timestamp.Before(from) || sample.Timestamp.After(to) {
+			continue
+		}
+		inRange = append(inRange, sample)
+	}
+
+	timeline := &Timeline{
+		From:        from,
+		To:          to,
+		Step:        step.String(),
+		SampleCount: len(inRange),
+		Points:      []Point{},
+		Transitions: DetectTransitions(inRange),
+	}
+
+	var current *Point
+	for _, sample := range inRange {
+		bucket := from.Add(sample.Timestamp.Sub(from) / step * step)
+		if current == nil || !current.Timestamp.Equal(bucket) {
+			if current != nil {
+				timeline.Points = append(timeline.Points, *current)
+			}
+			current = &Point{Timestamp: bucket, Status: sample.Status, MinScore: sample.Score}
+		}
+		if statusSeverity(sample.Status) > statusSeverity(current.Status) {
+			current.Status = sample.Status
+		}
+		if sample.Score < current.MinScore {
+			current.MinScore = sample.Score
+		}
+		current.Nodes = sample.Nodes
+		current.Pods = sample.Pods
+		current.Samples++
+	}
+	if current != nil {
+		timeline.Points = append(timeline.Points, *current)
+	}
+
+	if len(inRange) > 0 {
+		last := inRange[len(inRange)-1]
+		timeline.CurrentStatus = last.Status
+
+		// The status has held since the last transition into it, or at
+		// least since the start of the range when no transition occurred
+		since := inRange[0].Timestamp
+		if n := len(timeline.Transitions); n > 0 {
+			since = timeline.Transitions[n-1].Timestamp
+		}
+		timeline.StatusSince = &since
+	}
+
+	return timeline
+}
+
+// DetectTransitions returns every change of overall status between
+// consecutive samples, annotated with the counters that changed
+func DetectTransitions(samples []Sample) []Transition {
+	transitions := []Transition{}
+	for i := 1; i < len(samples); i++ {
+		prev, curr := samples[i-1], samples[i]
+		if prev.Status == curr.Status {
+			continue
+		}
+		transitions = append(transitions, Transition{
+			Timestamp: curr.Timestamp,
+			From:      prev.Status,
+			To:        curr.Status,
+			Changes:   describeChanges(prev, curr),
+		})
+	}
+	return transitions
+}
+
+// describeChanges lists the health counters that differ between two samples
+func describeChanges(prev, curr Sample) []string {
+	var changes []string
+	add := func(label string, before, after int) {
+		if before != after {
+			changes = append(changes, fmt.Sprintf("%s %d→%d", label, before, after))
+		}
+	}
+	add("ready nodes", prev.Nodes.Ready, curr.Nodes.Ready)
+	add("not-ready nodes", prev.Nodes.NotReady, curr.Nodes.NotReady)
+	add("pending pods", prev.Pods.Pending, curr.Pods.Pending)
+	add("failed pods", prev.Pods.Failed, curr.Pods.Failed)
+	add("unknown pods", prev.Pods.Unknown, curr.Pods.Unknown)
+	return changes
+}
+
+// statusSeverity orders health statuses from best to worst
+func statusSeverity(status string) int {
+	switch status {
+	case "healthy":
+		return 0
+	case "degraded":
+		return 1
+	case "unhealthy":
+		return 2
+	default:
+		return 1
+	}
+}
@@ -0,0 +1,109 @@
+package redact
+
+import (
+	"bufio"
+	"io"
+	"regexp"
+	"strings"
+)
+
+var (
+	pemKeyBegin = regexp.MustCompile(`-----BEGIN [A-Z ]*PRIVATE KEY-----`)
+	pemKeyEnd   = regexp.MustCompile(`-----END [A-Z ]*PRIVATE KEY-----`)
+	// pemBodyLine matches a base64 line of a PEM body, optionally after a
+	// log timestamp
+	pemBodyLine = regexp.MustCompile(`^(\S+ )?[A-Za-z0-9+/]{16,}={0,2}\r?$`)
+)
+
+// Reader masks secrets in a stream line by line, so output can be cut
+// anywhere afterwards without exposing part of a secret. Private key blocks
+// are tracked across lines: a block is masked even when the stream starts
+// inside it (e.g. a log tail), up to its END line.
+type Reader struct {
+	src     *bufio.Reader
+	pending []byte
+	held    []string // Possible key body lines seen before an END line
+	inKey   bool
+	count   int
+	err     error
+}
+
+// NewReader returns a Reader masking secrets read from r
+func NewReader(r io.Reader) *Reader {
+	return &Reader{src: bufio.NewReader(r)}
+}
+
+// Count returns the number of redactions made so far
+func (r *Reader) Count() int {
+	return r.count
+}
+
+// Read implements io.Reader
+func (r *Reader) Read(p []byte) (int, error) {
+	for len(r.pending) == 0 {
+		if r.err != nil {
+			// Lines held as a possible key body were not followed by an END line
+			if len(r.held) > 0 {
+				r.release()
+				continue
+			}
+			return 0, r.err
+		}
+		var line string
+		line, r.err = r.src.ReadString('\n')
+		if line != "" {
+			r.pending = append(r.pending, r.line(line)...)
+		}
+	}
+	n := copy(p, r.pending)
+	r.pending = r.pending[n:]
+	return n, nil
+}
+
+// line redacts one line, which includes its trailing newline if any
+func (r *Reader) line(line string) string {
+	body := strings.TrimSuffix(line, "\n")
+	newline := line[len(body):]
+
+	if r.inKey {
+		if pemKeyEnd.MatchString(body) {
+			r.inKey = false
+		}
+		return ""
+	}
+
+	if loc := pemKeyBegin.FindStringIndex(body); loc != nil && !pemKeyEnd.MatchString(body[loc[1]:]) {
+		r.release()
+		r.inKey = true
+		r.count++
+		prefix, n := String(body[:loc[0]])
+		r.count += n
+		return prefix + Placeholder + newline
+	}
+
+	// The stream started inside a key block: mask the body held so far
+	if pemKeyEnd.MatchString(body) && !pemKeyBegin.MatchString(body) {
+		r.held = nil
+		r.count++
+		return Placeholder + newline
+	}
+	if pemBodyLine.MatchString(body) {
+		r.held = append(r.held, line)
+		return ""
+	}
+
+	r.release()
+	masked, n := String(line)
+	r.count += n
+	return masked
+}
+
+// release emits held lines that turned out not to be a key body
+func (r *Reader) release() {
+	for _, held := range r.held {
+		masked, n := String(held)
+		r.count += n
+		r.pending = append(r.pending, masked...)
+	}
+	r.held = nil
+}
@@ -0,0 +1,176 @@
+// Package scheduling simulates the kube-scheduler filter phase against a
+// snapshot of nodes and pods, explaining why a pod does or does not fit on
+// each node. It covers the common predicates (resources, taints, node
+// affinity, topology spread, volume zones, pod count) rather than the full
+// scheduler framework.
+package scheduling
+
+import (
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Resources is a CPU/memory quantity in scheduler units
+type Resources struct {
+	MilliCPU int64 `json:"cpu_millicores"`
+	Memory   int64 `json:"memory_bytes"`
+}
+
+// Add returns r + o
+func (r Resources) Add(o Resources) Resources {
+	return Resources{MilliCPU: r.MilliCPU + o.MilliCPU, Memory: r.Memory + o.Memory}
+}
+
+// Sub returns r - o
+func (r Resources) Sub(o Resources) Resources {
+	return Resources{MilliCPU: r.MilliCPU - o.MilliCPU, Memory: r.Memory - o.Memory}
+}
+
+// PodRequests returns the effective scheduling requests of a pod (see
+// SumContainers) plus overhead
+func PodRequests(pod *corev1.Pod) Resources {
+	return SumContainers(&pod.Spec, ContainerRequests).Add(OverheadOf(&pod.Spec))
+}
+
+// SumContainers applies the Kubernetes effective-resource rule to a per
+// container quantity: app containers and restartable (sidecar) init
+// containers run together and are summed. Ordinary init containers run one
+// at a time, alongside the sidecars started before them, so each needs its
+// own quantity plus those sidecars. The pod needs the larger of the running
+// sum and the largest init step. Overhead is not included.
+//
+// For limits, a container without one reads as 0 here; callers must treat
+// the pod as unbounded in that case rather than use the partial sum.
+func SumContainers(spec *corev1.PodSpec, perContainer func(*corev1.Container) Resources) Resources {
+	var containers, sidecars, initMax Resources
+	for i := range spec.Containers {
+		containers = containers.Add(perContainer(&spec.Containers[i]))
+	}
+	for i := range spec.InitContainers {
+		c := &spec.InitContainers[i]
+		r := perContainer(c)
+		// Sidecar init containers (restartPolicy Always) keep running through
+		// later init containers and alongside app containers
+		if IsSidecar(c) {
+			sidecars = sidecars.Add(r)
+			continue
+		}
+		step := r.Add(sidecars)
+		initMax.MilliCPU = max(initMax.MilliCPU, step.MilliCPU)
+		initMax.Memory = max(initMax.Memory, step.Memory)
+	}
+	containers = containers.Add(sidecars)
+	return Resources{
+		MilliCPU: max(containers.MilliCPU, initMax.MilliCPU),
+		Memory:   max(containers.Memory, initMax.Memory),
+	}
+}
+
+// IsSidecar reports whether an init container is restartable (restartPolicy
+// Always) and so runs for the lifetime of the pod
+func IsSidecar(c *corev1.Container) bool {
+	return c.RestartPolicy != nil && *c.RestartPolicy == corev1.ContainerRestartPolicyAlways
+}
+
+// OverheadOf returns the RuntimeClass overhead recorded on a pod spec
+func OverheadOf(spec *corev1.PodSpec) Resources {
+	if spec.Overhead == nil {
+		return Resources{}
+	}
+	return Resources{MilliCPU: spec.Overhead.Cpu().MilliValue(), Memory: spec.Overhead.Memory().Value()}
+}
+
+// ContainerRequests returns container requests, falling back to limits when
+// requests are unset (the API server defaults requests to limits)
+func ContainerRequests(c *corev1.Container) Resources {
+	var r Resources
+	if q, ok := c.Resources.Requests[corev1.ResourceCPU]; ok {
+		r.MilliCPU = q.MilliValue()
+	} else if q, ok := c.Resources.Limits[corev1.ResourceCPU]; ok {
+		r.MilliCPU = q.MilliValue()
+	}
+	if q, ok := c.Resources.Requests[corev1.ResourceMemory]; ok {
+		r.Memory = q.Value()
+	} else if q, ok := c.Resources.Limits[corev1.ResourceMemory]; ok {
+		r.Memory = q.Value()
+	}
+	return r
+}
+
+// NodeInfo is a node with the pods assigned to it
+type NodeInfo struct {
+	Node        *corev1.Node
+	Pods        []*corev1.Pod
+	Allocatable Resources
+	Requested   Resources
+	AllowedPods int64
+}
+
+// Free returns allocatable minus requested resources
+func (n *NodeInfo) Free() Resources {
+	return n.Allocatable.Sub(n.Requested)
+}
+
+// AddPod assigns a pod to the node
+func (n *NodeInfo) AddPod(pod *corev1.Pod) {
+	n.Pods = append(n.Pods, pod)
+	n.Requested = n.Requested.Add(PodRequests(pod))
+}
+
+// RemovePod unassigns a pod from the node; it reports whether the pod was found
+func (n *NodeInfo) RemovePod(pod *corev1.Pod) bool {
+	for i, p := range n.Pods {
+		if p.Namespace == pod.Namespace && p.Name == pod.Name {
+			n.Pods = append(n.Pods[:i], n.Pods[i+1:]...)
+			n.Requested = n.Requested.Sub(PodRequests(p))
+			return true
+		}
+	}
+	return false
+}
+
+// NewNodeInfo creates a NodeInfo with no pods
+func NewNodeInfo(node *corev1.Node) *NodeInfo {
+	return &NodeInfo{
+		Node: node,
+		Allocatable: Resources{
+			MilliCPU: node.Status.Allocatable.Cpu().MilliValue(),
+			Memory:   node.Status.Allocatable.Memory().Value(),
+		},
+		AllowedPods: node.Status.Allocatable.Pods().Value(),
+	}
+}
+
+// NewNodeInfos builds NodeInfos for nodes and assigns every non-terminal,
+// bound pod to its node; the result preserves node order
+func NewNodeInfos(nodes []corev1.Node, pods []corev1.Pod) []*NodeInfo {
+	infos := make([]*NodeInfo, 0, len(nodes))
+	byName := make(map[string]*NodeInfo, len(nodes))
+	for i := range nodes {
+		info := NewNodeInfo(&nodes[i])
+		infos = append(infos, info)
+		byName[nodes[i].Name] = info
+	}
+	for i := range pods {
+		pod := &pods[i]
+		if pod.Spec.NodeName == "" || IsTerminal(pod) {
+			continue
+		}
+		if info, ok := byName[pod.Spec.NodeName]; ok {
+			info.AddPod(pod)
+		}
+	}
+	return infos
+}
+
+// IsTerminal reports whether a pod no longer consumes node resources
+func IsTerminal(pod *corev1.Pod) bool {
+	return pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed
+}
+
+// IsPending reports whether a pod is waiting to be scheduled
+func IsPending(pod *corev1.Pod) bool {
+	if pod.Status.Phase != corev1.PodPending || pod.Spec.NodeName != "" || pod.DeletionTimestamp != nil {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,389 @@
+package scheduling
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// Predicate names, one per filter the simulator evaluates
+const (
+	PredicateNodeUnschedulable = "NodeUnschedulable"
+	PredicateNodeNotReady      = "NodeNotReady"
+	PredicateNodeAffinity      = "NodeAffinity"
+	PredicateTaints            = "TaintToleration"
+	PredicateCPU               = "InsufficientCPU"
+	PredicateMemory            = "InsufficientMemory"
+	PredicatePodCount          = "TooManyPods"
+	PredicateTopologySpread    = "PodTopologySpread"
+	PredicateVolumeZone        = "VolumeNodeAffinity"
+)
+
+// Rejection explains why a node cannot run a pod
+type Rejection struct {
+	Predicate string `json:"predicate"`
+	Reason    string `json:"reason"`
+	// Shortfall is the missing amount for resource predicates (millicores or bytes)
+	Shortfall int64 `json:"shortfall,omitempty"`
+
+	taint       *corev1.Taint
+	topologyKey string
+}
+
+// Simulator evaluates pods against a snapshot of nodes
+type Simulator struct {
+	Nodes []*NodeInfo
+	// VolumeNodeAffinity maps "namespace/claim" to the node affinity of the bound PV
+	VolumeNodeAffinity map[string]*corev1.VolumeNodeAffinity
+	// UnboundClaims lists "namespace/claim" keys of PVCs that are not bound and
+	// will not be bound by the scheduler (Immediate binding mode)
+	UnboundClaims map[string]bool
+}
+
+// NewSimulator creates a simulator over the given nodes
+func NewSimulator(nodes []*NodeInfo) *Simulator {
+	return &Simulator{
+		Nodes:              nodes,
+		VolumeNodeAffinity: make(map[string]*corev1.VolumeNodeAffinity),
+		UnboundClaims:      make(map[string]bool),
+	}
+}
+
+// CheckNode runs every predicate for pod on node and returns the rejections;
+// an empty result means the pod fits
+func (s *Simulator) CheckNode(pod *corev1.Pod, node *NodeInfo) []Rejection {
+	var rejections []Rejection
+	rejections = append(rejections, checkNodeConditions(pod, node)...)
+	rejections = append(rejections, checkTaints(pod, node)...)
+	if r := checkNodeAffinity(pod, node.Node); r != nil {
+		rejections = append(rejections, *r)
+	}
+	rejections = append(rejections, checkResources(pod, node)...)
+	rejections = append(rejections, s.checkVolumeZones(pod, node.Node)...)
+	rejections = append(rejections, s.checkTopologySpread(pod, node)...)
+	return rejections
+}
+
+// checkNodeConditions rejects cordoned and not-ready nodes unless tolerated
+func checkNodeConditions(pod *corev1.Pod, node *NodeInfo) []Rejection {
+	var rejections []Rejection
+	unschedulable := corev1.Taint{Key: corev1.TaintNodeUnschedulable, Effect: corev1.TaintEffectNoSchedule}
+	if node.Node.Spec.Unschedulable && !tolerates(pod, &unschedulable) {
+		rejections = append(rejections, Rejection{
+			Predicate: PredicateNodeUnschedulable,
+			Reason:    "node is cordoned (unschedulable)",
+		})
+	}
+	notReady := corev1.Taint{Key: corev1.TaintNodeNotReady, Effect: corev1.TaintEffectNoSchedule}
+	if !nodeReady(node.Node) && !tolerates(pod, &notReady) {
+		rejections = append(rejections, Rejection{
+			Predicate: PredicateNodeNotReady,
+			Reason:    "node is not Ready",
+		})
+	}
+	return rejections
+}
+
+// checkTaints rejects NoSchedule/NoExecute taints the pod does not tolerate;
+// node lifecycle taints are reported by checkNodeConditions instead
+func checkTaints(pod *corev1.Pod, node *NodeInfo) []Rejection {
+	var rejections []Rejection
+	for i := range node.Node.Spec.Taints {
+		taint := &node.Node.Spec.Taints[i]
+		if taint.Effect == corev1.TaintEffectPreferNoSchedule {
+			continue
+		}
+		switch taint.Key {
+		case corev1.TaintNodeUnschedulable, corev1.TaintNodeNotReady, corev1.TaintNodeUnreachable:
+			continue
+		}
+		if tolerates(pod, taint) {
+			continue
+		}
+		rejections = append(rejections, Rejection{
+			Predicate: PredicateTaints,
+			Reason:    "untolerated taint " + taintString(taint),
+			taint:     taint,
+		})
+	}
+	return rejections
+}
+
+// checkNodeAffinity evaluates nodeSelector and required node affinity
+func checkNodeAffinity(pod *corev1.Pod, node *corev1.Node) *Rejection {
+	var mismatched []string
+	keys := make([]string, 0, len(pod.Spec.NodeSelector))
+	for key := range pod.Spec.NodeSelector {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		want := pod.Spec.NodeSelector[key]
+		if got, ok := node.Labels[key]; !ok || got != want {
+			mismatched = append(mismatched, fmt.Sprintf("%s=%s", key, want))
+		}
+	}
+	if len(mismatched) > 0 {
+		return &Rejection{
+			Predicate: PredicateNodeAffinity,
+			Reason:    "nodeSelector not matched: " + strings.Join(mismatched, ", "),
+		}
+	}
+
+	if a := pod.Spec.Affinity; a != nil && a.NodeAffinity != nil && a.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution != nil {
+		terms := a.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms
+		if !MatchNodeSelectorTerms(node, terms) {
+			return &Rejection{
+				Predicate: PredicateNodeAffinity,
+				Reason:    "required node affinity not matched: " + describeTerms(terms),
+			}
+		}
+	}
+	return nil
+}
+
+// checkResources compares pod requests with the node's free resources and pod slots
+func checkResources(pod *corev1.Pod, node *NodeInfo) []Rejection {
+	var rejections []Rejection
+	req := PodRequests(pod)
+	free := node.Free()
+	if req.MilliCPU > 0 && req.MilliCPU > free.MilliCPU {
+		rejections = append(rejections, Rejection{
+			Predicate: PredicateCPU,
+			Reason:    fmt.Sprintf("insufficient cpu: requests %dm, free %dm of %dm allocatable", req.MilliCPU, max(free.MilliCPU, 0), node.Allocatable.MilliCPU),
+			Shortfall: req.MilliCPU - max(free.MilliCPU, 0),
+		})
+	}
+	if req.Memory > 0 && req.Memory > free.Memory {
+		rejections = append(rejections, Rejection{
+			Predicate: PredicateMemory,
+			Reason:    fmt.Sprintf("insufficient memory: requests %s, free %s of %s allocatable", FormatBytes(req.Memory), FormatBytes(max(free.Memory, 0)), FormatBytes(node.Allocatable.Memory)),
+			Shortfall: req.Memory - max(free.Memory, 0),
+		})
+	}
+	if node.AllowedPods > 0 && int64(len(node.Pods))+1 > node.AllowedPods {
+		rejections = append(rejections, Rejection{
+			Predicate: PredicatePodCount,
+			Reason:    fmt.Sprintf("too many pods: %d/%d pod slots used", len(node.Pods), node.AllowedPods),
+		})
+	}
+	return rejections
+}
+
+// checkVolumeZones rejects nodes outside the node affinity of bound PVs
+func (s *Simulator) checkVolumeZones(pod *corev1.Pod, node *corev1.Node) []Rejection {
+	var rejections []Rejection
+	for _, v := range pod.Spec.Volumes {
+		if v.PersistentVolumeClaim == nil {
+			continue
+		}
+		key := pod.Namespace + "/" + v.PersistentVolumeClaim.ClaimName
+		affinity := s.VolumeNodeAffinity[key]
+		if affinity == nil || affinity.Required == nil {
+			continue
+		}
+		if !MatchNodeSelectorTerms(node, affinity.Required.NodeSelectorTerms) {
+			rejections = append(rejections, Rejection{
+				Predicate: PredicateVolumeZone,
+				Reason:    fmt.Sprintf("volume of PVC %s is pinned to %s", v.PersistentVolumeClaim.ClaimName, describeTerms(affinity.Required.NodeSelectorTerms)),
+			})
+		}
+	}
+	return rejections
+}
+
+// checkTopologySpread evaluates DoNotSchedule topology spread constraints
+func (s *Simulator) checkTopologySpread(pod *corev1.Pod, node *NodeInfo) []Rejection {
+	var rejections []Rejection
+	for _, c := range pod.Spec.TopologySpreadConstraints {
+		if c.WhenUnsatisfiable != corev1.DoNotSchedule {
+			continue
+		}
+		domain, ok := node.Node.Labels[c.TopologyKey]
+		if !ok {
+			rejections = append(rejections, Rejection{
+				Predicate:   PredicateTopologySpread,
+				Reason:      fmt.Sprintf("node has no %s label required by topology spread", c.TopologyKey),
+				topologyKey: c.TopologyKey,
+			})
+			continue
+		}
+
+		counts := s.topologyCounts(pod, c)
+		minCount := -1
+		for _, n := range counts {
+			if minCount < 0 || n < minCount {
+				minCount = n
+			}
+		}
+		if c.MinDomains != nil && int32(len(counts)) < *c.MinDomains {
+			minCount = 0
+		}
+		skew := counts[domain] + 1 - max(minCount, 0)
+		if int32(skew) > c.MaxSkew {
+			rejections = append(rejections, Rejection{
+				Predicate:   PredicateTopologySpread,
+				Reason:      fmt.Sprintf("topology spread on %s: placing here gives skew %d > maxSkew %d (%s=%s has %d matching pods, minimum domain has %d)", c.TopologyKey, skew, c.MaxSkew, c.TopologyKey, domain, counts[domain], max(minCount, 0)),
+				topologyKey: c.TopologyKey,
+			})
+		}
+	}
+	return rejections
+}
+
+// topologyCounts counts pods matching the constraint selector per domain, over
+// nodes eligible for the pod by node affinity (the scheduler's default policy)
+func (s *Simulator) topologyCounts(pod *corev1.Pod, c corev1.TopologySpreadConstraint) map[string]int {
+	counts := make(map[string]int)
+	selector, err := metav1.LabelSelectorAsSelector(c.LabelSelector)
+	if err != nil {
+		return counts
+	}
+	for _, n := range s.Nodes {
+		domain, ok := n.Node.Labels[c.TopologyKey]
+		if !ok || checkNodeAffinity(pod, n.Node) != nil {
+			continue
+		}
+		if _, seen := counts[domain]; !seen {
+			counts[domain] = 0
+		}
+		for _, p := range n.Pods {
+			if p.Namespace == pod.Namespace && p.DeletionTimestamp == nil && selector.Matches(labels.Set(p.Labels)) {
+				counts[domain]++
+			}
+		}
+	}
+	return counts
+}
+
+// MatchNodeSelectorTerms reports whether a node matches any of the terms
+// (terms are ORed, requirements within a term are ANDed)
+func MatchNodeSelectorTerms(node *corev1.Node, terms []corev1.NodeSelectorTerm) bool {
+	for _, term := range terms {
+		if len(term.MatchExpressions) == 0 && len(term.MatchFields) == 0 {
+			continue
+		}
+		matched := true
+		for _, req := range term.MatchExpressions {
+			if !matchRequirement(node.Labels, req) {
+				matched = false
+				break
+			}
+		}
+		for _, req := range term.MatchFields {
+			if !matched {
+				break
+			}
+			if req.Key != "metadata.name" || !matchRequirement(map[string]string{"metadata.name": node.Name}, req) {
+				matched = false
+			}
+		}
+		if matched {
+			return true
+		}
+	}
+	return false
+}
+
+// matchRequirement evaluates one node selector requirement against labels
+func matchRequirement(nodeLabels map[string]string, req corev1.NodeSelectorRequirement) bool {
+	value, exists := nodeLabels[req.Key]
+	switch req.Operator {
+	case corev1.NodeSelectorOpIn:
+		return exists && containsString(req.Values, value)
+	case corev1.NodeSelectorOpNotIn:
+		return !exists || !containsString(req.Values, value)
+	case corev1.NodeSelectorOpExists:
+		return exists
+	case corev1.NodeSelectorOpDoesNotExist:
+		return !exists
+	case corev1.NodeSelectorOpGt, corev1.NodeSelectorOpLt:
+		if !exists || len(req.Values) != 1 {
+			return false
+		}
+		have, err1 := strconv.ParseInt(value, 10, 64)
+		want, err2 := strconv.ParseInt(req.Values[0], 10, 64)
+		if err1 != nil || err2 != nil {
+			return false
+		}
+		if req.Operator == corev1.NodeSelectorOpGt {
+			return have > want
+		}
+		return have < want
+	}
+	return false
+}
+
+// describeTerms renders node selector terms for humans
+func describeTerms(terms []corev1.NodeSelectorTerm) string {
+	var parts []string
+	for _, term := range terms {
+		var reqs []string
+		for _, req := range append(append([]corev1.NodeSelectorRequirement{}, term.MatchExpressions...), term.MatchFields...) {
+			switch req.Operator {
+			case corev1.NodeSelectorOpExists, corev1.NodeSelectorOpDoesNotExist:
+				reqs = append(reqs, fmt.Sprintf("%s %s", req.Key, req.Operator))
+			default:
+				reqs = append(reqs, fmt.Sprintf("%s %s [%s]", req.Key, req.Operator, strings.Join(req.Values, ",")))
+			}
+		}
+		parts = append(parts, strings.Join(reqs, " AND "))
+	}
+	return strings.Join(parts, " OR ")
+}
+
+// tolerates reports whether any pod toleration tolerates the taint
+func tolerates(pod *corev1.Pod, taint *corev1.Taint) bool {
+	for i := range pod.Spec.Tolerations {
+		if pod.Spec.Tolerations[i].ToleratesTaint(taint) {
+			return true
+		}
+	}
+	return false
+}
+
+// taintString renders a taint as key=value:Effect
+func taintString(t *corev1.Taint) string {
+	if t.Value == "" {
+		return fmt.Sprintf("%s:%s", t.Key, t.Effect)
+	}
+	return fmt.Sprintf("%s=%s:%s", t.Key, t.Value, t.Effect)
+}
+
+// nodeReady reports whether the node Ready condition is true
+func nodeReady(node *corev1.Node) bool {
+	for _, c := range node.Status.Conditions {
+		if c.Type == corev1.NodeReady {
+			return c.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// containsString reports whether values contains v
+func containsString(values []string, v string) bool {
+	for _, value := range values {
+		if value == v {
+			return true
+		}
+	}
+	return false
+}
+
+// FormatBytes renders a byte count with a binary unit (Mi/Gi)
+func FormatBytes(b int64) string {
+	const gi = 1024 * 1024 * 1024
+	const mi = 1024 * 1024
+	if b >= gi && b%gi == 0 {
+		return fmt.Sprintf("%dGi", b/gi)
+	}
+	if b >= gi {
+		return fmt.Sprintf("%.1fGi", float64(b)/gi)
+	}
+	return fmt.Sprintf("%dMi", (b+mi-1)/mi)
+}
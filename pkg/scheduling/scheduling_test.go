@@ -0,0 +1,259 @@
+package scheduling
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func testNode(name string, cpu, memory string, nodeLabels map[string]string, taints ...corev1.Taint) corev1.Node {
+	return corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Labels: nodeLabels},
+		Spec:       corev1.NodeSpec{Taints: taints},
+		Status: corev1.NodeStatus{
+			Allocatable: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse(cpu),
+				corev1.ResourceMemory: resource.MustParse(memory),
+				corev1.ResourcePods:   resource.MustParse("110"),
+			},
+			Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: corev1.ConditionTrue}},
+		},
+	}
+}
+
+func testPod(name, node, cpu, memory string) corev1.Pod {
+	return corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "app", Labels: map[string]string{"app": "web"}},
+		Spec: corev1.PodSpec{
+			NodeName: node,
+			Containers: []corev1.Container{{
+				Name: "app",
+				Resources: corev1.ResourceRequirements{Requests: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse(cpu),
+					corev1.ResourceMemory: resource.MustParse(memory),
+				}},
+			}},
+		},
+		Status: corev1.PodStatus{Phase: corev1.PodRunning},
+	}
+}
+
+func predicates(rejections []Rejection) map[string]bool {
+	result := make(map[string]bool)
+	for _, r := range rejections {
+		result[r.Predicate] = true
+	}
+	return result
+}
+
+func TestPodRequests_InitAndSidecarContainers(t *testing.T) {
+	always := corev1.ContainerRestartPolicyAlways
+	pod := testPod("p", "", "200m", "100Mi")
+	pod.Spec.InitContainers = []corev1.Container{
+		{Name: "migrate", Resources: corev1.ResourceRequirements{Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")}}},
+		{Name: "proxy", RestartPolicy: &always, Resources: corev1.ResourceRequirements{Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("50Mi")}}},
+	}
+
+	req := PodRequests(&pod)
+	if req.MilliCPU != 1000 || req.Memory != 150*1024*1024 {
+		t.Errorf("Expected 1000m (init max) and 150Mi (app + sidecar), got %+v", req)
+	}
+}
+
+func TestSumContainers(t *testing.T) {
+	always := corev1.ContainerRestartPolicyAlways
+	container := func(name, cpu, memory string, sidecar bool) corev1.Container {
+		c := corev1.Container{Name: name, Resources: corev1.ResourceRequirements{Requests: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse(cpu),
+			corev1.ResourceMemory: resource.MustParse(memory),
+		}}}
+		if sidecar {
+			c.RestartPolicy = &always
+		}
+		return c
+	}
+
+	tests := []struct {
+		name  string
+		init  []corev1.Container
+		app   []corev1.Container
+		wantM int64
+		wantB int64
+	}{
+		{
+			name:  "app containers only",
+			app:   []corev1.Container{container("a", "100m", "64Mi", false), container("b", "200m", "64Mi", false)},
+			wantM: 300, wantB: 128 << 20,
+		},
+		{
+			name:  "init container larger than the app",
+			init:  []corev1.Container{container("migrate", "1", "32Mi", false)},
+			app:   []corev1.Container{container("app", "200m", "128Mi", false)},
+			wantM: 1000, wantB: 128 << 20,
+		},
+		{
+			// The init container runs next to the sidecar started before it
+			name:  "sidecar then init then app",
+			init:  []corev1.Container{container("proxy", "300m", "256Mi", true), container("migrate", "800m", "64Mi", false)},
+			app:   []corev1.Container{container("app", "500m", "128Mi", false)},
+			wantM: 1100, wantB: 384 << 20,
+		},
+		{
+			// A sidecar started after the init container does not add to it
+			name:  "init then sidecar then app",
+			init:  []corev1.Container{container("migrate", "800m", "64Mi", false), container("proxy", "300m", "256Mi", true)},
+			app:   []corev1.Container{container("app", "500m", "128Mi", false)},
+			wantM: 800, wantB: 384 << 20,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := corev1.PodSpec{InitContainers: tt.init, Containers: tt.app}
+			got := SumContainers(&spec, ContainerRequests)
+			if got.MilliCPU != tt.wantM || got.Memory != tt.wantB {
+				t.Errorf("SumContainers = %+v, want %dm/%d", got, tt.wantM, tt.wantB)
+			}
+		})
+	}
+}
+
+func TestCheckNode_Predicates(t *testing.T) {
+	nodes := []corev1.Node{
+		testNode("busy", "2", "4Gi", map[string]string{"zone": "a"}),
+		testNode("infra", "8", "16Gi", map[string]string{"zone": "a"}, corev1.Taint{Key: "dedicated", Value: "infra", Effect: corev1.TaintEffectNoSchedule}),
+		testNode("gpu", "8", "16Gi", map[string]string{"zone": "b", "gpu": "true"}),
+	}
+	nodes[0].Spec.Unschedulable = true
+	existing := []corev1.Pod{testPod("hog", "busy", "1800m", "1Gi")}
+	sim := NewSimulator(NewNodeInfos(nodes, existing))
+
+	pending := testPod("pending", "", "500m", "1Gi")
+	pending.Spec.NodeSelector = map[string]string{"gpu": "false"}
+
+	busy := predicates(sim.CheckNode(&pending, sim.Nodes[0]))
+	if !busy[PredicateNodeUnschedulable] || !busy[PredicateCPU] || !busy[PredicateNodeAffinity] {
+		t.Errorf("Expected cordon, cpu and selector rejections on busy, got %v", busy)
+	}
+	infra := predicates(sim.CheckNode(&pending, sim.Nodes[1]))
+	if !infra[PredicateTaints] || infra[PredicateCPU] {
+		t.Errorf("Expected only taint/selector rejections on infra, got %v", infra)
+	}
+
+	pending.Spec.NodeSelector = nil
+	pending.Spec.Tolerations = []corev1.Toleration{{Key: "dedicated", Operator: corev1.TolerationOpEqual, Value: "infra", Effect: corev1.TaintEffectNoSchedule}}
+	if rejections := sim.CheckNode(&pending, sim.Nodes[1]); len(rejections) != 0 {
+		t.Errorf("Expected pod with toleration to fit on infra, got %+v", rejections)
+	}
+}
+
+func TestCheckNode_NodeAffinityAndVolumeZone(t *testing.T) {
+	nodes := []corev1.Node{
+		testNode("a-1", "4", "8Gi", map[string]string{"topology.kubernetes.io/zone": "a"}),
+		testNode("b-1", "4", "8Gi", map[string]string{"topology.kubernetes.io/zone": "b"}),
+	}
+	sim := NewSimulator(NewNodeInfos(nodes, nil))
+	sim.VolumeNodeAffinity["app/data"] = &corev1.VolumeNodeAffinity{Required: &corev1.NodeSelector{
+		NodeSelectorTerms: []corev1.NodeSelectorTerm{{MatchExpressions: []corev1.NodeSelectorRequirement{{
+			Key: "topology.kubernetes.io/zone", Operator: corev1.NodeSelectorOpIn, Values: []string{"b"},
+		}}}},
+	}}
+
+	pod := testPod("db-0", "", "100m", "100Mi")
+	pod.Spec.Volumes = []corev1.Volume{{Name: "data", VolumeSource: corev1.VolumeSource{
+		PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "data"},
+	}}}
+	pod.Spec.Affinity = &corev1.Affinity{NodeAffinity: &corev1.NodeAffinity{
+		RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+			NodeSelectorTerms: []corev1.NodeSelectorTerm{{MatchExpressions: []corev1.NodeSelectorRequirement{{
+				Key: "topology.kubernetes.io/zone", Operator: corev1.NodeSelectorOpNotIn, Values: []string{"b"},
+			}}}},
+		},
+	}}
+
+	eval := sim.Evaluate(&pod)
+	if len(eval.FeasibleNodes) != 0 {
+		t.Fatalf("Expected no feasible nodes, got %v", eval.FeasibleNodes)
+	}
+	if eval.ReasonCounts[PredicateVolumeZone] != 1 || eval.ReasonCounts[PredicateNodeAffinity] != 1 {
+		t.Errorf("Expected one volume zone and one affinity rejection, got %v", eval.ReasonCounts)
+	}
+}
+
+func TestCheckNode_TopologySpread(t *testing.T) {
+	nodes := []corev1.Node{
+		testNode("a-1", "4", "8Gi", map[string]string{"zone": "a"}),
+		testNode("b-1", "4", "8Gi", map[string]string{"zone": "b"}),
+	}
+	existing := []corev1.Pod{testPod("web-1", "a-1", "100m", "100Mi"), testPod("web-2", "a-1", "100m", "100Mi")}
+	sim := NewSimulator(NewNodeInfos(nodes, existing))
+
+	pod := testPod("web-3", "", "100m", "100Mi")
+	pod.Spec.TopologySpreadConstraints = []corev1.TopologySpreadConstraint{{
+		MaxSkew:           1,
+		TopologyKey:       "zone",
+		WhenUnsatisfiable: corev1.DoNotSchedule,
+		LabelSelector:     &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}},
+	}}
+
+	eval := sim.Evaluate(&pod)
+	if len(eval.FeasibleNodes) != 1 || eval.FeasibleNodes[0] != "b-1" {
+		t.Errorf("Expected only b-1 to satisfy the spread, got %v (rejected %+v)", eval.FeasibleNodes, eval.Rejected)
+	}
+}
+
+func TestEvaluate_SuggestsSmallestChange(t *testing.T) {
+	nodes := []corev1.Node{
+		testNode("small", "1", "2Gi", nil),
+		testNode("tainted", "8", "16Gi", nil, corev1.Taint{Key: "dedicated", Value: "db", Effect: corev1.TaintEffectNoSchedule}),
+		testNode("nearly", "2", "4Gi", nil),
+	}
+	existing := []corev1.Pod{testPod("a", "small", "900m", "1Gi"), testPod("b", "nearly", "1600m", "1Gi")}
+	sim := NewSimulator(NewNodeInfos(nodes, existing))
+
+	pod := testPod("pending", "", "500m", "512Mi")
+	eval := sim.Evaluate(&pod)
+
+	if eval.Suggestion == nil || eval.Suggestion.Node != "nearly" {
+		t.Fatalf("Expected suggestion for node 'nearly' (100m short), got %+v", eval.Suggestion)
+	}
+	if len(eval.Suggestion.Changes) != 1 || eval.Suggestion.Changes[0] != "reduce the CPU request by 100m or free 100m on node nearly" {
+		t.Errorf("Unexpected changes: %v", eval.Suggestion.Changes)
+	}
+}
+
+func TestPredicatesFromSchedulerMessage(t *testing.T) {
+	testCases := []struct {
+		message string
+		want    []string
+	}{
+		{
+			"0/6 nodes are available: 3 Insufficient cpu, 3 node(s) had untolerated taint {node-role.kubernetes.io/master: }. preemption: 0/6 nodes are available",
+			[]string{PredicateCPU, PredicateTaints},
+		},
+		{
+			"0/3 nodes are available: 1 node(s) had untolerated taint {node.kubernetes.io/unschedulable: }, 2 node(s) didn't match Pod's node affinity/selector.",
+			[]string{PredicateNodeAffinity, PredicateNodeUnschedulable},
+		},
+		{
+			"0/3 nodes are available: 3 node(s) had volume node affinity conflict.",
+			[]string{PredicateVolumeZone},
+		},
+	}
+
+	for _, tc := range testCases {
+		got := PredicatesFromSchedulerMessage(tc.message)
+		if len(got) != len(tc.want) {
+			t.Errorf("For %q expected %v, got %v", tc.message, tc.want, got)
+			continue
+		}
+		for i := range got {
+			if got[i] != tc.want[i] {
+				t.Errorf("For %q expected %v, got %v", tc.message, tc.want, got)
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,219 @@
+package scheduling
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// NodeResult is the outcome of evaluating a pod on one node
+type NodeResult struct {
+	Node       string      `json:"node"`
+	Rejections []Rejection `json:"rejections"`
+}
+
+// Suggestion is the smallest change expected to make a pod schedulable
+type Suggestion struct {
+	Node    string   `json:"node,omitempty"`
+	Changes []string `json:"changes"`
+	Summary string   `json:"summary"`
+}
+
+// PodEvaluation is the result of simulating scheduling for one pod
+type PodEvaluation struct {
+	Requests      Resources      `json:"requests"`
+	FeasibleNodes []string       `json:"feasible_nodes"`
+	Rejected      []NodeResult   `json:"rejected_nodes"`
+	ReasonCounts  map[string]int `json:"reason_counts"`
+	PodIssues     []string       `json:"pod_issues,omitempty"`
+	Suggestion    *Suggestion    `json:"suggestion,omitempty"`
+}
+
+// Evaluate checks a pod against every node and suggests a fix when no node fits
+func (s *Simulator) Evaluate(pod *corev1.Pod) PodEvaluation {
+	eval := PodEvaluation{
+		Requests:      PodRequests(pod),
+		FeasibleNodes: []string{},
+		Rejected:      []NodeResult{},
+		ReasonCounts:  make(map[string]int),
+	}
+
+	for _, v := range pod.Spec.Volumes {
+		if v.PersistentVolumeClaim != nil && s.UnboundClaims[pod.Namespace+"/"+v.PersistentVolumeClaim.ClaimName] {
+			eval.PodIssues = append(eval.PodIssues, fmt.Sprintf("PVC %s is not bound", v.PersistentVolumeClaim.ClaimName))
+		}
+	}
+
+	for _, node := range s.Nodes {
+		rejections := s.CheckNode(pod, node)
+		if len(rejections) == 0 {
+			eval.FeasibleNodes = append(eval.FeasibleNodes, node.Node.Name)
+			continue
+		}
+		eval.Rejected = append(eval.Rejected, NodeResult{Node: node.Node.Name, Rejections: rejections})
+		counted := make(map[string]bool)
+		for _, r := range rejections {
+			if !counted[r.Predicate] {
+				eval.ReasonCounts[r.Predicate]++
+				counted[r.Predicate] = true
+			}
+		}
+	}
+
+	eval.Suggestion = suggest(&eval)
+	return eval
+}
+
+// predicateCost ranks how invasive fixing a predicate is; lower is easier
+var predicateCost = map[string]float64{
+	PredicateNodeUnschedulable: 1,
+	PredicateCPU:               2,
+	PredicateMemory:            2,
+	PredicateTaints:            3, // tainted nodes are usually dedicated to other workloads
+	PredicatePodCount:          3,
+	PredicateNodeAffinity:      3,
+	PredicateTopologySpread:    4,
+	PredicateNodeNotReady:      5,
+	PredicateVolumeZone:        6,
+}
+
+// suggest picks the node needing the cheapest set of changes
+func suggest(eval *PodEvaluation) *Suggestion {
+	if len(eval.PodIssues) > 0 {
+		return &Suggestion{
+			Changes: []string{"bind the pod's PersistentVolumeClaims (check the StorageClass provisioner and PVC events)"},
+			Summary: strings.Join(eval.PodIssues, "; "),
+		}
+	}
+	if len(eval.FeasibleNodes) > 0 {
+		return &Suggestion{
+			Changes: []string{},
+			Summary: fmt.Sprintf("pod fits on %d node(s) now (e.g. %s); the scheduler may not have retried yet or a constraint outside this simulation applies", len(eval.FeasibleNodes), eval.FeasibleNodes[0]),
+		}
+	}
+	if len(eval.Rejected) == 0 {
+		return &Suggestion{Changes: []string{"add nodes to the cluster"}, Summary: "no nodes in the cluster"}
+	}
+
+	var best *NodeResult
+	bestCost := 0.0
+	for i := range eval.Rejected {
+		result := &eval.Rejected[i]
+		cost := 0.0
+		for _, r := range result.Rejections {
+			cost += predicateCost[r.Predicate]
+			// Smaller shortfalls relative to the request are cheaper to fix
+			switch r.Predicate {
+			case PredicateCPU:
+				if eval.Requests.MilliCPU > 0 {
+					cost += float64(r.Shortfall) / float64(eval.Requests.MilliCPU)
+				}
+			case PredicateMemory:
+				if eval.Requests.Memory > 0 {
+					cost += float64(r.Shortfall) / float64(eval.Requests.Memory)
+				}
+			}
+		}
+		if best == nil || cost < bestCost {
+			best, bestCost = result, cost
+		}
+	}
+
+	changes := make([]string, 0, len(best.Rejections))
+	for _, r := range best.Rejections {
+		changes = append(changes, changeFor(best.Node, r))
+	}
+	return &Suggestion{
+		Node:    best.Node,
+		Changes: changes,
+		Summary: fmt.Sprintf("smallest change: %s", strings.Join(changes, " and ")),
+	}
+}
+
+// changeFor describes the change that clears one rejection
+func changeFor(node string, r Rejection) string {
+	switch r.Predicate {
+	case PredicateNodeUnschedulable:
+		return fmt.Sprintf("uncordon node %s", node)
+	case PredicateNodeNotReady:
+		return fmt.Sprintf("restore node %s to Ready", node)
+	case PredicateTaints:
+		if r.taint != nil {
+			return fmt.Sprintf("add a toleration for %s to the pod (fits on %s)", taintString(r.taint), node)
+		}
+		return fmt.Sprintf("tolerate the taints of node %s", node)
+	case PredicateNodeAffinity:
+		return fmt.Sprintf("relax the pod's node selector/affinity or label node %s to match (%s)", node, r.Reason)
+	case PredicateCPU:
+		return fmt.Sprintf("reduce the CPU request by %dm or free %dm on node %s", r.Shortfall, r.Shortfall, node)
+	case PredicateMemory:
+		return fmt.Sprintf("reduce the memory request by %s or free %s on node %s", FormatBytes(r.Shortfall), FormatBytes(r.Shortfall), node)
+	case PredicatePodCount:
+		return fmt.Sprintf("free a pod slot on node %s or raise its maxPods", node)
+	case PredicateTopologySpread:
+		return fmt.Sprintf("relax the %s topology spread constraint (maxSkew/whenUnsatisfiable) or add capacity in an under-populated %s domain", r.topologyKey, r.topologyKey)
+	case PredicateVolumeZone:
+		return fmt.Sprintf("add schedulable capacity where the pod's volume lives (%s)", r.Reason)
+	}
+	return r.Reason
+}
+
+// schedulerMessagePatterns map FailedScheduling message fragments to predicates
+var schedulerMessagePatterns = []struct {
+	pattern   *regexp.Regexp
+	predicate string
+}{
+	{regexp.MustCompile(`(?i)insufficient cpu`), PredicateCPU},
+	{regexp.MustCompile(`(?i)insufficient memory`), PredicateMemory},
+	{regexp.MustCompile(`(?i)too many pods`), PredicatePodCount},
+	{regexp.MustCompile(`(?i)node\(s\) were unschedulable|node\.kubernetes\.io/unschedulable`), PredicateNodeUnschedulable},
+	{regexp.MustCompile(`(?i)node\.kubernetes\.io/(not-ready|unreachable)`), PredicateNodeNotReady},
+	{regexp.MustCompile(`(?i)untolerated taint|had taint`), PredicateTaints},
+	{regexp.MustCompile(`(?i)didn't match pod's node affinity|didn't match node selector`), PredicateNodeAffinity},
+	{regexp.MustCompile(`(?i)topology spread constraints`), PredicateTopologySpread},
+	{regexp.MustCompile(`(?i)volume node affinity conflict`), PredicateVolumeZone},
+}
+
+// PredicatesFromSchedulerMessage extracts the predicates named in a
+// FailedScheduling event message, e.g. "0/6 nodes are available: 3
+// Insufficient cpu, 3 node(s) had untolerated taint {...}"
+func PredicatesFromSchedulerMessage(message string) []string {
+	seen := make(map[string]bool)
+	var predicates []string
+	for _, p := range schedulerMessagePatterns {
+		if !p.pattern.MatchString(message) || seen[p.predicate] {
+			continue
+		}
+		// Cordoned and not-ready nodes surface as taints; they map to their own predicates
+		if p.predicate == PredicateTaints && onlyLifecycleTaints(message) {
+			continue
+		}
+		seen[p.predicate] = true
+		predicates = append(predicates, p.predicate)
+	}
+	sort.Strings(predicates)
+	return predicates
+}
+
+// taintInMessage captures taint keys in scheduler messages: {key: value} or {key}
+var taintInMessage = regexp.MustCompile(`taint \{([^:}]+)`)
+
+// onlyLifecycleTaints reports whether every taint named in the message is a
+// node lifecycle taint (covered by the unschedulable/not-ready predicates)
+func onlyLifecycleTaints(message string) bool {
+	matches := taintInMessage.FindAllStringSubmatch(message, -1)
+	if len(matches) == 0 {
+		return false
+	}
+	for _, m := range matches {
+		switch strings.TrimSpace(m[1]) {
+		case corev1.TaintNodeUnschedulable, corev1.TaintNodeNotReady, corev1.TaintNodeUnreachable:
+		default:
+			return false
+		}
+	}
+	return true
+}